@@ -0,0 +1,140 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/apikey"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+func renderApiKeyError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case apikey.ErrInvalidName, apikey.ErrInvalidScope, apikey.ErrInvalidExpiry:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case apikey.ErrApiKeyNotFound:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+type ApiKeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	// Key is only sent when the key is created
+	Key string `json:"key,omitempty"`
+}
+
+func newApiKeyResponse(apiKey *db.ApiKey) *ApiKeyResponse {
+	return &ApiKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: nullTimePtr(apiKey.LastUsedAt),
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func (*ApiKeyResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type ApiKeyListResponse struct {
+	ApiKeys []*ApiKeyResponse `json:"apiKeys"`
+}
+
+func (*ApiKeyListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type createApiKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// 30 days from now when empty, at most a year
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func (c *createApiKeyRequest) Bind(r *http.Request) error {
+	if c.Name == "" || len(c.Scopes) == 0 {
+		return fmt.Errorf("missing name or/and scopes fields")
+	}
+	return nil
+}
+
+func (server *Server) createApiKey(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &createApiKeyRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	key, apiKey, err := server.apiKey.CreateApiKey(r.Context(), payload.User.ID, apikey.CreateApiKeyParams{
+		Name:      data.Name,
+		Scopes:    data.Scopes,
+		ExpiresAt: data.ExpiresAt,
+	})
+	if err != nil {
+		renderApiKeyError(w, r, err)
+		return
+	}
+	rsp := newApiKeyResponse(apiKey)
+	rsp.Key = key
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) getApiKeyList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	apiKeys, err := server.apiKey.GetApiKeyList(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &ApiKeyListResponse{ApiKeys: make([]*ApiKeyResponse, len(apiKeys))}
+	for i := range apiKeys {
+		rsp.ApiKeys[i] = newApiKeyResponse(&apiKeys[i])
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteApiKeyResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteApiKeyResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteApiKey(w http.ResponseWriter, r *http.Request) {
+	keyId, err := getIdFromURLPath(r, "keyID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.apiKey.DeleteApiKey(r.Context(), payload.User.ID, keyId); err != nil {
+		renderApiKeyError(w, r, err)
+		return
+	}
+	rsp := &deleteApiKeyResponse{
+		Message: fmt.Sprintf("delete api key id %d success", keyId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
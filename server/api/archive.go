@@ -0,0 +1,162 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/archive"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// afterDays is nil when done tasks are not archived automatically
+type ArchiveRuleResponse struct {
+	AfterDays *int32     `json:"afterDays"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+func newArchiveRuleResponse(rule *db.ArchiveRule) *ArchiveRuleResponse {
+	if rule == nil {
+		return &ArchiveRuleResponse{}
+	}
+	return &ArchiveRuleResponse{
+		AfterDays: &rule.AfterDays,
+		UpdatedAt: &rule.UpdatedAt,
+	}
+}
+
+func (*ArchiveRuleResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// {"afterDays": 14} archives done tasks 14 days after they were completed,
+// {"afterDays": null} turns automatic archiving off
+type UpdateArchiveRuleRequest struct {
+	AfterDays *int32 `json:"afterDays"`
+}
+
+func (c *UpdateArchiveRuleRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+// map errors from archive service to responses
+func renderArchiveError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case archive.ErrInvalidAfterDays, archive.ErrTaskNotDone:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		renderTaskError(w, r, err)
+	}
+}
+
+func (server *Server) getArchiveRule(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	rule, err := server.archive.GetRule(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, newArchiveRuleResponse(rule)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) updateArchiveRule(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &UpdateArchiveRuleRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	rule, err := server.archive.UpdateRule(r.Context(), payload.User.ID, data.AfterDays)
+	if err != nil {
+		renderArchiveError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newArchiveRuleResponse(rule)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// get archived tasks, the most recently archived first
+// /archive?pageId=1&limit=10
+func (server *Server) getArchivedTaskList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	queryStrings := r.URL.Query()
+	pageId, err := strconv.Atoi(queryStrings.Get("pageId"))
+	if err != nil {
+		pageId = 1
+	}
+	limit, err := strconv.Atoi(queryStrings.Get("limit"))
+	if err != nil {
+		limit = 10
+	}
+
+	taskList, err := server.archive.GetArchivedTaskList(r.Context(), payload.User.ID, int32(limit), int32(pageId))
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &TaskListResponse{Tasks: newTaskListResponse(taskList)}
+	if err := server.withTaskDetails(r.Context(), rsp.Tasks...); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// archive a done task without waiting for the archive rule
+func (server *Server) archiveTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	archived, err := server.archive.ArchiveTask(r.Context(), taskId, payload.User.ID)
+	if err != nil {
+		renderArchiveError(w, r, err)
+		return
+	}
+	server.renderChangedTask(w, r, archived)
+}
+
+// move an archived task back to the task list
+func (server *Server) unarchiveTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	unarchived, err := server.archive.UnarchiveTask(r.Context(), taskId, payload.User.ID)
+	if err != nil {
+		renderArchiveError(w, r, err)
+		return
+	}
+	server.renderChangedTask(w, r, unarchived)
+}
+
+func (server *Server) renderChangedTask(w http.ResponseWriter, r *http.Request, changed *db.Task) {
+	rsp, err := server.taskChanged(r.Context(), changed, event.TypeTaskUpdated)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
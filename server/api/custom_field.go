@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// custom field definitions of a project
+type CustomFieldResponse struct {
+	*db.CustomField
+}
+
+func (*CustomFieldResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type CustomFieldListResponse struct {
+	Fields []db.CustomField `json:"fields"`
+}
+
+func (*CustomFieldListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// type is ignored on update, a field keeps the type it was created with
+type CustomFieldRequest struct {
+	Name     string             `json:"name"`
+	Type     string             `json:"type"`
+	Options  []string           `json:"options"`
+	Rules    project.FieldRules `json:"rules"`
+	Position int32              `json:"position"`
+}
+
+func (c *CustomFieldRequest) Bind(r *http.Request) error {
+	if c.Name == "" {
+		return fmt.Errorf("name is a required field")
+	}
+	return nil
+}
+
+func (c *CustomFieldRequest) params() project.CustomFieldParams {
+	return project.CustomFieldParams{
+		Name:      c.Name,
+		FieldType: c.Type,
+		Options:   c.Options,
+		Rules:     c.Rules,
+		Position:  c.Position,
+	}
+}
+
+func renderFieldError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case project.ErrInvalidFieldType, project.ErrMissingOptions, project.ErrFieldNotInProject:
+		render.Render(w, r, ErrInvalidRequest(err))
+	default:
+		renderProjectError(w, r, err)
+	}
+}
+
+func (server *Server) getCustomFieldList(w http.ResponseWriter, r *http.Request) {
+	projectId, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	fields, err := server.project.GetFieldList(r.Context(), projectId, payload.User.ID)
+	if err != nil {
+		renderFieldError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &CustomFieldListResponse{Fields: fields}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) createCustomField(w http.ResponseWriter, r *http.Request) {
+	projectId, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &CustomFieldRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	field, err := server.project.CreateField(r.Context(), payload.User.ID, projectId, data.params())
+	if err != nil {
+		renderFieldError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &CustomFieldResponse{CustomField: field}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) updateCustomField(w http.ResponseWriter, r *http.Request) {
+	projectId, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	fieldId, err := getIdFromURLPath(r, "fieldID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &CustomFieldRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	field, err := server.project.UpdateField(r.Context(), payload.User.ID, projectId, fieldId, data.params())
+	if err != nil {
+		renderFieldError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &CustomFieldResponse{CustomField: field}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteCustomFieldResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteCustomFieldResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteCustomField(w http.ResponseWriter, r *http.Request) {
+	projectId, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	fieldId, err := getIdFromURLPath(r, "fieldID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.project.DeleteField(r.Context(), payload.User.ID, projectId, fieldId); err != nil {
+		renderFieldError(w, r, err)
+		return
+	}
+	rsp := &deleteCustomFieldResponse{
+		Message: fmt.Sprintf("delete field id %d success", fieldId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// custom field values of a task
+// PUT /task/123/fields {"values": {"4": 3, "5": ["a", "b"], "6": null}}
+type SetTaskFieldsRequest struct {
+	Values map[string]json.RawMessage `json:"values"`
+}
+
+func (c *SetTaskFieldsRequest) Bind(r *http.Request) error {
+	if len(c.Values) == 0 {
+		return fmt.Errorf("values is a required field")
+	}
+	for key := range c.Values {
+		if _, err := strconv.ParseInt(key, 10, 64); err != nil {
+			return fmt.Errorf("values must be keyed by field id, got %q", key)
+		}
+	}
+	return nil
+}
+
+type TaskFieldsResponse struct {
+	CustomFields map[string]json.RawMessage `json:"customFields"`
+}
+
+func (*TaskFieldsResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) setTaskFields(w http.ResponseWriter, r *http.Request) {
+	id, err := getTaskIdFromURLPath(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &SetTaskFieldsRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	values := make(map[int64]json.RawMessage, len(data.Values))
+	for key, value := range data.Values {
+		fieldId, _ := strconv.ParseInt(key, 10, 64)
+		values[fieldId] = value
+	}
+	result, err := server.task.SetFieldValues(r.Context(), int64(id), payload.User.ID, values)
+	if err != nil {
+		if _, ok := err.(*project.FieldValueError); ok {
+			render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+		switch err {
+		case task.ErrTaskNotInProject, task.ErrFieldNotInProject:
+			render.Render(w, r, ErrInvalidRequest(err))
+		default:
+			renderTaskError(w, r, err)
+		}
+		return
+	}
+	server.publishTaskUpdated(r.Context(), payload.User.ID, int64(id))
+	if err := render.Render(w, r, &TaskFieldsResponse{CustomFields: fieldValueMap(result)}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// custom field values keyed by field id
+func fieldValueMap(values []db.TaskCustomFieldValue) map[string]json.RawMessage {
+	m := make(map[string]json.RawMessage, len(values))
+	for _, value := range values {
+		m[strconv.FormatInt(value.FieldID, 10)] = value.Value
+	}
+	return m
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// defer a task by a number of minutes, until a time or until a natural date
+// like "next monday" which starts at midnight in tz (UTC by default)
+// {"minutes": 90}, {"until": "2024-03-14T09:00:00Z"} or {"date": "next monday", "tz": "Europe/Berlin"}
+type SnoozeTaskRequest struct {
+	Minutes int32      `json:"minutes"`
+	Until   *time.Time `json:"until"`
+	Date    string     `json:"date"`
+	Tz      string     `json:"tz"`
+	loc     *time.Location
+}
+
+func (c *SnoozeTaskRequest) Bind(r *http.Request) error {
+	given := 0
+	for _, set := range []bool{c.Minutes != 0, c.Until != nil, c.Date != ""} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		return fmt.Errorf("snooze needs one of minutes, until or date")
+	}
+	if c.Minutes < 0 {
+		return fmt.Errorf("minutes must be positive")
+	}
+	c.loc = time.UTC
+	if c.Tz != "" {
+		loc, err := time.LoadLocation(c.Tz)
+		if err != nil {
+			return fmt.Errorf("invalid tz %q", c.Tz)
+		}
+		c.loc = loc
+	}
+	return nil
+}
+
+func (c *SnoozeTaskRequest) until(now time.Time) (time.Time, error) {
+	switch {
+	case c.Until != nil:
+		return *c.Until, nil
+	case c.Date != "":
+		return task.ParseDeferDate(c.Date, now, c.loc)
+	}
+	return now.Add(time.Duration(c.Minutes) * time.Minute), nil
+}
+
+// map errors from deferring tasks to responses
+func renderDeferError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case task.ErrDeferInPast, task.ErrInvalidDeferDay:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		renderTaskError(w, r, err)
+	}
+}
+
+// hide a task from the task list until a later time,
+// GET /task?view=deferred lists the snoozed tasks
+func (server *Server) snoozeTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &SnoozeTaskRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	until, err := data.until(time.Now())
+	if err != nil {
+		renderDeferError(w, r, err)
+		return
+	}
+
+	deferred, err := server.task.DeferTask(r.Context(), taskId, payload.User.ID, until)
+	if err != nil {
+		renderDeferError(w, r, err)
+		return
+	}
+	server.renderChangedTask(w, r, deferred)
+}
+
+// bring a snoozed task back to the task list right away
+func (server *Server) unsnoozeTask(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	shown, err := server.task.UndeferTask(r.Context(), taskId, payload.User.ID)
+	if err != nil {
+		renderDeferError(w, r, err)
+		return
+	}
+	server.renderChangedTask(w, r, shown)
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	memdb "github.com/punkzberryz/todo/db/memory"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/mail"
+	"github.com/punkzberryz/todo/session"
+	"github.com/punkzberryz/todo/util"
+)
+
+// NewDevServer creates a server that keeps everything in memory and logs emails instead of
+// sending them, so it runs without Postgres, Redis or an SMTP account. It returns the store
+// for the workers, data is lost when the process exits
+func NewDevServer(config util.Config) (*Server, db.Store, error) {
+	store := memdb.NewStore()
+	sessionStore := session.NewMemoryStore()
+	server, err := NewServer(config, &store, &sessionStore, session.NewMemoryChallengeStore(), event.NewMemoryBroker(), mail.NewLogSender())
+	if err != nil {
+		return nil, nil, err
+	}
+	return server, store, nil
+}
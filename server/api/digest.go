@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/digest"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// time is the local time of day in timezone the digest is sent at,
+// weekday is the day of weekly digests (0 is Sunday)
+type DigestPreferenceResponse struct {
+	Frequency  string     `json:"frequency"`
+	Time       string     `json:"time"`
+	Timezone   string     `json:"timezone"`
+	Weekday    int16      `json:"weekday"`
+	NextSendAt *time.Time `json:"nextSendAt"`
+	LastSentAt *time.Time `json:"lastSentAt"`
+}
+
+func newDigestPreferenceResponse(pref *db.DigestPreference) *DigestPreferenceResponse {
+	return &DigestPreferenceResponse{
+		Frequency:  pref.Frequency,
+		Time:       digest.FormatSendTime(pref.SendMinute),
+		Timezone:   pref.Timezone,
+		Weekday:    pref.Weekday,
+		NextSendAt: nullTimePtr(pref.NextSendAt),
+		LastSentAt: nullTimePtr(pref.LastSentAt),
+	}
+}
+
+func (*DigestPreferenceResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// {"frequency": "daily", "time": "07:30", "timezone": "Asia/Bangkok", "weekday": 1}
+type UpdateDigestPreferenceRequest struct {
+	Frequency string `json:"frequency"`
+	Time      string `json:"time"`
+	Timezone  string `json:"timezone"`
+	Weekday   *int16 `json:"weekday"`
+}
+
+func (c *UpdateDigestPreferenceRequest) Bind(r *http.Request) error {
+	if c.Frequency == "" {
+		return fmt.Errorf("frequency is a required field")
+	}
+	if c.Time == "" {
+		c.Time = digest.FormatSendTime(digest.DefaultSendMinute)
+	}
+	if c.Timezone == "" {
+		c.Timezone = "UTC"
+	}
+	if c.Weekday == nil {
+		weekday := digest.DefaultWeekday
+		c.Weekday = &weekday
+	}
+	return nil
+}
+
+func (server *Server) getDigestPreference(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	pref, err := server.digest.GetPreference(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, newDigestPreferenceResponse(pref)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) updateDigestPreference(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &UpdateDigestPreferenceRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	sendMinute, err := digest.ParseSendTime(data.Time)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	pref, err := server.digest.UpdatePreference(r.Context(), payload.User.ID, digest.PreferenceParams{
+		Frequency:  data.Frequency,
+		SendMinute: sendMinute,
+		Weekday:    *data.Weekday,
+		Timezone:   data.Timezone,
+	})
+	if err != nil {
+		switch err {
+		case digest.ErrInvalidFrequency, digest.ErrInvalidSendTime, digest.ErrInvalidWeekday, digest.ErrInvalidTimezone:
+			render.Render(w, r, ErrInvalidRequest(err))
+		default:
+			render.Render(w, r, ErrInternalServer(err))
+		}
+		return
+	}
+	if err := render.Render(w, r, newDigestPreferenceResponse(pref)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type unsubscribeDigestResponse struct {
+	Message string `json:"message"`
+}
+
+func (*unsubscribeDigestResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// unsubscribeDigest is opened from the link in digest emails,
+// mail clients that support one-click unsubscribe POST to it
+func (server *Server) unsubscribeDigest(w http.ResponseWriter, r *http.Request) {
+	err := server.digest.Unsubscribe(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		if err == digest.ErrInvalidUnsubscribeToken {
+			render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &unsubscribeDigestResponse{
+		Message: "unsubscribed from digest emails",
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
@@ -41,6 +41,30 @@ func ErrUnauthorized(err error) render.Renderer {
 		ErrorText:      err.Error(),
 	}
 }
+func ErrForbidden(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: 403,
+		StatusText:     "Forbidden request.",
+		ErrorText:      err.Error(),
+	}
+}
+func ErrTooManyRequests(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: 429,
+		StatusText:     "Too many requests.",
+		ErrorText:      err.Error(),
+	}
+}
+func ErrConflict(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: 409,
+		StatusText:     "Conflict with current state.",
+		ErrorText:      err.Error(),
+	}
+}
 func ErrRender(err error) render.Renderer {
 	return &ErrResponse{
 		Err:            err,
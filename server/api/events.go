@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/session"
+)
+
+// a comment line is sent when nothing happened for this long,
+// it keeps proxies from closing the stream
+const eventHeartbeat = 25 * time.Second
+
+type deletedTaskEvent struct {
+	ID        int64  `json:"id"`
+	ProjectID *int64 `json:"projectId,omitempty"`
+}
+
+// publishTaskEvent notifies the event streams and queues the webhooks of the user about
+// a change of a task in projectId, the change is already saved so a failed publish
+// doesn't fail the request
+func (server *Server) publishTaskEvent(ctx context.Context, userId int64, projectId *int64, eventType string, data any) {
+	if _, err := server.events.Publish(ctx, userId, eventType, data); err != nil {
+		log.Printf("cannot publish %s event: %v", eventType, err)
+	}
+	if err := server.webhook.Enqueue(ctx, userId, projectId, eventType, data); err != nil {
+		log.Printf("cannot queue %s webhooks: %v", eventType, err)
+	}
+}
+
+// publishTaskUpdated loads a task with its details and publishes it
+func (server *Server) publishTaskUpdated(ctx context.Context, userId int64, taskId int64) {
+	task, err := server.task.GetTaskById(ctx, taskId, userId)
+	if err == nil {
+		_, err = server.taskChanged(ctx, task, event.TypeTaskUpdated)
+	}
+	if err != nil {
+		log.Printf("cannot publish %s event: %v", event.TypeTaskUpdated, err)
+	}
+}
+
+// a stream ticket is good for one connection within this time
+const streamTicketTTL = 30 * time.Second
+
+// streamTicket is what a ticket stands for, the scopes are only set for api keys
+type streamTicket struct {
+	Payload  *token.Payload `json:"payload"`
+	Scopes   []string       `json:"scopes,omitempty"`
+	ApiKey   bool           `json:"api_key"`
+	ApiKeyID int64          `json:"api_key_id,omitempty"`
+}
+
+type streamTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (*streamTicketResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// createStreamTicket hands out a ticket for ?ticket= on /events and /ws,
+// browsers can't set the authorization header on EventSource and WebSocket requests
+func (server *Server) createStreamTicket(w http.ResponseWriter, r *http.Request) {
+	ticket := streamTicket{Payload: r.Context().Value(payloadKey).(*token.Payload)}
+	ticket.Scopes, ticket.ApiKey = r.Context().Value(scopesKey).([]string)
+	ticket.ApiKeyID, _ = r.Context().Value(apiKeyIdKey).(int64)
+	value, err := json.Marshal(ticket)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	id := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &streamTicketResponse{
+		Ticket:    base64.RawURLEncoding.EncodeToString(id),
+		ExpiresAt: time.Now().Add(streamTicketTTL),
+	}
+	if err := server.tickets.SetChallenge(r.Context(), "stream-ticket:"+rsp.Ticket, value, streamTicketTTL); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// streamAuthMiddleware is authMiddleware that also takes a ticket from createStreamTicket
+// as ?ticket=. A ticket is single-use and short-lived, unlike an access token it is
+// worthless once the URL shows up in a log
+func (server *Server) streamAuthMiddleware(next http.Handler) http.Handler {
+	auth := server.authMiddleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("ticket")
+		if id == "" || r.Header.Get("authorization") != "" {
+			auth.ServeHTTP(w, r)
+			return
+		}
+		value, err := server.tickets.TakeChallenge(r.Context(), "stream-ticket:"+id)
+		if err != nil {
+			if err == session.ErrChallengeNotFound {
+				render.Render(w, r, ErrUnauthorized(fmt.Errorf("invalid or used stream ticket")))
+				return
+			}
+			render.Render(w, r, ErrInternalServer(err))
+			return
+		}
+		var ticket streamTicket
+		if err := json.Unmarshal(value, &ticket); err != nil {
+			render.Render(w, r, ErrInternalServer(err))
+			return
+		}
+		ctx := context.WithValue(r.Context(), payloadKey, ticket.Payload)
+		if ticket.ApiKey {
+			ctx = context.WithValue(ctx, scopesKey, ticket.Scopes)
+			ctx = context.WithValue(ctx, apiKeyIdKey, ticket.ApiKeyID)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// last event id from the Last-Event-ID header sent by reconnecting EventSource
+// or from ?lastEventId=
+func getLastEventId(r *http.Request) (int64, error) {
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("lastEventId")
+	}
+	if lastEventId == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(lastEventId, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event ID")
+	}
+	return id, nil
+}
+
+func writeServerSentEvent(w http.ResponseWriter, e *event.Event) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+	return err
+}
+
+// stream task events of the user as Server-Sent Events,
+// a client that reconnects with Last-Event-ID gets the events it missed
+// or a reset event when they are no longer buffered
+func (server *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	lastEventId, err := getLastEventId(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		render.Render(w, r, ErrInternalServer(fmt.Errorf("streaming is not supported")))
+		return
+	}
+
+	sub, err := server.events.Subscribe(r.Context(), payload.User.ID, lastEventId)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+
+	if sub.Missed {
+		reset := &event.Event{ID: sub.LastID, Type: event.TypeReset, Data: []byte("{}")}
+		if err := writeServerSentEvent(w, reset); err != nil {
+			return
+		}
+	}
+	for i := range sub.Replay {
+		if err := writeServerSentEvent(w, &sub.Replay[i]); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				//the subscription ended, the client reconnects with its last event id
+				return
+			}
+			if err := writeServerSentEvent(w, &e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+type TaskExportResponse struct {
+	Fields []db.CustomField `json:"fields"`
+	Tasks  []*TaskResponse  `json:"tasks"`
+}
+
+func (*TaskExportResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// export all tasks with their custom fields
+// /task/export?format=csv&projectId=2, format defaults to json
+func (server *Server) exportTasks(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	queryStrings := r.URL.Query()
+	format := queryStrings.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("format must be json or csv")))
+		return
+	}
+	var projectId sql.NullInt64
+	if value := queryStrings.Get("projectId"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid projectId")))
+			return
+		}
+		projectId = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	export, err := server.task.ExportTasks(r.Context(), payload.User.ID, projectId)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="tasks.csv"`)
+		if err := writeTaskExportCSV(w, export); err != nil {
+			render.Render(w, r, ErrInternalServer(err))
+		}
+		return
+	}
+
+	rsp := &TaskExportResponse{
+		Fields: export.Fields,
+		Tasks:  newTaskListResponse(export.Tasks),
+	}
+	for _, task := range rsp.Tasks {
+		if len(export.Values[task.ID]) > 0 {
+			task.CustomFields = fieldValueMap(export.Values[task.ID])
+		}
+		if len(export.Labels[task.ID]) > 0 {
+			task.Labels = export.Labels[task.ID]
+		}
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// one row per task, one column per custom field
+func writeTaskExportCSV(w http.ResponseWriter, export *task.Export) error {
+	header := []string{"id", "body", "isDone", "projectId", "statusId", "createdAt", "dueAt", "priority", "labels"}
+	nameCount := make(map[string]int, len(export.Fields))
+	for _, field := range export.Fields {
+		nameCount[field.Name]++
+	}
+	column := make(map[int64]int, len(export.Fields))
+	for _, field := range export.Fields {
+		column[field.ID] = len(header)
+		name := field.Name
+		if nameCount[name] > 1 {
+			//same field name in several projects
+			name = fmt.Sprintf("%s (#%d)", name, field.ID)
+		}
+		header = append(header, name)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, t := range export.Tasks {
+		record := make([]string, len(header))
+		record[0] = strconv.FormatInt(t.ID, 10)
+		record[1] = t.Body
+		record[2] = strconv.FormatBool(t.IsDone)
+		if t.ProjectID.Valid {
+			record[3] = strconv.FormatInt(t.ProjectID.Int64, 10)
+		}
+		if t.StatusID.Valid {
+			record[4] = strconv.FormatInt(t.StatusID.Int64, 10)
+		}
+		record[5] = t.CreatedAt.Format(time.RFC3339)
+		if t.DueAt.Valid {
+			record[6] = t.DueAt.Time.Format(time.RFC3339)
+		}
+		record[7] = task.PriorityName(t.Priority)
+		record[8] = strings.Join(export.Labels[t.ID], ";")
+		for _, value := range export.Values[t.ID] {
+			if i, ok := column[value.FieldID]; ok {
+				record[i] = csvFieldValue(value.Value)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// strings are written without quotes, multi select values are joined with ;
+func csvFieldValue(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	var list []string
+	if err := json.Unmarshal(value, &list); err == nil {
+		return strings.Join(list, ";")
+	}
+	return string(value)
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/inbox"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// email is empty when the email-to-task server is off
+type InboxResponse struct {
+	URL   string `json:"url"`
+	Email string `json:"email,omitempty"`
+}
+
+func (*InboxResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) newInboxResponse(inboxToken string) *InboxResponse {
+	rsp := &InboxResponse{URL: fmt.Sprintf("%s/inbox/%s", server.config.PublicURL, inboxToken)}
+	if server.config.InboxSMTPAddress != "" && server.config.InboxDomain != "" {
+		rsp.Email = fmt.Sprintf("%s@%s", inboxToken, server.config.InboxDomain)
+	}
+	return rsp
+}
+
+// the secret url and email address that create tasks for the user
+func (server *Server) getInbox(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	inboxToken, err := server.inbox.GetToken(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, server.newInboxResponse(inboxToken)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// replace the inbox url and email address, e.g. after they leaked
+func (server *Server) rotateInbox(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	inboxToken, err := server.inbox.RotateToken(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, server.newInboxResponse(inboxToken)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// a task posted to an inbox as JSON {body, dueAt, priority}
+// or as a form with the same fields, files of a multipart form are attached
+type InboxTaskRequest struct {
+	Body     string     `json:"body"`
+	DueAt    *time.Time `json:"dueAt"`
+	Priority string     `json:"priority"`
+	priority int16
+}
+
+func (c *InboxTaskRequest) Bind(r *http.Request) (err error) {
+	if c.Body == "" {
+		return fmt.Errorf("body is a required field")
+	}
+	c.priority, err = parsePriority(c.Priority)
+	return err
+}
+
+// bindInboxForm reads an urlencoded or multipart form
+func bindInboxForm(r *http.Request) (*InboxTaskRequest, []task.Attachment, error) {
+	//files beyond the limit are rejected by CheckAttachments
+	if err := r.ParseMultipartForm(task.MaxAttachmentSize); err != nil && err != http.ErrNotMultipart {
+		return nil, nil, err
+	}
+	data := &InboxTaskRequest{
+		Body:     r.FormValue("body"),
+		Priority: r.FormValue("priority"),
+	}
+	if dueAt := r.FormValue("dueAt"); dueAt != "" {
+		t, err := time.Parse(time.RFC3339, dueAt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dueAt must be formatted as RFC 3339")
+		}
+		data.DueAt = &t
+	}
+	if err := data.Bind(r); err != nil {
+		return nil, nil, err
+	}
+
+	var attachments []task.Attachment
+	if r.MultipartForm != nil {
+		for _, files := range r.MultipartForm.File {
+			for _, header := range files {
+				if header.Size > task.MaxAttachmentSize {
+					return nil, nil, task.ErrAttachmentTooLarge
+				}
+				file, err := header.Open()
+				if err != nil {
+					return nil, nil, err
+				}
+				content, err := io.ReadAll(file)
+				file.Close()
+				if err != nil {
+					return nil, nil, err
+				}
+				attachments = append(attachments, task.Attachment{
+					Filename:    header.Filename,
+					ContentType: header.Header.Get("Content-Type"),
+					Content:     content,
+				})
+			}
+		}
+	}
+	return data, attachments, task.CheckAttachments(attachments)
+}
+
+// POST /inbox/{token} - the token in the url authenticates the request
+func (server *Server) postToInbox(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, (task.MaxAttachments+1)*task.MaxAttachmentSize)
+
+	var data *InboxTaskRequest
+	var attachments []task.Attachment
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" {
+		data, attachments, err = bindInboxForm(r)
+	} else {
+		data = &InboxTaskRequest{}
+		err = render.Bind(r, data)
+	}
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	rsp, err := server.createInboxTask(r.Context(), chi.URLParam(r, "token"), &inbox.Message{
+		Body:        data.Body,
+		DueAt:       data.DueAt,
+		Priority:    data.priority,
+		Attachments: attachments,
+	})
+	if err != nil {
+		renderInboxError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// CreateInboxTask creates the task of an email sent to an inbox,
+// the email-to-task server calls it
+func (server *Server) CreateInboxTask(ctx context.Context, inboxToken string, msg *inbox.Message) error {
+	_, err := server.createInboxTask(ctx, inboxToken, msg)
+	return err
+}
+
+func (server *Server) createInboxTask(ctx context.Context, inboxToken string, msg *inbox.Message) (*TaskResponse, error) {
+	created, err := server.inbox.CreateTask(ctx, inboxToken, msg)
+	if err != nil {
+		return nil, err
+	}
+	return server.taskChanged(ctx, created, event.TypeTaskCreated)
+}
+
+// map errors from inbox service to responses
+func renderInboxError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case inbox.ErrInvalidToken:
+		render.Render(w, r, ErrNotFound)
+	case inbox.ErrEmptyBody, task.ErrAttachmentTooLarge, task.ErrTooManyAttachments:
+		render.Render(w, r, ErrInvalidRequest(err))
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+type TaskAttachmentListResponse struct {
+	Attachments []db.GetTaskAttachmentListRow `json:"attachments"`
+}
+
+func (*TaskAttachmentListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// map errors from attachment methods of task service to responses
+func renderAttachmentError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case task.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case task.ErrAttachmentNotInTask, sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+func (server *Server) getTaskAttachmentList(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	attachments, err := server.task.GetAttachmentList(r.Context(), taskId, payload.User.ID)
+	if err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &TaskAttachmentListResponse{Attachments: attachments}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// download an attachment
+func (server *Server) getTaskAttachment(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	attachmentId, err := getIdFromURLPath(r, "attachmentID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	attachment, err := server.task.GetAttachment(r.Context(), taskId, attachmentId, payload.User.ID)
+	if err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	//files come from other people, browsers must not run them on this origin
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(attachment.Content)
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/punkzberryz/todo/service/auth"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// first login step of a user with two-factor authentication
+type mfaChallengeResponse struct {
+	MfaRequired bool      `json:"mfa_required"`
+	MfaToken    string    `json:"mfa_token"`
+	MfaMethods  []string  `json:"mfa_methods"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (*mfaChallengeResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type loginMfaRequest struct {
+	MfaToken string `json:"mfa_token"`
+	// a totp code or a recovery code
+	Code string `json:"code"`
+}
+
+func (c *loginMfaRequest) Bind(r *http.Request) error {
+	if c.MfaToken == "" || c.Code == "" {
+		return fmt.Errorf("missing mfa_token or/and code fields")
+	}
+	return nil
+}
+
+func renderMfaError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case auth.ErrInvalidMfaCode, auth.ErrInvalidMfaChallenge:
+		render.Render(w, r, ErrUnauthorized(err))
+	case auth.ErrMfaLocked:
+		render.Render(w, r, ErrTooManyRequests(err))
+	case auth.ErrMfaAlreadyEnabled:
+		render.Render(w, r, ErrConflict(err))
+	case auth.ErrMfaNotEnabled, auth.ErrMfaNotStarted:
+		render.Render(w, r, ErrInvalidRequest(err))
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+func (server *Server) loginMfa(w http.ResponseWriter, r *http.Request) {
+	data := &loginMfaRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	user, err := server.auth.CompleteMfaLogin(r.Context(), data.MfaToken, data.Code)
+	if err != nil {
+		renderMfaError(w, r, err)
+		return
+	}
+	server.renderLogin(w, r, user)
+}
+
+type mfaStatusResponse struct {
+	*auth.MfaStatus
+}
+
+func (*mfaStatusResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) getMfaStatus(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	status, err := server.auth.GetMfaStatus(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, &mfaStatusResponse{status}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type totpEnrollmentResponse struct {
+	*auth.TotpEnrollment
+}
+
+func (*totpEnrollmentResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) enrollTotp(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	enrollment, err := server.auth.EnrollTotp(r.Context(), payload.User.ID, payload.User.Email)
+	if err != nil {
+		renderMfaError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &totpEnrollmentResponse{enrollment}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code"`
+}
+
+func (c *mfaCodeRequest) Bind(r *http.Request) error {
+	if c.Code == "" {
+		return fmt.Errorf("missing code")
+	}
+	return nil
+}
+
+// recovery codes are only ever shown in this response
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func (*recoveryCodesResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) confirmTotp(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &mfaCodeRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	codes, err := server.auth.ConfirmTotp(r.Context(), payload.User.ID, data.Code)
+	if err != nil {
+		renderMfaError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &recoveryCodesResponse{codes}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type disableTotpResponse struct {
+	Message string `json:"message"`
+}
+
+func (*disableTotpResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) disableTotp(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &mfaCodeRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if err := server.auth.DisableTotp(r.Context(), payload.User.ID, data.Code); err != nil {
+		renderMfaError(w, r, err)
+		return
+	}
+	rsp := &disableTotpResponse{
+		Message: "two-factor authentication is disabled",
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &mfaCodeRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	codes, err := server.auth.RegenerateRecoveryCodes(r.Context(), payload.User.ID, data.Code)
+	if err != nil {
+		renderMfaError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &recoveryCodesResponse{codes}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
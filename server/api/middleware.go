@@ -4,16 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"strings"
 
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/punkzberryz/todo/service/apikey"
+	"github.com/punkzberryz/todo/service/auth"
+	"github.com/punkzberryz/todo/service/token"
 )
 
 type ctxKey string
 
 const (
 	payloadKey ctxKey = "payload"
+	// scopesKey holds the scopes of an api key, requests with a login token have none and may do everything
+	scopesKey ctxKey = "scopes"
+	// apiKeyIdKey holds the id of the api key a request is authenticated with
+	apiKeyIdKey ctxKey = "apiKeyId"
 )
 
 func (server *Server) authMiddleware(next http.Handler) http.Handler {
@@ -34,9 +44,18 @@ func (server *Server) authMiddleware(next http.Handler) http.Handler {
 			}
 
 			accessToken := fields[1]
-			payload, err := server.token.Maker.VerifyToken(accessToken)
+			if strings.HasPrefix(accessToken, apikey.KeyPrefix) {
+				server.apiKeyAuth(w, r, next, accessToken)
+				return
+			}
+			payload, err := server.token.VerifyAccessToken(r.Context(), accessToken)
 			if err != nil {
-				render.Render(w, r, ErrUnauthorized(err))
+				switch err {
+				case token.ErrInvalidToken, token.ErrExpiredToken, token.ErrRevokedToken:
+					render.Render(w, r, ErrUnauthorized(err))
+				default:
+					render.Render(w, r, ErrInternalServer(err))
+				}
 				return
 			}
 
@@ -44,3 +63,104 @@ func (server *Server) authMiddleware(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 }
+
+// apiKeyAuth is authMiddleware for api keys, handlers see the key's user
+// in the payload like with a login token and the key's scopes in the context
+func (server *Server) apiKeyAuth(w http.ResponseWriter, r *http.Request, next http.Handler, key string) {
+	apiKey, user, err := server.apiKey.Authenticate(r.Context(), key)
+	if err != nil {
+		if err == apikey.ErrInvalidApiKey || err == apikey.ErrExpiredApiKey {
+			render.Render(w, r, ErrUnauthorized(err))
+			return
+		}
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	payload := &token.Payload{
+		User: token.User{
+			ID:       user.ID,
+			Email:    user.Email,
+			Username: user.Username,
+		},
+		IssuedAt:  apiKey.CreatedAt,
+		ExpiredAt: apiKey.ExpiresAt,
+	}
+	ctx := context.WithValue(r.Context(), payloadKey, payload)
+	ctx = context.WithValue(ctx, scopesKey, apiKey.Scopes)
+	ctx = context.WithValue(ctx, apiKeyIdKey, apiKey.ID)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// hasScope tells whether the request may use scope, it runs after authMiddleware
+func hasScope(r *http.Request, scope string) bool {
+	scopes, ok := r.Context().Value(scopesKey).([]string)
+	return !ok || apikey.HasScope(scopes, scope)
+}
+
+// scopeMiddleware only lets api keys with scope through
+func scopeMiddleware(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if !hasScope(r, scope) {
+					render.Render(w, r, ErrForbidden(fmt.Errorf("api key is missing the %s scope", scope)))
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+	}
+}
+
+// taskScopeMiddleware asks api keys for tasks:read to read and tasks:write for anything else
+func taskScopeMiddleware(next http.Handler) http.Handler {
+	read := scopeMiddleware(apikey.ScopeTasksRead)(next)
+	write := scopeMiddleware(apikey.ScopeTasksWrite)(next)
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				read.ServeHTTP(w, r)
+			default:
+				write.ServeHTTP(w, r)
+			}
+		})
+}
+
+// verifiedEmailMiddleware blocks users who haven't verified their email
+// when the server is configured to require it, it runs after authMiddleware
+func (server *Server) verifiedEmailMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !server.config.RequireVerifiedEmail {
+				next.ServeHTTP(w, r)
+				return
+			}
+			payload := r.Context().Value(payloadKey).(*token.Payload)
+			if err := server.auth.RequireVerifiedEmail(r.Context(), payload.User.ID); err != nil {
+				if err == auth.ErrEmailNotVerified {
+					render.Render(w, r, ErrForbidden(err))
+					return
+				}
+				render.Render(w, r, ErrInternalServer(err))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+}
+
+// requestLogger is middleware.Logger without query strings, they carry the tokens
+// of email links and stream tickets
+var requestLogger = middleware.RequestLogger(&queryFreeLogFormatter{
+	LogFormatter: &middleware.DefaultLogFormatter{Logger: log.New(os.Stdout, "", log.LstdFlags), NoColor: true},
+})
+
+type queryFreeLogFormatter struct {
+	middleware.LogFormatter
+}
+
+func (f *queryFreeLogFormatter) NewLogEntry(r *http.Request) middleware.LogEntry {
+	//a shallow copy, the handlers still get the query
+	logged := r.WithContext(r.Context())
+	logged.RequestURI = r.URL.EscapedPath()
+	return f.LogFormatter.NewLogEntry(logged)
+}
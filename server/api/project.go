@@ -0,0 +1,476 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+type ProjectResponse struct {
+	*db.Project
+	Statuses    []db.ProjectStatus    `json:"statuses,omitempty"`
+	Transitions []db.StatusTransition `json:"transitions,omitempty"`
+}
+
+func (*ProjectResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type ProjectListResponse struct {
+	Projects []db.Project `json:"projects"`
+}
+
+func (*ProjectListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// read an int64 id such as projectID or statusID from url path
+func getIdFromURLPath(r *http.Request, key string) (int64, error) {
+	value := chi.URLParam(r, key)
+	if value == "" {
+		return 0, fmt.Errorf("%s is required", key)
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+	return id, nil
+}
+
+// map errors from project service to responses
+func renderProjectError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case project.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case project.ErrStatusNotEmpty, project.ErrLastStatus:
+		render.Render(w, r, ErrConflict(err))
+	case project.ErrInvalidCategory, project.ErrInvalidWipLimit, project.ErrStatusNotInProject:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+// create new project with default status columns
+type CreateProjectRequest struct {
+	Name string `json:"name"`
+}
+
+func (c *CreateProjectRequest) Bind(r *http.Request) error {
+	if c.Name == "" {
+		return fmt.Errorf("name is a required field")
+	}
+	return nil
+}
+
+func (server *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	data := &CreateProjectRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	result, err := server.project.CreateProject(r.Context(), db.CreateProjectParams{
+		Name:    data.Name,
+		OwnerID: payload.User.ID,
+	})
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+
+	if err := render.Render(w, r, &ProjectResponse{
+		Project:  &result.Project,
+		Statuses: result.Statuses,
+	}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// get project with its statuses and transitions
+func (server *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	projectRsp, err := server.project.GetProjectById(r.Context(), id, payload.User.ID)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	statuses, err := server.project.GetStatusList(r.Context(), id, payload.User.ID)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	transitions, err := server.project.GetTransitionList(r.Context(), id, payload.User.ID)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+
+	if err := render.Render(w, r, &ProjectResponse{
+		Project:     projectRsp,
+		Statuses:    statuses,
+		Transitions: transitions,
+	}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// get project list by owner id
+func (server *Server) getProjectList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	projects, err := server.project.GetProjectList(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+
+	if err := render.Render(w, r, &ProjectListResponse{Projects: projects}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// rename project
+func (server *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &CreateProjectRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	projectRsp, err := server.project.UpdateProject(r.Context(), db.UpdateProjectParams{
+		ID:      id,
+		OwnerID: payload.User.ID,
+		Name:    data.Name,
+	})
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &ProjectResponse{Project: projectRsp}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// Delete project, same as deleteTask the response is always success
+// even if project doesn't exist or is not owned by the user
+type deleteProjectResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteProjectResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	err = server.project.DeleteProject(r.Context(), db.DeleteProjectParams{
+		ID:      id,
+		OwnerID: payload.User.ID,
+	})
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+
+	rsp := &deleteProjectResponse{
+		Message: fmt.Sprintf("delete project id %d success", id),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// board: tasks of a project grouped by status column
+type BoardColumnResponse struct {
+	Status db.ProjectStatus `json:"status"`
+	Tasks  []*TaskResponse  `json:"tasks"`
+}
+
+type BoardResponse struct {
+	Project db.Project            `json:"project"`
+	Columns []BoardColumnResponse `json:"columns"`
+}
+
+func (*BoardResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) getBoard(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	board, err := server.project.GetBoard(r.Context(), id, payload.User.ID)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+
+	rsp := &BoardResponse{
+		Project: board.Project,
+		Columns: make([]BoardColumnResponse, len(board.Columns)),
+	}
+	for i, column := range board.Columns {
+		rsp.Columns[i] = BoardColumnResponse{
+			Status: column.Status,
+			Tasks:  newTaskListResponse(column.Tasks),
+		}
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// status columns
+type ProjectStatusResponse struct {
+	*db.ProjectStatus
+}
+
+func (*ProjectStatusResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type ProjectStatusListResponse struct {
+	Statuses []db.ProjectStatus `json:"statuses"`
+}
+
+func (*ProjectStatusListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// wipLimit of 0 means no limit
+type ProjectStatusRequest struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Position int32  `json:"position"`
+	WipLimit int32  `json:"wipLimit"`
+}
+
+func (c *ProjectStatusRequest) Bind(r *http.Request) error {
+	if c.Name == "" || c.Category == "" {
+		return fmt.Errorf("name and category are required fields")
+	}
+	return nil
+}
+
+func (server *Server) getProjectStatusList(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	statuses, err := server.project.GetStatusList(r.Context(), id, payload.User.ID)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &ProjectStatusListResponse{Statuses: statuses}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) createProjectStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &ProjectStatusRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	status, err := server.project.CreateStatus(r.Context(), payload.User.ID, db.CreateProjectStatusParams{
+		ProjectID: id,
+		Name:      data.Name,
+		Category:  data.Category,
+		Position:  data.Position,
+		WipLimit:  data.WipLimit,
+	})
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &ProjectStatusResponse{ProjectStatus: status}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) updateProjectStatus(w http.ResponseWriter, r *http.Request) {
+	projectId, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	statusId, err := getIdFromURLPath(r, "statusID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &ProjectStatusRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	status, err := server.project.UpdateStatus(r.Context(), payload.User.ID, projectId, db.UpdateProjectStatusParams{
+		ID:       statusId,
+		Name:     data.Name,
+		Category: data.Category,
+		Position: data.Position,
+		WipLimit: data.WipLimit,
+	})
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &ProjectStatusResponse{ProjectStatus: status}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteProjectStatusResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteProjectStatusResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteProjectStatus(w http.ResponseWriter, r *http.Request) {
+	projectId, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	statusId, err := getIdFromURLPath(r, "statusID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.project.DeleteStatus(r.Context(), payload.User.ID, projectId, statusId); err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	rsp := &deleteProjectStatusResponse{
+		Message: fmt.Sprintf("delete status id %d success", statusId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// transitions: the whole list is replaced on every update
+type StatusTransitionListResponse struct {
+	Transitions []db.StatusTransition `json:"transitions"`
+}
+
+func (*StatusTransitionListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type statusTransitionRequest struct {
+	FromStatusID int64 `json:"fromStatusId"`
+	ToStatusID   int64 `json:"toStatusId"`
+}
+
+type ReplaceStatusTransitionsRequest struct {
+	Transitions []statusTransitionRequest `json:"transitions"`
+}
+
+func (c *ReplaceStatusTransitionsRequest) Bind(r *http.Request) error {
+	for _, transition := range c.Transitions {
+		if transition.FromStatusID == 0 || transition.ToStatusID == 0 {
+			return fmt.Errorf("fromStatusId and toStatusId are required fields")
+		}
+	}
+	return nil
+}
+
+func (server *Server) getStatusTransitionList(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	transitions, err := server.project.GetTransitionList(r.Context(), id, payload.User.ID)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &StatusTransitionListResponse{Transitions: transitions}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) replaceStatusTransitions(w http.ResponseWriter, r *http.Request) {
+	id, err := getIdFromURLPath(r, "projectID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &ReplaceStatusTransitionsRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	arg := make([]db.CreateStatusTransitionParams, len(data.Transitions))
+	for i, transition := range data.Transitions {
+		arg[i] = db.CreateStatusTransitionParams{
+			ProjectID:    id,
+			FromStatusID: transition.FromStatusID,
+			ToStatusID:   transition.ToStatusID,
+		}
+	}
+	transitions, err := server.project.ReplaceTransitions(r.Context(), payload.User.ID, id, arg)
+	if err != nil {
+		renderProjectError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &StatusTransitionListResponse{Transitions: transitions}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// minutesBeforeDue is set for reminders relative to the due date,
+// fireAt is when the reminder is sent next
+type ReminderResponse struct {
+	ID               int64      `json:"id"`
+	TaskID           int64      `json:"taskId"`
+	RemindAt         *time.Time `json:"remindAt"`
+	MinutesBeforeDue *int32     `json:"minutesBeforeDue"`
+	FireAt           *time.Time `json:"fireAt"`
+	SentAt           *time.Time `json:"sentAt"`
+	Attempts         int32      `json:"attempts"`
+	LastError        string     `json:"lastError,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+func newReminderResponse(reminder *db.Reminder) *ReminderResponse {
+	rsp := &ReminderResponse{
+		ID:        reminder.ID,
+		TaskID:    reminder.TaskID,
+		RemindAt:  nullTimePtr(reminder.RemindAt),
+		FireAt:    nullTimePtr(reminder.FireAt),
+		SentAt:    nullTimePtr(reminder.SentAt),
+		Attempts:  reminder.Attempts,
+		LastError: reminder.LastError,
+		CreatedAt: reminder.CreatedAt,
+	}
+	if reminder.OffsetMinutes.Valid {
+		rsp.MinutesBeforeDue = &reminder.OffsetMinutes.Int32
+	}
+	return rsp
+}
+
+func (*ReminderResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type ReminderListResponse struct {
+	Reminders []*ReminderResponse `json:"reminders"`
+}
+
+func (*ReminderListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// a reminder at a fixed time or relative to the due date of the task
+// {"remindAt": "2024-03-14T09:00:00Z"} or {"minutesBeforeDue": 60}
+type CreateReminderRequest struct {
+	RemindAt         *time.Time `json:"remindAt"`
+	MinutesBeforeDue *int32     `json:"minutesBeforeDue"`
+}
+
+func (c *CreateReminderRequest) Bind(r *http.Request) error {
+	if (c.RemindAt == nil) == (c.MinutesBeforeDue == nil) {
+		return task.ErrInvalidReminder
+	}
+	if c.MinutesBeforeDue != nil && *c.MinutesBeforeDue < 0 {
+		return fmt.Errorf("minutesBeforeDue must not be negative")
+	}
+	return nil
+}
+
+// snooze for a number of minutes or until a time
+// {"minutes": 10} or {"until": "2024-03-14T09:00:00Z"}
+type SnoozeReminderRequest struct {
+	Minutes int32      `json:"minutes"`
+	Until   *time.Time `json:"until"`
+}
+
+func (c *SnoozeReminderRequest) Bind(r *http.Request) error {
+	if (c.Minutes == 0) == (c.Until == nil) {
+		return fmt.Errorf("snooze needs either minutes or until")
+	}
+	if c.Minutes < 0 {
+		return fmt.Errorf("minutes must be positive")
+	}
+	return nil
+}
+
+func (c *SnoozeReminderRequest) until() time.Time {
+	if c.Until != nil {
+		return *c.Until
+	}
+	return time.Now().Add(time.Duration(c.Minutes) * time.Minute)
+}
+
+// map errors from reminder service to responses
+func renderReminderError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case task.ErrInvalidReminder, task.ErrReminderNotInTask, task.ErrSnoozeInPast:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		renderTaskError(w, r, err)
+	}
+}
+
+func (server *Server) getReminderList(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	reminders, err := server.task.GetReminderList(r.Context(), taskId, payload.User.ID)
+	if err != nil {
+		renderReminderError(w, r, err)
+		return
+	}
+	rsp := &ReminderListResponse{Reminders: make([]*ReminderResponse, len(reminders))}
+	for i := range reminders {
+		rsp.Reminders[i] = newReminderResponse(&reminders[i])
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) createReminder(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &CreateReminderRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	reminder, err := server.task.CreateReminder(r.Context(), taskId, payload.User.ID, task.ReminderParams{
+		RemindAt:         data.RemindAt,
+		MinutesBeforeDue: data.MinutesBeforeDue,
+	})
+	if err != nil {
+		renderReminderError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newReminderResponse(reminder)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) snoozeReminder(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	reminderId, err := getIdFromURLPath(r, "reminderID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &SnoozeReminderRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	reminder, err := server.task.SnoozeReminder(r.Context(), taskId, reminderId, payload.User.ID, data.until())
+	if err != nil {
+		renderReminderError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newReminderResponse(reminder)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteReminderResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteReminderResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteReminder(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	reminderId, err := getIdFromURLPath(r, "reminderID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.task.DeleteReminder(r.Context(), taskId, reminderId, payload.User.ID); err != nil {
+		renderReminderError(w, r, err)
+		return
+	}
+	rsp := &deleteReminderResponse{
+		Message: fmt.Sprintf("delete reminder id %d success", reminderId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// saved filters are named task queries, also known as smart lists
+type SavedFilterResponse struct {
+	*db.SavedFilter
+}
+
+func (*SavedFilterResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type SavedFilterListResponse struct {
+	Filters []db.SavedFilter `json:"filters"`
+}
+
+func (*SavedFilterListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type SavedFilterRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+func (c *SavedFilterRequest) Bind(r *http.Request) error {
+	if c.Name == "" {
+		return fmt.Errorf("name is a required field")
+	}
+	if c.Query == "" {
+		return fmt.Errorf("query is a required field")
+	}
+	return nil
+}
+
+// map errors from saved filter service to responses
+func renderSavedFilterError(w http.ResponseWriter, r *http.Request, err error) {
+	if _, ok := err.(*task.FilterError); ok {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	switch err {
+	case task.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case task.ErrFilterNameInUse:
+		render.Render(w, r, ErrConflict(err))
+	case task.ErrFilterNameRequired:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		renderTaskListError(w, r, err)
+	}
+}
+
+func (server *Server) getSavedFilterList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	filters, err := server.task.GetSavedFilterList(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, &SavedFilterListResponse{Filters: filters}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) createSavedFilter(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &SavedFilterRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	filter, err := server.task.CreateSavedFilter(r.Context(), db.CreateSavedFilterParams{
+		OwnerID: payload.User.ID,
+		Name:    data.Name,
+		Query:   data.Query,
+	})
+	if err != nil {
+		renderSavedFilterError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &SavedFilterResponse{SavedFilter: filter}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) getSavedFilter(w http.ResponseWriter, r *http.Request) {
+	filterId, err := getIdFromURLPath(r, "filterID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	filter, err := server.task.GetSavedFilterById(r.Context(), filterId, payload.User.ID)
+	if err != nil {
+		renderSavedFilterError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &SavedFilterResponse{SavedFilter: filter}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) updateSavedFilter(w http.ResponseWriter, r *http.Request) {
+	filterId, err := getIdFromURLPath(r, "filterID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &SavedFilterRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	filter, err := server.task.UpdateSavedFilter(r.Context(), db.UpdateSavedFilterParams{
+		ID:      filterId,
+		OwnerID: payload.User.ID,
+		Name:    data.Name,
+		Query:   data.Query,
+	})
+	if err != nil {
+		renderSavedFilterError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &SavedFilterResponse{SavedFilter: filter}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteSavedFilterResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteSavedFilterResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteSavedFilter(w http.ResponseWriter, r *http.Request) {
+	filterId, err := getIdFromURLPath(r, "filterID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	err = server.task.DeleteSavedFilter(r.Context(), db.DeleteSavedFilterParams{
+		ID:      filterId,
+		OwnerID: payload.User.ID,
+	})
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &deleteSavedFilterResponse{
+		Message: fmt.Sprintf("delete filter id %d success", filterId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// tasks matching a saved filter
+// /filters/3/tasks?pageId=1&limit=10&sort=due&tz=Europe/Berlin
+func (server *Server) getSavedFilterTasks(w http.ResponseWriter, r *http.Request) {
+	filterId, err := getIdFromURLPath(r, "filterID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	queryStrings := r.URL.Query()
+	pageId, err := strconv.Atoi(queryStrings.Get("pageId"))
+	if err != nil {
+		pageId = 1
+	}
+	limit, err := strconv.Atoi(queryStrings.Get("limit"))
+	if err != nil {
+		limit = 10
+	}
+	arg, err := parseTaskListParams(queryStrings)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	arg.OwnerID = payload.User.ID
+	arg.Limit = int32(limit)
+	arg.PageID = int32(pageId)
+
+	taskList, err := server.task.GetSavedFilterTasks(r.Context(), filterId, arg)
+	if err != nil {
+		renderSavedFilterError(w, r, err)
+		return
+	}
+	rsp := &TaskListResponse{Tasks: newTaskListResponse(taskList)}
+	if err := server.withTaskDetails(r.Context(), rsp.Tasks...); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type LabelListResponse struct {
+	Labels []db.Label `json:"labels"`
+}
+
+func (*LabelListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// labels are created by tagging tasks, see CreateTaskRequest
+func (server *Server) getLabelList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	labels, err := server.task.GetLabelList(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, &LabelListResponse{Labels: labels}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteLabelResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteLabelResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteLabel(w http.ResponseWriter, r *http.Request) {
+	labelId, err := getIdFromURLPath(r, "labelID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.task.DeleteLabel(r.Context(), labelId, payload.User.ID); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &deleteLabelResponse{
+		Message: fmt.Sprintf("delete label id %d success", labelId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
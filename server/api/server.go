@@ -7,26 +7,52 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/apikey"
+	"github.com/punkzberryz/todo/service/archive"
 	"github.com/punkzberryz/todo/service/auth"
+	"github.com/punkzberryz/todo/service/delta"
+	"github.com/punkzberryz/todo/service/digest"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/inbox"
 	"github.com/punkzberryz/todo/service/mail"
+	"github.com/punkzberryz/todo/service/project"
+	"github.com/punkzberryz/todo/service/stats"
 	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/template"
+	"github.com/punkzberryz/todo/service/timetrack"
 	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/service/webauthn"
+	"github.com/punkzberryz/todo/service/webhook"
 	"github.com/punkzberryz/todo/session"
 	"github.com/punkzberryz/todo/util"
 )
 
 type Server struct {
-	config util.Config
-	Router *chi.Mux
-	auth   auth.Auth
-	task   task.Task
-	token  token.Token
-	mail   mail.EmailSender
+	config    util.Config
+	Router    *chi.Mux
+	auth      auth.Auth
+	task      task.Task
+	project   project.Project
+	digest    digest.Digest
+	delta     delta.Delta
+	webhook   webhook.Webhook
+	inbox     inbox.Inbox
+	timetrack timetrack.TimeTrack
+	stats     stats.Stats
+	template  template.Template
+	archive   archive.Archive
+	token     token.Token
+	keyring   *token.Keyring //nil with symmetric tokens
+	webauthn  webauthn.WebAuthn
+	apiKey    apikey.ApiKey
+	mail      mail.EmailSender
+	events    event.Broker
+	tickets   session.ChallengeStore //single-use stream tickets
 }
 
 // Create new HTTP server and setup routing
-func NewServer(config util.Config, store *db.Store, session *session.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store *db.Store, session *session.Store, challenges session.ChallengeStore, events event.Broker, mailSender mail.EmailSender) (*Server, error) {
+	tokenMaker, keyring, err := newTokenMaker(config, *store)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %v", err)
 	}
@@ -38,58 +64,265 @@ func NewServer(config util.Config, store *db.Store, session *session.Store) (*Se
 		AccessTokenDuration:  config.AccessTokenDuration,
 	}
 	auth := auth.Auth{
-		Store: *store,
+		Store:           *store,
+		VerificationKey: auth.VerificationKey(config.TokenSymmetricKey),
+		ChallengeKey:    auth.MfaChallengeKey(config.TokenSymmetricKey),
+		Sessions:        &token,
 	}
 	task := task.Task{
 		Store: *store,
 	}
-	mailSender := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+	project := project.Project{
+		Store: *store,
+	}
+	digest := digest.Digest{
+		Store:          *store,
+		UnsubscribeKey: digest.UnsubscribeKey(config.TokenSymmetricKey),
+	}
+	delta := delta.Delta{
+		Store: *store,
+	}
+	webhook := webhook.Webhook{
+		Store:                *store,
+		AllowPrivateNetworks: config.AllowPrivateWebhooks,
+	}
+	inbox := inbox.Inbox{
+		Store: *store,
+		Task:  task,
+	}
+	timetrack := timetrack.TimeTrack{
+		Store: *store,
+	}
+	stats := stats.Stats{
+		Store: *store,
+	}
+	template := template.Template{
+		Store: *store,
+		Task:  task,
+	}
+	archive := archive.Archive{
+		Store: *store,
+		Task:  task,
+	}
+	webauthn := webauthn.WebAuthn{
+		Store:      *store,
+		Challenges: challenges,
+		RPID:       config.WebauthnRPID,
+		RPName:     "Todo",
+		Origins:    config.WebauthnOrigins,
+	}
+	apiKey := apikey.ApiKey{
+		Store: *store,
+	}
 
 	server := &Server{
-		config: config,
-		auth:   auth,
-		task:   task,
-		token:  token,
-		mail:   mailSender,
+		config:    config,
+		auth:      auth,
+		task:      task,
+		project:   project,
+		digest:    digest,
+		delta:     delta,
+		webhook:   webhook,
+		inbox:     inbox,
+		timetrack: timetrack,
+		stats:     stats,
+		template:  template,
+		archive:   archive,
+		token:     token,
+		keyring:   keyring,
+		webauthn:  webauthn,
+		apiKey:    apiKey,
+		mail:      mailSender,
+		events:    events,
+		tickets:   challenges,
 	}
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(requestLogger)
 	r.Use(middleware.Recoverer)
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("welcome v2"))
 	})
+	r.Get("/.well-known/jwks.json", server.getJWKS) //GET /.well-known/jwks.json - public keys that verify access tokens
 
 	//user-route
 	r.Route("/user", func(r chi.Router) {
 		r.Post("/", server.createUser)                                 //POST /user/
-		r.Post("/login", server.loginUser)                             //POST /user/login
+		r.Post("/login", server.loginUser)                             //POST /user/login - tokens, or {mfa_required, mfa_token} with two-factor authentication on
+		r.Post("/login/mfa", server.loginMfa)                          //POST /user/login/mfa - {mfa_token, code}, code is a totp or a recovery code
+		r.Post("/login/webauthn/begin", server.beginWebauthnLogin)     //POST /user/login/webauthn/begin - {mfa_token} as second factor, empty for a passkey login
+		r.Post("/login/webauthn/finish", server.finishWebauthnLogin)   //POST /user/login/webauthn/finish - {ceremony, mfa_token, credential}
 		r.Post("/logout", server.removeTokenSession)                   //POST /user/logout
 		r.Post("/reset-password-request", server.resetPasswordRequest) //POST /user/reset-password-request
 		r.Post("/reset-password", server.resetPassword)                //POST /user/reset-password
+		r.Get("/verify-email", server.verifyEmail)                     //GET /user/verify-email?token= - link in the verification email
+		r.Post("/verify-email", server.verifyEmail)                    //POST /user/verify-email - {token}
 	})
 
 	//token
 	r.Post("/tokens/renew_access", server.renewAccessToken)
 
 	// user-route-protected
+	r.With(server.authMiddleware, scopeMiddleware(apikey.ScopeTasksRead)).
+		Get("/me/stats", server.getStats) //GET /me/stats?from=&to=&period=day|week&tz= - created vs completed, streaks, overdue rate
 	r.Route("/me", func(r chi.Router) {
-		r.Use(server.authMiddleware)
-		r.Get("/", server.getCurrentUser) //GET /me/
+		r.Use(server.authMiddleware, scopeMiddleware(apikey.ScopeAdmin))
+		r.Get("/", server.getCurrentUser)                                                 //GET /me/
+		r.Post("/logout-all", server.logoutAll)                                           //POST /me/logout-all - revoke every session and access token
+		r.Get("/sessions", server.getSessionList)                                         //GET /me/sessions - device, ip, created and last used time of each login
+		r.Delete("/sessions/{sessionID}", server.deleteSession)                           //DELETE /me/sessions/{sessionID} - log one login out
+		r.Post("/verify-email/resend", server.resendVerificationEmail)                    //POST /me/verify-email/resend - at most once a minute
+		r.Get("/mfa", server.getMfaStatus)                                                //GET /me/mfa
+		r.Post("/mfa/totp", server.enrollTotp)                                            //POST /me/mfa/totp - new secret and otpauth uri, enabled by confirm
+		r.Post("/mfa/totp/confirm", server.confirmTotp)                                   //POST /me/mfa/totp/confirm - {code}, returns recovery codes once
+		r.Post("/mfa/totp/disable", server.disableTotp)                                   //POST /me/mfa/totp/disable - {code}
+		r.Post("/mfa/recovery-codes", server.regenerateRecoveryCodes)                     //POST /me/mfa/recovery-codes - {code}, replaces all recovery codes
+		r.Post("/webauthn/register/begin", server.beginWebauthnRegistration)              //POST /me/webauthn/register/begin - options for navigator.credentials.create
+		r.Post("/webauthn/register/finish", server.finishWebauthnRegistration)            //POST /me/webauthn/register/finish - {ceremony, name, credential}
+		r.Get("/webauthn/credentials", server.getWebauthnCredentialList)                  //GET /me/webauthn/credentials
+		r.Delete("/webauthn/credentials/{credentialID}", server.deleteWebauthnCredential) //DELETE /me/webauthn/credentials/{credentialID}
+		r.Get("/digest", server.getDigestPreference)                                      //GET /me/digest
+		r.Put("/digest", server.updateDigestPreference)                                   //PUT /me/digest - {frequency, time, timezone, weekday}
+		r.Get("/inbox", server.getInbox)                                                  //GET /me/inbox - url and email address that create tasks
+		r.Post("/inbox/rotate", server.rotateInbox)                                       //POST /me/inbox/rotate - new url and email address
+		r.Get("/archive", server.getArchiveRule)                                          //GET /me/archive
+		r.Put("/archive", server.updateArchiveRule)                                       //PUT /me/archive - {afterDays}, null turns automatic archiving off
+		r.Get("/api-keys", server.getApiKeyList)                                          //GET /me/api-keys
+		r.Post("/api-keys", server.createApiKey)                                          //POST /me/api-keys - {name, scopes, expiresAt}, the key is only in this response
+		r.Delete("/api-keys/{keyID}", server.deleteApiKey)                                //DELETE /me/api-keys/3 - revoke
+	})
+	//sync-route for offline clients
+	r.Route("/sync", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/", server.getSyncChanges)    //GET /sync?since=token - changes and deletions since token
+		r.Post("/", server.applySyncChanges) //POST /sync - {strategy, changes}
+	})
+	//event-stream
+	r.Route("/events", func(r chi.Router) {
+		r.With(server.authMiddleware, server.verifiedEmailMiddleware, scopeMiddleware(apikey.ScopeTasksRead)).
+			Post("/ticket", server.createStreamTicket) //POST /events/ticket - single-use ticket for ?ticket= on /events and /ws
+		r.With(server.streamAuthMiddleware, server.verifiedEmailMiddleware, scopeMiddleware(apikey.ScopeTasksRead)).
+			Get("/", server.streamEvents) //GET /events/ - Server-Sent Events, resumes from Last-Event-ID
+	})
+	//websocket
+	r.Route("/ws", func(r chi.Router) {
+		r.Use(server.streamAuthMiddleware, server.verifiedEmailMiddleware, scopeMiddleware(apikey.ScopeTasksRead))
+		r.Get("/", server.serveWebSocket) //GET /ws/ - subscribe to projects, change tasks with tasks:write
+	})
+	//inbox-route, the token in the url authenticates the request
+	r.Post("/inbox/{token}", server.postToInbox) //POST /inbox/abc - {body, dueAt, priority} as JSON or form, form files are attached
+	//digest-route, the token in the link authenticates the user
+	r.Route("/digest", func(r chi.Router) {
+		r.Get("/unsubscribe", server.unsubscribeDigest)  //GET /digest/unsubscribe?token=
+		r.Post("/unsubscribe", server.unsubscribeDigest) //POST /digest/unsubscribe?token=
 	})
 	//task-route
 	r.Route("/task", func(r chi.Router) {
-		r.Use(server.authMiddleware)             //require Header {Authorization: Bearer token}
-		r.Get("/{taskID}", server.getTask)       //GET /task/123
-		r.Post("/", server.createTask)           //POST /task/123
-		r.Get("/", server.getTaskList)           //GET /task/
-		r.Put("/{taskID}", server.updateTask)    //PUT /task/123 - edit task
-		r.Delete("/{taskID}", server.deleteTask) //DELETE /task/123 - delete dask
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware) //require Header {Authorization: Bearer token}
+		r.Get("/export", server.exportTasks)                                              //GET /task/export?format=csv
+		r.Get("/{taskID}", server.getTask)                                                //GET /task/123
+		r.Post("/", server.createTask)                                                    //POST /task/123
+		r.Get("/", server.getTaskList)                                                    //GET /task/ - view=deferred|all shows snoozed tasks, include=archived archived ones
+		r.Put("/{taskID}", server.updateTask)                                             //PUT /task/123 - edit task
+		r.Delete("/{taskID}", server.deleteTask)                                          //DELETE /task/123 - delete dask
+		r.Put("/{taskID}/fields", server.setTaskFields)                                   //PUT /task/123/fields - set custom field values
+		r.Get("/{taskID}/attachments", server.getTaskAttachmentList)                      //GET /task/123/attachments
+		r.Get("/{taskID}/attachments/{attachmentID}", server.getTaskAttachment)           //GET /task/123/attachments/5 - download
+		r.Post("/{taskID}/snooze", server.snoozeTask)                                     //POST /task/123/snooze - {minutes}, {until} or {date: "next monday", tz}
+		r.Delete("/{taskID}/snooze", server.unsnoozeTask)                                 //DELETE /task/123/snooze - show it again now
+		r.Get("/{taskID}/subtasks", server.getSubtasks)                                   //GET /task/123/subtasks
+		r.Get("/{taskID}/time", server.getTaskTimeEntries)                                //GET /task/123/time - time entries
+		r.Get("/{taskID}/reminders", server.getReminderList)                              //GET /task/123/reminders
+		r.Post("/{taskID}/reminders", server.createReminder)                              //POST /task/123/reminders - at a time or before due
+		r.Post("/{taskID}/reminders/{reminderID}/snooze", server.snoozeReminder)          //POST /task/123/reminders/4/snooze
+		r.Delete("/{taskID}/reminders/{reminderID}", server.deleteReminder)               //DELETE /task/123/reminders/4
+	})
+	//archive-route
+	r.Route("/archive", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/", server.getArchivedTaskList)      //GET /archive?pageId=1&limit=10
+		r.Post("/{taskID}", server.archiveTask)     //POST /archive/123 - archive a done task now
+		r.Delete("/{taskID}", server.unarchiveTask) //DELETE /archive/123 - back to the task list
+	})
+	//project-route
+	r.Route("/project", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/", server.getProjectList)                                      //GET /project/
+		r.Post("/", server.createProject)                                      //POST /project/ - with default statuses
+		r.Get("/{projectID}", server.getProject)                               //GET /project/1 - with statuses and transitions
+		r.Put("/{projectID}", server.updateProject)                            //PUT /project/1 - rename
+		r.Delete("/{projectID}", server.deleteProject)                         //DELETE /project/1
+		r.Get("/{projectID}/board", server.getBoard)                           //GET /project/1/board - tasks grouped by status
+		r.Get("/{projectID}/status", server.getProjectStatusList)              //GET /project/1/status
+		r.Post("/{projectID}/status", server.createProjectStatus)              //POST /project/1/status
+		r.Put("/{projectID}/status/{statusID}", server.updateProjectStatus)    //PUT /project/1/status/2
+		r.Delete("/{projectID}/status/{statusID}", server.deleteProjectStatus) //DELETE /project/1/status/2
+		r.Get("/{projectID}/transitions", server.getStatusTransitionList)      //GET /project/1/transitions
+		r.Put("/{projectID}/transitions", server.replaceStatusTransitions)     //PUT /project/1/transitions - replace all
+		r.Get("/{projectID}/fields", server.getCustomFieldList)                //GET /project/1/fields
+		r.Post("/{projectID}/fields", server.createCustomField)                //POST /project/1/fields
+		r.Put("/{projectID}/fields/{fieldID}", server.updateCustomField)       //PUT /project/1/fields/3
+		r.Delete("/{projectID}/fields/{fieldID}", server.deleteCustomField)    //DELETE /project/1/fields/3
+	})
+	//saved-filter-route
+	r.Route("/filters", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/", server.getSavedFilterList)                  //GET /filters/
+		r.Post("/", server.createSavedFilter)                  //POST /filters/ - {name, query}
+		r.Get("/{filterID}", server.getSavedFilter)            //GET /filters/3
+		r.Put("/{filterID}", server.updateSavedFilter)         //PUT /filters/3
+		r.Delete("/{filterID}", server.deleteSavedFilter)      //DELETE /filters/3
+		r.Get("/{filterID}/tasks", server.getSavedFilterTasks) //GET /filters/3/tasks - tasks matching the filter
+	})
+	//template-route
+	r.Route("/template", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/", server.getTemplateList)                              //GET /template/
+		r.Post("/", server.createTemplate)                              //POST /template/ - {name, body, priority, labels, dueOffsetMinutes, subtasks}
+		r.Get("/{templateID}", server.getTemplate)                      //GET /template/5
+		r.Put("/{templateID}", server.updateTemplate)                   //PUT /template/5
+		r.Delete("/{templateID}", server.deleteTemplate)                //DELETE /template/5
+		r.Post("/{templateID}/instantiate", server.instantiateTemplate) //POST /template/5/instantiate - {projectId, startAt, variables}
+	})
+	//webhook-route
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, scopeMiddleware(apikey.ScopeAdmin))
+		r.Get("/", server.getWebhookList)                                                 //GET /webhooks/
+		r.Post("/", server.createWebhook)                                                 //POST /webhooks/ - {url, projectId, events, secret}
+		r.Get("/{webhookID}", server.getWebhook)                                          //GET /webhooks/2
+		r.Put("/{webhookID}", server.updateWebhook)                                       //PUT /webhooks/2 - {url, projectId, events, active}
+		r.Delete("/{webhookID}", server.deleteWebhook)                                    //DELETE /webhooks/2
+		r.Get("/{webhookID}/deliveries", server.getWebhookDeliveryList)                   //GET /webhooks/2/deliveries - delivery log
+		r.Post("/{webhookID}/deliveries/{deliveryID}/redeliver", server.redeliverWebhook) //POST /webhooks/2/deliveries/9/redeliver
+	})
+	//time-tracking-route
+	r.Route("/time", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/current", server.getRunningTimer)              //GET /time/current - the running timer
+		r.Post("/start", server.startTimer)                    //POST /time/start - {taskId, note}, stops the running timer
+		r.Post("/stop", server.stopTimer)                      //POST /time/stop
+		r.Get("/entries", server.getTimeEntries)               //GET /time/entries?from=&to=&tz=&format=csv
+		r.Post("/entries", server.createTimeEntry)             //POST /time/entries - {taskId, startedAt, endedAt, note}
+		r.Put("/entries/{entryID}", server.updateTimeEntry)    //PUT /time/entries/7 - {startedAt, endedAt, note}
+		r.Delete("/entries/{entryID}", server.deleteTimeEntry) //DELETE /time/entries/7
+		r.Get("/report", server.getTimeReport)                 //GET /time/report?from=&to=&group_by=project|label|day&tz=
+	})
+	//label-route
+	r.Route("/label", func(r chi.Router) {
+		r.Use(server.authMiddleware, server.verifiedEmailMiddleware, taskScopeMiddleware)
+		r.Get("/", server.getLabelList)            //GET /label/
+		r.Delete("/{labelID}", server.deleteLabel) //DELETE /label/4 - remove from all tasks
 	})
 
 	server.Router = r
 	return server, nil
 }
+
+// Keyring returns the keyring asymmetric tokens are signed with, nil with symmetric tokens.
+// Its keys have to be rotated while the server runs, see token.RotationWorker
+func (server *Server) Keyring() *token.Keyring {
+	return server.keyring
+}
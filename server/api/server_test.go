@@ -0,0 +1,519 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"github.com/punkzberryz/todo/service/apikey"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+// testClient calls a dev server, it runs the whole API in memory
+type testClient struct {
+	t       *testing.T
+	baseURL string
+}
+
+func newTestClient(t *testing.T) *testClient {
+	return newTestClientWithConfig(t, util.DevConfig())
+}
+
+func newTestClientWithConfig(t *testing.T, config util.Config) *testClient {
+	server, _, err := NewDevServer(config)
+	require.NoError(t, err)
+	httpServer := httptest.NewServer(server.Router)
+	t.Cleanup(httpServer.Close)
+	return &testClient{t: t, baseURL: httpServer.URL}
+}
+
+// do sends body as JSON and decodes the response into rsp, it returns the status code
+func (c *testClient) do(method string, path string, accessToken string, body interface{}, rsp interface{}) int {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer res.Body.Close()
+	if rsp != nil && res.StatusCode < 300 {
+		require.NoError(c.t, json.NewDecoder(res.Body).Decode(rsp))
+	}
+	return res.StatusCode
+}
+
+type testLogin struct {
+	User  userResponse `json:"user"`
+	Token struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	} `json:"token"`
+}
+
+func (c *testClient) signup(email string) testLogin {
+	var login testLogin
+	status := c.do(http.MethodPost, "/user/", "", createUserRequest{Username: "user", Email: email, Password: "secret"}, &login)
+	require.Equal(c.t, http.StatusOK, status)
+	require.NotEmpty(c.t, login.Token.AccessToken)
+	return login
+}
+
+func (c *testClient) apiKey(accessToken string, scopes ...string) string {
+	var rsp ApiKeyResponse
+	status := c.do(http.MethodPost, "/me/api-keys", accessToken, createApiKeyRequest{Name: "key", Scopes: scopes}, &rsp)
+	require.Equal(c.t, http.StatusOK, status)
+	return rsp.Key
+}
+
+type testTask struct {
+	ID              int64      `json:"id"`
+	Body            string     `json:"body"`
+	IsDone          bool       `json:"isDone"`
+	DueAt           *time.Time `json:"dueAt"`
+	Priority        string     `json:"priority"`
+	EstimateMinutes *int32     `json:"estimateMinutes"`
+	Labels          []string   `json:"labels"`
+}
+
+func (c *testClient) taskList(accessToken string, query url.Values) []int64 {
+	var rsp struct {
+		Tasks []testTask `json:"tasks"`
+	}
+	status := c.do(http.MethodGet, "/task/?"+query.Encode(), accessToken, nil, &rsp)
+	require.Equal(c.t, http.StatusOK, status)
+	ids := []int64{}
+	for _, task := range rsp.Tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestDevServerTasks(t *testing.T) {
+	c := newTestClient(t)
+	login := c.signup("user@email.com")
+	accessToken := login.Token.AccessToken
+
+	//the email is taken
+	status := c.do(http.MethodPost, "/user/", "", createUserRequest{Username: "other", Email: "user@email.com", Password: "secret"}, nil)
+	require.Equal(t, http.StatusBadRequest, status)
+	status = c.do(http.MethodGet, "/task/", "", nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	var login2 testLogin
+	status = c.do(http.MethodPost, "/user/login", "", loginUserRequest{Email: "user@email.com", Password: "secret"}, &login2)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, login.User.Email, login2.User.Email)
+
+	var backend, docs testTask
+	status = c.do(http.MethodPost, "/task/", accessToken, map[string]interface{}{"body": "Fix login", "priority": "high", "labels": []string{"backend"}}, &backend)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "high", backend.Priority)
+	require.Equal(t, []string{"backend"}, backend.Labels)
+	status = c.do(http.MethodPost, "/task/", accessToken, map[string]interface{}{"body": "Write docs"}, &docs)
+	require.Equal(t, http.StatusOK, status)
+
+	require.Equal(t, []int64{backend.ID, docs.ID}, c.taskList(accessToken, url.Values{}))
+	require.Equal(t, []int64{backend.ID}, c.taskList(accessToken, url.Values{"filter": {"priority >= high and label:backend"}}))
+	require.Equal(t, []int64{docs.ID, backend.ID}, c.taskList(accessToken, url.Values{"sort": {"-id"}}))
+	status = c.do(http.MethodGet, "/task/?"+url.Values{"filter": {"due <"}}.Encode(), accessToken, nil, nil)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	var updated testTask
+	status = c.do(http.MethodPut, fmt.Sprintf("/task/%d", docs.ID), accessToken, map[string]interface{}{"body": "Write docs", "isDone": true}, &updated)
+	require.Equal(t, http.StatusOK, status)
+	require.True(t, updated.IsDone)
+	require.Equal(t, []int64{docs.ID}, c.taskList(accessToken, url.Values{"filter": {"done"}}))
+
+	//other users don't see the tasks
+	other := c.signup("other@email.com")
+	require.Empty(t, c.taskList(other.Token.AccessToken, url.Values{}))
+	status = c.do(http.MethodGet, fmt.Sprintf("/task/%d", backend.ID), other.Token.AccessToken, nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status = c.do(http.MethodDelete, fmt.Sprintf("/task/%d", backend.ID), accessToken, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, []int64{docs.ID}, c.taskList(accessToken, url.Values{}))
+}
+
+func TestDevServerUpdateTaskKeepsFields(t *testing.T) {
+	c := newTestClient(t)
+	accessToken := c.signup("user@email.com").Token.AccessToken
+
+	var created testTask
+	status := c.do(http.MethodPost, "/task/", accessToken, map[string]interface{}{
+		"body":            "Fix login",
+		"priority":        "high",
+		"dueAt":           "2030-01-02T15:04:05Z",
+		"estimateMinutes": 30,
+		"labels":          []string{"backend"},
+	}, &created)
+	require.Equal(t, http.StatusOK, status)
+
+	//fields left out keep their value
+	var updated testTask
+	status = c.do(http.MethodPut, fmt.Sprintf("/task/%d", created.ID), accessToken, map[string]interface{}{"body": "Fix login", "isDone": true}, &updated)
+	require.Equal(t, http.StatusOK, status)
+	require.True(t, updated.IsDone)
+	require.Equal(t, "high", updated.Priority)
+	require.NotNil(t, updated.DueAt)
+	require.True(t, created.DueAt.Equal(*updated.DueAt))
+	require.Equal(t, int32(30), *updated.EstimateMinutes)
+	require.Equal(t, []string{"backend"}, updated.Labels)
+
+	//null clears
+	status = c.do(http.MethodPut, fmt.Sprintf("/task/%d", created.ID), accessToken, map[string]interface{}{"body": "Fix login", "dueAt": nil, "estimateMinutes": nil, "priority": "low"}, &updated)
+	require.Equal(t, http.StatusOK, status)
+	require.False(t, updated.IsDone)
+	require.Nil(t, updated.DueAt)
+	require.Nil(t, updated.EstimateMinutes)
+	require.Equal(t, "low", updated.Priority)
+}
+
+func TestDevServerStreamTicket(t *testing.T) {
+	c := newTestClient(t)
+	accessToken := c.signup("user@email.com").Token.AccessToken
+
+	var ticket streamTicketResponse
+	status := c.do(http.MethodPost, "/events/ticket", accessToken, nil, &ticket)
+	require.Equal(t, http.StatusOK, status)
+	require.NotEmpty(t, ticket.Ticket)
+
+	stream := func() int {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/events/?ticket="+ticket.Ticket, nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+	require.Equal(t, http.StatusOK, stream())
+	//a ticket is good for one connection
+	require.Equal(t, http.StatusUnauthorized, stream())
+
+	//the access token is not taken from the query anymore
+	status = c.do(http.MethodGet, "/events/?access_token="+accessToken, "", nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+}
+
+// wsDial opens /ws/ with an access token or api key, header adds to the request
+func (c *testClient) wsDial(key string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Authorization", "Bearer "+key)
+	conn, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(c.baseURL, "http")+"/ws/", header)
+	if err == nil {
+		c.t.Cleanup(func() { conn.Close() })
+	}
+	return conn, res, err
+}
+
+func (c *testClient) wsConnect(key string) *websocket.Conn {
+	conn, res, err := c.wsDial(key, nil)
+	require.NoError(c.t, err)
+	res.Body.Close()
+	return conn
+}
+
+// wsMessage is an ack or an event
+type wsMessage struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id"`
+	OK      bool            `json:"ok"`
+	Error   string          `json:"error"`
+	EventID int64           `json:"eventId"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func wsRead(t *testing.T, conn *websocket.Conn) wsMessage {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var message wsMessage
+	require.NoError(t, conn.ReadJSON(&message))
+	return message
+}
+
+func wsSend(t *testing.T, conn *websocket.Conn, req map[string]interface{}) wsMessage {
+	require.NoError(t, conn.WriteJSON(req))
+	return wsRead(t, conn)
+}
+
+// wsClosed sends a ping and expects the server to close the connection with code
+func wsClosed(t *testing.T, conn *websocket.Conn, code int) {
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{"id": "ping", "type": "ping"}))
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, code), "got %v", err)
+}
+
+func TestDevServerWebSocketScopes(t *testing.T) {
+	c := newTestClient(t)
+	accessToken := c.signup("user@email.com").Token.AccessToken
+	create := map[string]interface{}{"id": "2", "type": "task.create", "task": map[string]interface{}{"body": "write tests"}}
+
+	//a read-only key connects but can't change tasks
+	conn := c.wsConnect(c.apiKey(accessToken, apikey.ScopeTasksRead))
+	require.True(t, wsSend(t, conn, map[string]interface{}{"id": "1", "type": "ping"}).OK)
+	ack := wsSend(t, conn, create)
+	require.False(t, ack.OK)
+	require.Contains(t, ack.Error, apikey.ScopeTasksWrite)
+
+	conn = c.wsConnect(c.apiKey(accessToken, apikey.ScopeTasksRead, apikey.ScopeTasksWrite))
+	require.True(t, wsSend(t, conn, create).OK)
+}
+
+func TestDevServerWebSocketEvents(t *testing.T) {
+	c := newTestClient(t)
+	accessToken := c.signup("user@email.com").Token.AccessToken
+	var project struct {
+		ID int64 `json:"id"`
+	}
+	status := c.do(http.MethodPost, "/project/", accessToken, map[string]interface{}{"name": "Website"}, &project)
+	require.Equal(t, http.StatusOK, status)
+
+	first, second := c.wsConnect(accessToken), c.wsConnect(accessToken)
+	for _, conn := range []*websocket.Conn{first, second} {
+		ack := wsSend(t, conn, map[string]interface{}{"id": "subscribe", "type": "subscribe", "projectId": project.ID})
+		require.Equal(t, "subscribe", ack.ID)
+		require.True(t, ack.OK)
+	}
+	create := func(conn *websocket.Conn, id string) testTask {
+		ack := wsSend(t, conn, map[string]interface{}{"id": id, "type": "task.create", "task": map[string]interface{}{"body": id, "projectId": project.ID}})
+		require.Equal(t, "ack", ack.Type)
+		require.Equal(t, id, ack.ID)
+		require.True(t, ack.OK, ack.Error)
+		var task testTask
+		require.NoError(t, json.Unmarshal(ack.Data, &task))
+		require.Equal(t, id, task.Body)
+		return task
+	}
+
+	//the ack carries the task, the other connection gets the event
+	created := create(first, "from first")
+	message := wsRead(t, second)
+	require.Equal(t, "task.created", message.Type)
+	var task testTask
+	require.NoError(t, json.Unmarshal(message.Data, &task))
+	require.Equal(t, created.ID, task.ID)
+
+	//the first event the first connection gets is the second connection's task, not its own
+	created = create(second, "from second")
+	message = wsRead(t, first)
+	require.Equal(t, "task.created", message.Type)
+	require.NoError(t, json.Unmarshal(message.Data, &task))
+	require.Equal(t, created.ID, task.ID)
+}
+
+func TestDevServerWebSocketRevoked(t *testing.T) {
+	c := newTestClient(t)
+	login := c.signup("user@email.com")
+	accessToken := login.Token.AccessToken
+
+	var key ApiKeyResponse
+	status := c.do(http.MethodPost, "/me/api-keys", accessToken, createApiKeyRequest{Name: "key", Scopes: []string{apikey.ScopeTasksRead}}, &key)
+	require.Equal(t, http.StatusOK, status)
+	conn := c.wsConnect(key.Key)
+	require.True(t, wsSend(t, conn, map[string]interface{}{"id": "1", "type": "ping"}).OK)
+	status = c.do(http.MethodDelete, fmt.Sprintf("/me/api-keys/%d", key.ID), accessToken, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	wsClosed(t, conn, wsCloseRevoked)
+
+	conn = c.wsConnect(accessToken)
+	require.True(t, wsSend(t, conn, map[string]interface{}{"id": "1", "type": "ping"}).OK)
+	status = c.do(http.MethodPost, "/me/logout-all", accessToken, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	wsClosed(t, conn, wsCloseRevoked)
+}
+
+func TestDevServerWebSocketOrigin(t *testing.T) {
+	c := newTestClient(t)
+	accessToken := c.signup("user@email.com").Token.AccessToken
+
+	_, res, err := c.wsDial(accessToken, http.Header{"Origin": {"https://evil.example"}})
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	require.Equal(t, http.StatusForbidden, res.StatusCode)
+	res.Body.Close()
+
+	_, res, err = c.wsDial(accessToken, http.Header{"Origin": {"http://localhost:3000"}})
+	require.NoError(t, err)
+	res.Body.Close()
+}
+
+func TestWebSocketSlowConsumer(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		//there is no writePump, nothing drains the send buffer
+		c := &wsConn{conn: conn, send: make(chan any, wsSendBuffer), done: make(chan struct{})}
+		for i := 0; i < wsSendBuffer; i++ {
+			c.enqueue(&wsAck{Type: "ack"})
+		}
+		select {
+		case <-c.done:
+			t.Error("closed before the send buffer was full")
+		default:
+		}
+		c.enqueue(&wsAck{Type: "ack"})
+	}))
+	defer httpServer.Close()
+
+	conn, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	res.Body.Close()
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), "got %v", err)
+}
+
+func TestDevServerStatsScope(t *testing.T) {
+	c := newTestClient(t)
+	accessToken := c.signup("user@email.com").Token.AccessToken
+
+	require.Equal(t, http.StatusOK, c.do(http.MethodGet, "/me/stats", accessToken, nil, nil))
+	//a read-only key can read stats but not the rest of /me
+	key := c.apiKey(accessToken, apikey.ScopeTasksRead)
+	require.Equal(t, http.StatusOK, c.do(http.MethodGet, "/me/stats", key, nil, nil))
+	require.Equal(t, http.StatusForbidden, c.do(http.MethodGet, "/me/", key, nil, nil))
+}
+
+func TestRequestLoggerDropsQuery(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := &queryFreeLogFormatter{LogFormatter: &middleware.DefaultLogFormatter{Logger: log.New(&buf, "", 0), NoColor: true}}
+	req := httptest.NewRequest(http.MethodGet, "/events/?ticket=secret", nil)
+	formatter.NewLogEntry(req).Write(http.StatusOK, 0, nil, 0, nil)
+	require.Contains(t, buf.String(), "/events/ ")
+	require.NotContains(t, buf.String(), "secret")
+	require.Equal(t, "secret", req.URL.Query().Get("ticket"))
+}
+
+func TestDevServerSessions(t *testing.T) {
+	c := newTestClient(t)
+	login := c.signup("user@email.com")
+	var login2 testLogin
+	status := c.do(http.MethodPost, "/user/login", "", loginUserRequest{Email: "user@email.com", Password: "secret"}, &login2)
+	require.Equal(t, http.StatusOK, status)
+
+	var sessions UserSessionListResponse
+	status = c.do(http.MethodGet, "/me/sessions", login.Token.AccessToken, nil, &sessions)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, sessions.Sessions, 2)
+
+	var renewed renewAccessTokenResponse
+	status = c.do(http.MethodPost, "/tokens/renew_access", "", renewAccessTokenRequest{RefreshToken: login.Token.RefreshToken}, &renewed)
+	require.Equal(t, http.StatusOK, status)
+	//a refresh token is used once
+	status = c.do(http.MethodPost, "/tokens/renew_access", "", renewAccessTokenRequest{RefreshToken: login.Token.RefreshToken}, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	status = c.do(http.MethodPost, "/me/logout-all", login2.Token.AccessToken, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	status = c.do(http.MethodGet, "/me/", login2.Token.AccessToken, nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+	status = c.do(http.MethodGet, "/me/", renewed.AccessToken, nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+
+	//the logged out sessions are not listed anymore
+	var login3 testLogin
+	status = c.do(http.MethodPost, "/user/login", "", loginUserRequest{Email: "user@email.com", Password: "secret"}, &login3)
+	require.Equal(t, http.StatusOK, status)
+	status = c.do(http.MethodGet, "/me/sessions", login3.Token.AccessToken, nil, &sessions)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, sessions.Sessions, 1)
+	require.True(t, sessions.Sessions[0].Current)
+}
+
+func TestDevServerDeleteSession(t *testing.T) {
+	c := newTestClient(t)
+	login := c.signup("user@email.com")
+	var login2 testLogin
+	status := c.do(http.MethodPost, "/user/login", "", loginUserRequest{Email: "user@email.com", Password: "secret"}, &login2)
+	require.Equal(t, http.StatusOK, status)
+	var renewed renewAccessTokenResponse
+	status = c.do(http.MethodPost, "/tokens/renew_access", "", renewAccessTokenRequest{RefreshToken: login.Token.RefreshToken}, &renewed)
+	require.Equal(t, http.StatusOK, status)
+
+	var sessions UserSessionListResponse
+	status = c.do(http.MethodGet, "/me/sessions", login2.Token.AccessToken, nil, &sessions)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, sessions.Sessions, 2)
+	var other *UserSessionResponse
+	for _, userSession := range sessions.Sessions {
+		if !userSession.Current {
+			other = userSession
+		}
+	}
+	require.NotNil(t, other)
+	status = c.do(http.MethodDelete, "/me/sessions/"+other.ID.String(), login2.Token.AccessToken, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	//every access token of the login stops working, not only the latest one
+	status = c.do(http.MethodGet, "/me/", renewed.AccessToken, nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+	status = c.do(http.MethodGet, "/me/", login.Token.AccessToken, nil, nil)
+	require.Equal(t, http.StatusUnauthorized, status)
+	status = c.do(http.MethodGet, "/me/", login2.Token.AccessToken, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+}
+
+func TestDevServerJWKS(t *testing.T) {
+	config := util.DevConfig()
+	config.TokenFormat = util.TokenFormatJWT
+	c := newTestClientWithConfig(t, config)
+	login := c.signup("user@email.com")
+
+	var jwks jwksResponse
+	status := c.do(http.MethodGet, "/.well-known/jwks.json", "", nil, &jwks)
+	require.Equal(t, http.StatusOK, status)
+	require.NotEmpty(t, jwks.Keys)
+
+	//another service verifies the access token with the published keys only
+	keyFunc := func(jwtToken *jwt.Token) (interface{}, error) {
+		for _, key := range jwks.Keys {
+			if key.Kid == jwtToken.Header["kid"] {
+				x, err := base64.RawURLEncoding.DecodeString(key.X)
+				return ed25519.PublicKey(x), err
+			}
+		}
+		return nil, token.ErrUnknownKey
+	}
+	payload := &token.Payload{}
+	_, err := jwt.ParseWithClaims(login.Token.AccessToken, payload, keyFunc, jwt.WithValidMethods([]string{"EdDSA"}))
+	require.NoError(t, err)
+	require.Equal(t, "user@email.com", payload.User.Email)
+
+	//symmetric tokens have no public keys
+	config.TokenFormat = util.TokenFormatPaseto
+	c = newTestClientWithConfig(t, config)
+	status = c.do(http.MethodGet, "/.well-known/jwks.json", "", nil, &jwks)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, jwks.Keys)
+}
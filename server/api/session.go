@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/session"
+	"github.com/punkzberryz/todo/util"
+)
+
+func renderSessionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case session.ErrTokenSessionNotFound:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+type UserSessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"userAgent"`
+	Ip         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	// Current is the session making the request
+	Current bool `json:"current"`
+}
+
+func newUserSessionResponse(userSession *session.UserSession, payload *token.Payload) *UserSessionResponse {
+	//ClientIp is the remote address, the port is of no use to anyone
+	ip := userSession.ClientIp
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return &UserSessionResponse{
+		ID:         userSession.ID,
+		Device:     util.DeviceName(userSession.UserAgent),
+		UserAgent:  userSession.UserAgent,
+		Ip:         ip,
+		CreatedAt:  userSession.CreatedAt,
+		LastUsedAt: userSession.LastUsedAt,
+		ExpiresAt:  userSession.ExpiresAt,
+		Current:    userSession.AccessTokenID == payload.ID,
+	}
+}
+
+type UserSessionListResponse struct {
+	Sessions []*UserSessionResponse `json:"sessions"`
+}
+
+func (*UserSessionListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) getSessionList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	userSessions, err := server.token.ListSessions(r.Context(), payload.User.ID)
+	if err != nil {
+		renderSessionError(w, r, err)
+		return
+	}
+	rsp := &UserSessionListResponse{Sessions: make([]*UserSessionResponse, len(userSessions))}
+	for i := range userSessions {
+		rsp.Sessions[i] = newUserSessionResponse(&userSessions[i], payload)
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteSessionResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteSessionResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// deleteSession logs out one session, its refresh token and access token stop working right away
+func (server *Server) deleteSession(w http.ResponseWriter, r *http.Request) {
+	sessionId, err := uuid.Parse(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid sessionID")))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.token.RevokeSession(r.Context(), payload.User.ID, sessionId); err != nil {
+		renderSessionError(w, r, err)
+		return
+	}
+	rsp := &deleteSessionResponse{
+		Message: fmt.Sprintf("delete session id %s success", sessionId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/punkzberryz/todo/service/stats"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+type StatsResponse struct {
+	*stats.Result
+}
+
+func (*StatsResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// statistics of the last 30 days unless from and to are set
+// /me/stats?from=2024-03-01&to=2024-03-31&period=week&tz=Europe/Berlin, period defaults to day
+func (server *Server) getStats(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	query := r.URL.Query()
+	from, to, loc, err := parseTimeRange(query, 30)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	period := query.Get("period")
+	if period == "" {
+		period = stats.PeriodDay
+	}
+
+	result, err := server.stats.Get(r.Context(), payload.User.ID, stats.Params{
+		From:     from,
+		To:       to,
+		Period:   period,
+		Location: loc,
+	})
+	if err != nil {
+		switch err {
+		case stats.ErrInvalidPeriod, stats.ErrInvalidRange, stats.ErrInvalidTimezone:
+			render.Render(w, r, ErrInvalidRequest(err))
+		default:
+			render.Render(w, r, ErrInternalServer(err))
+		}
+		return
+	}
+	if err := render.Render(w, r, &StatsResponse{Result: result}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
@@ -0,0 +1,351 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/delta"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// most client changes accepted in one POST /sync
+const maxSyncChanges = 100
+
+// seq is the version of an entity, clients send it back as baseSeq when changing it
+type SyncTaskResponse struct {
+	*TaskResponse
+	Seq int64 `json:"seq"`
+}
+
+type SyncLabelResponse struct {
+	*db.Label
+	Seq int64 `json:"seq"`
+}
+
+type SyncProjectResponse struct {
+	*db.Project
+	Seq int64 `json:"seq"`
+}
+
+type SyncDeletedResponse struct {
+	Entity string `json:"entity"`
+	ID     int64  `json:"id"`
+	Seq    int64  `json:"seq"`
+}
+
+// entities changed since the token, the returned token is sent as ?since= next time,
+// while hasMore is set the client asks again right away
+type SyncResponse struct {
+	Tasks    []*SyncTaskResponse    `json:"tasks"`
+	Labels   []*SyncLabelResponse   `json:"labels"`
+	Projects []*SyncProjectResponse `json:"projects"`
+	Deleted  []*SyncDeletedResponse `json:"deleted"`
+	Token    string                 `json:"token"`
+	HasMore  bool                   `json:"hasMore"`
+}
+
+func (*SyncResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// GET /sync?since=<token>, without since every entity is returned
+func (server *Server) getSyncChanges(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	changes, err := server.delta.GetChanges(r.Context(), payload.User.ID, r.URL.Query().Get("since"))
+	if err != nil {
+		if err == delta.ErrInvalidSyncToken {
+			render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+
+	rsp := &SyncResponse{
+		Tasks:    []*SyncTaskResponse{},
+		Labels:   []*SyncLabelResponse{},
+		Projects: []*SyncProjectResponse{},
+		Deleted:  []*SyncDeletedResponse{},
+		Token:    changes.Token,
+		HasMore:  changes.HasMore,
+	}
+	for _, change := range changes.Changes {
+		switch {
+		case change.Deleted:
+			rsp.Deleted = append(rsp.Deleted, &SyncDeletedResponse{Entity: change.Entity, ID: change.ID, Seq: change.Seq})
+		case change.Task != nil:
+			rsp.Tasks = append(rsp.Tasks, &SyncTaskResponse{TaskResponse: newTaskResponse(change.Task), Seq: change.Seq})
+		case change.Label != nil:
+			rsp.Labels = append(rsp.Labels, &SyncLabelResponse{Label: change.Label, Seq: change.Seq})
+		case change.Project != nil:
+			rsp.Projects = append(rsp.Projects, &SyncProjectResponse{Project: change.Project, Seq: change.Seq})
+		}
+	}
+	tasks := make([]*TaskResponse, len(rsp.Tasks))
+	for i, task := range rsp.Tasks {
+		tasks[i] = task.TaskResponse
+	}
+	if err := server.withTaskDetails(r.Context(), tasks...); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// a change made by an offline client, data is the body of the matching REST request.
+// id and baseSeq (the seq the client last saw) are set for update and delete,
+// modifiedAt is when the change was made and decides last-writer-wins conflicts.
+// tasks and projects can be created, updated and deleted, labels deleted.
+type SyncChangeRequest struct {
+	ClientID   string          `json:"clientId"`
+	Entity     string          `json:"entity"`
+	Op         string          `json:"op"`
+	ID         int64           `json:"id"`
+	BaseSeq    int64           `json:"baseSeq"`
+	ModifiedAt *time.Time      `json:"modifiedAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// changes are applied in order, strategy is server-wins unless set
+type SyncRequest struct {
+	Strategy string              `json:"strategy"`
+	Changes  []SyncChangeRequest `json:"changes"`
+}
+
+func (c *SyncRequest) Bind(r *http.Request) error {
+	if c.Strategy == "" {
+		c.Strategy = delta.StrategyServerWins
+	}
+	if !delta.ValidStrategy(c.Strategy) {
+		return delta.ErrInvalidStrategy
+	}
+	if len(c.Changes) > maxSyncChanges {
+		return fmt.Errorf("at most %d changes can be sent at once", maxSyncChanges)
+	}
+	return nil
+}
+
+// status is applied, skipped or error. conflict is set when the entity changed
+// on the server since baseSeq, a skipped change comes with the server version.
+type SyncChangeResult struct {
+	Index    int    `json:"index"`
+	ClientID string `json:"clientId,omitempty"`
+	Entity   string `json:"entity"`
+	ID       int64  `json:"id,omitempty"`
+	Status   string `json:"status"`
+	Conflict bool   `json:"conflict"`
+	Seq      int64  `json:"seq,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Server   any    `json:"server,omitempty"`
+}
+
+type SyncResultResponse struct {
+	Results []*SyncChangeResult `json:"results"`
+}
+
+func (*SyncResultResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// POST /sync - apply a batch of client changes
+func (server *Server) applySyncChanges(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &SyncRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	rsp := &SyncResultResponse{Results: make([]*SyncChangeResult, len(data.Changes))}
+	for i := range data.Changes {
+		change := &data.Changes[i]
+		result := &SyncChangeResult{Index: i, ClientID: change.ClientID, Entity: change.Entity, ID: change.ID}
+		if err := server.applySyncChange(r, payload.User.ID, data.Strategy, change, result); err != nil {
+			result.Status = "error"
+			result.Error = err.Error()
+		}
+		rsp.Results[i] = result
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) applySyncChange(r *http.Request, userId int64, strategy string, change *SyncChangeRequest, result *SyncChangeResult) error {
+	ctx := r.Context()
+	if change.Op == "create" {
+		id, err := server.syncCreate(r, userId, change)
+		if err != nil {
+			return err
+		}
+		result.ID = id
+		result.Status = "applied"
+		return server.syncSeq(ctx, userId, change.Entity, id, result)
+	}
+	if change.Op != "update" && change.Op != "delete" {
+		return fmt.Errorf("op must be create, update or delete")
+	}
+
+	state, err := server.delta.GetState(ctx, userId, change.Entity, change.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%s %d not found", change.Entity, change.ID)
+		}
+		return err
+	}
+	if change.Op == "delete" && state.Deleted {
+		result.Status = "applied"
+		result.Seq = state.Seq
+		return nil
+	}
+	var modifiedAt time.Time
+	if change.ModifiedAt != nil {
+		modifiedAt = *change.ModifiedAt
+	}
+	apply, conflict := delta.Resolve(strategy, change.BaseSeq, modifiedAt, state)
+	result.Conflict = conflict
+	if !apply {
+		result.Status = "skipped"
+		result.Seq = state.Seq
+		if !state.Deleted {
+			result.Server = server.syncServerVersion(ctx, userId, change.Entity, change.ID)
+		}
+		return nil
+	}
+
+	if change.Op == "delete" {
+		err = server.syncDelete(ctx, userId, change)
+	} else {
+		err = server.syncUpdate(r, userId, change)
+	}
+	if err != nil {
+		return err
+	}
+	result.Status = "applied"
+	return server.syncSeq(ctx, userId, change.Entity, change.ID, result)
+}
+
+// syncSeq sets the seq of an entity after a change was applied
+func (server *Server) syncSeq(ctx context.Context, userId int64, entity string, id int64, result *SyncChangeResult) error {
+	state, err := server.delta.GetState(ctx, userId, entity, id)
+	if err != nil {
+		return err
+	}
+	result.Seq = state.Seq
+	return nil
+}
+
+// decodeSyncData decodes and validates the data of a change like render.Bind
+func decodeSyncData(r *http.Request, data json.RawMessage, v render.Binder) error {
+	if len(data) == 0 {
+		return fmt.Errorf("data is a required field")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return v.Bind(r)
+}
+
+func (server *Server) syncCreate(r *http.Request, userId int64, change *SyncChangeRequest) (int64, error) {
+	switch change.Entity {
+	case delta.EntityTask:
+		data := &CreateTaskRequest{}
+		if err := decodeSyncData(r, change.Data, data); err != nil {
+			return 0, err
+		}
+		task, err := server.createTaskForUser(r.Context(), userId, data)
+		if err != nil {
+			return 0, err
+		}
+		return task.ID, nil
+	case delta.EntityProject:
+		data := &CreateProjectRequest{}
+		if err := decodeSyncData(r, change.Data, data); err != nil {
+			return 0, err
+		}
+		result, err := server.project.CreateProject(r.Context(), db.CreateProjectParams{
+			Name:    data.Name,
+			OwnerID: userId,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return result.Project.ID, nil
+	default:
+		return 0, fmt.Errorf("%s can't be created", change.Entity)
+	}
+}
+
+func (server *Server) syncUpdate(r *http.Request, userId int64, change *SyncChangeRequest) error {
+	switch change.Entity {
+	case delta.EntityTask:
+		data := &UpdateTaskRequest{}
+		if err := decodeSyncData(r, change.Data, data); err != nil {
+			return err
+		}
+		_, err := server.updateTaskForUser(r.Context(), userId, change.ID, data)
+		return err
+	case delta.EntityProject:
+		data := &CreateProjectRequest{}
+		if err := decodeSyncData(r, change.Data, data); err != nil {
+			return err
+		}
+		_, err := server.project.UpdateProject(r.Context(), db.UpdateProjectParams{
+			ID:      change.ID,
+			OwnerID: userId,
+			Name:    data.Name,
+		})
+		return err
+	default:
+		return fmt.Errorf("%s can't be updated", change.Entity)
+	}
+}
+
+func (server *Server) syncDelete(ctx context.Context, userId int64, change *SyncChangeRequest) error {
+	switch change.Entity {
+	case delta.EntityTask:
+		return server.deleteTaskForUser(ctx, userId, change.ID)
+	case delta.EntityProject:
+		return server.project.DeleteProject(ctx, db.DeleteProjectParams{
+			ID:      change.ID,
+			OwnerID: userId,
+		})
+	case delta.EntityLabel:
+		return server.task.DeleteLabel(ctx, change.ID, userId)
+	default:
+		return fmt.Errorf("%s can't be deleted", change.Entity)
+	}
+}
+
+// syncServerVersion is the current server version of an entity the client lost a conflict on
+func (server *Server) syncServerVersion(ctx context.Context, userId int64, entity string, id int64) any {
+	switch entity {
+	case delta.EntityTask:
+		task, err := server.task.GetTaskById(ctx, id, userId)
+		if err != nil {
+			return nil
+		}
+		rsp := newTaskResponse(task)
+		if err := server.withTaskDetails(ctx, rsp); err != nil {
+			return nil
+		}
+		return rsp
+	case delta.EntityProject:
+		project, err := server.project.GetProjectById(ctx, id, userId)
+		if err != nil {
+			return nil
+		}
+		return project
+	default:
+		return nil
+	}
+}
@@ -1,19 +1,85 @@
 package api
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/project"
 	"github.com/punkzberryz/todo/service/task"
 	"github.com/punkzberryz/todo/service/token"
 )
 
 type TaskResponse struct {
 	*db.Task
+	ProjectID       *int64                     `json:"projectId"`
+	StatusID        *int64                     `json:"statusId"`
+	DueAt           *time.Time                 `json:"dueAt"`
+	Priority        string                     `json:"priority"`
+	EstimateMinutes *int32                     `json:"estimateMinutes"`
+	ParentID        *int64                     `json:"parentId"`
+	ArchivedAt      *time.Time                 `json:"archivedAt"`
+	DeferredUntil   *time.Time                 `json:"deferredUntil"`
+	Labels          []string                   `json:"labels"`
+	CustomFields    map[string]json.RawMessage `json:"customFields,omitempty"`
+}
+
+func newTaskResponse(t *db.Task) *TaskResponse {
+	return &TaskResponse{
+		Task:            t,
+		ProjectID:       nullInt64Ptr(t.ProjectID),
+		StatusID:        nullInt64Ptr(t.StatusID),
+		DueAt:           nullTimePtr(t.DueAt),
+		Priority:        task.PriorityName(t.Priority),
+		EstimateMinutes: nullInt32Ptr(t.EstimateMinutes),
+		ParentID:        nullInt64Ptr(t.ParentID),
+		ArchivedAt:      nullTimePtr(t.ArchivedAt),
+		DeferredUntil:   nullTimePtr(t.DeferredUntil),
+		Labels:          []string{},
+	}
+}
+
+func newTaskListResponse(tasks []db.Task) []*TaskResponse {
+	list := make([]*TaskResponse, len(tasks))
+	for i := range tasks {
+		list[i] = newTaskResponse(&tasks[i])
+	}
+	return list
+}
+
+// attach labels and custom field values to task responses
+func (server *Server) withTaskDetails(ctx context.Context, tasks ...*TaskResponse) error {
+	taskIds := make([]int64, len(tasks))
+	for i, task := range tasks {
+		taskIds[i] = task.ID
+	}
+	values, err := server.task.GetFieldValues(ctx, taskIds)
+	if err != nil {
+		return err
+	}
+	labels, err := server.task.GetLabels(ctx, taskIds)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if len(values[task.ID]) > 0 {
+			task.CustomFields = fieldValueMap(values[task.ID])
+		}
+		if len(labels[task.ID]) > 0 {
+			task.Labels = labels[task.ID]
+		}
+	}
+	return nil
 }
 
 func (trsp *TaskResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -53,15 +119,46 @@ func (server *Server) getTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := render.Render(w, r, &TaskResponse{
-		Task: taskRsp,
-	}); err != nil {
+	rsp := newTaskResponse(taskRsp)
+	if err := server.withTaskDetails(r.Context(), rsp); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// GET /task/123/subtasks
+func (server *Server) getSubtasks(w http.ResponseWriter, r *http.Request) {
+	taskId, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	tasks, err := server.task.GetSubtasks(r.Context(), taskId, payload.User.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			render.Render(w, r, ErrNotFound)
+			return
+		}
+		renderTaskError(w, r, err)
+		return
+	}
+	rsp := &TaskListResponse{Tasks: newTaskListResponse(tasks)}
+	if err := server.withTaskDetails(r.Context(), rsp.Tasks...); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
 		render.Render(w, r, ErrRender(err))
 	}
 }
 
 type TaskListResponse struct {
-	Tasks []db.Task `json:"tasks"`
+	Tasks []*TaskResponse `json:"tasks"`
 }
 
 func (*TaskListResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -70,6 +167,12 @@ func (*TaskListResponse) Render(w http.ResponseWriter, r *http.Request) error {
 
 // get task list by owner id
 // /task?pageId=1&limit=10
+// optionally filtered and sorted by project, custom fields and a query,
+// tz is the time zone used for dates in the query
+// /task?projectId=2&cf.4=high&sort=-cf.5
+// /task?filter=due < %2B7d and label:backend and not done&tz=Europe/Berlin&sort=due
+// archived tasks are left out unless include=archived,
+// deferred tasks only show up with view=deferred or view=all
 func (server *Server) getTaskList(w http.ResponseWriter, r *http.Request) {
 	payload := r.Context().Value(payloadKey).(*token.Payload)
 
@@ -83,29 +186,77 @@ func (server *Server) getTaskList(w http.ResponseWriter, r *http.Request) {
 		limit = 10
 	}
 
-	taskList, err := server.task.GetTaskList(r.Context(), payload.User.ID, int32(limit), int32(pageId))
+	arg, err := parseTaskListParams(queryStrings)
 	if err != nil {
-		render.Render(w, r, ErrInternalServer(err))
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	var taskList []db.Task
+	if arg.ProjectID.Valid || len(arg.Fields) > 0 || arg.Filter != "" || arg.Sort != "" || arg.View != "" || arg.IncludeArchived {
+		arg.OwnerID = payload.User.ID
+		arg.Limit = int32(limit)
+		arg.PageID = int32(pageId)
+		taskList, err = server.task.FilterTaskList(r.Context(), arg)
+	} else {
+		taskList, err = server.task.GetTaskList(r.Context(), payload.User.ID, int32(limit), int32(pageId))
+	}
+	if err != nil {
+		renderTaskListError(w, r, err)
 		return
 	}
 
-	if err := render.Render(w, r, &TaskListResponse{Tasks: taskList}); err != nil {
+	rsp := &TaskListResponse{Tasks: newTaskListResponse(taskList)}
+	if err := server.withTaskDetails(r.Context(), rsp.Tasks...); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
 		render.Render(w, r, ErrRender(err))
 	}
 }
 
+// priority is a name (none, low, medium, high, urgent) or 0-4
 type CreateTaskRequest struct {
-	Body string `json:"body"`
+	Body      string     `json:"body"`
+	ProjectID *int64     `json:"projectId"`
+	StatusID  *int64     `json:"statusId"`
+	DueAt     *time.Time `json:"dueAt"`
+	Priority  string     `json:"priority"`
+	// EstimateMinutes is compared against tracked time in time reports
+	EstimateMinutes *int32   `json:"estimateMinutes"`
+	Labels          []string `json:"labels"`
+	priority        int16
 }
 
 // Create Bind function for Body request validation
-func (c *CreateTaskRequest) Bind(r *http.Request) error {
+func (c *CreateTaskRequest) Bind(r *http.Request) (err error) {
 	if c.Body == "" {
 		return fmt.Errorf("body is a required field")
 	}
+	if c.priority, err = parsePriority(c.Priority); err != nil {
+		return err
+	}
+	if err = checkEstimate(c.EstimateMinutes); err != nil {
+		return err
+	}
+	c.Labels, err = task.NormalizeLabels(c.Labels)
+	return err
+}
+
+func checkEstimate(estimate *int32) error {
+	if estimate != nil && *estimate < 0 {
+		return fmt.Errorf("estimateMinutes must not be negative")
+	}
 	return nil
 }
 
+func parsePriority(s string) (int16, error) {
+	if s == "" {
+		return task.PriorityNone, nil
+	}
+	return task.ParsePriority(s)
+}
+
 // create new task
 func (server *Server) createTask(w http.ResponseWriter, r *http.Request) {
 	payload := r.Context().Value(payloadKey).(*token.Payload)
@@ -116,33 +267,89 @@ func (server *Server) createTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := server.task.CreateTask(r.Context(),
+	rsp, err := server.createTaskForUser(r.Context(), payload.User.ID, data)
+	if err != nil {
+		renderTaskError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// createTaskForUser creates a task with its labels and publishes it,
+// the REST and WebSocket APIs both create tasks with it
+func (server *Server) createTaskForUser(ctx context.Context, userId int64, data *CreateTaskRequest) (*TaskResponse, error) {
+	task, err := server.task.CreateTask(ctx,
 		db.CreateTaskParams{
-			OwnerID: payload.User.ID,
-			Body:    data.Body,
+			OwnerID:         userId,
+			Body:            data.Body,
+			ProjectID:       toNullInt64(data.ProjectID),
+			StatusID:        toNullInt64(data.StatusID),
+			DueAt:           toNullTime(data.DueAt),
+			Priority:        data.priority,
+			EstimateMinutes: toNullInt32(data.EstimateMinutes),
 		},
 	)
 	if err != nil {
-		render.Render(w, r, ErrInternalServer(err))
-		return
+		return nil, err
+	}
+	if len(data.Labels) > 0 {
+		if _, err := server.task.SetLabels(ctx, task.ID, userId, data.Labels); err != nil {
+			return nil, err
+		}
 	}
+	return server.taskChanged(ctx, task, event.TypeTaskCreated)
+}
 
-	if err := render.Render(w, r, &TaskResponse{Task: task}); err != nil {
-		render.Render(w, r, ErrRender(err))
+// taskChanged loads the labels and custom field values of a task
+// and publishes it to the event streams of its owner
+func (server *Server) taskChanged(ctx context.Context, task *db.Task, eventType string) (*TaskResponse, error) {
+	rsp := newTaskResponse(task)
+	if err := server.withTaskDetails(ctx, rsp); err != nil {
+		return nil, err
 	}
+	server.publishTaskEvent(ctx, task.OwnerID, rsp.ProjectID, eventType, rsp)
+	return rsp, nil
 }
 
 // update task
+// statusId moves a project task to another column,
+// isDone still works for project tasks and picks the first done/todo column.
+// UpdateTaskRequest changes body and isDone, the other fields keep their value when they
+// are left out. A null dueAt or estimateMinutes clears it
 type UpdateTaskRequest struct {
-	Body   string `json:"body"`
-	IsDone bool   `json:"isDone"`
+	Body            string              `json:"body"`
+	IsDone          bool                `json:"isDone"`
+	StatusID        *int64              `json:"statusId"`
+	DueAt           optional[time.Time] `json:"dueAt"`
+	Priority        *string             `json:"priority"`
+	EstimateMinutes optional[int32]     `json:"estimateMinutes"`
+	Labels          *[]string           `json:"labels"`
+	priority        sql.NullInt16
 }
 
 // Update Bind function for Body request validation
-func (c *UpdateTaskRequest) Bind(r *http.Request) error {
+func (c *UpdateTaskRequest) Bind(r *http.Request) (err error) {
 	if c.Body == "" {
 		return fmt.Errorf("body is a required field")
 	}
+	if c.Priority != nil {
+		c.priority.Valid = true
+		if c.priority.Int16, err = parsePriority(*c.Priority); err != nil {
+			return err
+		}
+	}
+	if err = checkEstimate(c.EstimateMinutes.Value); err != nil {
+		return err
+	}
+	if c.Labels != nil {
+		labels, err := task.NormalizeLabels(*c.Labels)
+		if err != nil {
+			return err
+		}
+		c.Labels = &labels
+	}
 	return nil
 }
 
@@ -158,24 +365,44 @@ func (server *Server) updateTask(w http.ResponseWriter, r *http.Request) {
 		render.Render(w, r, ErrRender(err))
 		return
 	}
-	//We update task with Query with taskId and ownerId
-	//if userId doesn't match ownerId, the query will fail,
-	//hence we don't need to compare ownerId with userId anymore
-	task, err := server.task.UpdateTask(r.Context(), db.UpdateTaskParams{
-		ID:      int64(id),
-		Body:    data.Body,
-		IsDone:  data.IsDone,
-		OwnerID: payload.User.ID,
-	})
+
+	rsp, err := server.updateTaskForUser(r.Context(), payload.User.ID, int64(id), data)
 	if err != nil {
-		render.Render(w, r, ErrInternalServer(err))
+		renderTaskError(w, r, err)
 		return
 	}
-	if err := render.Render(w, r, &TaskResponse{Task: task}); err != nil {
+	if err := render.Render(w, r, rsp); err != nil {
 		render.Render(w, r, ErrRender(err))
 	}
 }
 
+// updateTaskForUser updates a task with its labels and publishes it
+func (server *Server) updateTaskForUser(ctx context.Context, userId int64, taskId int64, data *UpdateTaskRequest) (*TaskResponse, error) {
+	//the service loads the task first to check ownership and
+	//the project workflow before updating with taskId and ownerId
+	task, err := server.task.UpdateTask(ctx, db.UpdateTaskParams{
+		ID:                 taskId,
+		Body:               data.Body,
+		IsDone:             data.IsDone,
+		OwnerID:            userId,
+		StatusID:           toNullInt64(data.StatusID),
+		SetDueAt:           data.DueAt.Set,
+		DueAt:              toNullTime(data.DueAt.Value),
+		Priority:           data.priority,
+		SetEstimateMinutes: data.EstimateMinutes.Set,
+		EstimateMinutes:    toNullInt32(data.EstimateMinutes.Value),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data.Labels != nil {
+		if _, err := server.task.SetLabels(ctx, task.ID, userId, *data.Labels); err != nil {
+			return nil, err
+		}
+	}
+	return server.taskChanged(ctx, task, event.TypeTaskUpdated)
+}
+
 // Delete task
 // We improve response time by quering both
 // taskId and userId,
@@ -196,13 +423,8 @@ func (server *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	payload := r.Context().Value(payloadKey).(*token.Payload)
-	err = server.task.DeleteTask(r.Context(),
-		db.DeleteTaskParams{
-			ID:      int64(id),
-			OwnerID: payload.User.ID,
-		})
-	if err != nil {
-		render.Render(w, r, ErrInternalServer(err))
+	if err := server.deleteTaskForUser(r.Context(), payload.User.ID, int64(id)); err != nil {
+		renderTaskError(w, r, err)
 		return
 	}
 
@@ -213,3 +435,96 @@ func (server *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
 		render.Render(w, r, ErrRender(err))
 	}
 }
+
+// deleteTaskForUser deletes a task and publishes its id
+func (server *Server) deleteTaskForUser(ctx context.Context, userId int64, taskId int64) error {
+	//the project of the task decides which webhooks hear about it
+	deleted, err := server.task.GetTaskById(ctx, taskId, userId)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = server.task.DeleteTask(ctx,
+		db.DeleteTaskParams{
+			ID:      taskId,
+			OwnerID: userId,
+		})
+	if err != nil {
+		return err
+	}
+	projectId := nullInt64Ptr(deleted.ProjectID)
+	server.publishTaskEvent(ctx, userId, projectId, event.TypeTaskDeleted, &deletedTaskEvent{ID: taskId, ProjectID: projectId})
+	return nil
+}
+
+// map errors from task service to responses
+func renderTaskError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case task.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case task.ErrWipLimitReached, task.ErrTransitionNotAllowed:
+		render.Render(w, r, ErrConflict(err))
+	case task.ErrStatusNotInProject, task.ErrNoStatusInCategory, task.ErrInvalidLabel, sql.ErrNoRows:
+		render.Render(w, r, ErrInvalidRequest(err))
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+// read projectId, cf.<fieldId>, filter, tz, sort, view and include from query string
+func parseTaskListParams(query url.Values) (task.ListParams, error) {
+	var arg task.ListParams
+	if value := query.Get("projectId"); value != "" {
+		projectId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return arg, fmt.Errorf("invalid projectId")
+		}
+		arg.ProjectID = sql.NullInt64{Int64: projectId, Valid: true}
+	}
+	for key, values := range query {
+		if !strings.HasPrefix(key, "cf.") {
+			continue
+		}
+		fieldId, err := strconv.ParseInt(strings.TrimPrefix(key, "cf."), 10, 64)
+		if err != nil {
+			return arg, fmt.Errorf("invalid custom field filter %s", key)
+		}
+		for _, value := range values {
+			arg.Fields = append(arg.Fields, task.FieldFilter{FieldID: fieldId, Value: value})
+		}
+	}
+	arg.Filter = query.Get("filter")
+	if tz := query.Get("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return arg, fmt.Errorf("invalid tz %q", tz)
+		}
+		arg.Location = loc
+	}
+	arg.Sort = query.Get("sort")
+	arg.View = query.Get("view")
+	switch include := query.Get("include"); include {
+	case "":
+	case "archived":
+		arg.IncludeArchived = true
+	default:
+		return arg, fmt.Errorf("invalid include %q, only archived is supported", include)
+	}
+	return arg, nil
+}
+
+func renderTaskListError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.(type) {
+	case *project.FieldValueError, *task.FilterError:
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	switch err {
+	case task.ErrInvalidSort, task.ErrFieldNotFound, task.ErrInvalidView:
+		render.Render(w, r, ErrInvalidRequest(err))
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
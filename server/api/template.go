@@ -0,0 +1,266 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/service/template"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// variables are the {{names}} that need a value when the template is used
+type TemplateResponse struct {
+	*db.TaskTemplate
+	Priority         string             `json:"priority"`
+	DueOffsetMinutes *int32             `json:"dueOffsetMinutes"`
+	Subtasks         []template.Subtask `json:"subtasks"`
+	Variables        []string           `json:"variables"`
+}
+
+func (*TemplateResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func newTemplateResponse(t *db.TaskTemplate) (*TemplateResponse, error) {
+	rsp := &TemplateResponse{
+		TaskTemplate:     t,
+		Priority:         task.PriorityName(t.Priority),
+		DueOffsetMinutes: nullInt32Ptr(t.DueOffsetMinutes),
+	}
+	if err := json.Unmarshal(t.Subtasks, &rsp.Subtasks); err != nil {
+		return nil, err
+	}
+	rsp.Variables = template.Variables(t, rsp.Subtasks)
+	return rsp, nil
+}
+
+type TemplateListResponse struct {
+	Templates []*TemplateResponse `json:"templates"`
+}
+
+func (*TemplateListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// dueOffsetMinutes of the template and its subtasks set due dates relative to
+// when the template is used
+type TemplateRequest struct {
+	Name             string             `json:"name"`
+	Body             string             `json:"body"`
+	Priority         string             `json:"priority"`
+	Labels           []string           `json:"labels"`
+	DueOffsetMinutes *int32             `json:"dueOffsetMinutes"`
+	Subtasks         []template.Subtask `json:"subtasks"`
+	priority         int16
+}
+
+func (c *TemplateRequest) Bind(r *http.Request) (err error) {
+	if c.Name == "" {
+		return fmt.Errorf("name is a required field")
+	}
+	if c.Body == "" {
+		return fmt.Errorf("body is a required field")
+	}
+	c.priority, err = parsePriority(c.Priority)
+	return err
+}
+
+func (c *TemplateRequest) params() template.TemplateParams {
+	return template.TemplateParams{
+		Name:             c.Name,
+		Body:             c.Body,
+		Priority:         c.priority,
+		Labels:           c.Labels,
+		DueOffsetMinutes: c.DueOffsetMinutes,
+		Subtasks:         c.Subtasks,
+	}
+}
+
+// due dates are counted from startAt, which defaults to now.
+// The tasks are created in projectId when it is set
+type InstantiateTemplateRequest struct {
+	ProjectID *int64            `json:"projectId"`
+	StartAt   *time.Time        `json:"startAt"`
+	Variables map[string]string `json:"variables"`
+}
+
+func (c *InstantiateTemplateRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+// map errors from template service to responses
+func renderTemplateError(w http.ResponseWriter, r *http.Request, err error) {
+	if _, ok := err.(*template.MissingVariablesError); ok {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	switch err {
+	case template.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case template.ErrEmptyName, template.ErrEmptyBody, template.ErrTooManyTasks, template.ErrTooDeep,
+		task.ErrInvalidLabel, task.ErrInvalidPriority:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		renderTaskError(w, r, err)
+	}
+}
+
+func (server *Server) getTemplateList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	templates, err := server.template.GetTemplateList(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &TemplateListResponse{Templates: make([]*TemplateResponse, len(templates))}
+	for i := range templates {
+		if rsp.Templates[i], err = newTemplateResponse(&templates[i]); err != nil {
+			render.Render(w, r, ErrInternalServer(err))
+			return
+		}
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) renderTemplate(w http.ResponseWriter, r *http.Request, t *db.TaskTemplate) {
+	rsp, err := newTemplateResponse(t)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) createTemplate(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &TemplateRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	t, err := server.template.CreateTemplate(r.Context(), payload.User.ID, data.params())
+	if err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+	server.renderTemplate(w, r, t)
+}
+
+func (server *Server) getTemplate(w http.ResponseWriter, r *http.Request) {
+	templateId, err := getIdFromURLPath(r, "templateID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	t, err := server.template.GetTemplateById(r.Context(), templateId, payload.User.ID)
+	if err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+	server.renderTemplate(w, r, t)
+}
+
+func (server *Server) updateTemplate(w http.ResponseWriter, r *http.Request) {
+	templateId, err := getIdFromURLPath(r, "templateID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &TemplateRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	t, err := server.template.UpdateTemplate(r.Context(), templateId, payload.User.ID, data.params())
+	if err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+	server.renderTemplate(w, r, t)
+}
+
+type deleteTemplateResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteTemplateResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteTemplate(w http.ResponseWriter, r *http.Request) {
+	templateId, err := getIdFromURLPath(r, "templateID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.template.DeleteTemplate(r.Context(), templateId, payload.User.ID); err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+	rsp := &deleteTemplateResponse{
+		Message: fmt.Sprintf("delete template id %d success", templateId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// create the task of a template with all its subtasks,
+// the created tasks are returned with the template task first
+func (server *Server) instantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	templateId, err := getIdFromURLPath(r, "templateID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &InstantiateTemplateRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	startAt := time.Now()
+	if data.StartAt != nil {
+		startAt = *data.StartAt
+	}
+
+	result, err := server.template.Instantiate(r.Context(), templateId, payload.User.ID, template.InstantiateParams{
+		ProjectID: toNullInt64(data.ProjectID),
+		StartAt:   startAt,
+		Variables: data.Variables,
+	})
+	if err != nil {
+		renderTemplateError(w, r, err)
+		return
+	}
+
+	rsp := &TaskListResponse{Tasks: newTaskListResponse(result.Tasks)}
+	for _, t := range rsp.Tasks {
+		t.Labels = append(t.Labels, result.Labels[t.ID]...)
+		server.publishTaskEvent(r.Context(), payload.User.ID, t.ProjectID, event.TypeTaskCreated, t)
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
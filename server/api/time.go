@@ -0,0 +1,439 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/timetrack"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+// durationSeconds of a running entry counts until now
+type TimeEntryResponse struct {
+	*db.TimeEntry
+	EndedAt         *time.Time `json:"endedAt"`
+	DurationSeconds int64      `json:"durationSeconds"`
+}
+
+func (*TimeEntryResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func newTimeEntryResponse(e *db.TimeEntry) *TimeEntryResponse {
+	return &TimeEntryResponse{
+		TimeEntry:       e,
+		EndedAt:         nullTimePtr(e.EndedAt),
+		DurationSeconds: entrySeconds(e.StartedAt, e.EndedAt),
+	}
+}
+
+func entrySeconds(startedAt time.Time, endedAt sql.NullTime) int64 {
+	end := time.Now()
+	if endedAt.Valid {
+		end = endedAt.Time
+	}
+	return int64(end.Sub(startedAt) / time.Second)
+}
+
+type TimeEntryListResponse struct {
+	Entries []*TimeEntryResponse `json:"entries"`
+}
+
+func (*TimeEntryListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// stopped is the timer that was running before, if any
+type StartTimerResponse struct {
+	Started *TimeEntryResponse `json:"started"`
+	Stopped *TimeEntryResponse `json:"stopped"`
+}
+
+func (*StartTimerResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// an entry with its task, durationSeconds is not cut to the export range
+type TimeEntryExportResponse struct {
+	*db.GetTimeEntriesBetweenRow
+	EndedAt         *time.Time `json:"endedAt"`
+	ProjectID       *int64     `json:"projectId"`
+	EstimateMinutes *int32     `json:"estimateMinutes"`
+	DurationSeconds int64      `json:"durationSeconds"`
+}
+
+type TimeEntryExportListResponse struct {
+	Entries []*TimeEntryExportResponse `json:"entries"`
+}
+
+func (*TimeEntryExportListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type TimeReportResponse struct {
+	*timetrack.Report
+}
+
+func (*TimeReportResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type StartTimerRequest struct {
+	TaskID int64  `json:"taskId"`
+	Note   string `json:"note"`
+}
+
+func (c *StartTimerRequest) Bind(r *http.Request) error {
+	if c.TaskID == 0 {
+		return fmt.Errorf("taskId is a required field")
+	}
+	return nil
+}
+
+// taskId is only read when creating an entry
+type TimeEntryRequest struct {
+	TaskID    int64      `json:"taskId"`
+	StartedAt *time.Time `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt"`
+	Note      string     `json:"note"`
+}
+
+func (c *TimeEntryRequest) Bind(r *http.Request) error {
+	if c.StartedAt == nil {
+		return fmt.Errorf("startedAt is a required field")
+	}
+	return nil
+}
+
+func (c *TimeEntryRequest) params() timetrack.EntryParams {
+	return timetrack.EntryParams{
+		StartedAt: *c.StartedAt,
+		EndedAt:   c.EndedAt,
+		Note:      c.Note,
+	}
+}
+
+// map errors from time tracking service to responses
+func renderTimeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case timetrack.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case timetrack.ErrTimerRunning:
+		render.Render(w, r, ErrConflict(err))
+	case timetrack.ErrInvalidTimeRange, timetrack.ErrInvalidRange, timetrack.ErrInvalidGroupBy:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case timetrack.ErrNoTimerRunning, sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+// parseTimeRange reads from, to and tz from the query string.
+// from and to are RFC 3339 times or dates, a date is midnight in tz and to includes its whole day.
+// Without them the range is the last number of days, today included
+func parseTimeRange(query url.Values, days int) (from time.Time, to time.Time, loc *time.Location, err error) {
+	loc = time.UTC
+	if tz := query.Get("tz"); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return from, to, loc, fmt.Errorf("invalid tz %q", tz)
+		}
+	}
+	y, m, d := time.Now().In(loc).Date()
+	to = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	from = to.AddDate(0, 0, -days)
+
+	if value := query.Get("from"); value != "" {
+		if from, err = parseRangeTime(value, loc, false); err != nil {
+			return from, to, loc, fmt.Errorf("invalid from")
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if to, err = parseRangeTime(value, loc, true); err != nil {
+			return from, to, loc, fmt.Errorf("invalid to")
+		}
+	}
+	return from, to, loc, nil
+}
+
+func parseRangeTime(value string, loc *time.Location, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return t, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// GET /time/current - the running timer
+func (server *Server) getRunningTimer(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	entry, err := server.timetrack.GetRunning(r.Context(), payload.User.ID)
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newTimeEntryResponse(entry)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// POST /time/start - the running timer is stopped first
+func (server *Server) startTimer(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &StartTimerRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	result, err := server.timetrack.Start(r.Context(), payload.User.ID, data.TaskID, data.Note)
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	rsp := &StartTimerResponse{Started: newTimeEntryResponse(&result.Started)}
+	if result.Stopped != nil {
+		rsp.Stopped = newTimeEntryResponse(result.Stopped)
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// POST /time/stop
+func (server *Server) stopTimer(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	entry, err := server.timetrack.Stop(r.Context(), payload.User.ID)
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newTimeEntryResponse(entry)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// /time/entries?from=2024-03-01&to=2024-03-31&tz=Europe/Berlin&format=csv, format defaults to json
+func (server *Server) getTimeEntries(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("format must be json or csv")))
+		return
+	}
+	from, to, _, err := parseTimeRange(query, 7)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	entries, err := server.timetrack.GetEntries(r.Context(), payload.User.ID, from, to)
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="time-entries.csv"`)
+		if err := writeTimeEntryCSV(w, entries); err != nil {
+			render.Render(w, r, ErrInternalServer(err))
+		}
+		return
+	}
+
+	rsp := &TimeEntryExportListResponse{Entries: make([]*TimeEntryExportResponse, len(entries))}
+	for i := range entries {
+		entry := &entries[i]
+		rsp.Entries[i] = &TimeEntryExportResponse{
+			GetTimeEntriesBetweenRow: entry,
+			EndedAt:                  nullTimePtr(entry.EndedAt),
+			ProjectID:                nullInt64Ptr(entry.ProjectID),
+			EstimateMinutes:          nullInt32Ptr(entry.EstimateMinutes),
+			DurationSeconds:          entrySeconds(entry.StartedAt, entry.EndedAt),
+		}
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// one row per entry, endedAt is empty while the timer runs
+func writeTimeEntryCSV(w http.ResponseWriter, entries []db.GetTimeEntriesBetweenRow) error {
+	writer := csv.NewWriter(w)
+	header := []string{"id", "taskId", "task", "projectId", "startedAt", "endedAt", "durationSeconds", "estimateMinutes", "note"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		record := make([]string, len(header))
+		record[0] = strconv.FormatInt(entry.ID, 10)
+		record[1] = strconv.FormatInt(entry.TaskID, 10)
+		record[2] = entry.Body
+		if entry.ProjectID.Valid {
+			record[3] = strconv.FormatInt(entry.ProjectID.Int64, 10)
+		}
+		record[4] = entry.StartedAt.Format(time.RFC3339)
+		if entry.EndedAt.Valid {
+			record[5] = entry.EndedAt.Time.Format(time.RFC3339)
+		}
+		record[6] = strconv.FormatInt(entrySeconds(entry.StartedAt, entry.EndedAt), 10)
+		if entry.EstimateMinutes.Valid {
+			record[7] = strconv.FormatInt(int64(entry.EstimateMinutes.Int32), 10)
+		}
+		record[8] = entry.Note
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// POST /time/entries - {taskId, startedAt, endedAt, note}
+func (server *Server) createTimeEntry(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &TimeEntryRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	if data.TaskID == 0 {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("taskId is a required field")))
+		return
+	}
+
+	entry, err := server.timetrack.CreateEntry(r.Context(), payload.User.ID, data.TaskID, data.params())
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newTimeEntryResponse(entry)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// PUT /time/entries/7 - {startedAt, endedAt, note}
+func (server *Server) updateTimeEntry(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	id, err := getIdFromURLPath(r, "entryID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	data := &TimeEntryRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	entry, err := server.timetrack.UpdateEntry(r.Context(), payload.User.ID, id, data.params())
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newTimeEntryResponse(entry)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteTimeEntryResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteTimeEntryResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	id, err := getIdFromURLPath(r, "entryID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if err := server.timetrack.DeleteEntry(r.Context(), payload.User.ID, id); err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	rsp := &deleteTimeEntryResponse{
+		Message: fmt.Sprintf("delete time entry id %d success", id),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// /time/report?from=2024-03-01&to=2024-03-31&group_by=day&tz=Europe/Berlin, group_by defaults to project
+func (server *Server) getTimeReport(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	query := r.URL.Query()
+	from, to, loc, err := parseTimeRange(query, 7)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	groupBy := query.Get("group_by")
+	if groupBy == "" {
+		groupBy = timetrack.GroupByProject
+	}
+
+	report, err := server.timetrack.Report(r.Context(), payload.User.ID, timetrack.ReportParams{
+		From:     from,
+		To:       to,
+		GroupBy:  groupBy,
+		Location: loc,
+	})
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &TimeReportResponse{Report: report}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// GET /task/123/time - time entries of a task
+func (server *Server) getTaskTimeEntries(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	id, err := getIdFromURLPath(r, "taskID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	entries, err := server.timetrack.GetTaskEntries(r.Context(), payload.User.ID, id)
+	if err != nil {
+		renderTimeError(w, r, err)
+		return
+	}
+	rsp := &TimeEntryListResponse{Entries: make([]*TimeEntryResponse, len(entries))}
+	for i := range entries {
+		rsp.Entries[i] = newTimeEntryResponse(&entries[i])
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
@@ -1,13 +1,37 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/util"
 )
 
+// newTokenMaker creates the maker of config.TokenFormat, the keyring is nil for symmetric tokens.
+// The signing keys are loaded from the store, the keys of this period are created if there are none
+func newTokenMaker(config util.Config, store db.Store) (token.Maker, *token.Keyring, error) {
+	switch config.TokenFormat {
+	case util.TokenFormatPasetoPublic, util.TokenFormatJWT:
+		keyring, err := token.NewKeyring(context.Background(), store, config.TokenKeySecret, config.TokenKeyRotation, config.TokenKeyGracePeriod)
+		if err != nil {
+			return nil, nil, err
+		}
+		if config.TokenFormat == util.TokenFormatJWT {
+			return token.NewJWTEdDSAMaker(keyring), keyring, nil
+		}
+		return token.NewPasetoPublicMaker(keyring), keyring, nil
+	default:
+		maker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+		return maker, nil, err
+	}
+}
+
 // For refresh token
 type renewAccessTokenRequest struct {
 	RefreshToken string `json:"refresh_token"`
@@ -21,8 +45,10 @@ func (c *renewAccessTokenRequest) Bind(r *http.Request) error {
 }
 
 type renewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
 func (*renewAccessTokenResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -36,15 +62,17 @@ func (server *Server) renewAccessToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newToken, err := server.token.RenewAccessToken(r.Context(), data.RefreshToken)
+	newToken, err := server.token.RenewAccessToken(r.Context(), data.RefreshToken, r.RemoteAddr)
 	if err != nil {
 		render.Render(w, r, ErrUnauthorized(err))
 		return
 	}
 
 	rsp := renewAccessTokenResponse{
-		AccessToken:          newToken.AccessToken,
-		AccessTokenExpiresAt: newToken.AccessTokenExpiresAt,
+		AccessToken:           newToken.AccessToken,
+		AccessTokenExpiresAt:  newToken.AccessTokenExpiresAt,
+		RefreshToken:          newToken.RefreshToken,
+		RefreshTokenExpiresAt: newToken.RefreshTokenExpiresAt,
 	}
 
 	if err := render.Render(w, r, &rsp); err != nil {
@@ -72,10 +100,8 @@ func (*removeTokenSessionResponse) Render(w http.ResponseWriter, r *http.Request
 	return nil
 }
 
-// Caveat is that we only delete Refresh Token Session,
-// This means user cannot refresh AccessToken when the token is expired
-// However, user can still
-// perform some tasks using the AccessToken, if the token is not expired
+// The refresh token session is deleted, and the access token from
+// the Authorization header, when sent, is revoked so it stops working right away
 func (server *Server) removeTokenSession(w http.ResponseWriter, r *http.Request) {
 	data := &removeTokenSessionRequest{}
 	if err := render.Bind(r, data); err != nil {
@@ -83,6 +109,13 @@ func (server *Server) removeTokenSession(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if fields := strings.Fields(r.Header.Get("authorization")); len(fields) == 2 && strings.ToLower(fields[0]) == "bearer" {
+		if err := server.token.RevokeAccessToken(r.Context(), fields[1]); err != nil {
+			render.Render(w, r, ErrUnauthorized(err))
+			return
+		}
+	}
+
 	//Delete session
 	err := server.token.DeleteTokenSession(r.Context(), data.RefreshToken)
 	if err != nil {
@@ -97,3 +130,49 @@ func (server *Server) removeTokenSession(w http.ResponseWriter, r *http.Request)
 		render.Render(w, r, ErrRender(err))
 	}
 }
+
+type logoutAllResponse struct {
+	Message string `json:"message"`
+}
+
+func (*logoutAllResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// logoutAll revokes every session and access token of the user, including the one making the request
+func (server *Server) logoutAll(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	if err := server.token.RevokeUserTokens(r.Context(), payload.User.ID); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &logoutAllResponse{
+		Message: "logged out of all sessions",
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type jwksResponse struct {
+	Keys []token.JWK `json:"keys"`
+}
+
+func (*jwksResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// getJWKS publishes the public keys of the keyring so other services verify access tokens
+// without the secret, the key set is empty when tokens are symmetric
+func (server *Server) getJWKS(w http.ResponseWriter, r *http.Request) {
+	rsp := &jwksResponse{Keys: []token.JWK{}}
+	if server.keyring != nil {
+		rsp.Keys = server.keyring.JWKS()
+		//the next key is in the set a rotation ahead, a cached set knows it before it signs
+		maxAge := min(time.Hour, server.config.TokenKeyRotation/2)
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
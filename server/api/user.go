@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"net/mail"
+	"net/url"
 
 	"time"
 
@@ -24,10 +27,21 @@ func (*loginOrCreateUserResponse) Render(w http.ResponseWriter, r *http.Request)
 }
 
 type userResponse struct {
-	Username          string    `json:"username"`
-	Email             string    `json:"email"`
-	PasswordChangedAt time.Time `json:"password_changed_at"`
-	CreatedAt         time.Time `json:"created_at"`
+	Username          string     `json:"username"`
+	Email             string     `json:"email"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at"`
+	PasswordChangedAt time.Time  `json:"password_changed_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func newUserResponse(user *db.User) *userResponse {
+	return &userResponse{
+		Username:          user.Username,
+		Email:             user.Email,
+		EmailVerifiedAt:   nullTimePtr(user.EmailVerifiedAt),
+		PasswordChangedAt: user.PasswordChangedAt,
+		CreatedAt:         user.CreatedAt,
+	}
 }
 
 func (*userResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -76,6 +90,11 @@ func (server *Server) createUser(w http.ResponseWriter, r *http.Request) {
 		render.Render(w, r, ErrInternalServer(err))
 		return
 	}
+	//the account is usable without verification unless the server requires it,
+	//so a failed email doesn't fail the signup, the user can ask for another one
+	if err := server.sendVerificationEmail(r.Context(), user.ID); err != nil {
+		log.Printf("cannot send verification email to user %d: %v", user.ID, err)
+	}
 
 	tokenRsp, err := server.token.CreateNewAccessToken(r.Context(), token.CreateTokenParams{
 		User: token.User{
@@ -92,12 +111,7 @@ func (server *Server) createUser(w http.ResponseWriter, r *http.Request) {
 
 	rsp := &loginOrCreateUserResponse{
 		Token: tokenRsp,
-		User: &userResponse{
-			Username:          user.Username,
-			Email:             user.Email,
-			PasswordChangedAt: user.PasswordChangedAt,
-			CreatedAt:         user.CreatedAt,
-		},
+		User:  newUserResponse(user),
 	}
 
 	if err := render.Render(w, r, rsp); err != nil {
@@ -144,6 +158,30 @@ func (server *Server) loginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	methods, err := server.auth.MfaMethods(r.Context(), user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	if len(methods) > 0 {
+		//no tokens until the second step at /user/login/mfa or /user/login/webauthn
+		challenge, expiresAt := server.auth.NewMfaChallenge(user.ID)
+		rsp := &mfaChallengeResponse{
+			MfaRequired: true,
+			MfaToken:    challenge,
+			MfaMethods:  methods,
+			ExpiresAt:   expiresAt,
+		}
+		if err := render.Render(w, r, rsp); err != nil {
+			render.Render(w, r, ErrRender(err))
+		}
+		return
+	}
+	server.renderLogin(w, r, user)
+}
+
+// renderLogin creates a session for a user who proved who they are
+func (server *Server) renderLogin(w http.ResponseWriter, r *http.Request, user *db.User) {
 	tokenRsp, err := server.token.CreateNewAccessToken(r.Context(), token.CreateTokenParams{
 		User: token.User{
 			ID:       user.ID,
@@ -155,16 +193,12 @@ func (server *Server) loginUser(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		render.Render(w, r, ErrInternalServer(err))
+		return
 	}
 
 	rsp := &loginOrCreateUserResponse{
 		Token: tokenRsp,
-		User: &userResponse{
-			Username:          user.Username,
-			Email:             user.Email,
-			PasswordChangedAt: user.PasswordChangedAt,
-			CreatedAt:         user.CreatedAt,
-		},
+		User:  newUserResponse(user),
 	}
 
 	if err := render.Render(w, r, rsp); err != nil {
@@ -281,13 +315,85 @@ func (server *Server) getCurrentUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rsp := userResponse{
-		Username:          user.Username,
-		Email:             user.Email,
-		PasswordChangedAt: user.PasswordChangedAt,
-		CreatedAt:         user.CreatedAt,
+	if err := render.Render(w, r, newUserResponse(user)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// sendVerificationEmail emails a verification link to the user
+func (server *Server) sendVerificationEmail(ctx context.Context, userId int64) error {
+	user, verificationToken, err := server.auth.SendVerification(ctx, userId)
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/user/verify-email?token=%s", server.config.PublicURL, url.QueryEscape(verificationToken))
+	return server.mail.SendEmail(m.MakeEmailForEmailVerification(link, user.Email))
+}
+
+// token from the verification email, it is read from the query string when the link is opened
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+func (c *verifyEmailRequest) Bind(r *http.Request) error {
+	if c.Token == "" {
+		return fmt.Errorf("missing token field")
+	}
+	return nil
+}
+
+func (server *Server) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	data := &verifyEmailRequest{Token: r.URL.Query().Get("token")}
+	if r.Method == http.MethodPost {
+		if err := render.Bind(r, data); err != nil {
+			render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+	} else if data.Token == "" {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("missing token")))
+		return
+	}
+
+	user, err := server.auth.VerifyEmail(r.Context(), data.Token)
+	if err != nil {
+		if err == auth.ErrInvalidVerificationToken {
+			render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+		render.Render(w, r, ErrInternalServer(err))
+		return
 	}
-	if err := render.Render(w, r, &rsp); err != nil {
+	if err := render.Render(w, r, newUserResponse(user)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type resendVerificationEmailResponse struct {
+	Message string `json:"message"`
+}
+
+func (*resendVerificationEmailResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) resendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.sendVerificationEmail(r.Context(), payload.User.ID); err != nil {
+		switch err {
+		case auth.ErrEmailAlreadyVerified:
+			render.Render(w, r, ErrConflict(err))
+		case auth.ErrVerificationThrottled:
+			render.Render(w, r, ErrTooManyRequests(err))
+		default:
+			render.Render(w, r, ErrInternalServer(err))
+		}
+		return
+	}
+	rsp := &resendVerificationEmailResponse{
+		Message: fmt.Sprintf("verification email has been sent to %s", payload.User.Email),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
 		render.Render(w, r, ErrRender(err))
 	}
 }
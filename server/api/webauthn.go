@@ -0,0 +1,233 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/auth"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/service/webauthn"
+)
+
+func renderWebauthnError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case webauthn.ErrInvalidCeremony, webauthn.ErrInvalidResponse, webauthn.ErrChallengeMismatch,
+		webauthn.ErrOriginNotAllowed, webauthn.ErrRPIDMismatch, webauthn.ErrUnsupportedKey,
+		webauthn.ErrInvalidCBOR, webauthn.ErrInvalidCredentialName:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case webauthn.ErrUnknownCredential, webauthn.ErrBadSignature, webauthn.ErrSignCountMismatch,
+		webauthn.ErrUserNotPresent, webauthn.ErrUserNotVerified, auth.ErrInvalidMfaChallenge:
+		render.Render(w, r, ErrUnauthorized(err))
+	case webauthn.ErrCredentialExists:
+		render.Render(w, r, ErrConflict(err))
+	case webauthn.ErrCredentialNotFound:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+type creationCeremonyResponse struct {
+	*webauthn.CreationCeremony
+}
+
+func (*creationCeremonyResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type requestCeremonyResponse struct {
+	*webauthn.RequestCeremony
+}
+
+func (*requestCeremonyResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type webauthnCredentialResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+func newWebauthnCredentialResponse(credential *db.WebauthnCredential) *webauthnCredentialResponse {
+	return &webauthnCredentialResponse{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: nullTimePtr(credential.LastUsedAt),
+	}
+}
+
+func (*webauthnCredentialResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type webauthnCredentialListResponse struct {
+	Credentials []*webauthnCredentialResponse `json:"credentials"`
+}
+
+func (*webauthnCredentialListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) beginWebauthnRegistration(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	ceremony, err := server.webauthn.BeginRegistration(r.Context(), payload.User.ID, payload.User.Email, payload.User.Username)
+	if err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &creationCeremonyResponse{ceremony}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type finishWebauthnRegistrationRequest struct {
+	Ceremony   string                         `json:"ceremony"`
+	Name       string                         `json:"name"`
+	Credential *webauthn.RegistrationResponse `json:"credential"`
+}
+
+func (c *finishWebauthnRegistrationRequest) Bind(r *http.Request) error {
+	if c.Ceremony == "" || c.Credential == nil {
+		return fmt.Errorf("missing ceremony or/and credential fields")
+	}
+	return nil
+}
+
+func (server *Server) finishWebauthnRegistration(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &finishWebauthnRegistrationRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	credential, err := server.webauthn.FinishRegistration(r.Context(), payload.User.ID, data.Ceremony, data.Name, data.Credential)
+	if err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newWebauthnCredentialResponse(credential)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) getWebauthnCredentialList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	credentials, err := server.webauthn.ListCredentials(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &webauthnCredentialListResponse{Credentials: make([]*webauthnCredentialResponse, len(credentials))}
+	for i := range credentials {
+		rsp.Credentials[i] = newWebauthnCredentialResponse(&credentials[i])
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteWebauthnCredentialResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteWebauthnCredentialResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteWebauthnCredential(w http.ResponseWriter, r *http.Request) {
+	credentialId, err := getIdFromURLPath(r, "credentialID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.webauthn.DeleteCredential(r.Context(), payload.User.ID, credentialId); err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	rsp := &deleteWebauthnCredentialResponse{
+		Message: fmt.Sprintf("delete credential id %d success", credentialId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// without mfa_token it is a passkey login, with it the second step after the password
+type beginWebauthnLoginRequest struct {
+	MfaToken string `json:"mfa_token"`
+}
+
+func (c *beginWebauthnLoginRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+// loginUserId is the user of the mfa token, zero without one
+func (server *Server) loginUserId(mfaToken string) (int64, error) {
+	if mfaToken == "" {
+		return 0, nil
+	}
+	return server.auth.ParseMfaChallenge(mfaToken)
+}
+
+func (server *Server) beginWebauthnLogin(w http.ResponseWriter, r *http.Request) {
+	data := &beginWebauthnLoginRequest{}
+	if r.ContentLength != 0 {
+		if err := render.Bind(r, data); err != nil {
+			render.Render(w, r, ErrInvalidRequest(err))
+			return
+		}
+	}
+	userId, err := server.loginUserId(data.MfaToken)
+	if err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	ceremony, err := server.webauthn.BeginLogin(r.Context(), userId)
+	if err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, &requestCeremonyResponse{ceremony}); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type finishWebauthnLoginRequest struct {
+	Ceremony   string                      `json:"ceremony"`
+	MfaToken   string                      `json:"mfa_token"`
+	Credential *webauthn.AssertionResponse `json:"credential"`
+}
+
+func (c *finishWebauthnLoginRequest) Bind(r *http.Request) error {
+	if c.Ceremony == "" || c.Credential == nil {
+		return fmt.Errorf("missing ceremony or/and credential fields")
+	}
+	return nil
+}
+
+func (server *Server) finishWebauthnLogin(w http.ResponseWriter, r *http.Request) {
+	data := &finishWebauthnLoginRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	userId, err := server.loginUserId(data.MfaToken)
+	if err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	user, err := server.webauthn.FinishLogin(r.Context(), userId, data.Ceremony, data.Credential)
+	if err != nil {
+		renderWebauthnError(w, r, err)
+		return
+	}
+	server.renderLogin(w, r, user)
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/service/webhook"
+)
+
+// secret is only sent when the webhook is created
+type WebhookResponse struct {
+	*db.Webhook
+	ProjectID *int64 `json:"projectId"`
+	Secret    string `json:"secret,omitempty"`
+}
+
+func (*WebhookResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func newWebhookResponse(h *db.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		Webhook:   h,
+		ProjectID: nullInt64Ptr(h.ProjectID),
+	}
+}
+
+type WebhookListResponse struct {
+	Webhooks []*WebhookResponse `json:"webhooks"`
+}
+
+func (*WebhookListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// status is pending until the receiver answers with 2xx (delivered) or the retries run out (failed)
+type WebhookDeliveryResponse struct {
+	*db.WebhookDelivery
+	NextAttemptAt  *time.Time `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	ResponseStatus *int32     `json:"responseStatus"`
+	Status         string     `json:"status"`
+}
+
+func (*WebhookDeliveryResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func newWebhookDeliveryResponse(d *db.WebhookDelivery) *WebhookDeliveryResponse {
+	rsp := &WebhookDeliveryResponse{
+		WebhookDelivery: d,
+		NextAttemptAt:   nullTimePtr(d.NextAttemptAt),
+		DeliveredAt:     nullTimePtr(d.DeliveredAt),
+		ResponseStatus:  nullInt32Ptr(d.ResponseStatus),
+		Status:          "pending",
+	}
+	if d.DeliveredAt.Valid {
+		rsp.Status = "delivered"
+	} else if !d.NextAttemptAt.Valid {
+		rsp.Status = "failed"
+	}
+	return rsp
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+}
+
+func (*WebhookDeliveryListResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// events are the event types sent, all of them when empty.
+// projectId limits the webhook to the tasks of a project.
+// secret is only read on create, a random one is made when empty
+type WebhookRequest struct {
+	URL       string   `json:"url"`
+	ProjectID *int64   `json:"projectId"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret"`
+	Active    *bool    `json:"active"`
+}
+
+func (c *WebhookRequest) Bind(r *http.Request) error {
+	if c.URL == "" {
+		return fmt.Errorf("url is a required field")
+	}
+	return nil
+}
+
+func (c *WebhookRequest) params() webhook.WebhookParams {
+	return webhook.WebhookParams{
+		ProjectID: c.ProjectID,
+		URL:       c.URL,
+		Events:    c.Events,
+		Secret:    c.Secret,
+		Active:    c.Active == nil || *c.Active,
+	}
+}
+
+// map errors from webhook service to responses
+func renderWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case webhook.ErrOwnerNotMatched:
+		render.Render(w, r, ErrUnauthorized(err))
+	case webhook.ErrInvalidURL, webhook.ErrPrivateAddress, webhook.ErrInvalidEvent, webhook.ErrInvalidSecret:
+		render.Render(w, r, ErrInvalidRequest(err))
+	case sql.ErrNoRows:
+		render.Render(w, r, ErrNotFound)
+	default:
+		render.Render(w, r, ErrInternalServer(err))
+	}
+}
+
+func (server *Server) getWebhookList(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	webhooks, err := server.webhook.GetWebhookList(r.Context(), payload.User.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &WebhookListResponse{Webhooks: make([]*WebhookResponse, len(webhooks))}
+	for i := range webhooks {
+		rsp.Webhooks[i] = newWebhookResponse(&webhooks[i])
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &WebhookRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	hook, err := server.webhook.CreateWebhook(r.Context(), payload.User.ID, data.params())
+	if err != nil {
+		renderWebhookError(w, r, err)
+		return
+	}
+	rsp := newWebhookResponse(hook)
+	rsp.Secret = hook.Secret
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := getIdFromURLPath(r, "webhookID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	hook, err := server.webhook.GetWebhookById(r.Context(), webhookId, payload.User.ID)
+	if err != nil {
+		renderWebhookError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newWebhookResponse(hook)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+func (server *Server) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := getIdFromURLPath(r, "webhookID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	data := &WebhookRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+
+	hook, err := server.webhook.UpdateWebhook(r.Context(), webhookId, payload.User.ID, data.params())
+	if err != nil {
+		renderWebhookError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newWebhookResponse(hook)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+type deleteWebhookResponse struct {
+	Message string `json:"message"`
+}
+
+func (*deleteWebhookResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (server *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := getIdFromURLPath(r, "webhookID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	if err := server.webhook.DeleteWebhook(r.Context(), webhookId, payload.User.ID); err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	rsp := &deleteWebhookResponse{
+		Message: fmt.Sprintf("delete webhook id %d success", webhookId),
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// delivery log of a webhook, latest first
+// /webhooks/2/deliveries?pageId=1&limit=20
+func (server *Server) getWebhookDeliveryList(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := getIdFromURLPath(r, "webhookID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	queryStrings := r.URL.Query()
+	pageId, err := strconv.Atoi(queryStrings.Get("pageId"))
+	if err != nil || pageId < 1 {
+		pageId = 1
+	}
+	limit, err := strconv.Atoi(queryStrings.Get("limit"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	deliveries, err := server.webhook.GetDeliveries(r.Context(), webhookId, payload.User.ID, int32(limit), int32(pageId))
+	if err != nil {
+		renderWebhookError(w, r, err)
+		return
+	}
+	rsp := &WebhookDeliveryListResponse{Deliveries: make([]*WebhookDeliveryResponse, len(deliveries))}
+	for i := range deliveries {
+		rsp.Deliveries[i] = newWebhookDeliveryResponse(&deliveries[i])
+	}
+	if err := render.Render(w, r, rsp); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
+
+// queue a past delivery again, it is sent as a new delivery
+func (server *Server) redeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := getIdFromURLPath(r, "webhookID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	deliveryId, err := getIdFromURLPath(r, "deliveryID")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+
+	delivery, err := server.webhook.Redeliver(r.Context(), webhookId, deliveryId, payload.User.ID)
+	if err != nil {
+		renderWebhookError(w, r, err)
+		return
+	}
+	if err := render.Render(w, r, newWebhookDeliveryResponse(delivery)); err != nil {
+		render.Render(w, r, ErrRender(err))
+	}
+}
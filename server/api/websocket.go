@@ -0,0 +1,355 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/punkzberryz/todo/service/apikey"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/token"
+)
+
+const (
+	wsWriteWait = 10 * time.Second
+	// the client must answer pings within wsPongWait
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	wsReadLimit  = 64 << 10
+	// a client that lets this many messages queue up is disconnected,
+	// it can reconnect with its last event id
+	wsSendBuffer = 64
+	// close codes sent to clients, 4000-4999 are for applications
+	wsCloseTokenExpired = 4001
+	wsCloseRevoked      = 4002
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// checkWebSocketOrigin lets browsers open a WebSocket from the configured origins only.
+// Requests without an Origin header don't come from a browser page
+func (server *Server) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range server.config.WebsocketOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// messages from clients, id is echoed in the ack
+// {"id": "1", "type": "subscribe", "projectId": 3}
+// {"id": "2", "type": "task.create", "task": {"body": "write tests", "projectId": 3}}
+// {"id": "3", "type": "task.update", "taskId": 12, "task": {"body": "write tests", "isDone": true}}
+// {"id": "4", "type": "task.delete", "taskId": 12}
+type wsRequest struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	ProjectID int64           `json:"projectId"`
+	TaskID    int64           `json:"taskId"`
+	Task      json.RawMessage `json:"task"`
+}
+
+// wsAck answers every request, data is the result of task mutations
+type wsAck struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	Data  any    `json:"data,omitempty"`
+}
+
+// wsEvent is a task event of a subscribed project,
+// task.deleted events only carry the task id and are sent for every project
+type wsEvent struct {
+	Type    string          `json:"type"`
+	EventID int64           `json:"eventId"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// wsConn is a WebSocket connection of a user
+type wsConn struct {
+	server  *Server
+	conn    *websocket.Conn
+	request *http.Request
+	userId  int64
+	payload *token.Payload
+	// apiKeyId is the api key the connection was opened with, 0 for a login token
+	apiKeyId int64
+	// id is the origin of the events caused by this connection,
+	// they are not sent back as the ack already carries the result
+	id   string
+	send chan any
+	done chan struct{}
+	once sync.Once
+
+	mu       sync.Mutex
+	projects map[int64]bool
+}
+
+// serve the WebSocket API, clients subscribe to projects and change tasks
+// with the same service methods as the REST API.
+// ?lastEventId= resumes the events after a reconnect.
+func (server *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	payload := r.Context().Value(payloadKey).(*token.Payload)
+	lastEventId, err := getLastEventId(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	sub, err := server.events.Subscribe(r.Context(), payload.User.ID, lastEventId)
+	if err != nil {
+		render.Render(w, r, ErrInternalServer(err))
+		return
+	}
+	defer sub.Close()
+
+	upgrader := wsUpgrader
+	upgrader.CheckOrigin = server.checkWebSocketOrigin
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		//the upgrader already replied with an error
+		return
+	}
+	c := &wsConn{
+		server:   server,
+		conn:     conn,
+		request:  r,
+		userId:   payload.User.ID,
+		payload:  payload,
+		id:       uuid.NewString(),
+		send:     make(chan any, wsSendBuffer),
+		done:     make(chan struct{}),
+		projects: map[int64]bool{},
+	}
+	c.apiKeyId, _ = r.Context().Value(apiKeyIdKey).(int64)
+	go c.writePump(payload.ExpiredAt)
+	go c.eventPump(sub)
+	c.readPump()
+}
+
+// close ends the connection, the pumps stop when done is closed
+func (c *wsConn) close(code int, reason string) {
+	c.once.Do(func() {
+		close(c.done)
+		message := websocket.FormatCloseMessage(code, reason)
+		c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait))
+		c.conn.Close()
+	})
+}
+
+// enqueue queues a message without blocking,
+// a client that doesn't read its messages is disconnected
+func (c *wsConn) enqueue(message any) {
+	select {
+	case c.send <- message:
+	case <-c.done:
+	default:
+		c.close(websocket.CloseTryAgainLater, "too slow, reconnect with lastEventId")
+	}
+}
+
+// checkAccess closes the connection once the token it was opened with is revoked,
+// by logging out or ending the session, or its api key is deleted.
+// It returns false when the connection is closed
+func (c *wsConn) checkAccess() bool {
+	var err error
+	if c.apiKeyId != 0 {
+		err = c.server.apiKey.CheckApiKey(c.request.Context(), c.userId, c.apiKeyId)
+	} else {
+		err = c.server.token.CheckRevoked(c.request.Context(), c.payload)
+	}
+	switch err {
+	case nil:
+		return true
+	case token.ErrRevokedToken, apikey.ErrInvalidApiKey, apikey.ErrExpiredApiKey:
+		c.close(wsCloseRevoked, err.Error())
+		return false
+	}
+	//the session store is down, the token was valid when the connection was opened
+	log.Printf("cannot check the access of websocket of user %d: %v", c.userId, err)
+	return true
+}
+
+func (c *wsConn) writePump(tokenExpiredAt time.Time) {
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+	expired := time.NewTimer(time.Until(tokenExpiredAt))
+	defer expired.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case message := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(message); err != nil {
+				c.close(websocket.CloseGoingAway, "")
+				return
+			}
+		case <-ping.C:
+			if !c.checkAccess() {
+				return
+			}
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				c.close(websocket.CloseGoingAway, "")
+				return
+			}
+		case <-expired.C:
+			c.close(wsCloseTokenExpired, "access token expired")
+			return
+		}
+	}
+}
+
+// eventPump forwards the events of subscribed projects
+func (c *wsConn) eventPump(sub *event.Subscription) {
+	if sub.Missed {
+		c.enqueue(&wsEvent{Type: event.TypeReset, EventID: sub.LastID, Data: []byte("{}")})
+	}
+	for i := range sub.Replay {
+		c.forward(&sub.Replay[i])
+	}
+	for {
+		select {
+		case <-c.done:
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				c.close(websocket.CloseTryAgainLater, "too slow, reconnect with lastEventId")
+				return
+			}
+			c.forward(&e)
+		}
+	}
+}
+
+func (c *wsConn) forward(e *event.Event) {
+	if e.Origin == c.id {
+		return
+	}
+	if e.Type != event.TypeTaskDeleted {
+		var task struct {
+			ProjectID *int64 `json:"projectId"`
+		}
+		if err := json.Unmarshal(e.Data, &task); err != nil || task.ProjectID == nil {
+			return
+		}
+		c.mu.Lock()
+		subscribed := c.projects[*task.ProjectID]
+		c.mu.Unlock()
+		if !subscribed {
+			return
+		}
+	}
+	c.enqueue(&wsEvent{Type: e.Type, EventID: e.ID, Data: e.Data})
+}
+
+// readPump handles requests one at a time so acks are sent in order,
+// each request is checked against revoked tokens first
+func (c *wsConn) readPump() {
+	defer c.close(websocket.CloseNormalClosure, "")
+	c.conn.SetReadLimit(wsReadLimit)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("websocket of user %d closed: %v", c.userId, err)
+			}
+			return
+		}
+		if !c.checkAccess() {
+			return
+		}
+		req := &wsRequest{}
+		if err := json.Unmarshal(message, req); err != nil {
+			c.enqueue(&wsAck{Type: "ack", Error: "invalid message"})
+			continue
+		}
+		ack := &wsAck{Type: "ack", ID: req.ID, OK: true}
+		if ack.Data, err = c.handle(req); err != nil {
+			ack.OK = false
+			ack.Error = err.Error()
+			ack.Data = nil
+		}
+		c.enqueue(ack)
+	}
+}
+
+func (c *wsConn) handle(req *wsRequest) (any, error) {
+	ctx := event.WithOrigin(c.request.Context(), c.id)
+	switch req.Type {
+	case "ping":
+		return nil, nil
+	case "subscribe":
+		if _, err := c.server.project.GetProjectById(ctx, req.ProjectID, c.userId); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.projects[req.ProjectID] = true
+		c.mu.Unlock()
+		return nil, nil
+	case "unsubscribe":
+		c.mu.Lock()
+		delete(c.projects, req.ProjectID)
+		c.mu.Unlock()
+		return nil, nil
+	case "task.create", "task.update", "task.delete":
+		//connecting takes tasks:read, api keys need tasks:write to change tasks
+		if !hasScope(c.request, apikey.ScopeTasksWrite) {
+			return nil, fmt.Errorf("api key is missing the %s scope", apikey.ScopeTasksWrite)
+		}
+		return c.mutate(ctx, req)
+	default:
+		return nil, fmt.Errorf("unknown message type %q", req.Type)
+	}
+}
+
+// mutate changes a task with the same service methods as the REST API
+func (c *wsConn) mutate(ctx context.Context, req *wsRequest) (any, error) {
+	switch req.Type {
+	case "task.create":
+		data := &CreateTaskRequest{}
+		if err := c.bind(req.Task, data); err != nil {
+			return nil, err
+		}
+		return c.server.createTaskForUser(ctx, c.userId, data)
+	case "task.update":
+		data := &UpdateTaskRequest{}
+		if err := c.bind(req.Task, data); err != nil {
+			return nil, err
+		}
+		return c.server.updateTaskForUser(ctx, c.userId, req.TaskID, data)
+	default:
+		return nil, c.server.deleteTaskForUser(ctx, c.userId, req.TaskID)
+	}
+}
+
+// bind decodes and validates a request body like render.Bind
+func (c *wsConn) bind(body json.RawMessage, v render.Binder) error {
+	if len(body) == 0 {
+		return fmt.Errorf("task is a required field")
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+	return v.Bind(c.request)
+}
@@ -0,0 +1,156 @@
+package memdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonValue is a jsonb value, numbers are kept as json.Number.
+// A jsonb null is a jsonValue with v nil, unlike a SQL NULL which is nil itself
+type jsonValue struct {
+	v interface{}
+}
+
+func parseJSON(raw []byte) (jsonValue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return jsonValue{}, fmt.Errorf("invalid input syntax for type json: %w", err)
+	}
+	return jsonValue{v: v}, nil
+}
+
+// text is the value as text like the #>> '{}' operator returns it, false for a jsonb null
+func (j jsonValue) text() (string, bool) {
+	switch v := j.v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	}
+	raw, _ := json.Marshal(j.v)
+	return string(raw), true
+}
+
+func compareOrdered[T int64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// jsonRank is the order of jsonb types: Object > Array > Boolean > Number > String > Null
+func jsonRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case string:
+		return 1
+	case json.Number:
+		return 2
+	case bool:
+		return 3
+	case []interface{}:
+		return 4
+	}
+	return 5
+}
+
+// compareJSON orders jsonb values, equal values compare as 0 like jsonb =
+func compareJSON(a interface{}, b interface{}) int {
+	if ra, rb := jsonRank(a), jsonRank(b); ra != rb {
+		return compareOrdered(int64(ra), int64(rb))
+	}
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case json.Number:
+		x, _ := a.Float64()
+		y, _ := b.(json.Number).Float64()
+		return compareOrdered(x, y)
+	case bool:
+		switch b := b.(bool); {
+		case a == b:
+			return 0
+		case !a:
+			return -1
+		}
+		return 1
+	case []interface{}:
+		b := b.([]interface{})
+		if len(a) != len(b) {
+			return compareOrdered(int64(len(a)), int64(len(b)))
+		}
+		for i := range a {
+			if c := compareJSON(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		if len(a) != len(b) {
+			return compareOrdered(int64(len(a)), int64(len(b)))
+		}
+		for key, v := range a {
+			w, ok := b[key]
+			if !ok {
+				x, _ := jsonValue{v: a}.text()
+				y, _ := jsonValue{v: b}.text()
+				return strings.Compare(x, y)
+			}
+			if c := compareJSON(v, w); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// jsonContains is the jsonb @> operator
+func jsonContains(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, w := range b {
+			v, ok := a[key]
+			if !ok || !jsonContains(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		list, ok := b.([]interface{})
+		if !ok {
+			//an array contains a primitive value it has as element
+			if jsonRank(b) >= 4 {
+				return false
+			}
+			list = []interface{}{b}
+		}
+		for _, w := range list {
+			found := false
+			for _, v := range a {
+				if jsonContains(v, w) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return jsonRank(a) == jsonRank(b) && compareJSON(a, b) == 0
+}
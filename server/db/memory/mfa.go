@@ -0,0 +1,283 @@
+package memdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+func (q *Queries) GetUserTotp(ctx context.Context, userID int64) (db.UserTotp, error) {
+	defer q.lock()()
+	totp, ok := q.data.userTotp[userID]
+	if !ok {
+		return db.UserTotp{}, sql.ErrNoRows
+	}
+	return totp, nil
+}
+
+func (q *Queries) UpsertPendingTotp(ctx context.Context, arg db.UpsertPendingTotpParams) (db.UserTotp, error) {
+	defer q.lock()()
+	if err := q.userExists("user_totp", "user_id", arg.UserID); err != nil {
+		return db.UserTotp{}, err
+	}
+	//an enabled secret is not replaced, the conflicting row is skipped
+	if totp, ok := q.data.userTotp[arg.UserID]; ok && totp.EnabledAt.Valid {
+		return db.UserTotp{}, sql.ErrNoRows
+	}
+	totp := db.UserTotp{
+		UserID:    arg.UserID,
+		Secret:    bytes.Clone(arg.Secret),
+		CreatedAt: now(),
+	}
+	q.data.userTotp[totp.UserID] = totp
+	return totp, nil
+}
+
+func (q *Queries) EnableUserTotp(ctx context.Context, arg db.EnableUserTotpParams) (db.UserTotp, error) {
+	defer q.lock()()
+	totp, ok := q.data.userTotp[arg.UserID]
+	if !ok || totp.EnabledAt.Valid {
+		return db.UserTotp{}, sql.ErrNoRows
+	}
+	totp.EnabledAt = sql.NullTime{Time: now(), Valid: true}
+	totp.LastStep = arg.LastStep
+	q.data.userTotp[totp.UserID] = totp
+	return totp, nil
+}
+
+func (q *Queries) DeleteUserTotp(ctx context.Context, userID int64) error {
+	defer q.lock()()
+	delete(q.data.userTotp, userID)
+	return nil
+}
+
+func (q *Queries) UseTotpStep(ctx context.Context, arg db.UseTotpStepParams) (int64, error) {
+	defer q.lock()()
+	totp, ok := q.data.userTotp[arg.UserID]
+	if !ok || !arg.Step.Valid || (totp.LastStep.Valid && totp.LastStep.Int64 >= arg.Step.Int64) {
+		return 0, nil
+	}
+	totp.LastStep = arg.Step
+	totp.FailedAttempts = 0
+	q.data.userTotp[totp.UserID] = totp
+	return 1, nil
+}
+
+func (q *Queries) RecordTotpFailure(ctx context.Context, arg db.RecordTotpFailureParams) (db.UserTotp, error) {
+	defer q.lock()()
+	totp, ok := q.data.userTotp[arg.UserID]
+	if !ok {
+		return db.UserTotp{}, sql.ErrNoRows
+	}
+	if totp.FailedAttempts+1 >= arg.MaxAttempts {
+		totp.FailedAttempts = 0
+		totp.LockedUntil = sql.NullTime{Time: arg.LockedUntil, Valid: true}
+	} else {
+		totp.FailedAttempts++
+	}
+	q.data.userTotp[totp.UserID] = totp
+	return totp, nil
+}
+
+func (q *Queries) CreateRecoveryCodes(ctx context.Context, arg db.CreateRecoveryCodesParams) error {
+	defer q.lock()()
+	if err := q.userExists("mfa_recovery_codes", "user_id", arg.UserID); err != nil {
+		return err
+	}
+	codes := []db.MfaRecoveryCode{}
+	for _, codeHash := range arg.CodeHashes {
+		for _, other := range q.data.mfaRecoveryCodes {
+			if other.UserID == arg.UserID && bytes.Equal(other.CodeHash, codeHash) {
+				return uniqueViolation("mfa_recovery_codes_user_id_code_hash_idx")
+			}
+		}
+		for _, other := range codes {
+			if bytes.Equal(other.CodeHash, codeHash) {
+				return uniqueViolation("mfa_recovery_codes_user_id_code_hash_idx")
+			}
+		}
+		codes = append(codes, db.MfaRecoveryCode{
+			UserID:    arg.UserID,
+			CodeHash:  bytes.Clone(codeHash),
+			CreatedAt: now(),
+		})
+	}
+	for _, code := range codes {
+		code.ID = q.data.nextID("mfa_recovery_codes")
+		q.data.mfaRecoveryCodes[code.ID] = code
+	}
+	return nil
+}
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID int64) error {
+	defer q.lock()()
+	for id, code := range q.data.mfaRecoveryCodes {
+		if code.UserID == userID {
+			delete(q.data.mfaRecoveryCodes, id)
+		}
+	}
+	return nil
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg db.UseRecoveryCodeParams) (int64, error) {
+	defer q.lock()()
+	for _, code := range q.data.mfaRecoveryCodes {
+		if code.UserID == arg.UserID && bytes.Equal(code.CodeHash, arg.CodeHash) && !code.UsedAt.Valid {
+			code.UsedAt = sql.NullTime{Time: now(), Valid: true}
+			q.data.mfaRecoveryCodes[code.ID] = code
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (q *Queries) CountRecoveryCodes(ctx context.Context, userID int64) (int64, error) {
+	defer q.lock()()
+	var count int64
+	for _, code := range q.data.mfaRecoveryCodes {
+		if code.UserID == userID && !code.UsedAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *Queries) CreateWebauthnCredential(ctx context.Context, arg db.CreateWebauthnCredentialParams) (db.WebauthnCredential, error) {
+	defer q.lock()()
+	for _, other := range q.data.webauthnCredentials {
+		if bytes.Equal(other.CredentialID, arg.CredentialID) {
+			return db.WebauthnCredential{}, uniqueViolation("webauthn_credentials_credential_id_key")
+		}
+	}
+	if err := q.userExists("webauthn_credentials", "user_id", arg.UserID); err != nil {
+		return db.WebauthnCredential{}, err
+	}
+	credential := db.WebauthnCredential{
+		ID:           q.data.nextID("webauthn_credentials"),
+		UserID:       arg.UserID,
+		CredentialID: bytes.Clone(arg.CredentialID),
+		PublicKey:    bytes.Clone(arg.PublicKey),
+		SignCount:    arg.SignCount,
+		Name:         arg.Name,
+		CreatedAt:    now(),
+	}
+	q.data.webauthnCredentials[credential.ID] = credential
+	return credential, nil
+}
+
+func (q *Queries) GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (db.WebauthnCredential, error) {
+	defer q.lock()()
+	for _, credential := range q.data.webauthnCredentials {
+		if bytes.Equal(credential.CredentialID, credentialID) {
+			return credential, nil
+		}
+	}
+	return db.WebauthnCredential{}, sql.ErrNoRows
+}
+
+func (q *Queries) ListWebauthnCredentials(ctx context.Context, userID int64) ([]db.WebauthnCredential, error) {
+	defer q.lock()()
+	return selectRows(q.data.webauthnCredentials, func(credential db.WebauthnCredential) bool {
+		return credential.UserID == userID
+	}, func(a, b db.WebauthnCredential) bool {
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) CountWebauthnCredentials(ctx context.Context, userID int64) (int64, error) {
+	defer q.lock()()
+	var count int64
+	for _, credential := range q.data.webauthnCredentials {
+		if credential.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *Queries) UpdateWebauthnSignCount(ctx context.Context, arg db.UpdateWebauthnSignCountParams) (int64, error) {
+	defer q.lock()()
+	credential, ok := q.data.webauthnCredentials[arg.ID]
+	if !ok || !(credential.SignCount < arg.SignCount || (credential.SignCount == 0 && arg.SignCount == 0)) {
+		return 0, nil
+	}
+	credential.SignCount = arg.SignCount
+	credential.LastUsedAt = sql.NullTime{Time: now(), Valid: true}
+	q.data.webauthnCredentials[credential.ID] = credential
+	return 1, nil
+}
+
+func (q *Queries) DeleteWebauthnCredential(ctx context.Context, arg db.DeleteWebauthnCredentialParams) (int64, error) {
+	defer q.lock()()
+	credential, ok := q.data.webauthnCredentials[arg.ID]
+	if !ok || credential.UserID != arg.UserID {
+		return 0, nil
+	}
+	delete(q.data.webauthnCredentials, credential.ID)
+	return 1, nil
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+	defer q.lock()()
+	for _, other := range q.data.apiKeys {
+		if bytes.Equal(other.KeyHash, arg.KeyHash) {
+			return db.ApiKey{}, uniqueViolation("api_keys_key_hash_key")
+		}
+	}
+	if err := q.userExists("api_keys", "user_id", arg.UserID); err != nil {
+		return db.ApiKey{}, err
+	}
+	key := db.ApiKey{
+		ID:        q.data.nextID("api_keys"),
+		UserID:    arg.UserID,
+		Name:      arg.Name,
+		Prefix:    arg.Prefix,
+		KeyHash:   bytes.Clone(arg.KeyHash),
+		Scopes:    append([]string{}, arg.Scopes...),
+		ExpiresAt: arg.ExpiresAt,
+		CreatedAt: now(),
+	}
+	q.data.apiKeys[key.ID] = key
+	return key, nil
+}
+
+func (q *Queries) GetApiKeyByHash(ctx context.Context, keyHash []byte) (db.ApiKey, error) {
+	defer q.lock()()
+	for _, key := range q.data.apiKeys {
+		if bytes.Equal(key.KeyHash, keyHash) {
+			return key, nil
+		}
+	}
+	return db.ApiKey{}, sql.ErrNoRows
+}
+
+func (q *Queries) ListApiKeys(ctx context.Context, userID int64) ([]db.ApiKey, error) {
+	defer q.lock()()
+	return selectRows(q.data.apiKeys, func(key db.ApiKey) bool {
+		return key.UserID == userID
+	}, func(a, b db.ApiKey) bool {
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) TouchApiKey(ctx context.Context, arg db.TouchApiKeyParams) error {
+	defer q.lock()()
+	key, ok := q.data.apiKeys[arg.ID]
+	if ok && (!key.LastUsedAt.Valid || key.LastUsedAt.Time.Before(arg.UsedBefore)) {
+		key.LastUsedAt = sql.NullTime{Time: arg.Now, Valid: true}
+		q.data.apiKeys[key.ID] = key
+	}
+	return nil
+}
+
+func (q *Queries) DeleteApiKey(ctx context.Context, arg db.DeleteApiKeyParams) (int64, error) {
+	defer q.lock()()
+	key, ok := q.data.apiKeys[arg.ID]
+	if !ok || key.UserID != arg.UserID {
+		return 0, nil
+	}
+	delete(q.data.apiKeys, key.ID)
+	return 1, nil
+}
@@ -0,0 +1,430 @@
+package memdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+func (q *Queries) CreateProject(ctx context.Context, arg db.CreateProjectParams) (db.Project, error) {
+	defer q.lock()()
+	if err := q.userExists("projects", "owner_id", arg.OwnerID); err != nil {
+		return db.Project{}, err
+	}
+	project := db.Project{
+		ID:        q.data.nextID("projects"),
+		Name:      arg.Name,
+		OwnerID:   arg.OwnerID,
+		CreatedAt: now(),
+	}
+	q.data.projects[project.ID] = project
+	q.recordSyncChange("project", project.ID, project.OwnerID, false)
+	return project, nil
+}
+
+func (q *Queries) GetProject(ctx context.Context, id int64) (db.Project, error) {
+	defer q.lock()()
+	project, ok := q.data.projects[id]
+	if !ok {
+		return db.Project{}, sql.ErrNoRows
+	}
+	return project, nil
+}
+
+// LockProject is GetProject, transactions are serialized so the row lock is implied
+func (q *Queries) LockProject(ctx context.Context, id int64) (db.Project, error) {
+	return q.GetProject(ctx, id)
+}
+
+// LockProjectForShare is GetProject, transactions are serialized so the row lock is implied
+func (q *Queries) LockProjectForShare(ctx context.Context, id int64) (db.Project, error) {
+	return q.GetProject(ctx, id)
+}
+
+func (q *Queries) GetProjectList(ctx context.Context, ownerID int64) ([]db.Project, error) {
+	defer q.lock()()
+	return selectRows(q.data.projects, func(project db.Project) bool {
+		return project.OwnerID == ownerID
+	}, func(a, b db.Project) bool {
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) GetProjectsByIds(ctx context.Context, ids []int64) ([]db.Project, error) {
+	defer q.lock()()
+	return selectRows(q.data.projects, func(project db.Project) bool {
+		return containsID(ids, project.ID)
+	}, func(a, b db.Project) bool {
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) UpdateProject(ctx context.Context, arg db.UpdateProjectParams) (db.Project, error) {
+	defer q.lock()()
+	project, ok := q.data.projects[arg.ID]
+	if !ok || project.OwnerID != arg.OwnerID {
+		return db.Project{}, sql.ErrNoRows
+	}
+	project.Name = arg.Name
+	q.data.projects[project.ID] = project
+	q.recordSyncChange("project", project.ID, project.OwnerID, false)
+	return project, nil
+}
+
+func (q *Queries) DeleteProject(ctx context.Context, arg db.DeleteProjectParams) error {
+	defer q.lock()()
+	project, ok := q.data.projects[arg.ID]
+	if !ok || project.OwnerID != arg.OwnerID {
+		return nil
+	}
+	for _, status := range q.data.projectStatuses {
+		if status.ProjectID == project.ID {
+			q.deleteProjectStatus(status.ID)
+		}
+	}
+	for _, field := range q.data.customFields {
+		if field.ProjectID == project.ID {
+			q.deleteCustomField(field.ID)
+		}
+	}
+	for _, webhook := range q.data.webhooks {
+		if webhook.ProjectID.Valid && webhook.ProjectID.Int64 == project.ID {
+			q.deleteWebhook(webhook.ID)
+		}
+	}
+	for _, task := range q.data.tasks {
+		if task.ProjectID.Valid && task.ProjectID.Int64 == project.ID {
+			task.ProjectID = sql.NullInt64{}
+			q.setTask(task)
+		}
+	}
+	delete(q.data.projects, project.ID)
+	q.recordSyncChange("project", project.ID, project.OwnerID, true)
+	return nil
+}
+
+func (q *Queries) projectExists(table string, projectId int64) error {
+	if _, ok := q.data.projects[projectId]; !ok {
+		return foreignKeyViolation(table, table+"_project_id_fkey")
+	}
+	return nil
+}
+
+func (q *Queries) CreateProjectStatus(ctx context.Context, arg db.CreateProjectStatusParams) (db.ProjectStatus, error) {
+	defer q.lock()()
+	if err := q.projectExists("project_statuses", arg.ProjectID); err != nil {
+		return db.ProjectStatus{}, err
+	}
+	status := db.ProjectStatus{
+		ProjectID: arg.ProjectID,
+		Name:      arg.Name,
+		Category:  arg.Category,
+		Position:  arg.Position,
+		WipLimit:  arg.WipLimit,
+		CreatedAt: now(),
+	}
+	if err := q.checkProjectStatusName(status); err != nil {
+		return db.ProjectStatus{}, err
+	}
+	status.ID = q.data.nextID("project_statuses")
+	q.data.projectStatuses[status.ID] = status
+	return status, nil
+}
+
+// checkProjectStatusName is UNIQUE (project_id, name)
+func (q *Queries) checkProjectStatusName(status db.ProjectStatus) error {
+	for _, other := range q.data.projectStatuses {
+		if other.ID != status.ID && other.ProjectID == status.ProjectID && other.Name == status.Name {
+			return uniqueViolation("project_statuses_project_id_name_key")
+		}
+	}
+	return nil
+}
+
+func (q *Queries) GetProjectStatus(ctx context.Context, id int64) (db.ProjectStatus, error) {
+	defer q.lock()()
+	status, ok := q.data.projectStatuses[id]
+	if !ok {
+		return db.ProjectStatus{}, sql.ErrNoRows
+	}
+	return status, nil
+}
+
+// LockProjectStatus is GetProjectStatus, transactions are serialized so the row lock is implied
+func (q *Queries) LockProjectStatus(ctx context.Context, id int64) (db.ProjectStatus, error) {
+	return q.GetProjectStatus(ctx, id)
+}
+
+func (q *Queries) GetProjectStatusList(ctx context.Context, projectID int64) ([]db.ProjectStatus, error) {
+	defer q.lock()()
+	return selectRows(q.data.projectStatuses, func(status db.ProjectStatus) bool {
+		return status.ProjectID == projectID
+	}, func(a, b db.ProjectStatus) bool {
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) UpdateProjectStatus(ctx context.Context, arg db.UpdateProjectStatusParams) (db.ProjectStatus, error) {
+	defer q.lock()()
+	status, ok := q.data.projectStatuses[arg.ID]
+	if !ok {
+		return db.ProjectStatus{}, sql.ErrNoRows
+	}
+	status.Name = arg.Name
+	status.Category = arg.Category
+	status.Position = arg.Position
+	status.WipLimit = arg.WipLimit
+	if err := q.checkProjectStatusName(status); err != nil {
+		return db.ProjectStatus{}, err
+	}
+	q.data.projectStatuses[status.ID] = status
+	return status, nil
+}
+
+func (q *Queries) SyncTasksWithStatus(ctx context.Context, id int64) (int64, error) {
+	defer q.lock()()
+	status, ok := q.data.projectStatuses[id]
+	if !ok {
+		return 0, nil
+	}
+	isDone := status.Category == "done"
+	var count int64
+	for _, task := range q.data.tasks {
+		if !task.StatusID.Valid || task.StatusID.Int64 != id || task.IsDone == isDone {
+			continue
+		}
+		task.IsDone = isDone
+		if isDone {
+			task.CompletedAt = sql.NullTime{Time: now(), Valid: true}
+		} else {
+			task.CompletedAt = sql.NullTime{}
+			task.ArchivedAt = sql.NullTime{}
+		}
+		q.setTask(task)
+		count++
+	}
+	return count, nil
+}
+
+func (q *Queries) DeleteProjectStatus(ctx context.Context, id int64) error {
+	defer q.lock()()
+	q.deleteProjectStatus(id)
+	return nil
+}
+
+func (q *Queries) deleteProjectStatus(id int64) {
+	if _, ok := q.data.projectStatuses[id]; !ok {
+		return
+	}
+	for key := range q.data.statusTransitions {
+		if key.FromStatusID == id || key.ToStatusID == id {
+			delete(q.data.statusTransitions, key)
+		}
+	}
+	for _, task := range q.data.tasks {
+		if task.StatusID.Valid && task.StatusID.Int64 == id {
+			task.StatusID = sql.NullInt64{}
+			q.setTask(task)
+		}
+	}
+	delete(q.data.projectStatuses, id)
+}
+
+func (q *Queries) CreateStatusTransition(ctx context.Context, arg db.CreateStatusTransitionParams) (db.StatusTransition, error) {
+	defer q.lock()()
+	key := statusTransitionKey{FromStatusID: arg.FromStatusID, ToStatusID: arg.ToStatusID}
+	if _, ok := q.data.statusTransitions[key]; ok {
+		return db.StatusTransition{}, uniqueViolation("status_transitions_pkey")
+	}
+	if err := q.projectExists("status_transitions", arg.ProjectID); err != nil {
+		return db.StatusTransition{}, err
+	}
+	if _, ok := q.data.projectStatuses[arg.FromStatusID]; !ok {
+		return db.StatusTransition{}, foreignKeyViolation("status_transitions", "status_transitions_from_status_id_fkey")
+	}
+	if _, ok := q.data.projectStatuses[arg.ToStatusID]; !ok {
+		return db.StatusTransition{}, foreignKeyViolation("status_transitions", "status_transitions_to_status_id_fkey")
+	}
+	transition := db.StatusTransition(arg)
+	q.data.statusTransitions[key] = transition
+	return transition, nil
+}
+
+func (q *Queries) GetStatusTransitionList(ctx context.Context, projectID int64) ([]db.StatusTransition, error) {
+	defer q.lock()()
+	return selectRows(q.data.statusTransitions, func(transition db.StatusTransition) bool {
+		return transition.ProjectID == projectID
+	}, func(a, b db.StatusTransition) bool {
+		if a.FromStatusID != b.FromStatusID {
+			return a.FromStatusID < b.FromStatusID
+		}
+		return a.ToStatusID < b.ToStatusID
+	}), nil
+}
+
+func (q *Queries) DeleteStatusTransitions(ctx context.Context, projectID int64) error {
+	defer q.lock()()
+	for key, transition := range q.data.statusTransitions {
+		if transition.ProjectID == projectID {
+			delete(q.data.statusTransitions, key)
+		}
+	}
+	return nil
+}
+
+func (q *Queries) CreateCustomField(ctx context.Context, arg db.CreateCustomFieldParams) (db.CustomField, error) {
+	defer q.lock()()
+	if err := q.projectExists("custom_fields", arg.ProjectID); err != nil {
+		return db.CustomField{}, err
+	}
+	field := db.CustomField{
+		ProjectID: arg.ProjectID,
+		Name:      arg.Name,
+		FieldType: arg.FieldType,
+		Options:   bytes.Clone(arg.Options),
+		Rules:     bytes.Clone(arg.Rules),
+		Position:  arg.Position,
+		CreatedAt: now(),
+	}
+	if err := q.checkCustomFieldName(field); err != nil {
+		return db.CustomField{}, err
+	}
+	field.ID = q.data.nextID("custom_fields")
+	q.data.customFields[field.ID] = field
+	return field, nil
+}
+
+// checkCustomFieldName is UNIQUE (project_id, name)
+func (q *Queries) checkCustomFieldName(field db.CustomField) error {
+	for _, other := range q.data.customFields {
+		if other.ID != field.ID && other.ProjectID == field.ProjectID && other.Name == field.Name {
+			return uniqueViolation("custom_fields_project_id_name_key")
+		}
+	}
+	return nil
+}
+
+func (q *Queries) GetCustomField(ctx context.Context, id int64) (db.CustomField, error) {
+	defer q.lock()()
+	field, ok := q.data.customFields[id]
+	if !ok {
+		return db.CustomField{}, sql.ErrNoRows
+	}
+	return field, nil
+}
+
+func (q *Queries) GetCustomFieldList(ctx context.Context, projectID int64) ([]db.CustomField, error) {
+	defer q.lock()()
+	return selectRows(q.data.customFields, func(field db.CustomField) bool {
+		return field.ProjectID == projectID
+	}, lessCustomField), nil
+}
+
+func (q *Queries) GetCustomFieldListByOwner(ctx context.Context, ownerID int64) ([]db.CustomField, error) {
+	defer q.lock()()
+	return selectRows(q.data.customFields, func(field db.CustomField) bool {
+		return q.data.projects[field.ProjectID].OwnerID == ownerID
+	}, lessCustomField), nil
+}
+
+// lessCustomField is ORDER BY project_id, position, id
+func lessCustomField(a, b db.CustomField) bool {
+	if a.ProjectID != b.ProjectID {
+		return a.ProjectID < b.ProjectID
+	}
+	if a.Position != b.Position {
+		return a.Position < b.Position
+	}
+	return a.ID < b.ID
+}
+
+func (q *Queries) UpdateCustomField(ctx context.Context, arg db.UpdateCustomFieldParams) (db.CustomField, error) {
+	defer q.lock()()
+	field, ok := q.data.customFields[arg.ID]
+	if !ok {
+		return db.CustomField{}, sql.ErrNoRows
+	}
+	field.Name = arg.Name
+	field.Options = bytes.Clone(arg.Options)
+	field.Rules = bytes.Clone(arg.Rules)
+	field.Position = arg.Position
+	if err := q.checkCustomFieldName(field); err != nil {
+		return db.CustomField{}, err
+	}
+	q.data.customFields[field.ID] = field
+	return field, nil
+}
+
+func (q *Queries) DeleteCustomField(ctx context.Context, id int64) error {
+	defer q.lock()()
+	q.deleteCustomField(id)
+	return nil
+}
+
+func (q *Queries) deleteCustomField(id int64) {
+	for key := range q.data.taskCustomFieldValues {
+		if key.FieldID == id {
+			q.deleteTaskCustomFieldValue(key)
+		}
+	}
+	delete(q.data.customFields, id)
+}
+
+func (q *Queries) UpsertTaskCustomFieldValue(ctx context.Context, arg db.UpsertTaskCustomFieldValueParams) (db.TaskCustomFieldValue, error) {
+	defer q.lock()()
+	if _, ok := q.data.tasks[arg.TaskID]; !ok {
+		return db.TaskCustomFieldValue{}, foreignKeyViolation("task_custom_field_values", "task_custom_field_values_task_id_fkey")
+	}
+	if _, ok := q.data.customFields[arg.FieldID]; !ok {
+		return db.TaskCustomFieldValue{}, foreignKeyViolation("task_custom_field_values", "task_custom_field_values_field_id_fkey")
+	}
+	value := db.TaskCustomFieldValue{
+		TaskID:    arg.TaskID,
+		FieldID:   arg.FieldID,
+		Value:     bytes.Clone(arg.Value),
+		UpdatedAt: now(),
+	}
+	q.data.taskCustomFieldValues[taskFieldKey{TaskID: value.TaskID, FieldID: value.FieldID}] = value
+	q.recordTaskSyncChange(value.TaskID)
+	return value, nil
+}
+
+func (q *Queries) DeleteTaskCustomFieldValue(ctx context.Context, arg db.DeleteTaskCustomFieldValueParams) error {
+	defer q.lock()()
+	q.deleteTaskCustomFieldValue(taskFieldKey{TaskID: arg.TaskID, FieldID: arg.FieldID})
+	return nil
+}
+
+func (q *Queries) deleteTaskCustomFieldValue(key taskFieldKey) {
+	if _, ok := q.data.taskCustomFieldValues[key]; !ok {
+		return
+	}
+	delete(q.data.taskCustomFieldValues, key)
+	q.recordTaskSyncChange(key.TaskID)
+}
+
+func (q *Queries) GetTaskCustomFieldValues(ctx context.Context, taskID int64) ([]db.TaskCustomFieldValue, error) {
+	defer q.lock()()
+	return q.getTaskCustomFieldValues([]int64{taskID}), nil
+}
+
+func (q *Queries) GetCustomFieldValuesByTasks(ctx context.Context, taskIds []int64) ([]db.TaskCustomFieldValue, error) {
+	defer q.lock()()
+	return q.getTaskCustomFieldValues(taskIds), nil
+}
+
+func (q *Queries) getTaskCustomFieldValues(taskIds []int64) []db.TaskCustomFieldValue {
+	return selectRows(q.data.taskCustomFieldValues, func(value db.TaskCustomFieldValue) bool {
+		return containsID(taskIds, value.TaskID)
+	}, func(a, b db.TaskCustomFieldValue) bool {
+		if a.TaskID != b.TaskID {
+			return a.TaskID < b.TaskID
+		}
+		return a.FieldID < b.FieldID
+	})
+}
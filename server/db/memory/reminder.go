@@ -0,0 +1,209 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+func (q *Queries) CreateReminder(ctx context.Context, arg db.CreateReminderParams) (db.Reminder, error) {
+	defer q.lock()()
+	if _, ok := q.data.tasks[arg.TaskID]; !ok {
+		return db.Reminder{}, foreignKeyViolation("reminders", "reminders_task_id_fkey")
+	}
+	if err := q.userExists("reminders", "owner_id", arg.OwnerID); err != nil {
+		return db.Reminder{}, err
+	}
+	reminder := db.Reminder{
+		ID:            q.data.nextID("reminders"),
+		TaskID:        arg.TaskID,
+		OwnerID:       arg.OwnerID,
+		RemindAt:      arg.RemindAt,
+		OffsetMinutes: arg.OffsetMinutes,
+		FireAt:        arg.FireAt,
+		CreatedAt:     now(),
+	}
+	q.data.reminders[reminder.ID] = reminder
+	return reminder, nil
+}
+
+func (q *Queries) GetReminder(ctx context.Context, id int64) (db.Reminder, error) {
+	defer q.lock()()
+	reminder, ok := q.data.reminders[id]
+	if !ok {
+		return db.Reminder{}, sql.ErrNoRows
+	}
+	return reminder, nil
+}
+
+func (q *Queries) GetReminderListByTask(ctx context.Context, taskID int64) ([]db.Reminder, error) {
+	defer q.lock()()
+	return selectRows(q.data.reminders, func(reminder db.Reminder) bool {
+		return reminder.TaskID == taskID
+	}, func(a, b db.Reminder) bool {
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) DeleteReminder(ctx context.Context, arg db.DeleteReminderParams) error {
+	defer q.lock()()
+	if reminder, ok := q.data.reminders[arg.ID]; ok && reminder.OwnerID == arg.OwnerID {
+		delete(q.data.reminders, reminder.ID)
+	}
+	return nil
+}
+
+func (q *Queries) SnoozeReminder(ctx context.Context, arg db.SnoozeReminderParams) (db.Reminder, error) {
+	defer q.lock()()
+	reminder, ok := q.data.reminders[arg.ID]
+	if !ok {
+		return db.Reminder{}, sql.ErrNoRows
+	}
+	reminder.FireAt = arg.FireAt
+	reminder.SentAt = sql.NullTime{}
+	reminder.Attempts = 0
+	reminder.LastError = ""
+	q.data.reminders[reminder.ID] = reminder
+	return reminder, nil
+}
+
+func (q *Queries) ResetRelativeReminders(ctx context.Context, arg db.ResetRelativeRemindersParams) error {
+	defer q.lock()()
+	for id, reminder := range q.data.reminders {
+		if reminder.TaskID != arg.TaskID || !reminder.OffsetMinutes.Valid || reminder.SentAt.Valid {
+			continue
+		}
+		reminder.FireAt = sql.NullTime{}
+		if arg.DueAt.Valid {
+			offset := time.Duration(reminder.OffsetMinutes.Int32) * time.Minute
+			reminder.FireAt = sql.NullTime{Time: arg.DueAt.Time.Add(-offset), Valid: true}
+		}
+		q.data.reminders[id] = reminder
+	}
+	return nil
+}
+
+func (q *Queries) ClaimDueReminder(ctx context.Context, arg db.ClaimDueReminderParams) (db.ClaimDueReminderRow, error) {
+	defer q.lock()()
+	reminders := selectRows(q.data.reminders, func(reminder db.Reminder) bool {
+		task := q.data.tasks[reminder.TaskID]
+		return !reminder.SentAt.Valid && reminder.FireAt.Valid && arg.Now.Valid &&
+			!reminder.FireAt.Time.After(arg.Now.Time) && reminder.Attempts < arg.MaxAttempts &&
+			!task.IsDone && !task.ArchivedAt.Valid
+	}, func(a, b db.Reminder) bool {
+		return a.FireAt.Time.Before(b.FireAt.Time)
+	})
+	if len(reminders) == 0 {
+		return db.ClaimDueReminderRow{}, sql.ErrNoRows
+	}
+	reminder := reminders[0]
+	task := q.data.tasks[reminder.TaskID]
+	user := q.data.users[reminder.OwnerID]
+	return db.ClaimDueReminderRow{
+		ID:       reminder.ID,
+		TaskID:   reminder.TaskID,
+		FireAt:   reminder.FireAt,
+		Attempts: reminder.Attempts,
+		Body:     task.Body,
+		DueAt:    task.DueAt,
+		Username: user.Username,
+		Email:    user.Email,
+	}, nil
+}
+
+func (q *Queries) MarkReminderSent(ctx context.Context, arg db.MarkReminderSentParams) error {
+	defer q.lock()()
+	if reminder, ok := q.data.reminders[arg.ID]; ok {
+		reminder.SentAt = arg.SentAt
+		q.data.reminders[reminder.ID] = reminder
+	}
+	return nil
+}
+
+func (q *Queries) RecordReminderFailure(ctx context.Context, arg db.RecordReminderFailureParams) error {
+	defer q.lock()()
+	if reminder, ok := q.data.reminders[arg.ID]; ok {
+		reminder.Attempts++
+		reminder.LastError = arg.LastError
+		reminder.FireAt = arg.FireAt
+		q.data.reminders[reminder.ID] = reminder
+	}
+	return nil
+}
+
+func (q *Queries) GetDigestPreference(ctx context.Context, userID int64) (db.DigestPreference, error) {
+	defer q.lock()()
+	preference, ok := q.data.digestPreferences[userID]
+	if !ok {
+		return db.DigestPreference{}, sql.ErrNoRows
+	}
+	return preference, nil
+}
+
+func (q *Queries) UpsertDigestPreference(ctx context.Context, arg db.UpsertDigestPreferenceParams) (db.DigestPreference, error) {
+	defer q.lock()()
+	if err := q.userExists("digest_preferences", "user_id", arg.UserID); err != nil {
+		return db.DigestPreference{}, err
+	}
+	preference := q.data.digestPreferences[arg.UserID]
+	preference.UserID = arg.UserID
+	preference.Frequency = arg.Frequency
+	preference.SendMinute = arg.SendMinute
+	preference.Weekday = arg.Weekday
+	preference.Timezone = arg.Timezone
+	preference.NextSendAt = arg.NextSendAt
+	preference.UpdatedAt = now()
+	q.data.digestPreferences[preference.UserID] = preference
+	return preference, nil
+}
+
+func (q *Queries) UnsubscribeDigest(ctx context.Context, userID int64) error {
+	defer q.lock()()
+	if preference, ok := q.data.digestPreferences[userID]; ok {
+		preference.Frequency = "off"
+		preference.NextSendAt = sql.NullTime{}
+		preference.UpdatedAt = now()
+		q.data.digestPreferences[userID] = preference
+	}
+	return nil
+}
+
+func (q *Queries) ClaimDueDigest(ctx context.Context, now sql.NullTime) (db.ClaimDueDigestRow, error) {
+	defer q.lock()()
+	preferences := selectRows(q.data.digestPreferences, func(preference db.DigestPreference) bool {
+		return preference.Frequency != "off" && preference.NextSendAt.Valid && now.Valid &&
+			!preference.NextSendAt.Time.After(now.Time)
+	}, func(a, b db.DigestPreference) bool {
+		return a.NextSendAt.Time.Before(b.NextSendAt.Time)
+	})
+	if len(preferences) == 0 {
+		return db.ClaimDueDigestRow{}, sql.ErrNoRows
+	}
+	preference := preferences[0]
+	user := q.data.users[preference.UserID]
+	return db.ClaimDueDigestRow{
+		UserID:     preference.UserID,
+		Frequency:  preference.Frequency,
+		SendMinute: preference.SendMinute,
+		Weekday:    preference.Weekday,
+		Timezone:   preference.Timezone,
+		NextSendAt: preference.NextSendAt,
+		LastSentAt: preference.LastSentAt,
+		Username:   user.Username,
+		Email:      user.Email,
+	}, nil
+}
+
+func (q *Queries) SetDigestNextSendAt(ctx context.Context, arg db.SetDigestNextSendAtParams) error {
+	defer q.lock()()
+	if preference, ok := q.data.digestPreferences[arg.UserID]; ok {
+		preference.NextSendAt = arg.NextSendAt
+		if arg.LastSentAt.Valid {
+			preference.LastSentAt = arg.LastSentAt
+		}
+		q.data.digestPreferences[preference.UserID] = preference
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+func (q *Queries) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+	defer q.lock()()
+	if _, ok := q.data.sessions[arg.ID]; ok {
+		return db.Session{}, uniqueViolation("sessions_pkey")
+	}
+	for _, session := range q.data.sessions {
+		if session.RefreshToken == arg.RefreshToken {
+			return db.Session{}, uniqueViolation("sessions_refresh_token_key")
+		}
+	}
+	if err := q.userExists("sessions", "user_id", arg.UserID); err != nil {
+		return db.Session{}, err
+	}
+	session := db.Session{
+		ID:                   arg.ID,
+		UserID:               arg.UserID,
+		RefreshToken:         arg.RefreshToken,
+		UserAgent:            arg.UserAgent,
+		ClientIp:             arg.ClientIp,
+		IsBlocked:            arg.IsBlocked,
+		ExpiresAt:            arg.ExpiresAt,
+		CreatedAt:            now(),
+		FamilyID:             arg.FamilyID,
+		AccessTokenID:        arg.AccessTokenID,
+		AccessTokenExpiresAt: arg.AccessTokenExpiresAt,
+	}
+	q.data.sessions[session.ID] = session
+	return session, nil
+}
+
+func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	defer q.lock()()
+	session, ok := q.data.sessions[id]
+	if !ok {
+		return db.Session{}, sql.ErrNoRows
+	}
+	return session, nil
+}
+
+func (q *Queries) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	defer q.lock()()
+	delete(q.data.sessions, id)
+	return nil
+}
+
+func (q *Queries) ReplaceSession(ctx context.Context, arg db.ReplaceSessionParams) (int64, error) {
+	defer q.lock()()
+	session, ok := q.data.sessions[arg.ID]
+	if !ok || session.ReplacedBy.Valid {
+		return 0, nil
+	}
+	session.ReplacedBy = uuid.NullUUID{UUID: arg.ReplacedBy, Valid: true}
+	q.data.sessions[session.ID] = session
+	return 1, nil
+}
+
+func (q *Queries) BlockSession(ctx context.Context, id uuid.UUID) (int64, error) {
+	defer q.lock()()
+	session, ok := q.data.sessions[id]
+	if !ok {
+		return 0, nil
+	}
+	session.IsBlocked = true
+	q.data.sessions[session.ID] = session
+	return 1, nil
+}
+
+func (q *Queries) DeleteCurrentSession(ctx context.Context, arg db.DeleteCurrentSessionParams) (int64, error) {
+	defer q.lock()()
+	var deleted int64
+	for id, session := range q.data.sessions {
+		if session.FamilyID == arg.FamilyID && session.UserID == arg.UserID && !session.ReplacedBy.Valid {
+			delete(q.data.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (q *Queries) ListSessionFamily(ctx context.Context, arg db.ListSessionFamilyParams) ([]db.Session, error) {
+	defer q.lock()()
+	return selectRows(q.data.sessions, func(session db.Session) bool {
+		return session.FamilyID == arg.FamilyID && session.UserID == arg.UserID
+	}, func(a, b db.Session) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}), nil
+}
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID int64) error {
+	defer q.lock()()
+	for id, session := range q.data.sessions {
+		if session.UserID == userID {
+			delete(q.data.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (q *Queries) ListUserSessions(ctx context.Context, userID int64) ([]db.ListUserSessionsRow, error) {
+	defer q.lock()()
+	current := now()
+	sessions := selectRows(q.data.sessions, func(s db.Session) bool {
+		return s.UserID == userID && !s.ReplacedBy.Valid && !s.IsBlocked && s.ExpiresAt.After(current)
+	}, func(a, b db.Session) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	items := []db.ListUserSessionsRow{}
+	for _, s := range sessions {
+		f, ok := q.data.sessions[s.FamilyID]
+		if !ok {
+			continue
+		}
+		items = append(items, db.ListUserSessionsRow{
+			FamilyID:             s.FamilyID,
+			ID:                   s.ID,
+			AccessTokenID:        s.AccessTokenID,
+			AccessTokenExpiresAt: s.AccessTokenExpiresAt,
+			UserAgent:            f.UserAgent,
+			ClientIp:             s.ClientIp,
+			CreatedAt:            f.CreatedAt,
+			LastUsedAt:           s.CreatedAt,
+			ExpiresAt:            s.ExpiresAt,
+		})
+	}
+	return items, nil
+}
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) (int64, error) {
+	defer q.lock()()
+	var deleted int64
+	for id, session := range q.data.sessions {
+		if session.ExpiresAt.Before(expiresAt) {
+			delete(q.data.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, arg db.RevokeTokenParams) error {
+	defer q.lock()()
+	if _, ok := q.data.revokedTokens[arg.ID]; !ok {
+		q.data.revokedTokens[arg.ID] = db.RevokedToken{ID: arg.ID, ExpiresAt: arg.ExpiresAt}
+	}
+	return nil
+}
+
+func (q *Queries) RevokeUserTokens(ctx context.Context, arg db.RevokeUserTokensParams) error {
+	defer q.lock()()
+	if err := q.userExists("user_token_revocations", "user_id", arg.UserID); err != nil {
+		return err
+	}
+	q.data.userTokenRevocations[arg.UserID] = db.UserTokenRevocation{
+		UserID:        arg.UserID,
+		RevokedBefore: arg.RevokedBefore,
+		ExpiresAt:     arg.ExpiresAt,
+	}
+	return nil
+}
+
+func (q *Queries) IsTokenRevoked(ctx context.Context, arg db.IsTokenRevokedParams) (bool, error) {
+	defer q.lock()()
+	current := now()
+	if revoked, ok := q.data.revokedTokens[arg.ID]; ok && revoked.ExpiresAt.After(current) {
+		return true, nil
+	}
+	revocation, ok := q.data.userTokenRevocations[arg.UserID]
+	return ok && revocation.ExpiresAt.After(current) && !revocation.RevokedBefore.Before(arg.IssuedAt), nil
+}
+
+func (q *Queries) DeleteExpiredRevokedTokens(ctx context.Context, expiresAt time.Time) (int64, error) {
+	defer q.lock()()
+	var deleted int64
+	for id, revoked := range q.data.revokedTokens {
+		if revoked.ExpiresAt.Before(expiresAt) {
+			delete(q.data.revokedTokens, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (q *Queries) DeleteExpiredUserTokenRevocations(ctx context.Context, expiresAt time.Time) (int64, error) {
+	defer q.lock()()
+	var deleted int64
+	for userId, revocation := range q.data.userTokenRevocations {
+		if revocation.ExpiresAt.Before(expiresAt) {
+			delete(q.data.userTokenRevocations, userId)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (q *Queries) SetLoginChallenge(ctx context.Context, arg db.SetLoginChallengeParams) error {
+	defer q.lock()()
+	q.data.loginChallenges[arg.Key] = db.LoginChallenge{
+		Key:       arg.Key,
+		Value:     append([]byte{}, arg.Value...),
+		ExpiresAt: arg.ExpiresAt,
+	}
+	return nil
+}
+
+func (q *Queries) TakeLoginChallenge(ctx context.Context, key string) ([]byte, error) {
+	defer q.lock()()
+	challenge, ok := q.data.loginChallenges[key]
+	if !ok || !challenge.ExpiresAt.After(now()) {
+		return nil, sql.ErrNoRows
+	}
+	delete(q.data.loginChallenges, key)
+	return challenge.Value, nil
+}
+
+func (q *Queries) DeleteExpiredLoginChallenges(ctx context.Context, expiresAt time.Time) (int64, error) {
+	defer q.lock()()
+	var deleted int64
+	for key, challenge := range q.data.loginChallenges {
+		if challenge.ExpiresAt.Before(expiresAt) {
+			delete(q.data.loginChallenges, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CreateSigningKey inserts a key unless one with the same not_before exists, then it fails
+// with sql.ErrNoRows like the query that does nothing on conflict
+func (q *Queries) CreateSigningKey(ctx context.Context, arg db.CreateSigningKeyParams) (db.SigningKey, error) {
+	defer q.lock()()
+	for _, key := range q.data.signingKeys {
+		if key.NotBefore.Equal(arg.NotBefore) {
+			return db.SigningKey{}, sql.ErrNoRows
+		}
+	}
+	if _, ok := q.data.signingKeys[arg.ID]; ok {
+		return db.SigningKey{}, uniqueViolation("signing_keys_pkey")
+	}
+	key := db.SigningKey{
+		ID:         arg.ID,
+		PrivateKey: append([]byte{}, arg.PrivateKey...),
+		NotBefore:  arg.NotBefore,
+		NotAfter:   arg.NotAfter,
+		CreatedAt:  now(),
+	}
+	q.data.signingKeys[key.ID] = key
+	return key, nil
+}
+
+func (q *Queries) ListSigningKeys(ctx context.Context, notAfter time.Time) ([]db.SigningKey, error) {
+	defer q.lock()()
+	return selectRows(q.data.signingKeys, func(key db.SigningKey) bool {
+		return key.NotAfter.After(notAfter)
+	}, func(a, b db.SigningKey) bool {
+		return a.NotBefore.Before(b.NotBefore)
+	}), nil
+}
+
+func (q *Queries) DeleteExpiredSigningKeys(ctx context.Context, notAfter time.Time) (int64, error) {
+	defer q.lock()()
+	var deleted int64
+	for id, key := range q.data.signingKeys {
+		if key.NotAfter.Before(notAfter) {
+			delete(q.data.signingKeys, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,243 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// localDate is (t AT TIME ZONE loc)::date, at midnight UTC like lib/pq scans a date
+func localDate(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// truncateDate is date_trunc of a date, weeks start on Monday
+func truncateDate(period string, date time.Time) (time.Time, error) {
+	switch period {
+	case "day":
+		return date, nil
+	case "week":
+		return date.AddDate(0, 0, -(int(date.Weekday())+6)%7), nil
+	case "month":
+		return date.AddDate(0, 0, 1-date.Day()), nil
+	}
+	return time.Time{}, fmt.Errorf("unit %q not recognized for type timestamp without time zone", period)
+}
+
+// between is from <= t AND t < to
+func between(t time.Time, from time.Time, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+// taskStats sums the FILTER aggregates shared by the stats queries
+type taskStats struct {
+	created         int64
+	completed       int64
+	completionTotal float64
+	due             int64
+	overdue         int64
+}
+
+func (s *taskStats) add(task db.Task, from time.Time, to time.Time, current time.Time) {
+	if between(task.CreatedAt, from, to) {
+		s.created++
+	}
+	if task.CompletedAt.Valid && between(task.CompletedAt.Time, from, to) {
+		s.completed++
+		s.completionTotal += task.CompletedAt.Time.Sub(task.CreatedAt).Seconds()
+	}
+	dueBefore := to
+	if current.Before(dueBefore) {
+		dueBefore = current
+	}
+	if task.DueAt.Valid && between(task.DueAt.Time, from, dueBefore) {
+		s.due++
+		if !task.CompletedAt.Valid || task.CompletedAt.Time.After(task.DueAt.Time) {
+			s.overdue++
+		}
+	}
+}
+
+func (s *taskStats) avgCompletionSeconds() float64 {
+	if s.completed == 0 {
+		return 0
+	}
+	return s.completionTotal / float64(s.completed)
+}
+
+// inStatsRange is the WHERE of the grouped stats queries
+func inStatsRange(task db.Task, from time.Time, to time.Time) bool {
+	return between(task.CreatedAt, from, to) ||
+		(task.CompletedAt.Valid && between(task.CompletedAt.Time, from, to)) ||
+		(task.DueAt.Valid && between(task.DueAt.Time, from, to))
+}
+
+func (q *Queries) GetTaskCountsByPeriod(ctx context.Context, arg db.GetTaskCountsByPeriodParams) ([]db.GetTaskCountsByPeriodRow, error) {
+	defer q.lock()()
+	loc, err := time.LoadLocation(arg.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[time.Time]db.GetTaskCountsByPeriodRow{}
+	for _, task := range q.data.tasks {
+		if task.OwnerID != arg.OwnerID {
+			continue
+		}
+		if between(task.CreatedAt, arg.FromTime, arg.ToTime) {
+			start, err := truncateDate(arg.Period, localDate(task.CreatedAt, loc))
+			if err != nil {
+				return nil, err
+			}
+			row := counts[start]
+			row.PeriodStart = start
+			row.Created++
+			counts[start] = row
+		}
+		if task.CompletedAt.Valid && between(task.CompletedAt.Time, arg.FromTime, arg.ToTime) {
+			start, err := truncateDate(arg.Period, localDate(task.CompletedAt.Time, loc))
+			if err != nil {
+				return nil, err
+			}
+			row := counts[start]
+			row.PeriodStart = start
+			row.Completed++
+			counts[start] = row
+		}
+	}
+	return selectRows(counts, nil, func(a, b db.GetTaskCountsByPeriodRow) bool {
+		return a.PeriodStart.Before(b.PeriodStart)
+	}), nil
+}
+
+func (q *Queries) GetCompletionStreaks(ctx context.Context, arg db.GetCompletionStreaksParams) (db.GetCompletionStreaksRow, error) {
+	defer q.lock()()
+	loc, err := time.LoadLocation(arg.Timezone)
+	if err != nil {
+		return db.GetCompletionStreaksRow{}, err
+	}
+	seen := map[time.Time]bool{}
+	days := []time.Time{}
+	for _, task := range q.data.tasks {
+		if task.OwnerID != arg.OwnerID || !task.CompletedAt.Valid {
+			continue
+		}
+		day := localDate(task.CompletedAt.Time, loc)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	var result db.GetCompletionStreaksRow
+	yesterday := arg.Today.AddDate(0, 0, -1)
+	var streak int64
+	for i, day := range days {
+		if i > 0 && day.Equal(days[i-1].AddDate(0, 0, 1)) {
+			streak++
+		} else {
+			streak = 1
+		}
+		//a streak is counted once it ended, at the last day or before the next gap
+		if i+1 < len(days) && days[i+1].Equal(day.AddDate(0, 0, 1)) {
+			continue
+		}
+		result.LongestStreak = max(result.LongestStreak, streak)
+		if !day.Before(yesterday) {
+			result.CurrentStreak = max(result.CurrentStreak, streak)
+		}
+	}
+	return result, nil
+}
+
+func (q *Queries) GetTaskStatsSummary(ctx context.Context, arg db.GetTaskStatsSummaryParams) (db.GetTaskStatsSummaryRow, error) {
+	defer q.lock()()
+	current := now()
+	var stats taskStats
+	for _, task := range q.data.tasks {
+		if task.OwnerID == arg.OwnerID {
+			stats.add(task, arg.FromTime, arg.ToTime, current)
+		}
+	}
+	return db.GetTaskStatsSummaryRow{
+		Created:              stats.created,
+		Completed:            stats.completed,
+		AvgCompletionSeconds: stats.avgCompletionSeconds(),
+		Due:                  stats.due,
+		Overdue:              stats.overdue,
+	}, nil
+}
+
+func (q *Queries) GetTaskStatsByProject(ctx context.Context, arg db.GetTaskStatsByProjectParams) ([]db.GetTaskStatsByProjectRow, error) {
+	defer q.lock()()
+	current := now()
+	stats := map[sql.NullInt64]*taskStats{}
+	for _, task := range q.data.tasks {
+		if task.OwnerID != arg.OwnerID || !inStatsRange(task, arg.FromTime, arg.ToTime) {
+			continue
+		}
+		if stats[task.ProjectID] == nil {
+			stats[task.ProjectID] = &taskStats{}
+		}
+		stats[task.ProjectID].add(task, arg.FromTime, arg.ToTime, current)
+	}
+	items := []db.GetTaskStatsByProjectRow{}
+	for projectId, s := range stats {
+		items = append(items, db.GetTaskStatsByProjectRow{
+			ProjectID:            projectId,
+			Name:                 q.data.projects[projectId.Int64].Name,
+			Created:              s.created,
+			Completed:            s.completed,
+			AvgCompletionSeconds: s.avgCompletionSeconds(),
+			Due:                  s.due,
+			Overdue:              s.overdue,
+		})
+	}
+	//ORDER BY project_id NULLS FIRST
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ProjectID.Valid != items[j].ProjectID.Valid {
+			return !items[i].ProjectID.Valid
+		}
+		return items[i].ProjectID.Int64 < items[j].ProjectID.Int64
+	})
+	return items, nil
+}
+
+func (q *Queries) GetTaskStatsByLabel(ctx context.Context, arg db.GetTaskStatsByLabelParams) ([]db.GetTaskStatsByLabelRow, error) {
+	defer q.lock()()
+	current := now()
+	stats := map[int64]*taskStats{}
+	for key := range q.data.taskLabels {
+		task := q.data.tasks[key.TaskID]
+		if task.OwnerID != arg.OwnerID || !inStatsRange(task, arg.FromTime, arg.ToTime) {
+			continue
+		}
+		if stats[key.LabelID] == nil {
+			stats[key.LabelID] = &taskStats{}
+		}
+		stats[key.LabelID].add(task, arg.FromTime, arg.ToTime, current)
+	}
+	items := []db.GetTaskStatsByLabelRow{}
+	for labelId, s := range stats {
+		items = append(items, db.GetTaskStatsByLabelRow{
+			LabelID:              labelId,
+			Name:                 q.data.labels[labelId].Name,
+			Created:              s.created,
+			Completed:            s.completed,
+			AvgCompletionSeconds: s.avgCompletionSeconds(),
+			Due:                  s.due,
+			Overdue:              s.overdue,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
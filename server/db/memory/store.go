@@ -0,0 +1,361 @@
+// Package memdb is an in-memory db.Store for development and tests.
+// It keeps the unique and foreign key constraints, cascades and sync triggers of the schema
+// in db/migration and fails with the same *pq.Error codes, so services behave as they do on Postgres.
+// Transactions are serialized and work on a copy of the tables, the rows they changed are
+// written back on commit. Statements outside of a transaction are not blocked by one, so a
+// transaction may call back into code that uses the store.
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+type statusTransitionKey struct {
+	FromStatusID int64
+	ToStatusID   int64
+}
+
+type taskFieldKey struct {
+	TaskID  int64
+	FieldID int64
+}
+
+type syncChangeKey struct {
+	Entity   string
+	EntityID int64
+}
+
+// sequences are shared by every copy of the tables, like in Postgres they are not rolled back
+type sequences struct {
+	mu     sync.Mutex
+	serial map[string]int64
+	// sync_change_seq
+	syncChange int64
+	// last transaction id handed out
+	xid int64
+}
+
+// database holds one map per table
+type database struct {
+	seq *sequences
+
+	users                 map[int64]db.User
+	sessions              map[uuid.UUID]db.Session
+	passwordResetSessions map[string]db.PasswordResetSession
+	revokedTokens         map[uuid.UUID]db.RevokedToken
+	userTokenRevocations  map[int64]db.UserTokenRevocation
+	loginChallenges       map[string]db.LoginChallenge
+	userTotp              map[int64]db.UserTotp
+	mfaRecoveryCodes      map[int64]db.MfaRecoveryCode
+	webauthnCredentials   map[int64]db.WebauthnCredential
+	apiKeys               map[int64]db.ApiKey
+	projects              map[int64]db.Project
+	projectStatuses       map[int64]db.ProjectStatus
+	statusTransitions     map[statusTransitionKey]db.StatusTransition
+	customFields          map[int64]db.CustomField
+	taskCustomFieldValues map[taskFieldKey]db.TaskCustomFieldValue
+	tasks                 map[int64]db.Task
+	labels                map[int64]db.Label
+	taskLabels            map[db.TaskLabel]bool
+	savedFilters          map[int64]db.SavedFilter
+	reminders             map[int64]db.Reminder
+	digestPreferences     map[int64]db.DigestPreference
+	syncChanges           map[syncChangeKey]db.SyncChange
+	webhooks              map[int64]db.Webhook
+	webhookDeliveries     map[int64]db.WebhookDelivery
+	inboxes               map[int64]db.Inbox
+	taskAttachments       map[int64]db.TaskAttachment
+	timeEntries           map[int64]db.TimeEntry
+	taskTemplates         map[int64]db.TaskTemplate
+	archiveRules          map[int64]db.ArchiveRule
+	signingKeys           map[string]db.SigningKey
+}
+
+func newDatabase() *database {
+	return &database{
+		seq:                   &sequences{serial: map[string]int64{}},
+		users:                 map[int64]db.User{},
+		sessions:              map[uuid.UUID]db.Session{},
+		passwordResetSessions: map[string]db.PasswordResetSession{},
+		revokedTokens:         map[uuid.UUID]db.RevokedToken{},
+		userTokenRevocations:  map[int64]db.UserTokenRevocation{},
+		loginChallenges:       map[string]db.LoginChallenge{},
+		userTotp:              map[int64]db.UserTotp{},
+		mfaRecoveryCodes:      map[int64]db.MfaRecoveryCode{},
+		webauthnCredentials:   map[int64]db.WebauthnCredential{},
+		apiKeys:               map[int64]db.ApiKey{},
+		projects:              map[int64]db.Project{},
+		projectStatuses:       map[int64]db.ProjectStatus{},
+		statusTransitions:     map[statusTransitionKey]db.StatusTransition{},
+		customFields:          map[int64]db.CustomField{},
+		taskCustomFieldValues: map[taskFieldKey]db.TaskCustomFieldValue{},
+		tasks:                 map[int64]db.Task{},
+		labels:                map[int64]db.Label{},
+		taskLabels:            map[db.TaskLabel]bool{},
+		savedFilters:          map[int64]db.SavedFilter{},
+		reminders:             map[int64]db.Reminder{},
+		digestPreferences:     map[int64]db.DigestPreference{},
+		syncChanges:           map[syncChangeKey]db.SyncChange{},
+		webhooks:              map[int64]db.Webhook{},
+		webhookDeliveries:     map[int64]db.WebhookDelivery{},
+		inboxes:               map[int64]db.Inbox{},
+		taskAttachments:       map[int64]db.TaskAttachment{},
+		timeEntries:           map[int64]db.TimeEntry{},
+		taskTemplates:         map[int64]db.TaskTemplate{},
+		archiveRules:          map[int64]db.ArchiveRule{},
+		signingKeys:           map[string]db.SigningKey{},
+	}
+}
+
+// clone copies the tables, rows are never changed in place so the rows themselves are shared
+func (d *database) clone() *database {
+	return &database{
+		seq:                   d.seq,
+		users:                 maps.Clone(d.users),
+		sessions:              maps.Clone(d.sessions),
+		passwordResetSessions: maps.Clone(d.passwordResetSessions),
+		revokedTokens:         maps.Clone(d.revokedTokens),
+		userTokenRevocations:  maps.Clone(d.userTokenRevocations),
+		loginChallenges:       maps.Clone(d.loginChallenges),
+		userTotp:              maps.Clone(d.userTotp),
+		mfaRecoveryCodes:      maps.Clone(d.mfaRecoveryCodes),
+		webauthnCredentials:   maps.Clone(d.webauthnCredentials),
+		apiKeys:               maps.Clone(d.apiKeys),
+		projects:              maps.Clone(d.projects),
+		projectStatuses:       maps.Clone(d.projectStatuses),
+		statusTransitions:     maps.Clone(d.statusTransitions),
+		customFields:          maps.Clone(d.customFields),
+		taskCustomFieldValues: maps.Clone(d.taskCustomFieldValues),
+		tasks:                 maps.Clone(d.tasks),
+		labels:                maps.Clone(d.labels),
+		taskLabels:            maps.Clone(d.taskLabels),
+		savedFilters:          maps.Clone(d.savedFilters),
+		reminders:             maps.Clone(d.reminders),
+		digestPreferences:     maps.Clone(d.digestPreferences),
+		syncChanges:           maps.Clone(d.syncChanges),
+		webhooks:              maps.Clone(d.webhooks),
+		webhookDeliveries:     maps.Clone(d.webhookDeliveries),
+		inboxes:               maps.Clone(d.inboxes),
+		taskAttachments:       maps.Clone(d.taskAttachments),
+		timeEntries:           maps.Clone(d.timeEntries),
+		taskTemplates:         maps.Clone(d.taskTemplates),
+		archiveRules:          maps.Clone(d.archiveRules),
+		signingKeys:           maps.Clone(d.signingKeys),
+	}
+}
+
+// merge writes the rows a transaction changed in its copy of the tables,
+// base is the copy the transaction started from
+func (d *database) merge(base *database, changed *database) {
+	mergeTable(d.users, base.users, changed.users)
+	mergeTable(d.sessions, base.sessions, changed.sessions)
+	mergeTable(d.passwordResetSessions, base.passwordResetSessions, changed.passwordResetSessions)
+	mergeTable(d.revokedTokens, base.revokedTokens, changed.revokedTokens)
+	mergeTable(d.userTokenRevocations, base.userTokenRevocations, changed.userTokenRevocations)
+	mergeTable(d.loginChallenges, base.loginChallenges, changed.loginChallenges)
+	mergeTable(d.userTotp, base.userTotp, changed.userTotp)
+	mergeTable(d.mfaRecoveryCodes, base.mfaRecoveryCodes, changed.mfaRecoveryCodes)
+	mergeTable(d.webauthnCredentials, base.webauthnCredentials, changed.webauthnCredentials)
+	mergeTable(d.apiKeys, base.apiKeys, changed.apiKeys)
+	mergeTable(d.projects, base.projects, changed.projects)
+	mergeTable(d.projectStatuses, base.projectStatuses, changed.projectStatuses)
+	mergeTable(d.statusTransitions, base.statusTransitions, changed.statusTransitions)
+	mergeTable(d.customFields, base.customFields, changed.customFields)
+	mergeTable(d.taskCustomFieldValues, base.taskCustomFieldValues, changed.taskCustomFieldValues)
+	mergeTable(d.tasks, base.tasks, changed.tasks)
+	mergeTable(d.labels, base.labels, changed.labels)
+	mergeTable(d.taskLabels, base.taskLabels, changed.taskLabels)
+	mergeTable(d.savedFilters, base.savedFilters, changed.savedFilters)
+	mergeTable(d.reminders, base.reminders, changed.reminders)
+	mergeTable(d.digestPreferences, base.digestPreferences, changed.digestPreferences)
+	mergeTable(d.syncChanges, base.syncChanges, changed.syncChanges)
+	mergeTable(d.webhooks, base.webhooks, changed.webhooks)
+	mergeTable(d.webhookDeliveries, base.webhookDeliveries, changed.webhookDeliveries)
+	mergeTable(d.inboxes, base.inboxes, changed.inboxes)
+	mergeTable(d.taskAttachments, base.taskAttachments, changed.taskAttachments)
+	mergeTable(d.timeEntries, base.timeEntries, changed.timeEntries)
+	mergeTable(d.taskTemplates, base.taskTemplates, changed.taskTemplates)
+	mergeTable(d.archiveRules, base.archiveRules, changed.archiveRules)
+	mergeTable(d.signingKeys, base.signingKeys, changed.signingKeys)
+}
+
+// mergeTable writes the rows that were inserted, updated or deleted between base and changed,
+// a row changed by a statement while the transaction ran is overwritten
+func mergeTable[K comparable, V any](table map[K]V, base map[K]V, changed map[K]V) {
+	for key := range base {
+		if _, ok := changed[key]; !ok {
+			delete(table, key)
+		}
+	}
+	for key, row := range changed {
+		if old, ok := base[key]; !ok || !reflect.DeepEqual(old, row) {
+			table[key] = row
+		}
+	}
+}
+
+// nextID is the next value of the bigserial id of a table
+func (d *database) nextID(table string) int64 {
+	d.seq.mu.Lock()
+	defer d.seq.mu.Unlock()
+	d.seq.serial[table]++
+	return d.seq.serial[table]
+}
+
+// Queries implements db.Querier on the tables
+type Queries struct {
+	data *database
+	// mu is nil inside a transaction, nothing else sees the copy of the tables of a transaction
+	mu *sync.Mutex
+	// xid is the transaction id of the writes of a transaction, 0 until the first write
+	xid int64
+}
+
+func (q *Queries) lock() func() {
+	if q.mu == nil {
+		return func() {}
+	}
+	q.mu.Lock()
+	//a statement outside of a transaction is a transaction of its own
+	q.xid = 0
+	return q.mu.Unlock
+}
+
+// txid is pg_current_xact_id()
+func (q *Queries) txid() int64 {
+	if q.xid == 0 {
+		q.data.seq.mu.Lock()
+		q.data.seq.xid++
+		q.xid = q.data.seq.xid
+		q.data.seq.mu.Unlock()
+	}
+	return q.xid
+}
+
+var _ db.Querier = (*Queries)(nil)
+
+// Store is an in-memory db.Store, it is safe for concurrent use
+type Store struct {
+	// mu guards the tables
+	mu sync.Mutex
+	// txMu serializes transactions
+	txMu sync.Mutex
+	*Queries
+}
+
+var _ db.Store = (*Store)(nil)
+
+// NewStore creates an empty Store
+func NewStore() db.Store {
+	store := &Store{}
+	store.Queries = &Queries{
+		data: newDatabase(),
+		mu:   &store.mu,
+	}
+	return store
+}
+
+// execTx runs fn on a copy of the tables and writes its changes back when it succeeds
+func (store *Store) execTx(ctx context.Context, fn func(*Queries) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	store.txMu.Lock()
+	defer store.txMu.Unlock()
+
+	store.mu.Lock()
+	base := store.data.clone()
+	store.mu.Unlock()
+
+	tx := base.clone()
+	if err := fn(&Queries{data: tx}); err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	store.data.merge(base, tx)
+	store.mu.Unlock()
+	return nil
+}
+
+// now is the time of now() in queries, Postgres keeps microseconds
+func now() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+func uniqueViolation(constraint string) error {
+	return &pq.Error{
+		Code:       "23505",
+		Message:    fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		Constraint: constraint,
+	}
+}
+
+func foreignKeyViolation(table string, constraint string) error {
+	return &pq.Error{
+		Code:       "23503",
+		Message:    fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		Table:      table,
+		Constraint: constraint,
+	}
+}
+
+// referencedViolation is the error of changing a row that is still referenced
+func referencedViolation(table string, constraint string) error {
+	return &pq.Error{
+		Code:       "23503",
+		Message:    fmt.Sprintf("update or delete on table %q violates foreign key constraint %q", table, constraint),
+		Table:      table,
+		Constraint: constraint,
+	}
+}
+
+// selectRows returns the rows of a table that keep holds, sorted by less
+func selectRows[K comparable, V any](table map[K]V, keep func(V) bool, less func(a, b V) bool) []V {
+	items := []V{}
+	for _, row := range table {
+		if keep == nil || keep(row) {
+			items = append(items, row)
+		}
+	}
+	if less != nil {
+		sort.Slice(items, func(i, j int) bool {
+			return less(items[i], items[j])
+		})
+	}
+	return items
+}
+
+// page applies LIMIT and OFFSET
+func page[V any](items []V, limit int32, offset int32) []V {
+	if offset < 0 {
+		offset = 0
+	}
+	if int(offset) >= len(items) {
+		return []V{}
+	}
+	items = items[offset:]
+	if limit >= 0 && int(limit) < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/db/storetest"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomUser(t *testing.T, store db.Store) db.User {
+	user, err := store.CreateUser(context.Background(), db.CreateUserParams{
+		Username:       util.RandomString(6),
+		HashedPassword: util.RandomString(20),
+		Email:          util.RandomString(8) + "@email.com",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func requirePqError(t *testing.T, err error, code string) {
+	pqErr, ok := err.(*pq.Error)
+	require.True(t, ok, "%v is not a *pq.Error", err)
+	require.Equal(t, code, pqErr.Code.Name())
+}
+
+func TestConstraints(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	user := createRandomUser(t, store)
+
+	_, err := store.CreateUser(ctx, db.CreateUserParams{Username: "other", HashedPassword: "secret", Email: user.Email})
+	requirePqError(t, err, "unique_violation")
+
+	_, err = store.CreateTask(ctx, db.CreateTaskParams{Body: "task", OwnerID: user.ID + 1})
+	requirePqError(t, err, "foreign_key_violation")
+	_, err = store.CreateTask(ctx, db.CreateTaskParams{Body: "task", OwnerID: user.ID, ProjectID: sql.NullInt64{Int64: 5, Valid: true}})
+	requirePqError(t, err, "foreign_key_violation")
+
+	_, err = store.GetTask(ctx, 1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestTxRollback(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	user := createRandomUser(t, store)
+
+	//the second status has the same name, the project is not created either
+	_, err := store.CreateProjectTx(ctx, db.CreateProjectTxParams{
+		CreateProjectParams: db.CreateProjectParams{Name: "project", OwnerID: user.ID},
+		Statuses: []db.CreateProjectStatusParams{
+			{Name: "To Do", Category: "todo"},
+			{Name: "To Do", Category: "done", Position: 1},
+		},
+	})
+	requirePqError(t, err, "unique_violation")
+	projects, err := store.GetProjectList(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, projects)
+
+	result, err := store.CreateProjectTx(ctx, db.CreateProjectTxParams{
+		CreateProjectParams: db.CreateProjectParams{Name: "project", OwnerID: user.ID},
+		Statuses: []db.CreateProjectStatusParams{
+			{Name: "To Do", Category: "todo"},
+			{Name: "Done", Category: "done", Position: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Statuses, 2)
+	statuses, err := store.GetProjectStatusList(ctx, result.Project.ID)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+}
+
+func TestDeleteTaskCascades(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	user := createRandomUser(t, store)
+
+	parent, err := store.CreateTask(ctx, db.CreateTaskParams{Body: "parent", OwnerID: user.ID})
+	require.NoError(t, err)
+	child, err := store.CreateTask(ctx, db.CreateTaskParams{Body: "child", OwnerID: user.ID, ParentID: sql.NullInt64{Int64: parent.ID, Valid: true}})
+	require.NoError(t, err)
+	label, err := store.UpsertLabel(ctx, db.UpsertLabelParams{OwnerID: user.ID, Name: "backend"})
+	require.NoError(t, err)
+	require.NoError(t, store.AddTaskLabel(ctx, db.AddTaskLabelParams{TaskID: child.ID, LabelID: label.ID}))
+
+	require.NoError(t, store.DeleteTask(ctx, db.DeleteTaskParams{ID: parent.ID, OwnerID: user.ID}))
+	_, err = store.GetTask(ctx, child.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	labels, err := store.GetLabelsByTasks(ctx, []int64{child.ID})
+	require.NoError(t, err)
+	require.Empty(t, labels)
+}
+
+func TestWipLimit(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	user := createRandomUser(t, store)
+	result, err := store.CreateProjectTx(ctx, db.CreateProjectTxParams{
+		CreateProjectParams: db.CreateProjectParams{Name: "project", OwnerID: user.ID},
+		Statuses: []db.CreateProjectStatusParams{
+			{Name: "Doing", Category: "in_progress", WipLimit: 2},
+		},
+	})
+	require.NoError(t, err)
+	arg := db.CreateTaskParams{
+		Body:      "task",
+		OwnerID:   user.ID,
+		ProjectID: sql.NullInt64{Int64: result.Project.ID, Valid: true},
+		StatusID:  sql.NullInt64{Int64: result.Statuses[0].ID, Valid: true},
+	}
+
+	//the subtask would be the third task in the status, nothing is created
+	_, err = store.CreateTaskTx(ctx, arg)
+	require.NoError(t, err)
+	_, err = store.InstantiateTemplateTx(ctx, db.InstantiateTemplateTxParams{
+		OwnerID: user.ID,
+		Root:    db.TemplateTask{Task: arg, Subtasks: []db.TemplateTask{{Task: arg}}},
+	})
+	require.ErrorIs(t, err, db.ErrWipLimitReached)
+	count, err := store.CountTasksByStatus(ctx, arg.StatusID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = store.CreateTaskTx(ctx, arg)
+	require.NoError(t, err)
+	_, err = store.CreateTaskTx(ctx, arg)
+	require.ErrorIs(t, err, db.ErrWipLimitReached)
+}
+
+func TestSearchTasks(t *testing.T) {
+	storetest.SearchTasks(t, NewStore())
+}
+
+func TestWorkflow(t *testing.T) {
+	storetest.Workflow(t, NewStore())
+}
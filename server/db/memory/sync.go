@@ -0,0 +1,93 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// nextSyncSeq is nextval('sync_change_seq')
+func (d *database) nextSyncSeq() int64 {
+	d.seq.mu.Lock()
+	defer d.seq.mu.Unlock()
+	d.seq.syncChange++
+	return d.seq.syncChange
+}
+
+// recordSyncChange is the record_sync_change trigger of tasks, labels and projects
+func (q *Queries) recordSyncChange(entity string, entityId int64, ownerId int64, deleted bool) {
+	q.data.syncChanges[syncChangeKey{Entity: entity, EntityID: entityId}] = db.SyncChange{
+		Entity:    entity,
+		EntityID:  entityId,
+		OwnerID:   ownerId,
+		Seq:       q.data.nextSyncSeq(),
+		ChangeXid: q.txid(),
+		ChangedAt: now(),
+		Deleted:   deleted,
+	}
+}
+
+// recordTaskSyncChange is the record_task_sync_change trigger of task labels and custom field values
+func (q *Queries) recordTaskSyncChange(taskId int64) {
+	key := syncChangeKey{Entity: "task", EntityID: taskId}
+	change, ok := q.data.syncChanges[key]
+	if !ok || change.Deleted {
+		return
+	}
+	change.Seq = q.data.nextSyncSeq()
+	change.ChangeXid = q.txid()
+	change.ChangedAt = now()
+	q.data.syncChanges[key] = change
+}
+
+// GetSyncSnapshotXmin is the next transaction id. Transactions are serialized and
+// statements hold the lock of the tables, so none is running when a transaction asks.
+func (q *Queries) GetSyncSnapshotXmin(ctx context.Context) (string, error) {
+	defer q.lock()()
+	q.data.seq.mu.Lock()
+	defer q.data.seq.mu.Unlock()
+	return strconv.FormatInt(q.data.seq.xid+1, 10), nil
+}
+
+func (q *Queries) GetSyncChanges(ctx context.Context, arg db.GetSyncChangesParams) ([]db.GetSyncChangesRow, error) {
+	defer q.lock()()
+	since, err := strconv.ParseInt(arg.Since, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	changes := selectRows(q.data.syncChanges, func(change db.SyncChange) bool {
+		return change.OwnerID == arg.OwnerID && change.ChangeXid.(int64) >= since && change.Seq > arg.AfterSeq
+	}, func(a, b db.SyncChange) bool {
+		return a.Seq < b.Seq
+	})
+	items := []db.GetSyncChangesRow{}
+	for _, change := range page(changes, arg.RowLimit, 0) {
+		items = append(items, db.GetSyncChangesRow{
+			Entity:    change.Entity,
+			EntityID:  change.EntityID,
+			OwnerID:   change.OwnerID,
+			Seq:       change.Seq,
+			ChangedAt: change.ChangedAt,
+			Deleted:   change.Deleted,
+		})
+	}
+	return items, nil
+}
+
+func (q *Queries) GetSyncChange(ctx context.Context, arg db.GetSyncChangeParams) (db.GetSyncChangeRow, error) {
+	defer q.lock()()
+	change, ok := q.data.syncChanges[syncChangeKey{Entity: arg.Entity, EntityID: arg.EntityID}]
+	if !ok {
+		return db.GetSyncChangeRow{}, sql.ErrNoRows
+	}
+	return db.GetSyncChangeRow{
+		Entity:    change.Entity,
+		EntityID:  change.EntityID,
+		OwnerID:   change.OwnerID,
+		Seq:       change.Seq,
+		ChangedAt: change.ChangedAt,
+		Deleted:   change.Deleted,
+	}, nil
+}
@@ -0,0 +1,621 @@
+package memdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// setTask writes a task row and records the change for sync
+func (q *Queries) setTask(task db.Task) {
+	q.data.tasks[task.ID] = task
+	q.recordSyncChange("task", task.ID, task.OwnerID, false)
+}
+
+// checkTaskReferences checks the foreign keys of a task
+func (q *Queries) checkTaskReferences(task db.Task) error {
+	if err := q.userExists("tasks", "owner_id", task.OwnerID); err != nil {
+		return err
+	}
+	if task.ProjectID.Valid {
+		if err := q.projectExists("tasks", task.ProjectID.Int64); err != nil {
+			return err
+		}
+	}
+	if _, ok := q.data.projectStatuses[task.StatusID.Int64]; task.StatusID.Valid && !ok {
+		return foreignKeyViolation("tasks", "tasks_status_id_fkey")
+	}
+	if _, ok := q.data.tasks[task.ParentID.Int64]; task.ParentID.Valid && !ok {
+		return foreignKeyViolation("tasks", "tasks_parent_id_fkey")
+	}
+	return nil
+}
+
+// deleteTask deletes a task with its subtasks and everything that references them
+func (q *Queries) deleteTask(task db.Task) {
+	for _, subtask := range q.data.tasks {
+		if subtask.ParentID.Valid && subtask.ParentID.Int64 == task.ID {
+			q.deleteTask(subtask)
+		}
+	}
+	for key := range q.data.taskCustomFieldValues {
+		if key.TaskID == task.ID {
+			delete(q.data.taskCustomFieldValues, key)
+		}
+	}
+	for key := range q.data.taskLabels {
+		if key.TaskID == task.ID {
+			delete(q.data.taskLabels, key)
+		}
+	}
+	for id, reminder := range q.data.reminders {
+		if reminder.TaskID == task.ID {
+			delete(q.data.reminders, id)
+		}
+	}
+	for id, attachment := range q.data.taskAttachments {
+		if attachment.TaskID == task.ID {
+			delete(q.data.taskAttachments, id)
+		}
+	}
+	for id, entry := range q.data.timeEntries {
+		if entry.TaskID == task.ID {
+			delete(q.data.timeEntries, id)
+		}
+	}
+	delete(q.data.tasks, task.ID)
+	q.recordSyncChange("task", task.ID, task.OwnerID, true)
+}
+
+func lessTaskID(a, b db.Task) bool {
+	return a.ID < b.ID
+}
+
+// lessDueAt is ORDER BY due_at, id
+func lessDueAt(a, b db.Task) bool {
+	if !a.DueAt.Time.Equal(b.DueAt.Time) {
+		return a.DueAt.Time.Before(b.DueAt.Time)
+	}
+	return a.ID < b.ID
+}
+
+// inRange is from <= t AND t < to, false when any of them is NULL
+func inRange(t sql.NullTime, from sql.NullTime, to sql.NullTime) bool {
+	return t.Valid && from.Valid && to.Valid && !t.Time.Before(from.Time) && t.Time.Before(to.Time)
+}
+
+func (q *Queries) CreateTask(ctx context.Context, arg db.CreateTaskParams) (db.Task, error) {
+	defer q.lock()()
+	task := db.Task{
+		Body:            arg.Body,
+		IsDone:          arg.IsDone,
+		OwnerID:         arg.OwnerID,
+		CreatedAt:       now(),
+		ProjectID:       arg.ProjectID,
+		StatusID:        arg.StatusID,
+		DueAt:           arg.DueAt,
+		Priority:        arg.Priority,
+		EstimateMinutes: arg.EstimateMinutes,
+		ParentID:        arg.ParentID,
+	}
+	if task.IsDone {
+		task.CompletedAt = sql.NullTime{Time: task.CreatedAt, Valid: true}
+	}
+	if err := q.checkTaskReferences(task); err != nil {
+		return db.Task{}, err
+	}
+	task.ID = q.data.nextID("tasks")
+	q.setTask(task)
+	return task, nil
+}
+
+func (q *Queries) GetTask(ctx context.Context, id int64) (db.Task, error) {
+	defer q.lock()()
+	task, ok := q.data.tasks[id]
+	if !ok {
+		return db.Task{}, sql.ErrNoRows
+	}
+	return task, nil
+}
+
+func (q *Queries) GetSubtasks(ctx context.Context, parentID sql.NullInt64) ([]db.Task, error) {
+	defer q.lock()()
+	return selectRows(q.data.tasks, func(task db.Task) bool {
+		return parentID.Valid && task.ParentID.Valid && task.ParentID.Int64 == parentID.Int64
+	}, lessTaskID), nil
+}
+
+func (q *Queries) GetTaskList(ctx context.Context, arg db.GetTaskListParams) ([]db.Task, error) {
+	defer q.lock()()
+	current := now()
+	tasks := selectRows(q.data.tasks, func(task db.Task) bool {
+		return task.OwnerID == arg.OwnerID && !task.ArchivedAt.Valid &&
+			(!task.DeferredUntil.Valid || !task.DeferredUntil.Time.After(current))
+	}, lessTaskID)
+	return page(tasks, arg.Limit, arg.Offset), nil
+}
+
+func (q *Queries) GetTaskListByProject(ctx context.Context, projectID sql.NullInt64) ([]db.Task, error) {
+	defer q.lock()()
+	return selectRows(q.data.tasks, func(task db.Task) bool {
+		return projectID.Valid && task.ProjectID.Valid && task.ProjectID.Int64 == projectID.Int64 && !task.ArchivedAt.Valid
+	}, lessTaskID), nil
+}
+
+func (q *Queries) CountTasksByStatus(ctx context.Context, statusID sql.NullInt64) (int64, error) {
+	defer q.lock()()
+	var count int64
+	for _, task := range q.data.tasks {
+		if statusID.Valid && task.StatusID.Valid && task.StatusID.Int64 == statusID.Int64 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *Queries) UpdateTask(ctx context.Context, arg db.UpdateTaskParams) (db.Task, error) {
+	defer q.lock()()
+	task, ok := q.data.tasks[arg.ID]
+	if !ok || task.OwnerID != arg.OwnerID {
+		return db.Task{}, sql.ErrNoRows
+	}
+	task.Body = arg.Body
+	task.IsDone = arg.IsDone
+	task.StatusID = arg.StatusID
+	if arg.SetDueAt {
+		task.DueAt = arg.DueAt
+	}
+	if arg.Priority.Valid {
+		task.Priority = arg.Priority.Int16
+	}
+	if arg.SetEstimateMinutes {
+		task.EstimateMinutes = arg.EstimateMinutes
+	}
+	if !task.IsDone {
+		task.CompletedAt = sql.NullTime{}
+		task.ArchivedAt = sql.NullTime{}
+	} else if !task.CompletedAt.Valid {
+		task.CompletedAt = sql.NullTime{Time: now(), Valid: true}
+	}
+	if err := q.checkTaskReferences(task); err != nil {
+		return db.Task{}, err
+	}
+	q.setTask(task)
+	return task, nil
+}
+
+func (q *Queries) SetTaskDeferredUntil(ctx context.Context, arg db.SetTaskDeferredUntilParams) (db.Task, error) {
+	defer q.lock()()
+	task, ok := q.data.tasks[arg.ID]
+	if !ok || task.OwnerID != arg.OwnerID {
+		return db.Task{}, sql.ErrNoRows
+	}
+	task.DeferredUntil = arg.DeferredUntil
+	q.setTask(task)
+	return task, nil
+}
+
+func (q *Queries) DeleteTask(ctx context.Context, arg db.DeleteTaskParams) error {
+	defer q.lock()()
+	task, ok := q.data.tasks[arg.ID]
+	if ok && task.OwnerID == arg.OwnerID {
+		q.deleteTask(task)
+	}
+	return nil
+}
+
+func (q *Queries) GetTasksDueBetween(ctx context.Context, arg db.GetTasksDueBetweenParams) ([]db.Task, error) {
+	defer q.lock()()
+	tasks := selectRows(q.data.tasks, func(task db.Task) bool {
+		return task.OwnerID == arg.OwnerID && !task.IsDone && inRange(task.DueAt, arg.FromTime, arg.ToTime)
+	}, lessDueAt)
+	return page(tasks, 100, 0), nil
+}
+
+func (q *Queries) GetOverdueTasks(ctx context.Context, arg db.GetOverdueTasksParams) ([]db.Task, error) {
+	defer q.lock()()
+	tasks := selectRows(q.data.tasks, func(task db.Task) bool {
+		return task.OwnerID == arg.OwnerID && !task.IsDone &&
+			task.DueAt.Valid && arg.Before.Valid && task.DueAt.Time.Before(arg.Before.Time)
+	}, lessDueAt)
+	return page(tasks, 100, 0), nil
+}
+
+func (q *Queries) GetTasksCompletedBetween(ctx context.Context, arg db.GetTasksCompletedBetweenParams) ([]db.Task, error) {
+	defer q.lock()()
+	tasks := selectRows(q.data.tasks, func(task db.Task) bool {
+		return task.OwnerID == arg.OwnerID && task.IsDone && inRange(task.CompletedAt, arg.FromTime, arg.ToTime)
+	}, func(a, b db.Task) bool {
+		if !a.CompletedAt.Time.Equal(b.CompletedAt.Time) {
+			return a.CompletedAt.Time.Before(b.CompletedAt.Time)
+		}
+		return a.ID < b.ID
+	})
+	return page(tasks, 100, 0), nil
+}
+
+func (q *Queries) GetTasksByIds(ctx context.Context, ids []int64) ([]db.Task, error) {
+	defer q.lock()()
+	return selectRows(q.data.tasks, func(task db.Task) bool {
+		return containsID(ids, task.ID)
+	}, lessTaskID), nil
+}
+
+func (q *Queries) UpsertLabel(ctx context.Context, arg db.UpsertLabelParams) (db.Label, error) {
+	defer q.lock()()
+	if err := q.userExists("labels", "owner_id", arg.OwnerID); err != nil {
+		return db.Label{}, err
+	}
+	label := db.Label{
+		OwnerID:   arg.OwnerID,
+		Name:      arg.Name,
+		CreatedAt: now(),
+	}
+	for _, existing := range q.data.labels {
+		if existing.OwnerID == arg.OwnerID && existing.Name == arg.Name {
+			label = existing
+		}
+	}
+	if label.ID == 0 {
+		label.ID = q.data.nextID("labels")
+	}
+	//ON CONFLICT DO UPDATE fires the update trigger too
+	q.data.labels[label.ID] = label
+	q.recordSyncChange("label", label.ID, label.OwnerID, false)
+	return label, nil
+}
+
+func (q *Queries) GetLabelList(ctx context.Context, ownerID int64) ([]db.Label, error) {
+	defer q.lock()()
+	return selectRows(q.data.labels, func(label db.Label) bool {
+		return label.OwnerID == ownerID
+	}, func(a, b db.Label) bool {
+		return a.Name < b.Name
+	}), nil
+}
+
+func (q *Queries) GetLabelsByIds(ctx context.Context, ids []int64) ([]db.Label, error) {
+	defer q.lock()()
+	return selectRows(q.data.labels, func(label db.Label) bool {
+		return containsID(ids, label.ID)
+	}, func(a, b db.Label) bool {
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) DeleteLabel(ctx context.Context, arg db.DeleteLabelParams) error {
+	defer q.lock()()
+	label, ok := q.data.labels[arg.ID]
+	if !ok || label.OwnerID != arg.OwnerID {
+		return nil
+	}
+	for key := range q.data.taskLabels {
+		if key.LabelID == label.ID {
+			delete(q.data.taskLabels, key)
+			q.recordTaskSyncChange(key.TaskID)
+		}
+	}
+	delete(q.data.labels, label.ID)
+	q.recordSyncChange("label", label.ID, label.OwnerID, true)
+	return nil
+}
+
+func (q *Queries) AddTaskLabel(ctx context.Context, arg db.AddTaskLabelParams) error {
+	defer q.lock()()
+	if _, ok := q.data.tasks[arg.TaskID]; !ok {
+		return foreignKeyViolation("task_labels", "task_labels_task_id_fkey")
+	}
+	if _, ok := q.data.labels[arg.LabelID]; !ok {
+		return foreignKeyViolation("task_labels", "task_labels_label_id_fkey")
+	}
+	key := db.TaskLabel(arg)
+	if q.data.taskLabels[key] {
+		return nil
+	}
+	q.data.taskLabels[key] = true
+	q.recordTaskSyncChange(key.TaskID)
+	return nil
+}
+
+func (q *Queries) DeleteTaskLabels(ctx context.Context, taskID int64) error {
+	defer q.lock()()
+	for key := range q.data.taskLabels {
+		if key.TaskID == taskID {
+			delete(q.data.taskLabels, key)
+			q.recordTaskSyncChange(taskID)
+		}
+	}
+	return nil
+}
+
+func (q *Queries) GetLabelsByTasks(ctx context.Context, taskIds []int64) ([]db.GetLabelsByTasksRow, error) {
+	defer q.lock()()
+	items := []db.GetLabelsByTasksRow{}
+	for key := range q.data.taskLabels {
+		if containsID(taskIds, key.TaskID) {
+			label := q.data.labels[key.LabelID]
+			items = append(items, db.GetLabelsByTasksRow{
+				TaskID: key.TaskID,
+				ID:     label.ID,
+				Name:   label.Name,
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].TaskID != items[j].TaskID {
+			return items[i].TaskID < items[j].TaskID
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
+
+func (q *Queries) CreateSavedFilter(ctx context.Context, arg db.CreateSavedFilterParams) (db.SavedFilter, error) {
+	defer q.lock()()
+	if err := q.userExists("saved_filters", "owner_id", arg.OwnerID); err != nil {
+		return db.SavedFilter{}, err
+	}
+	filter := db.SavedFilter{
+		OwnerID:   arg.OwnerID,
+		Name:      arg.Name,
+		Query:     arg.Query,
+		CreatedAt: now(),
+	}
+	if err := q.checkSavedFilterName(filter); err != nil {
+		return db.SavedFilter{}, err
+	}
+	filter.ID = q.data.nextID("saved_filters")
+	q.data.savedFilters[filter.ID] = filter
+	return filter, nil
+}
+
+// checkSavedFilterName is UNIQUE (owner_id, name)
+func (q *Queries) checkSavedFilterName(filter db.SavedFilter) error {
+	for _, other := range q.data.savedFilters {
+		if other.ID != filter.ID && other.OwnerID == filter.OwnerID && other.Name == filter.Name {
+			return uniqueViolation("saved_filters_owner_id_name_key")
+		}
+	}
+	return nil
+}
+
+func (q *Queries) GetSavedFilter(ctx context.Context, id int64) (db.SavedFilter, error) {
+	defer q.lock()()
+	filter, ok := q.data.savedFilters[id]
+	if !ok {
+		return db.SavedFilter{}, sql.ErrNoRows
+	}
+	return filter, nil
+}
+
+func (q *Queries) GetSavedFilterList(ctx context.Context, ownerID int64) ([]db.SavedFilter, error) {
+	defer q.lock()()
+	return selectRows(q.data.savedFilters, func(filter db.SavedFilter) bool {
+		return filter.OwnerID == ownerID
+	}, func(a, b db.SavedFilter) bool {
+		return a.Name < b.Name
+	}), nil
+}
+
+func (q *Queries) UpdateSavedFilter(ctx context.Context, arg db.UpdateSavedFilterParams) (db.SavedFilter, error) {
+	defer q.lock()()
+	filter, ok := q.data.savedFilters[arg.ID]
+	if !ok || filter.OwnerID != arg.OwnerID {
+		return db.SavedFilter{}, sql.ErrNoRows
+	}
+	filter.Name = arg.Name
+	filter.Query = arg.Query
+	if err := q.checkSavedFilterName(filter); err != nil {
+		return db.SavedFilter{}, err
+	}
+	q.data.savedFilters[filter.ID] = filter
+	return filter, nil
+}
+
+func (q *Queries) DeleteSavedFilter(ctx context.Context, arg db.DeleteSavedFilterParams) error {
+	defer q.lock()()
+	if filter, ok := q.data.savedFilters[arg.ID]; ok && filter.OwnerID == arg.OwnerID {
+		delete(q.data.savedFilters, filter.ID)
+	}
+	return nil
+}
+
+func (q *Queries) GetArchiveRule(ctx context.Context, userID int64) (db.ArchiveRule, error) {
+	defer q.lock()()
+	rule, ok := q.data.archiveRules[userID]
+	if !ok {
+		return db.ArchiveRule{}, sql.ErrNoRows
+	}
+	return rule, nil
+}
+
+func (q *Queries) UpsertArchiveRule(ctx context.Context, arg db.UpsertArchiveRuleParams) (db.ArchiveRule, error) {
+	defer q.lock()()
+	if err := q.userExists("archive_rules", "user_id", arg.UserID); err != nil {
+		return db.ArchiveRule{}, err
+	}
+	rule := db.ArchiveRule{
+		UserID:    arg.UserID,
+		AfterDays: arg.AfterDays,
+		UpdatedAt: now(),
+	}
+	q.data.archiveRules[rule.UserID] = rule
+	return rule, nil
+}
+
+func (q *Queries) DeleteArchiveRule(ctx context.Context, userID int64) error {
+	defer q.lock()()
+	delete(q.data.archiveRules, userID)
+	return nil
+}
+
+func (q *Queries) ArchiveDoneTasks(ctx context.Context, arg db.ArchiveDoneTasksParams) (int64, error) {
+	defer q.lock()()
+	tasks := selectRows(q.data.tasks, func(task db.Task) bool {
+		rule, ok := q.data.archiveRules[task.OwnerID]
+		return ok && task.IsDone && !task.ArchivedAt.Valid && task.CompletedAt.Valid &&
+			!task.CompletedAt.Time.After(arg.Now.AddDate(0, 0, -int(rule.AfterDays)))
+	}, lessTaskID)
+	tasks = page(tasks, arg.BatchSize, 0)
+	for _, task := range tasks {
+		task.ArchivedAt = sql.NullTime{Time: arg.Now, Valid: true}
+		q.setTask(task)
+	}
+	return int64(len(tasks)), nil
+}
+
+func (q *Queries) ArchiveTask(ctx context.Context, arg db.ArchiveTaskParams) (db.Task, error) {
+	defer q.lock()()
+	task, ok := q.data.tasks[arg.ID]
+	if !ok || task.OwnerID != arg.OwnerID || !task.IsDone {
+		return db.Task{}, sql.ErrNoRows
+	}
+	if !task.ArchivedAt.Valid {
+		task.ArchivedAt = sql.NullTime{Time: now(), Valid: true}
+	}
+	q.setTask(task)
+	return task, nil
+}
+
+func (q *Queries) UnarchiveTask(ctx context.Context, arg db.UnarchiveTaskParams) (db.Task, error) {
+	defer q.lock()()
+	task, ok := q.data.tasks[arg.ID]
+	if !ok || task.OwnerID != arg.OwnerID {
+		return db.Task{}, sql.ErrNoRows
+	}
+	task.ArchivedAt = sql.NullTime{}
+	q.setTask(task)
+	return task, nil
+}
+
+func (q *Queries) GetArchivedTaskList(ctx context.Context, arg db.GetArchivedTaskListParams) ([]db.Task, error) {
+	defer q.lock()()
+	tasks := selectRows(q.data.tasks, func(task db.Task) bool {
+		return task.OwnerID == arg.OwnerID && task.ArchivedAt.Valid
+	}, func(a, b db.Task) bool {
+		if !a.ArchivedAt.Time.Equal(b.ArchivedAt.Time) {
+			return a.ArchivedAt.Time.After(b.ArchivedAt.Time)
+		}
+		return a.ID > b.ID
+	})
+	return page(tasks, arg.Limit, arg.Offset), nil
+}
+
+func (q *Queries) CreateTaskAttachment(ctx context.Context, arg db.CreateTaskAttachmentParams) (db.CreateTaskAttachmentRow, error) {
+	defer q.lock()()
+	if _, ok := q.data.tasks[arg.TaskID]; !ok {
+		return db.CreateTaskAttachmentRow{}, foreignKeyViolation("task_attachments", "task_attachments_task_id_fkey")
+	}
+	attachment := db.TaskAttachment{
+		ID:          q.data.nextID("task_attachments"),
+		TaskID:      arg.TaskID,
+		Filename:    arg.Filename,
+		ContentType: arg.ContentType,
+		Size:        arg.Size,
+		Content:     bytes.Clone(arg.Content),
+		CreatedAt:   now(),
+	}
+	q.data.taskAttachments[attachment.ID] = attachment
+	return db.CreateTaskAttachmentRow(attachmentRow(attachment)), nil
+}
+
+func attachmentRow(attachment db.TaskAttachment) db.GetTaskAttachmentListRow {
+	return db.GetTaskAttachmentListRow{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func (q *Queries) GetTaskAttachment(ctx context.Context, id int64) (db.TaskAttachment, error) {
+	defer q.lock()()
+	attachment, ok := q.data.taskAttachments[id]
+	if !ok {
+		return db.TaskAttachment{}, sql.ErrNoRows
+	}
+	return attachment, nil
+}
+
+func (q *Queries) GetTaskAttachmentList(ctx context.Context, taskID int64) ([]db.GetTaskAttachmentListRow, error) {
+	defer q.lock()()
+	items := []db.GetTaskAttachmentListRow{}
+	for _, attachment := range selectRows(q.data.taskAttachments, func(attachment db.TaskAttachment) bool {
+		return attachment.TaskID == taskID
+	}, func(a, b db.TaskAttachment) bool {
+		return a.ID < b.ID
+	}) {
+		items = append(items, attachmentRow(attachment))
+	}
+	return items, nil
+}
+
+func (q *Queries) CreateTaskTemplate(ctx context.Context, arg db.CreateTaskTemplateParams) (db.TaskTemplate, error) {
+	defer q.lock()()
+	if err := q.userExists("task_templates", "owner_id", arg.OwnerID); err != nil {
+		return db.TaskTemplate{}, err
+	}
+	template := db.TaskTemplate{
+		ID:               q.data.nextID("task_templates"),
+		OwnerID:          arg.OwnerID,
+		Name:             arg.Name,
+		Body:             arg.Body,
+		Priority:         arg.Priority,
+		Labels:           append([]string{}, arg.Labels...),
+		DueOffsetMinutes: arg.DueOffsetMinutes,
+		Subtasks:         bytes.Clone(arg.Subtasks),
+		CreatedAt:        now(),
+	}
+	q.data.taskTemplates[template.ID] = template
+	return template, nil
+}
+
+func (q *Queries) GetTaskTemplate(ctx context.Context, id int64) (db.TaskTemplate, error) {
+	defer q.lock()()
+	template, ok := q.data.taskTemplates[id]
+	if !ok {
+		return db.TaskTemplate{}, sql.ErrNoRows
+	}
+	return template, nil
+}
+
+func (q *Queries) GetTaskTemplateList(ctx context.Context, ownerID int64) ([]db.TaskTemplate, error) {
+	defer q.lock()()
+	return selectRows(q.data.taskTemplates, func(template db.TaskTemplate) bool {
+		return template.OwnerID == ownerID
+	}, func(a, b db.TaskTemplate) bool {
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	}), nil
+}
+
+func (q *Queries) UpdateTaskTemplate(ctx context.Context, arg db.UpdateTaskTemplateParams) (db.TaskTemplate, error) {
+	defer q.lock()()
+	template, ok := q.data.taskTemplates[arg.ID]
+	if !ok || template.OwnerID != arg.OwnerID {
+		return db.TaskTemplate{}, sql.ErrNoRows
+	}
+	template.Name = arg.Name
+	template.Body = arg.Body
+	template.Priority = arg.Priority
+	template.Labels = append([]string{}, arg.Labels...)
+	template.DueOffsetMinutes = arg.DueOffsetMinutes
+	template.Subtasks = bytes.Clone(arg.Subtasks)
+	q.data.taskTemplates[template.ID] = template
+	return template, nil
+}
+
+func (q *Queries) DeleteTaskTemplate(ctx context.Context, arg db.DeleteTaskTemplateParams) error {
+	defer q.lock()()
+	if template, ok := q.data.taskTemplates[arg.ID]; ok && template.OwnerID == arg.OwnerID {
+		delete(q.data.taskTemplates, template.ID)
+	}
+	return nil
+}
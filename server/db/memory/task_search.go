@@ -0,0 +1,376 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// sqlBool is the value of a condition, like in SQL a comparison with NULL is unknown,
+// it does not match and NOT of it does not match either
+type sqlBool int
+
+const (
+	sqlFalse sqlBool = iota
+	sqlTrue
+	sqlUnknown
+)
+
+func toSQLBool(b bool) sqlBool {
+	if b {
+		return sqlTrue
+	}
+	return sqlFalse
+}
+
+func (b sqlBool) not() sqlBool {
+	switch b {
+	case sqlTrue:
+		return sqlFalse
+	case sqlFalse:
+		return sqlTrue
+	}
+	return sqlUnknown
+}
+
+func nullInt64(v sql.NullInt64) interface{} {
+	if !v.Valid {
+		return nil
+	}
+	return v.Int64
+}
+
+func nullTime(v sql.NullTime) interface{} {
+	if !v.Valid {
+		return nil
+	}
+	return v.Time
+}
+
+// taskColumn is the value of a column of a task, nil when it is NULL
+func taskColumn(task db.Task, column db.TaskColumn) (interface{}, error) {
+	switch column {
+	case db.TaskColumnID:
+		return task.ID, nil
+	case db.TaskColumnOwnerID:
+		return task.OwnerID, nil
+	case db.TaskColumnProjectID:
+		return nullInt64(task.ProjectID), nil
+	case db.TaskColumnStatusID:
+		return nullInt64(task.StatusID), nil
+	case db.TaskColumnIsDone:
+		return task.IsDone, nil
+	case db.TaskColumnPriority:
+		return int64(task.Priority), nil
+	case db.TaskColumnDueAt:
+		return nullTime(task.DueAt), nil
+	case db.TaskColumnCreatedAt:
+		return task.CreatedAt, nil
+	case db.TaskColumnArchivedAt:
+		return nullTime(task.ArchivedAt), nil
+	case db.TaskColumnDeferredUntil:
+		return nullTime(task.DeferredUntil), nil
+	}
+	return nil, fmt.Errorf("unknown task column %q", column)
+}
+
+// filterValue converts a value of a filter like database/sql does before it is sent to Postgres
+func filterValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// compareValues orders two values that are not NULL
+func compareValues(a interface{}, b interface{}) (int, error) {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return compareOrdered(a, b), nil
+		case float64:
+			return compareOrdered(float64(a), b), nil
+		}
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return compareOrdered(a, float64(b)), nil
+		case float64:
+			return compareOrdered(a, b), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), nil
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, nil
+			case !a:
+				return -1, nil
+			}
+			return 1, nil
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), nil
+		}
+	case jsonValue:
+		if b, ok := b.(jsonValue); ok {
+			return compareJSON(a.v, b.v), nil
+		}
+	}
+	return 0, fmt.Errorf("operator does not exist: %T = %T", a, b)
+}
+
+// compareOps are the operators of db.FilterCompare and db.FilterFieldCompare
+var compareOps = map[string]func(cmp int) bool{
+	"=":  func(cmp int) bool { return cmp == 0 },
+	"<>": func(cmp int) bool { return cmp != 0 },
+	"<":  func(cmp int) bool { return cmp < 0 },
+	"<=": func(cmp int) bool { return cmp <= 0 },
+	">":  func(cmp int) bool { return cmp > 0 },
+	">=": func(cmp int) bool { return cmp >= 0 },
+}
+
+// compare applies the operator of a filter to two values, it is unknown when one is NULL
+func compare(a interface{}, op string, b interface{}) (sqlBool, error) {
+	holds, ok := compareOps[op]
+	if !ok {
+		return sqlFalse, fmt.Errorf("unknown operator %q", op)
+	}
+	if a == nil || b == nil {
+		return sqlUnknown, nil
+	}
+	cmp, err := compareValues(a, filterValue(b))
+	if err != nil {
+		return sqlFalse, err
+	}
+	return toSQLBool(holds(cmp)), nil
+}
+
+// matchTask evaluates a filter for a task the way SQLStore's query does
+func (q *Queries) matchTask(filter db.TaskFilter, task db.Task) (sqlBool, error) {
+	switch f := filter.(type) {
+	case db.FilterAnd:
+		result := sqlTrue
+		for _, filter := range f {
+			ok, err := q.matchTask(filter, task)
+			if err != nil {
+				return sqlFalse, err
+			}
+			if ok == sqlFalse {
+				return sqlFalse, nil
+			}
+			if ok == sqlUnknown {
+				result = sqlUnknown
+			}
+		}
+		return result, nil
+	case db.FilterOr:
+		result := sqlFalse
+		for _, filter := range f {
+			ok, err := q.matchTask(filter, task)
+			if err != nil {
+				return sqlFalse, err
+			}
+			if ok == sqlTrue {
+				return sqlTrue, nil
+			}
+			if ok == sqlUnknown {
+				result = sqlUnknown
+			}
+		}
+		return result, nil
+	case db.FilterNot:
+		ok, err := q.matchTask(f.Filter, task)
+		return ok.not(), err
+	case db.FilterCompare:
+		value, err := taskColumn(task, f.Column)
+		if err != nil {
+			return sqlFalse, err
+		}
+		return compare(value, f.Op, f.Value)
+	case db.FilterIsNull:
+		value, err := taskColumn(task, f.Column)
+		return toSQLBool(value == nil), err
+	case db.FilterBodyContains:
+		return toSQLBool(strings.Contains(strings.ToLower(task.Body), strings.ToLower(f.Text))), nil
+	case db.FilterBodyEquals:
+		return toSQLBool(strings.ToLower(task.Body) == strings.ToLower(f.Text)), nil
+	case db.FilterStatusName:
+		if !task.StatusID.Valid {
+			return sqlUnknown, nil
+		}
+		status, ok := q.data.projectStatuses[task.StatusID.Int64]
+		if !ok || !strings.EqualFold(status.Name, f.Name) {
+			return sqlFalse, nil
+		}
+		return toSQLBool(q.data.projects[status.ProjectID].OwnerID == f.OwnerID), nil
+	case db.FilterProjectName:
+		if !task.ProjectID.Valid {
+			return sqlUnknown, nil
+		}
+		project, ok := q.data.projects[task.ProjectID.Int64]
+		return toSQLBool(ok && project.OwnerID == f.OwnerID && strings.EqualFold(project.Name, f.Name)), nil
+	case db.FilterLabel:
+		for key := range q.data.taskLabels {
+			if key.TaskID != task.ID {
+				continue
+			}
+			if label, ok := q.data.labels[key.LabelID]; f.Name == "" || (ok && strings.EqualFold(label.Name, f.Name)) {
+				return sqlTrue, nil
+			}
+		}
+		return sqlFalse, nil
+	case db.FilterFieldSet:
+		_, ok, err := q.fieldValue(task.ID, f.FieldID)
+		return toSQLBool(ok), err
+	case db.FilterFieldEquals:
+		value, ok, err := q.fieldValue(task.ID, f.FieldID)
+		if err != nil || !ok {
+			return sqlFalse, err
+		}
+		want, err := parseJSON(f.Value)
+		if err != nil {
+			return sqlFalse, err
+		}
+		if f.Contains {
+			return toSQLBool(jsonContains(value.v, want.v)), nil
+		}
+		return toSQLBool(compareJSON(value.v, want.v) == 0), nil
+	case db.FilterFieldContains:
+		value, ok, err := q.fieldValue(task.ID, f.FieldID)
+		if err != nil || !ok {
+			return sqlFalse, err
+		}
+		text, ok := value.text()
+		return toSQLBool(ok && strings.Contains(strings.ToLower(text), strings.ToLower(f.Text))), nil
+	case db.FilterFieldCompare:
+		value, ok, err := q.fieldValue(task.ID, f.FieldID)
+		if err != nil || !ok {
+			return sqlFalse, err
+		}
+		text, ok := value.text()
+		if !ok {
+			return sqlFalse, nil
+		}
+		var left interface{} = text
+		switch f.Value.(type) {
+		case float64:
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return sqlFalse, fmt.Errorf("invalid input syntax for type numeric: %q", text)
+			}
+			left = n
+		case string:
+		default:
+			return sqlFalse, fmt.Errorf("cannot compare custom field values with %T", f.Value)
+		}
+		//the value is inside EXISTS, a row it does not hold for is left out
+		matched, err := compare(left, f.Op, f.Value)
+		return toSQLBool(matched == sqlTrue), err
+	}
+	return sqlFalse, fmt.Errorf("unsupported task filter %T", filter)
+}
+
+// fieldValue is the value of a custom field of a task, false when it has none
+func (q *Queries) fieldValue(taskId int64, fieldId int64) (jsonValue, bool, error) {
+	value, ok := q.data.taskCustomFieldValues[taskFieldKey{TaskID: taskId, FieldID: fieldId}]
+	if !ok {
+		return jsonValue{}, false, nil
+	}
+	v, err := parseJSON(value.Value)
+	return v, err == nil, err
+}
+
+// orderKey is the value a task is sorted by, nil when it is NULL
+func (q *Queries) orderKey(order db.TaskOrder, task db.Task) (interface{}, error) {
+	if order.FieldID != 0 {
+		value, ok, err := q.fieldValue(task.ID, order.FieldID)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return value, nil
+	}
+	return taskColumn(task, order.Column)
+}
+
+// SearchTasks evaluates a task filter, see db.SearchTasksParams
+func (store *Store) SearchTasks(ctx context.Context, arg db.SearchTasksParams) ([]db.Task, error) {
+	q := store.Queries
+	defer q.lock()()
+
+	type match struct {
+		task db.Task
+		keys []interface{}
+	}
+	matches := []match{}
+	for _, task := range selectRows(q.data.tasks, nil, lessTaskID) {
+		ok, err := q.matchTask(arg.Filter, task)
+		if err != nil {
+			return nil, err
+		}
+		if ok != sqlTrue {
+			continue
+		}
+		m := match{task: task}
+		for _, order := range arg.OrderBy {
+			key, err := q.orderKey(order, task)
+			if err != nil {
+				return nil, err
+			}
+			m.keys = append(m.keys, key)
+		}
+		matches = append(matches, m)
+	}
+
+	//the tasks are sorted by id already, which breaks ties
+	var sortErr error
+	sort.SliceStable(matches, func(i, j int) bool {
+		for k, order := range arg.OrderBy {
+			a, b := matches[i].keys[k], matches[j].keys[k]
+			if a == nil || b == nil {
+				if (a == nil) == (b == nil) {
+					continue
+				}
+				//like Postgres NULLs are larger than any value unless NullsLast is set
+				nullsFirst := order.Desc && !order.NullsLast
+				return (a == nil) == nullsFirst
+			}
+			cmp, err := compareValues(a, b)
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			if cmp != 0 {
+				return (cmp < 0) != order.Desc
+			}
+		}
+		return false
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	items := []db.Task{}
+	for _, m := range page(matches, arg.Limit, arg.Offset) {
+		items = append(items, m.task)
+	}
+	return items, nil
+}
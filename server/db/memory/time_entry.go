@@ -0,0 +1,142 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// checkTimeEntry checks the foreign keys of a time entry and that the owner
+// runs one timer at a time
+func (q *Queries) checkTimeEntry(entry db.TimeEntry) error {
+	if err := q.userExists("time_entries", "owner_id", entry.OwnerID); err != nil {
+		return err
+	}
+	if _, ok := q.data.tasks[entry.TaskID]; !ok {
+		return foreignKeyViolation("time_entries", "time_entries_task_id_fkey")
+	}
+	if entry.EndedAt.Valid {
+		return nil
+	}
+	for _, other := range q.data.timeEntries {
+		if other.ID != entry.ID && other.OwnerID == entry.OwnerID && !other.EndedAt.Valid {
+			return uniqueViolation("time_entries_owner_id_idx")
+		}
+	}
+	return nil
+}
+
+func lessStartedAt(a, b db.TimeEntry) bool {
+	if !a.StartedAt.Equal(b.StartedAt) {
+		return a.StartedAt.Before(b.StartedAt)
+	}
+	return a.ID < b.ID
+}
+
+func (q *Queries) CreateTimeEntry(ctx context.Context, arg db.CreateTimeEntryParams) (db.TimeEntry, error) {
+	defer q.lock()()
+	entry := db.TimeEntry{
+		OwnerID:   arg.OwnerID,
+		TaskID:    arg.TaskID,
+		StartedAt: arg.StartedAt,
+		EndedAt:   arg.EndedAt,
+		Note:      arg.Note,
+		CreatedAt: now(),
+	}
+	if err := q.checkTimeEntry(entry); err != nil {
+		return db.TimeEntry{}, err
+	}
+	entry.ID = q.data.nextID("time_entries")
+	q.data.timeEntries[entry.ID] = entry
+	return entry, nil
+}
+
+func (q *Queries) GetTimeEntry(ctx context.Context, id int64) (db.TimeEntry, error) {
+	defer q.lock()()
+	entry, ok := q.data.timeEntries[id]
+	if !ok {
+		return db.TimeEntry{}, sql.ErrNoRows
+	}
+	return entry, nil
+}
+
+func (q *Queries) GetRunningTimeEntry(ctx context.Context, ownerID int64) (db.TimeEntry, error) {
+	defer q.lock()()
+	for _, entry := range q.data.timeEntries {
+		if entry.OwnerID == ownerID && !entry.EndedAt.Valid {
+			return entry, nil
+		}
+	}
+	return db.TimeEntry{}, sql.ErrNoRows
+}
+
+func (q *Queries) GetTimeEntryListByTask(ctx context.Context, taskID int64) ([]db.TimeEntry, error) {
+	defer q.lock()()
+	return selectRows(q.data.timeEntries, func(entry db.TimeEntry) bool {
+		return entry.TaskID == taskID
+	}, lessStartedAt), nil
+}
+
+func (q *Queries) GetTimeEntriesBetween(ctx context.Context, arg db.GetTimeEntriesBetweenParams) ([]db.GetTimeEntriesBetweenRow, error) {
+	defer q.lock()()
+	items := []db.GetTimeEntriesBetweenRow{}
+	for _, entry := range selectRows(q.data.timeEntries, func(entry db.TimeEntry) bool {
+		return entry.OwnerID == arg.OwnerID && entry.StartedAt.Before(arg.ToTime) &&
+			(!entry.EndedAt.Valid || entry.EndedAt.Time.After(arg.FromTime))
+	}, lessStartedAt) {
+		task := q.data.tasks[entry.TaskID]
+		items = append(items, db.GetTimeEntriesBetweenRow{
+			ID:              entry.ID,
+			TaskID:          entry.TaskID,
+			StartedAt:       entry.StartedAt,
+			EndedAt:         entry.EndedAt,
+			Note:            entry.Note,
+			Body:            task.Body,
+			ProjectID:       task.ProjectID,
+			EstimateMinutes: task.EstimateMinutes,
+		})
+	}
+	return items, nil
+}
+
+func (q *Queries) StopTimeEntry(ctx context.Context, arg db.StopTimeEntryParams) (db.TimeEntry, error) {
+	defer q.lock()()
+	for _, entry := range q.data.timeEntries {
+		if entry.OwnerID != arg.OwnerID || entry.EndedAt.Valid {
+			continue
+		}
+		endedAt := arg.EndedAt
+		if endedAt.Before(entry.StartedAt) {
+			endedAt = entry.StartedAt
+		}
+		entry.EndedAt = sql.NullTime{Time: endedAt, Valid: true}
+		q.data.timeEntries[entry.ID] = entry
+		return entry, nil
+	}
+	return db.TimeEntry{}, sql.ErrNoRows
+}
+
+func (q *Queries) UpdateTimeEntry(ctx context.Context, arg db.UpdateTimeEntryParams) (db.TimeEntry, error) {
+	defer q.lock()()
+	entry, ok := q.data.timeEntries[arg.ID]
+	if !ok || entry.OwnerID != arg.OwnerID {
+		return db.TimeEntry{}, sql.ErrNoRows
+	}
+	entry.StartedAt = arg.StartedAt
+	entry.EndedAt = arg.EndedAt
+	entry.Note = arg.Note
+	if err := q.checkTimeEntry(entry); err != nil {
+		return db.TimeEntry{}, err
+	}
+	q.data.timeEntries[entry.ID] = entry
+	return entry, nil
+}
+
+func (q *Queries) DeleteTimeEntry(ctx context.Context, arg db.DeleteTimeEntryParams) error {
+	defer q.lock()()
+	if entry, ok := q.data.timeEntries[arg.ID]; ok && entry.OwnerID == arg.OwnerID {
+		delete(q.data.timeEntries, entry.ID)
+	}
+	return nil
+}
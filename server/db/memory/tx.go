@@ -0,0 +1,492 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+func (store *Store) CreateProjectTx(ctx context.Context, arg db.CreateProjectTxParams) (db.CreateProjectTxResult, error) {
+	var result db.CreateProjectTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Project, err = q.CreateProject(ctx, arg.CreateProjectParams)
+		if err != nil {
+			return err
+		}
+
+		result.Statuses = make([]db.ProjectStatus, 0, len(arg.Statuses))
+		for _, statusArg := range arg.Statuses {
+			statusArg.ProjectID = result.Project.ID
+			status, err := q.CreateProjectStatus(ctx, statusArg)
+			if err != nil {
+				return err
+			}
+			result.Statuses = append(result.Statuses, status)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (store *Store) UpdateProjectStatusTx(ctx context.Context, arg db.UpdateProjectStatusParams) (db.ProjectStatus, error) {
+	var result db.ProjectStatus
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.UpdateProjectStatus(ctx, arg)
+		if err != nil {
+			return err
+		}
+		_, err = q.SyncTasksWithStatus(ctx, result.ID)
+		return err
+	})
+
+	return result, err
+}
+
+func (store *Store) ReplaceStatusTransitionsTx(ctx context.Context, arg db.ReplaceStatusTransitionsTxParams) ([]db.StatusTransition, error) {
+	transitions := make([]db.StatusTransition, 0, len(arg.Transitions))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.LockProject(ctx, arg.ProjectID); err != nil {
+			return err
+		}
+		if err := q.DeleteStatusTransitions(ctx, arg.ProjectID); err != nil {
+			return err
+		}
+		for _, transitionArg := range arg.Transitions {
+			transitionArg.ProjectID = arg.ProjectID
+			transition, err := q.CreateStatusTransition(ctx, transitionArg)
+			if err != nil {
+				return err
+			}
+			transitions = append(transitions, transition)
+		}
+		return nil
+	})
+
+	return transitions, err
+}
+
+func (store *Store) SetCustomFieldValuesTx(ctx context.Context, arg db.SetCustomFieldValuesTxParams) ([]db.TaskCustomFieldValue, error) {
+	var values []db.TaskCustomFieldValue
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for fieldID, value := range arg.Values {
+			if value == nil {
+				err := q.DeleteTaskCustomFieldValue(ctx, db.DeleteTaskCustomFieldValueParams{
+					TaskID:  arg.TaskID,
+					FieldID: fieldID,
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			_, err := q.UpsertTaskCustomFieldValue(ctx, db.UpsertTaskCustomFieldValueParams{
+				TaskID:  arg.TaskID,
+				FieldID: fieldID,
+				Value:   value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		values, err = q.GetTaskCustomFieldValues(ctx, arg.TaskID)
+		return err
+	})
+
+	return values, err
+}
+
+func (store *Store) SetTaskLabelsTx(ctx context.Context, arg db.SetTaskLabelsTxParams) ([]db.Label, error) {
+	labels := make([]db.Label, 0, len(arg.Names))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteTaskLabels(ctx, arg.TaskID); err != nil {
+			return err
+		}
+		for _, name := range arg.Names {
+			label, err := q.addTaskLabel(ctx, arg.TaskID, arg.OwnerID, name)
+			if err != nil {
+				return err
+			}
+			labels = append(labels, label)
+		}
+		return nil
+	})
+
+	return labels, err
+}
+
+// addTaskLabel labels a task, the label is created when the owner has none of that name
+func (q *Queries) addTaskLabel(ctx context.Context, taskId int64, ownerId int64, name string) (db.Label, error) {
+	label, err := q.UpsertLabel(ctx, db.UpsertLabelParams{
+		OwnerID: ownerId,
+		Name:    name,
+	})
+	if err != nil {
+		return db.Label{}, err
+	}
+	err = q.AddTaskLabel(ctx, db.AddTaskLabelParams{
+		TaskID:  taskId,
+		LabelID: label.ID,
+	})
+	return label, err
+}
+
+func (store *Store) DeliverReminderTx(ctx context.Context, arg db.DeliverReminderTxParams) (db.DeliverReminderTxResult, error) {
+	var result db.DeliverReminderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		reminder, err := q.ClaimDueReminder(ctx, db.ClaimDueReminderParams{
+			Now:         sql.NullTime{Time: arg.Now, Valid: true},
+			MaxAttempts: arg.MaxAttempts,
+		})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.Found = true
+		result.Reminder = reminder
+
+		if result.DeliveryErr = arg.Deliver(reminder); result.DeliveryErr != nil {
+			return q.RecordReminderFailure(ctx, db.RecordReminderFailureParams{
+				ID:        reminder.ID,
+				LastError: result.DeliveryErr.Error(),
+				FireAt:    sql.NullTime{Time: arg.RetryAt(reminder.Attempts + 1), Valid: true},
+			})
+		}
+		return q.MarkReminderSent(ctx, db.MarkReminderSentParams{
+			ID:     reminder.ID,
+			SentAt: sql.NullTime{Time: arg.Now, Valid: true},
+		})
+	})
+
+	return result, err
+}
+
+func (store *Store) DeliverDigestTx(ctx context.Context, arg db.DeliverDigestTxParams) (db.DeliverDigestTxResult, error) {
+	var result db.DeliverDigestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		digest, err := q.ClaimDueDigest(ctx, sql.NullTime{Time: arg.Now, Valid: true})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.Found = true
+		result.Digest = digest
+
+		if result.DeliveryErr = arg.Deliver(digest); result.DeliveryErr != nil {
+			return q.SetDigestNextSendAt(ctx, db.SetDigestNextSendAtParams{
+				UserID:     digest.UserID,
+				NextSendAt: sql.NullTime{Time: arg.RetryAt, Valid: true},
+			})
+		}
+		return q.SetDigestNextSendAt(ctx, db.SetDigestNextSendAtParams{
+			UserID:     digest.UserID,
+			NextSendAt: sql.NullTime{Time: arg.NextSendAt(digest), Valid: true},
+			LastSentAt: sql.NullTime{Time: arg.Now, Valid: true},
+		})
+	})
+
+	return result, err
+}
+
+func (store *Store) GetSyncChangesTx(ctx context.Context, arg db.GetSyncChangesTxParams) (db.GetSyncChangesTxResult, error) {
+	var result db.GetSyncChangesTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Xmin, err = q.GetSyncSnapshotXmin(ctx)
+		if err != nil {
+			return err
+		}
+		result.Changes, err = q.GetSyncChanges(ctx, db.GetSyncChangesParams{
+			OwnerID:  arg.OwnerID,
+			Since:    arg.Since,
+			AfterSeq: arg.AfterSeq,
+			RowLimit: arg.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		ids := map[string][]int64{}
+		for _, change := range result.Changes {
+			if !change.Deleted {
+				ids[change.Entity] = append(ids[change.Entity], change.EntityID)
+			}
+		}
+		if result.Tasks, err = q.GetTasksByIds(ctx, ids["task"]); err != nil {
+			return err
+		}
+		if result.Labels, err = q.GetLabelsByIds(ctx, ids["label"]); err != nil {
+			return err
+		}
+		result.Projects, err = q.GetProjectsByIds(ctx, ids["project"])
+		return err
+	})
+
+	return result, err
+}
+
+func (store *Store) DeliverWebhookTx(ctx context.Context, arg db.DeliverWebhookTxParams) (db.DeliverWebhookTxResult, error) {
+	var result db.DeliverWebhookTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		delivery, err := q.ClaimDueWebhookDelivery(ctx, sql.NullTime{Time: arg.Now, Valid: true})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.Found = true
+		result.Delivery = delivery
+
+		result.Status, result.DeliveryErr = arg.Deliver(delivery)
+		attempt := db.RecordWebhookDeliveryAttemptParams{
+			ID:             delivery.ID,
+			ResponseStatus: sql.NullInt32{Int32: int32(result.Status), Valid: result.Status != 0},
+		}
+		if result.DeliveryErr != nil {
+			attempt.LastError = result.DeliveryErr.Error()
+			retryAt, retry := arg.RetryAt(delivery.Attempts + 1)
+			attempt.NextAttemptAt = sql.NullTime{Time: retryAt, Valid: retry}
+		} else {
+			attempt.DeliveredAt = sql.NullTime{Time: arg.Now, Valid: true}
+		}
+		return q.RecordWebhookDeliveryAttempt(ctx, attempt)
+	})
+
+	return result, err
+}
+
+func (store *Store) StartTimerTx(ctx context.Context, arg db.StartTimerTxParams) (db.StartTimerTxResult, error) {
+	var result db.StartTimerTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		stopped, err := q.StopTimeEntry(ctx, db.StopTimeEntryParams{
+			EndedAt: arg.Now,
+			OwnerID: arg.OwnerID,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil {
+			result.Stopped = &stopped
+		}
+
+		result.Started, err = q.CreateTimeEntry(ctx, db.CreateTimeEntryParams{
+			OwnerID:   arg.OwnerID,
+			TaskID:    arg.TaskID,
+			StartedAt: arg.Now,
+			Note:      arg.Note,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func (store *Store) CreateTaskTx(ctx context.Context, arg db.CreateTaskParams) (db.Task, error) {
+	var result db.Task
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.checkWipLimit(ctx, arg.StatusID); err != nil {
+			return err
+		}
+		var err error
+		result, err = q.CreateTask(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+func (store *Store) UpdateTaskTx(ctx context.Context, arg db.UpdateTaskTxParams) (db.Task, error) {
+	var result db.Task
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.Transition != nil {
+			if err := q.checkTransition(ctx, *arg.Transition); err != nil {
+				return err
+			}
+		}
+		if arg.CheckWipLimit {
+			if err := q.checkWipLimit(ctx, arg.StatusID); err != nil {
+				return err
+			}
+		}
+		var err error
+		result, err = q.UpdateTask(ctx, arg.UpdateTaskParams)
+		return err
+	})
+
+	return result, err
+}
+
+// checkWipLimit fails with db.ErrWipLimitReached when the status is full
+func (q *Queries) checkWipLimit(ctx context.Context, statusId sql.NullInt64) error {
+	if !statusId.Valid {
+		return nil
+	}
+	status, err := q.LockProjectStatus(ctx, statusId.Int64)
+	if err != nil {
+		return err
+	}
+	if status.WipLimit == 0 {
+		return nil
+	}
+	count, err := q.CountTasksByStatus(ctx, statusId)
+	if err != nil {
+		return err
+	}
+	if count >= int64(status.WipLimit) {
+		return db.ErrWipLimitReached
+	}
+	return nil
+}
+
+// checkTransition fails with db.ErrTransitionNotAllowed when the workflow of the project
+// does not allow the move
+func (q *Queries) checkTransition(ctx context.Context, arg db.CreateStatusTransitionParams) error {
+	if _, err := q.LockProjectForShare(ctx, arg.ProjectID); err != nil {
+		return err
+	}
+	transitions, err := q.GetStatusTransitionList(ctx, arg.ProjectID)
+	if err != nil {
+		return err
+	}
+	restricted := false
+	for _, transition := range transitions {
+		if transition.FromStatusID != arg.FromStatusID {
+			continue
+		}
+		if transition.ToStatusID == arg.ToStatusID {
+			return nil
+		}
+		restricted = true
+	}
+	if restricted {
+		return db.ErrTransitionNotAllowed
+	}
+	return nil
+}
+
+func (store *Store) InstantiateTemplateTx(ctx context.Context, arg db.InstantiateTemplateTxParams) (db.InstantiateTemplateTxResult, error) {
+	result := db.InstantiateTemplateTxResult{Labels: map[int64][]string{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		return q.createTemplateTask(ctx, arg.OwnerID, arg.Root, sql.NullInt64{}, &result)
+	})
+
+	return result, err
+}
+
+// createTemplateTask creates a task of a template and then its subtasks
+func (q *Queries) createTemplateTask(ctx context.Context, ownerId int64, t db.TemplateTask, parentId sql.NullInt64, result *db.InstantiateTemplateTxResult) error {
+	params := t.Task
+	params.OwnerID = ownerId
+	params.ParentID = parentId
+	if err := q.checkWipLimit(ctx, params.StatusID); err != nil {
+		return err
+	}
+	task, err := q.CreateTask(ctx, params)
+	if err != nil {
+		return err
+	}
+	result.Tasks = append(result.Tasks, task)
+
+	for _, name := range t.Labels {
+		label, err := q.addTaskLabel(ctx, task.ID, ownerId, name)
+		if err != nil {
+			return err
+		}
+		result.Labels[task.ID] = append(result.Labels[task.ID], label.Name)
+	}
+
+	for _, subtask := range t.Subtasks {
+		err := q.createTemplateTask(ctx, ownerId, subtask, sql.NullInt64{Int64: task.ID, Valid: true}, result)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *Store) EnableTotpTx(ctx context.Context, arg db.EnableTotpTxParams) (db.UserTotp, error) {
+	var result db.UserTotp
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.EnableUserTotp(ctx, db.EnableUserTotpParams{
+			UserID:   arg.UserID,
+			LastStep: sql.NullInt64{Int64: arg.Step, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return q.replaceRecoveryCodes(ctx, arg.UserID, arg.CodeHashes)
+	})
+
+	return result, err
+}
+
+func (store *Store) ReplaceRecoveryCodesTx(ctx context.Context, arg db.ReplaceRecoveryCodesTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		return q.replaceRecoveryCodes(ctx, arg.UserID, arg.CodeHashes)
+	})
+}
+
+func (q *Queries) replaceRecoveryCodes(ctx context.Context, userId int64, codeHashes [][]byte) error {
+	if err := q.DeleteRecoveryCodes(ctx, userId); err != nil {
+		return err
+	}
+	return q.CreateRecoveryCodes(ctx, db.CreateRecoveryCodesParams{
+		UserID:     userId,
+		CodeHashes: codeHashes,
+	})
+}
+
+func (store *Store) DisableTotpTx(ctx context.Context, userId int64) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteUserTotp(ctx, userId); err != nil {
+			return err
+		}
+		return q.DeleteRecoveryCodes(ctx, userId)
+	})
+}
+
+func (store *Store) RotateSessionTx(ctx context.Context, arg db.RotateSessionTxParams) (db.Session, error) {
+	var result db.Session
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		replaced, err := q.ReplaceSession(ctx, db.ReplaceSessionParams{
+			ReplacedBy: arg.Next.ID,
+			ID:         arg.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if replaced == 0 {
+			return sql.ErrNoRows
+		}
+		result, err = q.CreateSession(ctx, arg.Next)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,156 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// userExists checks a foreign key to users
+func (q *Queries) userExists(table string, column string, userId int64) error {
+	if _, ok := q.data.users[userId]; !ok {
+		return foreignKeyViolation(table, table+"_"+column+"_fkey")
+	}
+	return nil
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	defer q.lock()()
+	for _, user := range q.data.users {
+		if user.Email == arg.Email {
+			return db.User{}, uniqueViolation("users_email_key")
+		}
+	}
+	user := db.User{
+		ID:                q.data.nextID("users"),
+		Username:          arg.Username,
+		Email:             arg.Email,
+		HashedPassword:    arg.HashedPassword,
+		PasswordChangedAt: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:         now(),
+	}
+	q.data.users[user.ID] = user
+	return user, nil
+}
+
+// findUser is WHERE id = $1 OR email = $2 LIMIT 1
+func (q *Queries) findUser(id int64, email string) (db.User, bool) {
+	for _, user := range selectRows(q.data.users, nil, func(a, b db.User) bool { return a.ID < b.ID }) {
+		if user.ID == id || user.Email == email {
+			return user, true
+		}
+	}
+	return db.User{}, false
+}
+
+func (q *Queries) GetUser(ctx context.Context, arg db.GetUserParams) (db.User, error) {
+	defer q.lock()()
+	user, ok := q.findUser(arg.ID, arg.Email)
+	if !ok {
+		return db.User{}, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+	defer q.lock()()
+	user, ok := q.findUser(arg.ID, arg.Email)
+	if !ok {
+		return db.User{}, sql.ErrNoRows
+	}
+	if arg.NewEmail.Valid && arg.NewEmail.String != user.Email {
+		for _, other := range q.data.users {
+			if other.Email == arg.NewEmail.String {
+				return db.User{}, uniqueViolation("users_email_key")
+			}
+		}
+		//password reset sessions reference the email without ON UPDATE CASCADE
+		if _, ok := q.data.passwordResetSessions[user.Email]; ok {
+			return db.User{}, referencedViolation("users", "password_reset_sessions_email_fkey")
+		}
+		user.Email = arg.NewEmail.String
+	}
+	if arg.Username.Valid {
+		user.Username = arg.Username.String
+	}
+	if arg.HashedPassword.Valid {
+		user.HashedPassword = arg.HashedPassword.String
+	}
+	if arg.PasswordChangedAt.Valid {
+		user.PasswordChangedAt = arg.PasswordChangedAt.Time
+	}
+	q.data.users[user.ID] = user
+	return user, nil
+}
+
+func (q *Queries) ClaimVerificationEmail(ctx context.Context, arg db.ClaimVerificationEmailParams) (db.User, error) {
+	defer q.lock()()
+	user, ok := q.data.users[arg.ID]
+	if !ok || user.EmailVerifiedAt.Valid ||
+		(user.VerificationSentAt.Valid && user.VerificationSentAt.Time.After(arg.ResendAfter)) {
+		return db.User{}, sql.ErrNoRows
+	}
+	user.VerificationSentAt = sql.NullTime{Time: arg.Now, Valid: true}
+	q.data.users[user.ID] = user
+	return user, nil
+}
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg db.VerifyUserEmailParams) (db.User, error) {
+	defer q.lock()()
+	user, ok := q.data.users[arg.ID]
+	if !ok || user.Email != arg.Email {
+		return db.User{}, sql.ErrNoRows
+	}
+	if !user.EmailVerifiedAt.Valid {
+		user.EmailVerifiedAt = sql.NullTime{Time: now(), Valid: true}
+	}
+	q.data.users[user.ID] = user
+	return user, nil
+}
+
+func (q *Queries) CreatePasswordResetSession(ctx context.Context, arg db.CreatePasswordResetSessionParams) (db.PasswordResetSession, error) {
+	defer q.lock()()
+	if _, ok := q.data.passwordResetSessions[arg.Email]; ok {
+		return db.PasswordResetSession{}, uniqueViolation("password_reset_sessions_pkey")
+	}
+	if _, ok := q.findUser(0, arg.Email); !ok {
+		return db.PasswordResetSession{}, foreignKeyViolation("password_reset_sessions", "password_reset_sessions_email_fkey")
+	}
+	session := db.PasswordResetSession{
+		Email:     arg.Email,
+		Otp:       arg.Otp,
+		ExpiresAt: arg.ExpiresAt,
+		CreatedAt: now(),
+	}
+	q.data.passwordResetSessions[session.Email] = session
+	return session, nil
+}
+
+func (q *Queries) GetPasswordResetSession(ctx context.Context, email string) (db.PasswordResetSession, error) {
+	defer q.lock()()
+	session, ok := q.data.passwordResetSessions[email]
+	if !ok {
+		return db.PasswordResetSession{}, sql.ErrNoRows
+	}
+	return session, nil
+}
+
+func (q *Queries) UpdatePasswordResetSession(ctx context.Context, arg db.UpdatePasswordResetSessionParams) (db.PasswordResetSession, error) {
+	defer q.lock()()
+	session, ok := q.data.passwordResetSessions[arg.Email]
+	if !ok {
+		return db.PasswordResetSession{}, sql.ErrNoRows
+	}
+	session.Otp = arg.Otp
+	session.ExpiresAt = arg.ExpiresAt
+	q.data.passwordResetSessions[session.Email] = session
+	return session, nil
+}
+
+func (q *Queries) DeletePasswordResetSession(ctx context.Context, email string) error {
+	defer q.lock()()
+	delete(q.data.passwordResetSessions, email)
+	return nil
+}
@@ -0,0 +1,211 @@
+package memdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"slices"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// deleteWebhook deletes a webhook with its deliveries
+func (q *Queries) deleteWebhook(id int64) {
+	for deliveryId, delivery := range q.data.webhookDeliveries {
+		if delivery.WebhookID == id {
+			delete(q.data.webhookDeliveries, deliveryId)
+		}
+	}
+	delete(q.data.webhooks, id)
+}
+
+func lessWebhookID(a, b db.Webhook) bool {
+	return a.ID < b.ID
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+	defer q.lock()()
+	if err := q.userExists("webhooks", "owner_id", arg.OwnerID); err != nil {
+		return db.Webhook{}, err
+	}
+	if arg.ProjectID.Valid {
+		if err := q.projectExists("webhooks", arg.ProjectID.Int64); err != nil {
+			return db.Webhook{}, err
+		}
+	}
+	webhook := db.Webhook{
+		ID:        q.data.nextID("webhooks"),
+		OwnerID:   arg.OwnerID,
+		ProjectID: arg.ProjectID,
+		Url:       arg.Url,
+		Events:    append([]string{}, arg.Events...),
+		Secret:    arg.Secret,
+		Active:    true,
+		CreatedAt: now(),
+	}
+	q.data.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+func (q *Queries) GetWebhook(ctx context.Context, id int64) (db.Webhook, error) {
+	defer q.lock()()
+	webhook, ok := q.data.webhooks[id]
+	if !ok {
+		return db.Webhook{}, sql.ErrNoRows
+	}
+	return webhook, nil
+}
+
+func (q *Queries) GetWebhookList(ctx context.Context, ownerID int64) ([]db.Webhook, error) {
+	defer q.lock()()
+	return selectRows(q.data.webhooks, func(webhook db.Webhook) bool {
+		return webhook.OwnerID == ownerID
+	}, lessWebhookID), nil
+}
+
+func (q *Queries) GetWebhooksForEvent(ctx context.Context, arg db.GetWebhooksForEventParams) ([]db.Webhook, error) {
+	defer q.lock()()
+	return selectRows(q.data.webhooks, func(webhook db.Webhook) bool {
+		return webhook.OwnerID == arg.OwnerID && webhook.Active &&
+			(!webhook.ProjectID.Valid || (arg.ProjectID.Valid && webhook.ProjectID.Int64 == arg.ProjectID.Int64)) &&
+			(len(webhook.Events) == 0 || slices.Contains(webhook.Events, arg.Event))
+	}, lessWebhookID), nil
+}
+
+func (q *Queries) UpdateWebhook(ctx context.Context, arg db.UpdateWebhookParams) (db.Webhook, error) {
+	defer q.lock()()
+	webhook, ok := q.data.webhooks[arg.ID]
+	if !ok || webhook.OwnerID != arg.OwnerID {
+		return db.Webhook{}, sql.ErrNoRows
+	}
+	if arg.ProjectID.Valid {
+		if err := q.projectExists("webhooks", arg.ProjectID.Int64); err != nil {
+			return db.Webhook{}, err
+		}
+	}
+	webhook.ProjectID = arg.ProjectID
+	webhook.Url = arg.Url
+	webhook.Events = append([]string{}, arg.Events...)
+	webhook.Active = arg.Active
+	q.data.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+func (q *Queries) DeleteWebhook(ctx context.Context, arg db.DeleteWebhookParams) error {
+	defer q.lock()()
+	if webhook, ok := q.data.webhooks[arg.ID]; ok && webhook.OwnerID == arg.OwnerID {
+		q.deleteWebhook(webhook.ID)
+	}
+	return nil
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+	defer q.lock()()
+	if _, ok := q.data.webhooks[arg.WebhookID]; !ok {
+		return db.WebhookDelivery{}, foreignKeyViolation("webhook_deliveries", "webhook_deliveries_webhook_id_fkey")
+	}
+	delivery := db.WebhookDelivery{
+		ID:            q.data.nextID("webhook_deliveries"),
+		WebhookID:     arg.WebhookID,
+		Event:         arg.Event,
+		Payload:       bytes.Clone(arg.Payload),
+		NextAttemptAt: arg.NextAttemptAt,
+		CreatedAt:     now(),
+	}
+	q.data.webhookDeliveries[delivery.ID] = delivery
+	return delivery, nil
+}
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id int64) (db.WebhookDelivery, error) {
+	defer q.lock()()
+	delivery, ok := q.data.webhookDeliveries[id]
+	if !ok {
+		return db.WebhookDelivery{}, sql.ErrNoRows
+	}
+	return delivery, nil
+}
+
+func (q *Queries) GetWebhookDeliveryList(ctx context.Context, arg db.GetWebhookDeliveryListParams) ([]db.WebhookDelivery, error) {
+	defer q.lock()()
+	deliveries := selectRows(q.data.webhookDeliveries, func(delivery db.WebhookDelivery) bool {
+		return delivery.WebhookID == arg.WebhookID
+	}, func(a, b db.WebhookDelivery) bool {
+		return a.ID > b.ID
+	})
+	return page(deliveries, arg.Limit, arg.Offset), nil
+}
+
+func (q *Queries) ClaimDueWebhookDelivery(ctx context.Context, now sql.NullTime) (db.ClaimDueWebhookDeliveryRow, error) {
+	defer q.lock()()
+	deliveries := selectRows(q.data.webhookDeliveries, func(delivery db.WebhookDelivery) bool {
+		return delivery.NextAttemptAt.Valid && now.Valid && !delivery.NextAttemptAt.Time.After(now.Time) &&
+			q.data.webhooks[delivery.WebhookID].Active
+	}, func(a, b db.WebhookDelivery) bool {
+		return a.NextAttemptAt.Time.Before(b.NextAttemptAt.Time)
+	})
+	if len(deliveries) == 0 {
+		return db.ClaimDueWebhookDeliveryRow{}, sql.ErrNoRows
+	}
+	delivery := deliveries[0]
+	webhook := q.data.webhooks[delivery.WebhookID]
+	return db.ClaimDueWebhookDeliveryRow{
+		ID:        delivery.ID,
+		WebhookID: delivery.WebhookID,
+		Event:     delivery.Event,
+		Payload:   delivery.Payload,
+		Attempts:  delivery.Attempts,
+		Url:       webhook.Url,
+		Secret:    webhook.Secret,
+	}, nil
+}
+
+func (q *Queries) RecordWebhookDeliveryAttempt(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) error {
+	defer q.lock()()
+	if delivery, ok := q.data.webhookDeliveries[arg.ID]; ok {
+		delivery.Attempts++
+		delivery.ResponseStatus = arg.ResponseStatus
+		delivery.LastError = arg.LastError
+		delivery.NextAttemptAt = arg.NextAttemptAt
+		delivery.DeliveredAt = arg.DeliveredAt
+		q.data.webhookDeliveries[delivery.ID] = delivery
+	}
+	return nil
+}
+
+func (q *Queries) GetInbox(ctx context.Context, userID int64) (db.Inbox, error) {
+	defer q.lock()()
+	inbox, ok := q.data.inboxes[userID]
+	if !ok {
+		return db.Inbox{}, sql.ErrNoRows
+	}
+	return inbox, nil
+}
+
+func (q *Queries) GetInboxByToken(ctx context.Context, token string) (db.Inbox, error) {
+	defer q.lock()()
+	for _, inbox := range q.data.inboxes {
+		if inbox.Token == token {
+			return inbox, nil
+		}
+	}
+	return db.Inbox{}, sql.ErrNoRows
+}
+
+func (q *Queries) UpsertInbox(ctx context.Context, arg db.UpsertInboxParams) (db.Inbox, error) {
+	defer q.lock()()
+	if err := q.userExists("inboxes", "user_id", arg.UserID); err != nil {
+		return db.Inbox{}, err
+	}
+	for _, other := range q.data.inboxes {
+		if other.UserID != arg.UserID && other.Token == arg.Token {
+			return db.Inbox{}, uniqueViolation("inboxes_token_key")
+		}
+	}
+	inbox := db.Inbox{
+		UserID:    arg.UserID,
+		Token:     arg.Token,
+		CreatedAt: now(),
+	}
+	q.data.inboxes[inbox.UserID] = inbox
+	return inbox, nil
+}
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "status_id";
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "project_id";
+DROP TABLE IF EXISTS "status_transitions";
+DROP TABLE IF EXISTS "project_statuses";
+DROP TABLE IF EXISTS "projects";
\ No newline at end of file
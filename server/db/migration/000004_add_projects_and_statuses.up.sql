@@ -0,0 +1,43 @@
+CREATE TABLE "projects" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "owner_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "project_statuses" (
+  "id" bigserial PRIMARY KEY,
+  "project_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "category" varchar NOT NULL,
+  "position" integer NOT NULL DEFAULT 0,
+  "wip_limit" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "project_statuses_category_check" CHECK ("category" IN ('todo', 'in_progress', 'done')),
+  CONSTRAINT "project_statuses_wip_limit_check" CHECK ("wip_limit" >= 0),
+  UNIQUE ("project_id", "name")
+);
+
+CREATE TABLE "status_transitions" (
+  "project_id" bigint NOT NULL,
+  "from_status_id" bigint NOT NULL,
+  "to_status_id" bigint NOT NULL,
+  PRIMARY KEY ("from_status_id", "to_status_id")
+);
+
+ALTER TABLE "tasks" ADD COLUMN "project_id" bigint;
+ALTER TABLE "tasks" ADD COLUMN "status_id" bigint;
+
+CREATE INDEX ON "projects" ("owner_id");
+CREATE INDEX ON "project_statuses" ("project_id");
+CREATE INDEX ON "status_transitions" ("project_id");
+CREATE INDEX ON "tasks" ("project_id");
+CREATE INDEX ON "tasks" ("status_id");
+
+ALTER TABLE "projects" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+ALTER TABLE "project_statuses" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+ALTER TABLE "status_transitions" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+ALTER TABLE "status_transitions" ADD FOREIGN KEY ("from_status_id") REFERENCES "project_statuses" ("id") ON DELETE CASCADE;
+ALTER TABLE "status_transitions" ADD FOREIGN KEY ("to_status_id") REFERENCES "project_statuses" ("id") ON DELETE CASCADE;
+ALTER TABLE "tasks" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE SET NULL;
+ALTER TABLE "tasks" ADD FOREIGN KEY ("status_id") REFERENCES "project_statuses" ("id") ON DELETE SET NULL;
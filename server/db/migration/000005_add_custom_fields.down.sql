@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "task_custom_field_values";
+DROP TABLE IF EXISTS "custom_fields";
\ No newline at end of file
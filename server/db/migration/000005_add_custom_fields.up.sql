@@ -0,0 +1,27 @@
+CREATE TABLE "custom_fields" (
+  "id" bigserial PRIMARY KEY,
+  "project_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "field_type" varchar NOT NULL,
+  "options" jsonb NOT NULL DEFAULT '[]',
+  "rules" jsonb NOT NULL DEFAULT '{}',
+  "position" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "custom_fields_field_type_check" CHECK ("field_type" IN ('text', 'number', 'date', 'select', 'multi_select', 'checkbox', 'user')),
+  UNIQUE ("project_id", "name")
+);
+
+CREATE TABLE "task_custom_field_values" (
+  "task_id" bigint NOT NULL,
+  "field_id" bigint NOT NULL,
+  "value" jsonb NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("task_id", "field_id")
+);
+
+CREATE INDEX ON "custom_fields" ("project_id");
+CREATE INDEX ON "task_custom_field_values" ("field_id");
+
+ALTER TABLE "custom_fields" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+ALTER TABLE "task_custom_field_values" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
+ALTER TABLE "task_custom_field_values" ADD FOREIGN KEY ("field_id") REFERENCES "custom_fields" ("id") ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "saved_filters";
+DROP TABLE IF EXISTS "task_labels";
+DROP TABLE IF EXISTS "labels";
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "priority";
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "due_at";
\ No newline at end of file
@@ -0,0 +1,33 @@
+ALTER TABLE "tasks" ADD COLUMN "due_at" timestamptz;
+ALTER TABLE "tasks" ADD COLUMN "priority" smallint NOT NULL DEFAULT 0;
+
+CREATE TABLE "labels" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("owner_id", "name")
+);
+
+CREATE TABLE "task_labels" (
+  "task_id" bigint NOT NULL,
+  "label_id" bigint NOT NULL,
+  PRIMARY KEY ("task_id", "label_id")
+);
+
+CREATE TABLE "saved_filters" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "query" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("owner_id", "name")
+);
+
+CREATE INDEX ON "tasks" ("due_at");
+CREATE INDEX ON "task_labels" ("label_id");
+
+ALTER TABLE "labels" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
+ALTER TABLE "task_labels" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
+ALTER TABLE "task_labels" ADD FOREIGN KEY ("label_id") REFERENCES "labels" ("id") ON DELETE CASCADE;
+ALTER TABLE "saved_filters" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
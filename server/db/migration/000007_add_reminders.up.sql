@@ -0,0 +1,22 @@
+CREATE TABLE "reminders" (
+  "id" bigserial PRIMARY KEY,
+  "task_id" bigint NOT NULL,
+  "owner_id" bigint NOT NULL,
+  "remind_at" timestamptz,
+  "offset_minutes" int,
+  "fire_at" timestamptz,
+  "sent_at" timestamptz,
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("remind_at" IS NOT NULL OR "offset_minutes" IS NOT NULL)
+);
+
+COMMENT ON COLUMN "reminders"."offset_minutes" IS 'minutes before the due date of the task';
+COMMENT ON COLUMN "reminders"."fire_at" IS 'next delivery time, null while a relative reminder has no due date';
+
+CREATE INDEX ON "reminders" ("task_id");
+CREATE INDEX ON "reminders" ("fire_at") WHERE "sent_at" IS NULL;
+
+ALTER TABLE "reminders" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
+ALTER TABLE "reminders" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id");
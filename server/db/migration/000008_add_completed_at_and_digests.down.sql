@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "digest_preferences";
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "completed_at";
@@ -0,0 +1,21 @@
+ALTER TABLE "tasks" ADD COLUMN "completed_at" timestamptz;
+UPDATE "tasks" SET "completed_at" = "created_at" WHERE "is_done";
+
+CREATE TABLE "digest_preferences" (
+  "user_id" bigint PRIMARY KEY,
+  "frequency" varchar NOT NULL DEFAULT 'off' CHECK ("frequency" IN ('off', 'daily', 'weekly')),
+  "send_minute" int NOT NULL DEFAULT 420 CHECK ("send_minute" >= 0 AND "send_minute" < 1440),
+  "weekday" smallint NOT NULL DEFAULT 1 CHECK ("weekday" >= 0 AND "weekday" <= 6),
+  "timezone" varchar NOT NULL DEFAULT 'UTC',
+  "next_send_at" timestamptz,
+  "last_sent_at" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "digest_preferences"."send_minute" IS 'minutes after midnight in timezone';
+COMMENT ON COLUMN "digest_preferences"."weekday" IS 'day of weekly digests, 0 is Sunday';
+
+CREATE INDEX ON "tasks" ("owner_id", "completed_at");
+CREATE INDEX ON "digest_preferences" ("next_send_at");
+
+ALTER TABLE "digest_preferences" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "task_custom_field_values_sync_change" ON "task_custom_field_values";
+DROP TRIGGER IF EXISTS "task_labels_sync_change" ON "task_labels";
+DROP TRIGGER IF EXISTS "projects_sync_change" ON "projects";
+DROP TRIGGER IF EXISTS "labels_sync_change" ON "labels";
+DROP TRIGGER IF EXISTS "tasks_sync_change" ON "tasks";
+DROP FUNCTION IF EXISTS record_task_sync_change();
+DROP FUNCTION IF EXISTS record_sync_change();
+DROP TABLE IF EXISTS "sync_changes";
+DROP SEQUENCE IF EXISTS "sync_change_seq";
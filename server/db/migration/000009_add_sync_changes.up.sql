@@ -0,0 +1,76 @@
+CREATE SEQUENCE "sync_change_seq";
+
+-- one row per synced entity, a deleted entity keeps its row as a tombstone
+CREATE TABLE "sync_changes" (
+  "entity" varchar NOT NULL CHECK ("entity" IN ('task', 'label', 'project')),
+  "entity_id" bigint NOT NULL,
+  "owner_id" bigint NOT NULL,
+  "seq" bigint NOT NULL DEFAULT (nextval('sync_change_seq')),
+  "change_xid" xid8 NOT NULL DEFAULT (pg_current_xact_id()),
+  "changed_at" timestamptz NOT NULL DEFAULT (now()),
+  "deleted" boolean NOT NULL DEFAULT false,
+  PRIMARY KEY ("entity", "entity_id")
+);
+
+COMMENT ON COLUMN "sync_changes"."seq" IS 'increases with every change, used to order changes and detect conflicts';
+COMMENT ON COLUMN "sync_changes"."change_xid" IS 'transaction of the last change, sync tokens hold the oldest transaction still running';
+
+CREATE INDEX ON "sync_changes" ("owner_id", "seq");
+
+ALTER TABLE "sync_changes" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE FUNCTION record_sync_change() RETURNS trigger AS $$
+DECLARE
+  changed record;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed := OLD;
+  ELSE
+    changed := NEW;
+  END IF;
+  INSERT INTO sync_changes (entity, entity_id, owner_id, deleted)
+  VALUES (TG_ARGV[0], changed.id, changed.owner_id, TG_OP = 'DELETE')
+  ON CONFLICT (entity, entity_id) DO UPDATE
+  SET
+    seq = nextval('sync_change_seq'),
+    change_xid = pg_current_xact_id(),
+    changed_at = now(),
+    deleted = EXCLUDED.deleted;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- labels and custom field values are part of the synced task
+CREATE FUNCTION record_task_sync_change() RETURNS trigger AS $$
+DECLARE
+  changed_task_id bigint;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed_task_id := OLD.task_id;
+  ELSE
+    changed_task_id := NEW.task_id;
+  END IF;
+  UPDATE sync_changes
+  SET
+    seq = nextval('sync_change_seq'),
+    change_xid = pg_current_xact_id(),
+    changed_at = now()
+  WHERE entity = 'task' AND entity_id = changed_task_id AND NOT deleted;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "tasks_sync_change" AFTER INSERT OR UPDATE OR DELETE ON "tasks"
+FOR EACH ROW EXECUTE FUNCTION record_sync_change('task');
+CREATE TRIGGER "labels_sync_change" AFTER INSERT OR UPDATE OR DELETE ON "labels"
+FOR EACH ROW EXECUTE FUNCTION record_sync_change('label');
+CREATE TRIGGER "projects_sync_change" AFTER INSERT OR UPDATE OR DELETE ON "projects"
+FOR EACH ROW EXECUTE FUNCTION record_sync_change('project');
+CREATE TRIGGER "task_labels_sync_change" AFTER INSERT OR DELETE ON "task_labels"
+FOR EACH ROW EXECUTE FUNCTION record_task_sync_change();
+CREATE TRIGGER "task_custom_field_values_sync_change" AFTER INSERT OR UPDATE OR DELETE ON "task_custom_field_values"
+FOR EACH ROW EXECUTE FUNCTION record_task_sync_change();
+
+INSERT INTO sync_changes (entity, entity_id, owner_id) SELECT 'project', id, owner_id FROM projects ORDER BY id;
+INSERT INTO sync_changes (entity, entity_id, owner_id) SELECT 'label', id, owner_id FROM labels ORDER BY id;
+INSERT INTO sync_changes (entity, entity_id, owner_id) SELECT 'task', id, owner_id FROM tasks ORDER BY id;
@@ -0,0 +1,37 @@
+CREATE TABLE "webhooks" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "project_id" bigint,
+  "url" varchar NOT NULL,
+  "events" varchar[] NOT NULL DEFAULT '{}',
+  "secret" varchar NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "webhooks"."project_id" IS 'only tasks of the project trigger the webhook, null for every task of the owner';
+COMMENT ON COLUMN "webhooks"."events" IS 'event types sent to the webhook, empty for all';
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "webhook_id" bigint NOT NULL,
+  "event" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamptz,
+  "delivered_at" timestamptz,
+  "response_status" int,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "webhook_deliveries"."next_attempt_at" IS 'null once delivered or given up';
+COMMENT ON COLUMN "webhook_deliveries"."response_status" IS 'HTTP status of the last attempt, null when the request failed';
+
+CREATE INDEX ON "webhooks" ("owner_id");
+CREATE INDEX ON "webhook_deliveries" ("webhook_id", "id");
+CREATE INDEX ON "webhook_deliveries" ("next_attempt_at") WHERE "next_attempt_at" IS NOT NULL;
+
+ALTER TABLE "webhooks" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "webhooks" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id") ON DELETE CASCADE;
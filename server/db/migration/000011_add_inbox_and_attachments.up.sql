@@ -0,0 +1,22 @@
+CREATE TABLE "inboxes" (
+  "user_id" bigint PRIMARY KEY,
+  "token" varchar UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "inboxes"."token" IS 'secret in the inbox url and the local part of the inbox email address';
+
+CREATE TABLE "task_attachments" (
+  "id" bigserial PRIMARY KEY,
+  "task_id" bigint NOT NULL,
+  "filename" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size" bigint NOT NULL,
+  "content" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "task_attachments" ("task_id");
+
+ALTER TABLE "inboxes" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "task_attachments" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
@@ -0,0 +1,22 @@
+ALTER TABLE "tasks" ADD COLUMN "estimate_minutes" int CHECK ("estimate_minutes" >= 0);
+
+CREATE TABLE "time_entries" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "task_id" bigint NOT NULL,
+  "started_at" timestamptz NOT NULL,
+  "ended_at" timestamptz,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("ended_at" IS NULL OR "ended_at" >= "started_at")
+);
+
+COMMENT ON COLUMN "time_entries"."ended_at" IS 'null while the timer is running';
+
+-- a user runs one timer at a time
+CREATE UNIQUE INDEX ON "time_entries" ("owner_id") WHERE "ended_at" IS NULL;
+CREATE INDEX ON "time_entries" ("owner_id", "started_at");
+CREATE INDEX ON "time_entries" ("task_id");
+
+ALTER TABLE "time_entries" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "time_entries" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
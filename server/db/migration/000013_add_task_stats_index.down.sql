@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "tasks_owner_id_created_at_idx";
@@ -0,0 +1,2 @@
+-- statistics count the tasks a user created per day
+CREATE INDEX ON "tasks" ("owner_id", "created_at");
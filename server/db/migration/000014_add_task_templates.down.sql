@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "task_templates";
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "parent_id";
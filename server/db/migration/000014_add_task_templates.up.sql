@@ -0,0 +1,21 @@
+ALTER TABLE "tasks" ADD COLUMN "parent_id" bigint;
+
+CREATE TABLE "task_templates" (
+  "id" bigserial PRIMARY KEY,
+  "owner_id" bigint NOT NULL,
+  "name" varchar NOT NULL,
+  "body" varchar NOT NULL,
+  "priority" smallint NOT NULL DEFAULT 0,
+  "labels" varchar[] NOT NULL DEFAULT '{}',
+  "due_offset_minutes" int,
+  "subtasks" jsonb NOT NULL DEFAULT '[]',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "task_templates"."due_offset_minutes" IS 'due date of created tasks relative to when the template is used';
+
+CREATE INDEX ON "tasks" ("parent_id");
+CREATE INDEX ON "task_templates" ("owner_id");
+
+ALTER TABLE "tasks" ADD FOREIGN KEY ("parent_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
+ALTER TABLE "task_templates" ADD FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
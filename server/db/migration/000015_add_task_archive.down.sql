@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "archive_rules";
+ALTER TABLE IF EXISTS "tasks" DROP COLUMN IF EXISTS "archived_at";
@@ -0,0 +1,14 @@
+ALTER TABLE "tasks" ADD COLUMN "archived_at" timestamptz;
+
+CREATE TABLE "archive_rules" (
+  "user_id" bigint PRIMARY KEY,
+  "after_days" int NOT NULL CHECK ("after_days" > 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "tasks"."archived_at" IS 'archived tasks are hidden from the task list';
+COMMENT ON COLUMN "archive_rules"."after_days" IS 'done tasks are archived this many days after they were completed';
+
+CREATE INDEX ON "tasks" ("owner_id", "archived_at");
+
+ALTER TABLE "archive_rules" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+ALTER TABLE "tasks" ADD COLUMN "deferred_until" timestamptz;
+
+COMMENT ON COLUMN "tasks"."deferred_until" IS 'the task is hidden from the task list until then';
+
+CREATE INDEX ON "tasks" ("owner_id", "deferred_until");
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "users" DROP COLUMN IF EXISTS "verification_sent_at";
+ALTER TABLE IF EXISTS "users" DROP COLUMN IF EXISTS "email_verified_at";
@@ -0,0 +1,7 @@
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamptz;
+ALTER TABLE "users" ADD COLUMN "verification_sent_at" timestamptz;
+
+COMMENT ON COLUMN "users"."verification_sent_at" IS 'when the last verification email was sent, resends are throttled by it';
+
+-- accounts created before verification existed keep working
+UPDATE "users" SET "email_verified_at" = "created_at";
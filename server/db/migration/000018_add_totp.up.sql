@@ -0,0 +1,27 @@
+CREATE TABLE "user_totp" (
+  "user_id" bigint PRIMARY KEY,
+  "secret" bytea NOT NULL,
+  "enabled_at" timestamptz,
+  "last_step" bigint,
+  "failed_attempts" int NOT NULL DEFAULT 0,
+  "locked_until" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "code_hash" bytea NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "user_totp"."enabled_at" IS 'null until the user confirmed the secret with a first code';
+COMMENT ON COLUMN "user_totp"."last_step" IS 'time step of the last accepted code, older steps are rejected so codes cannot be replayed';
+COMMENT ON COLUMN "user_totp"."locked_until" IS 'codes are not checked until then after too many failed attempts';
+COMMENT ON COLUMN "mfa_recovery_codes"."code_hash" IS 'sha256 of the recovery code, the code itself is only shown once';
+
+CREATE UNIQUE INDEX ON "mfa_recovery_codes" ("user_id", "code_hash");
+
+ALTER TABLE "user_totp" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "mfa_recovery_codes" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
@@ -0,0 +1,18 @@
+CREATE TABLE "webauthn_credentials" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "credential_id" bytea UNIQUE NOT NULL,
+  "public_key" bytea NOT NULL,
+  "sign_count" bigint NOT NULL DEFAULT 0,
+  "name" varchar NOT NULL,
+  "last_used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "webauthn_credentials"."credential_id" IS 'raw id the authenticator chose for the credential';
+COMMENT ON COLUMN "webauthn_credentials"."public_key" IS 'COSE encoded public key from the attested credential data';
+COMMENT ON COLUMN "webauthn_credentials"."sign_count" IS 'last signature counter, a counter that does not grow hints at a cloned authenticator';
+
+CREATE INDEX ON "webauthn_credentials" ("user_id");
+
+ALTER TABLE "webauthn_credentials" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "login_challenges";
+DROP TABLE IF EXISTS "user_token_revocations";
+DROP TABLE IF EXISTS "revoked_tokens";
+
+ALTER TABLE "sessions"
+  DROP COLUMN IF EXISTS "family_id",
+  DROP COLUMN IF EXISTS "replaced_by",
+  DROP COLUMN IF EXISTS "access_token_id",
+  DROP COLUMN IF EXISTS "access_token_expires_at";
@@ -0,0 +1,43 @@
+ALTER TABLE "sessions"
+  ADD COLUMN "family_id" uuid,
+  ADD COLUMN "replaced_by" uuid,
+  ADD COLUMN "access_token_id" uuid,
+  ADD COLUMN "access_token_expires_at" timestamptz;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'first session of the login, sessions rotated from it share it';
+COMMENT ON COLUMN "sessions"."replaced_by" IS 'session that rotated this one, kept until it expires to detect reuse';
+COMMENT ON COLUMN "sessions"."access_token_id" IS 'access token issued with the refresh token';
+
+CREATE INDEX ON "sessions" ("user_id");
+
+CREATE INDEX ON "sessions" ("family_id");
+
+CREATE INDEX ON "sessions" ("expires_at");
+
+CREATE TABLE "revoked_tokens" (
+  "id" uuid PRIMARY KEY,
+  "expires_at" timestamptz NOT NULL
+);
+
+COMMENT ON COLUMN "revoked_tokens"."expires_at" IS 'when the token expires, the row is of no use after';
+
+CREATE TABLE "user_token_revocations" (
+  "user_id" bigint PRIMARY KEY,
+  "revoked_before" timestamptz NOT NULL,
+  "expires_at" timestamptz NOT NULL
+);
+
+COMMENT ON COLUMN "user_token_revocations"."revoked_before" IS 'tokens of the user issued until then are rejected';
+COMMENT ON COLUMN "user_token_revocations"."expires_at" IS 'when the last of those tokens expires';
+
+ALTER TABLE "user_token_revocations" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "login_challenges" (
+  "key" varchar PRIMARY KEY,
+  "value" bytea NOT NULL,
+  "expires_at" timestamptz NOT NULL
+);
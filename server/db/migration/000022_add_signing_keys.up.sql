@@ -0,0 +1,14 @@
+CREATE TABLE "signing_keys" (
+  "id" varchar PRIMARY KEY,
+  "private_key" bytea NOT NULL,
+  "not_before" timestamptz UNIQUE NOT NULL,
+  "not_after" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "signing_keys"."id" IS 'RFC 7638 thumbprint of the public key, the kid of tokens';
+COMMENT ON COLUMN "signing_keys"."private_key" IS 'seed of the Ed25519 private key encrypted with TOKEN_KEY_SECRET, the XChaCha20-Poly1305 nonce goes first';
+COMMENT ON COLUMN "signing_keys"."not_before" IS 'the key signs tokens from then, servers creating the same key race on it';
+COMMENT ON COLUMN "signing_keys"."not_after" IS 'until then, its tokens verify for the grace period after';
+
+CREATE INDEX ON "signing_keys" ("not_after");
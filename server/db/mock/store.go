@@ -10,7 +10,9 @@ package mockdb
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	db "github.com/punkzberryz/todo/db/sqlc"
@@ -40,6 +42,304 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddTaskLabel mocks base method.
+func (m *MockStore) AddTaskLabel(arg0 context.Context, arg1 db.AddTaskLabelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskLabel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskLabel indicates an expected call of AddTaskLabel.
+func (mr *MockStoreMockRecorder) AddTaskLabel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskLabel", reflect.TypeOf((*MockStore)(nil).AddTaskLabel), arg0, arg1)
+}
+
+// ArchiveDoneTasks mocks base method.
+func (m *MockStore) ArchiveDoneTasks(arg0 context.Context, arg1 db.ArchiveDoneTasksParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveDoneTasks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveDoneTasks indicates an expected call of ArchiveDoneTasks.
+func (mr *MockStoreMockRecorder) ArchiveDoneTasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveDoneTasks", reflect.TypeOf((*MockStore)(nil).ArchiveDoneTasks), arg0, arg1)
+}
+
+// ArchiveTask mocks base method.
+func (m *MockStore) ArchiveTask(arg0 context.Context, arg1 db.ArchiveTaskParams) (db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveTask", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveTask indicates an expected call of ArchiveTask.
+func (mr *MockStoreMockRecorder) ArchiveTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveTask", reflect.TypeOf((*MockStore)(nil).ArchiveTask), arg0, arg1)
+}
+
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSession", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), arg0, arg1)
+}
+
+// ClaimDueDigest mocks base method.
+func (m *MockStore) ClaimDueDigest(arg0 context.Context, arg1 sql.NullTime) (db.ClaimDueDigestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDigest", arg0, arg1)
+	ret0, _ := ret[0].(db.ClaimDueDigestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDigest indicates an expected call of ClaimDueDigest.
+func (mr *MockStoreMockRecorder) ClaimDueDigest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDigest", reflect.TypeOf((*MockStore)(nil).ClaimDueDigest), arg0, arg1)
+}
+
+// ClaimDueReminder mocks base method.
+func (m *MockStore) ClaimDueReminder(arg0 context.Context, arg1 db.ClaimDueReminderParams) (db.ClaimDueReminderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueReminder", arg0, arg1)
+	ret0, _ := ret[0].(db.ClaimDueReminderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueReminder indicates an expected call of ClaimDueReminder.
+func (mr *MockStoreMockRecorder) ClaimDueReminder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueReminder", reflect.TypeOf((*MockStore)(nil).ClaimDueReminder), arg0, arg1)
+}
+
+// ClaimDueWebhookDelivery mocks base method.
+func (m *MockStore) ClaimDueWebhookDelivery(arg0 context.Context, arg1 sql.NullTime) (db.ClaimDueWebhookDeliveryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(db.ClaimDueWebhookDeliveryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueWebhookDelivery indicates an expected call of ClaimDueWebhookDelivery.
+func (mr *MockStoreMockRecorder) ClaimDueWebhookDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueWebhookDelivery", reflect.TypeOf((*MockStore)(nil).ClaimDueWebhookDelivery), arg0, arg1)
+}
+
+// ClaimVerificationEmail mocks base method.
+func (m *MockStore) ClaimVerificationEmail(arg0 context.Context, arg1 db.ClaimVerificationEmailParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimVerificationEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimVerificationEmail indicates an expected call of ClaimVerificationEmail.
+func (mr *MockStoreMockRecorder) ClaimVerificationEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimVerificationEmail", reflect.TypeOf((*MockStore)(nil).ClaimVerificationEmail), arg0, arg1)
+}
+
+// CountRecoveryCodes mocks base method.
+func (m *MockStore) CountRecoveryCodes(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecoveryCodes indicates an expected call of CountRecoveryCodes.
+func (mr *MockStoreMockRecorder) CountRecoveryCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecoveryCodes", reflect.TypeOf((*MockStore)(nil).CountRecoveryCodes), arg0, arg1)
+}
+
+// CountTasksByStatus mocks base method.
+func (m *MockStore) CountTasksByStatus(arg0 context.Context, arg1 sql.NullInt64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasksByStatus", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasksByStatus indicates an expected call of CountTasksByStatus.
+func (mr *MockStoreMockRecorder) CountTasksByStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasksByStatus", reflect.TypeOf((*MockStore)(nil).CountTasksByStatus), arg0, arg1)
+}
+
+// CountWebauthnCredentials mocks base method.
+func (m *MockStore) CountWebauthnCredentials(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWebauthnCredentials", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWebauthnCredentials indicates an expected call of CountWebauthnCredentials.
+func (mr *MockStoreMockRecorder) CountWebauthnCredentials(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWebauthnCredentials", reflect.TypeOf((*MockStore)(nil).CountWebauthnCredentials), arg0, arg1)
+}
+
+// CreateApiKey mocks base method.
+func (m *MockStore) CreateApiKey(arg0 context.Context, arg1 db.CreateApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockStoreMockRecorder) CreateApiKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockStore)(nil).CreateApiKey), arg0, arg1)
+}
+
+// CreateCustomField mocks base method.
+func (m *MockStore) CreateCustomField(arg0 context.Context, arg1 db.CreateCustomFieldParams) (db.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", arg0, arg1)
+	ret0, _ := ret[0].(db.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockStoreMockRecorder) CreateCustomField(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockStore)(nil).CreateCustomField), arg0, arg1)
+}
+
+// CreatePasswordResetSession mocks base method.
+func (m *MockStore) CreatePasswordResetSession(arg0 context.Context, arg1 db.CreatePasswordResetSessionParams) (db.PasswordResetSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordResetSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetSession indicates an expected call of CreatePasswordResetSession.
+func (mr *MockStoreMockRecorder) CreatePasswordResetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetSession", reflect.TypeOf((*MockStore)(nil).CreatePasswordResetSession), arg0, arg1)
+}
+
+// CreateProject mocks base method.
+func (m *MockStore) CreateProject(arg0 context.Context, arg1 db.CreateProjectParams) (db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", arg0, arg1)
+	ret0, _ := ret[0].(db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockStoreMockRecorder) CreateProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockStore)(nil).CreateProject), arg0, arg1)
+}
+
+// CreateProjectStatus mocks base method.
+func (m *MockStore) CreateProjectStatus(arg0 context.Context, arg1 db.CreateProjectStatusParams) (db.ProjectStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProjectStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.ProjectStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProjectStatus indicates an expected call of CreateProjectStatus.
+func (mr *MockStoreMockRecorder) CreateProjectStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectStatus", reflect.TypeOf((*MockStore)(nil).CreateProjectStatus), arg0, arg1)
+}
+
+// CreateProjectTx mocks base method.
+func (m *MockStore) CreateProjectTx(arg0 context.Context, arg1 db.CreateProjectTxParams) (db.CreateProjectTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProjectTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateProjectTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProjectTx indicates an expected call of CreateProjectTx.
+func (mr *MockStoreMockRecorder) CreateProjectTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectTx", reflect.TypeOf((*MockStore)(nil).CreateProjectTx), arg0, arg1)
+}
+
+// CreateRecoveryCodes mocks base method.
+func (m *MockStore) CreateRecoveryCodes(arg0 context.Context, arg1 db.CreateRecoveryCodesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecoveryCodes indicates an expected call of CreateRecoveryCodes.
+func (mr *MockStoreMockRecorder) CreateRecoveryCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecoveryCodes", reflect.TypeOf((*MockStore)(nil).CreateRecoveryCodes), arg0, arg1)
+}
+
+// CreateReminder mocks base method.
+func (m *MockStore) CreateReminder(arg0 context.Context, arg1 db.CreateReminderParams) (db.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReminder", arg0, arg1)
+	ret0, _ := ret[0].(db.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReminder indicates an expected call of CreateReminder.
+func (mr *MockStoreMockRecorder) CreateReminder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReminder", reflect.TypeOf((*MockStore)(nil).CreateReminder), arg0, arg1)
+}
+
+// CreateSavedFilter mocks base method.
+func (m *MockStore) CreateSavedFilter(arg0 context.Context, arg1 db.CreateSavedFilterParams) (db.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedFilter", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedFilter indicates an expected call of CreateSavedFilter.
+func (mr *MockStoreMockRecorder) CreateSavedFilter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedFilter", reflect.TypeOf((*MockStore)(nil).CreateSavedFilter), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +355,36 @@ func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
 }
 
+// CreateSigningKey mocks base method.
+func (m *MockStore) CreateSigningKey(arg0 context.Context, arg1 db.CreateSigningKeyParams) (db.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSigningKey", arg0, arg1)
+	ret0, _ := ret[0].(db.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSigningKey indicates an expected call of CreateSigningKey.
+func (mr *MockStoreMockRecorder) CreateSigningKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSigningKey", reflect.TypeOf((*MockStore)(nil).CreateSigningKey), arg0, arg1)
+}
+
+// CreateStatusTransition mocks base method.
+func (m *MockStore) CreateStatusTransition(arg0 context.Context, arg1 db.CreateStatusTransitionParams) (db.StatusTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatusTransition", arg0, arg1)
+	ret0, _ := ret[0].(db.StatusTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatusTransition indicates an expected call of CreateStatusTransition.
+func (mr *MockStoreMockRecorder) CreateStatusTransition(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatusTransition", reflect.TypeOf((*MockStore)(nil).CreateStatusTransition), arg0, arg1)
+}
+
 // CreateTask mocks base method.
 func (m *MockStore) CreateTask(arg0 context.Context, arg1 db.CreateTaskParams) (db.Task, error) {
 	m.ctrl.T.Helper()
@@ -70,120 +400,2326 @@ func (mr *MockStoreMockRecorder) CreateTask(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockStore)(nil).CreateTask), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// CreateTaskAttachment mocks base method.
+func (m *MockStore) CreateTaskAttachment(arg0 context.Context, arg1 db.CreateTaskAttachmentParams) (db.CreateTaskAttachmentRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "CreateTaskAttachment", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateTaskAttachmentRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+// CreateTaskAttachment indicates an expected call of CreateTaskAttachment.
+func (mr *MockStoreMockRecorder) CreateTaskAttachment(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskAttachment", reflect.TypeOf((*MockStore)(nil).CreateTaskAttachment), arg0, arg1)
 }
 
-// DeleteSession mocks base method.
-func (m *MockStore) DeleteSession(arg0 context.Context, arg1 uuid.UUID) error {
+// CreateTaskTemplate mocks base method.
+func (m *MockStore) CreateTaskTemplate(arg0 context.Context, arg1 db.CreateTaskTemplateParams) (db.TaskTemplate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSession indicates an expected call of DeleteSession.
-func (mr *MockStoreMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+// CreateTaskTemplate indicates an expected call of CreateTaskTemplate.
+func (mr *MockStoreMockRecorder) CreateTaskTemplate(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTemplate", reflect.TypeOf((*MockStore)(nil).CreateTaskTemplate), arg0, arg1)
 }
 
-// DeleteTask mocks base method.
-func (m *MockStore) DeleteTask(arg0 context.Context, arg1 db.DeleteTaskParams) error {
+// CreateTaskTx mocks base method.
+func (m *MockStore) CreateTaskTx(arg0 context.Context, arg1 db.CreateTaskParams) (db.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateTaskTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockStoreMockRecorder) DeleteTask(arg0, arg1 any) *gomock.Call {
+// CreateTaskTx indicates an expected call of CreateTaskTx.
+func (mr *MockStoreMockRecorder) CreateTaskTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockStore)(nil).DeleteTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTx", reflect.TypeOf((*MockStore)(nil).CreateTaskTx), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// CreateTimeEntry mocks base method.
+func (m *MockStore) CreateTimeEntry(arg0 context.Context, arg1 db.CreateTimeEntryParams) (db.TimeEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "CreateTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.TimeEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockStoreMockRecorder) CreateTimeEntry(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockStore)(nil).CreateTimeEntry), arg0, arg1)
 }
 
-// GetTask mocks base method.
-func (m *MockStore) GetTask(arg0 context.Context, arg1 int64) (db.Task, error) {
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", arg0, arg1)
-	ret0, _ := ret[0].(db.Task)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockStoreMockRecorder) GetTask(arg0, arg1 any) *gomock.Call {
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockStore)(nil).GetTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
 }
 
-// GetTaskList mocks base method.
-func (m *MockStore) GetTaskList(arg0 context.Context, arg1 db.GetTaskListParams) ([]db.Task, error) {
+// CreateWebauthnCredential mocks base method.
+func (m *MockStore) CreateWebauthnCredential(arg0 context.Context, arg1 db.CreateWebauthnCredentialParams) (db.WebauthnCredential, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskList", arg0, arg1)
-	ret0, _ := ret[0].([]db.Task)
+	ret := m.ctrl.Call(m, "CreateWebauthnCredential", arg0, arg1)
+	ret0, _ := ret[0].(db.WebauthnCredential)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTaskList indicates an expected call of GetTaskList.
-func (mr *MockStoreMockRecorder) GetTaskList(arg0, arg1 any) *gomock.Call {
+// CreateWebauthnCredential indicates an expected call of CreateWebauthnCredential.
+func (mr *MockStoreMockRecorder) CreateWebauthnCredential(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskList", reflect.TypeOf((*MockStore)(nil).GetTaskList), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebauthnCredential", reflect.TypeOf((*MockStore)(nil).CreateWebauthnCredential), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 db.GetUserParams) (db.User, error) {
+// CreateWebhook mocks base method.
+func (m *MockStore) CreateWebhook(arg0 context.Context, arg1 db.CreateWebhookParams) (db.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(db.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockStoreMockRecorder) CreateWebhook(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockStore)(nil).CreateWebhook), arg0, arg1)
 }
 
-// UpdateTask mocks base method.
-func (m *MockStore) UpdateTask(arg0 context.Context, arg1 db.UpdateTaskParams) (db.Task, error) {
+// CreateWebhookDelivery mocks base method.
+func (m *MockStore) CreateWebhookDelivery(arg0 context.Context, arg1 db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1)
-	ret0, _ := ret[0].(db.Task)
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockStoreMockRecorder) UpdateTask(arg0, arg1 any) *gomock.Call {
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockStoreMockRecorder) CreateWebhookDelivery(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockStore)(nil).UpdateTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).CreateWebhookDelivery), arg0, arg1)
+}
+
+// DeleteApiKey mocks base method.
+func (m *MockStore) DeleteApiKey(arg0 context.Context, arg1 db.DeleteApiKeyParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApiKey", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApiKey indicates an expected call of DeleteApiKey.
+func (mr *MockStoreMockRecorder) DeleteApiKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApiKey", reflect.TypeOf((*MockStore)(nil).DeleteApiKey), arg0, arg1)
+}
+
+// DeleteArchiveRule mocks base method.
+func (m *MockStore) DeleteArchiveRule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArchiveRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArchiveRule indicates an expected call of DeleteArchiveRule.
+func (mr *MockStoreMockRecorder) DeleteArchiveRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArchiveRule", reflect.TypeOf((*MockStore)(nil).DeleteArchiveRule), arg0, arg1)
+}
+
+// DeleteCurrentSession mocks base method.
+func (m *MockStore) DeleteCurrentSession(arg0 context.Context, arg1 db.DeleteCurrentSessionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCurrentSession", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCurrentSession indicates an expected call of DeleteCurrentSession.
+func (mr *MockStoreMockRecorder) DeleteCurrentSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCurrentSession", reflect.TypeOf((*MockStore)(nil).DeleteCurrentSession), arg0, arg1)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockStore) DeleteCustomField(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockStoreMockRecorder) DeleteCustomField(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockStore)(nil).DeleteCustomField), arg0, arg1)
+}
+
+// DeleteExpiredLoginChallenges mocks base method.
+func (m *MockStore) DeleteExpiredLoginChallenges(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLoginChallenges", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredLoginChallenges indicates an expected call of DeleteExpiredLoginChallenges.
+func (mr *MockStoreMockRecorder) DeleteExpiredLoginChallenges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLoginChallenges", reflect.TypeOf((*MockStore)(nil).DeleteExpiredLoginChallenges), arg0, arg1)
+}
+
+// DeleteExpiredRevokedTokens mocks base method.
+func (m *MockStore) DeleteExpiredRevokedTokens(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRevokedTokens", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRevokedTokens indicates an expected call of DeleteExpiredRevokedTokens.
+func (mr *MockStoreMockRecorder) DeleteExpiredRevokedTokens(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRevokedTokens", reflect.TypeOf((*MockStore)(nil).DeleteExpiredRevokedTokens), arg0, arg1)
+}
+
+// DeleteExpiredSessions mocks base method.
+func (m *MockStore) DeleteExpiredSessions(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSessions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSessions indicates an expected call of DeleteExpiredSessions.
+func (mr *MockStoreMockRecorder) DeleteExpiredSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockStore)(nil).DeleteExpiredSessions), arg0, arg1)
+}
+
+// DeleteExpiredSigningKeys mocks base method.
+func (m *MockStore) DeleteExpiredSigningKeys(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSigningKeys", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSigningKeys indicates an expected call of DeleteExpiredSigningKeys.
+func (mr *MockStoreMockRecorder) DeleteExpiredSigningKeys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSigningKeys", reflect.TypeOf((*MockStore)(nil).DeleteExpiredSigningKeys), arg0, arg1)
+}
+
+// DeleteExpiredUserTokenRevocations mocks base method.
+func (m *MockStore) DeleteExpiredUserTokenRevocations(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUserTokenRevocations", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredUserTokenRevocations indicates an expected call of DeleteExpiredUserTokenRevocations.
+func (mr *MockStoreMockRecorder) DeleteExpiredUserTokenRevocations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUserTokenRevocations", reflect.TypeOf((*MockStore)(nil).DeleteExpiredUserTokenRevocations), arg0, arg1)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockStore) DeleteLabel(arg0 context.Context, arg1 db.DeleteLabelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockStoreMockRecorder) DeleteLabel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockStore)(nil).DeleteLabel), arg0, arg1)
+}
+
+// DeletePasswordResetSession mocks base method.
+func (m *MockStore) DeletePasswordResetSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordResetSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordResetSession indicates an expected call of DeletePasswordResetSession.
+func (mr *MockStoreMockRecorder) DeletePasswordResetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordResetSession", reflect.TypeOf((*MockStore)(nil).DeletePasswordResetSession), arg0, arg1)
+}
+
+// DeleteProject mocks base method.
+func (m *MockStore) DeleteProject(arg0 context.Context, arg1 db.DeleteProjectParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockStoreMockRecorder) DeleteProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockStore)(nil).DeleteProject), arg0, arg1)
+}
+
+// DeleteProjectStatus mocks base method.
+func (m *MockStore) DeleteProjectStatus(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProjectStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProjectStatus indicates an expected call of DeleteProjectStatus.
+func (mr *MockStoreMockRecorder) DeleteProjectStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectStatus", reflect.TypeOf((*MockStore)(nil).DeleteProjectStatus), arg0, arg1)
+}
+
+// DeleteRecoveryCodes mocks base method.
+func (m *MockStore) DeleteRecoveryCodes(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteRecoveryCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteRecoveryCodes), arg0, arg1)
+}
+
+// DeleteReminder mocks base method.
+func (m *MockStore) DeleteReminder(arg0 context.Context, arg1 db.DeleteReminderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReminder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReminder indicates an expected call of DeleteReminder.
+func (mr *MockStoreMockRecorder) DeleteReminder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReminder", reflect.TypeOf((*MockStore)(nil).DeleteReminder), arg0, arg1)
+}
+
+// DeleteSavedFilter mocks base method.
+func (m *MockStore) DeleteSavedFilter(arg0 context.Context, arg1 db.DeleteSavedFilterParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedFilter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedFilter indicates an expected call of DeleteSavedFilter.
+func (mr *MockStoreMockRecorder) DeleteSavedFilter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedFilter", reflect.TypeOf((*MockStore)(nil).DeleteSavedFilter), arg0, arg1)
+}
+
+// DeleteSession mocks base method.
+func (m *MockStore) DeleteSession(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockStoreMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0, arg1)
+}
+
+// DeleteStatusTransitions mocks base method.
+func (m *MockStore) DeleteStatusTransitions(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStatusTransitions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStatusTransitions indicates an expected call of DeleteStatusTransitions.
+func (mr *MockStoreMockRecorder) DeleteStatusTransitions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStatusTransitions", reflect.TypeOf((*MockStore)(nil).DeleteStatusTransitions), arg0, arg1)
+}
+
+// DeleteTask mocks base method.
+func (m *MockStore) DeleteTask(arg0 context.Context, arg1 db.DeleteTaskParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockStoreMockRecorder) DeleteTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockStore)(nil).DeleteTask), arg0, arg1)
+}
+
+// DeleteTaskCustomFieldValue mocks base method.
+func (m *MockStore) DeleteTaskCustomFieldValue(arg0 context.Context, arg1 db.DeleteTaskCustomFieldValueParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskCustomFieldValue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskCustomFieldValue indicates an expected call of DeleteTaskCustomFieldValue.
+func (mr *MockStoreMockRecorder) DeleteTaskCustomFieldValue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskCustomFieldValue", reflect.TypeOf((*MockStore)(nil).DeleteTaskCustomFieldValue), arg0, arg1)
+}
+
+// DeleteTaskLabels mocks base method.
+func (m *MockStore) DeleteTaskLabels(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskLabels", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskLabels indicates an expected call of DeleteTaskLabels.
+func (mr *MockStoreMockRecorder) DeleteTaskLabels(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskLabels", reflect.TypeOf((*MockStore)(nil).DeleteTaskLabels), arg0, arg1)
+}
+
+// DeleteTaskTemplate mocks base method.
+func (m *MockStore) DeleteTaskTemplate(arg0 context.Context, arg1 db.DeleteTaskTemplateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTemplate indicates an expected call of DeleteTaskTemplate.
+func (mr *MockStoreMockRecorder) DeleteTaskTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTemplate", reflect.TypeOf((*MockStore)(nil).DeleteTaskTemplate), arg0, arg1)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockStore) DeleteTimeEntry(arg0 context.Context, arg1 db.DeleteTimeEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockStoreMockRecorder) DeleteTimeEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockStore)(nil).DeleteTimeEntry), arg0, arg1)
+}
+
+// DeleteUserSessions mocks base method.
+func (m *MockStore) DeleteUserSessions(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockStoreMockRecorder) DeleteUserSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockStore)(nil).DeleteUserSessions), arg0, arg1)
+}
+
+// DeleteUserTotp mocks base method.
+func (m *MockStore) DeleteUserTotp(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTotp", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTotp indicates an expected call of DeleteUserTotp.
+func (mr *MockStoreMockRecorder) DeleteUserTotp(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTotp", reflect.TypeOf((*MockStore)(nil).DeleteUserTotp), arg0, arg1)
+}
+
+// DeleteWebauthnCredential mocks base method.
+func (m *MockStore) DeleteWebauthnCredential(arg0 context.Context, arg1 db.DeleteWebauthnCredentialParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebauthnCredential", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebauthnCredential indicates an expected call of DeleteWebauthnCredential.
+func (mr *MockStoreMockRecorder) DeleteWebauthnCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebauthnCredential", reflect.TypeOf((*MockStore)(nil).DeleteWebauthnCredential), arg0, arg1)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockStore) DeleteWebhook(arg0 context.Context, arg1 db.DeleteWebhookParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockStoreMockRecorder) DeleteWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockStore)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// DeliverDigestTx mocks base method.
+func (m *MockStore) DeliverDigestTx(arg0 context.Context, arg1 db.DeliverDigestTxParams) (db.DeliverDigestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDigestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DeliverDigestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDigestTx indicates an expected call of DeliverDigestTx.
+func (mr *MockStoreMockRecorder) DeliverDigestTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDigestTx", reflect.TypeOf((*MockStore)(nil).DeliverDigestTx), arg0, arg1)
+}
+
+// DeliverReminderTx mocks base method.
+func (m *MockStore) DeliverReminderTx(arg0 context.Context, arg1 db.DeliverReminderTxParams) (db.DeliverReminderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverReminderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DeliverReminderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverReminderTx indicates an expected call of DeliverReminderTx.
+func (mr *MockStoreMockRecorder) DeliverReminderTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverReminderTx", reflect.TypeOf((*MockStore)(nil).DeliverReminderTx), arg0, arg1)
+}
+
+// DeliverWebhookTx mocks base method.
+func (m *MockStore) DeliverWebhookTx(arg0 context.Context, arg1 db.DeliverWebhookTxParams) (db.DeliverWebhookTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverWebhookTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DeliverWebhookTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverWebhookTx indicates an expected call of DeliverWebhookTx.
+func (mr *MockStoreMockRecorder) DeliverWebhookTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverWebhookTx", reflect.TypeOf((*MockStore)(nil).DeliverWebhookTx), arg0, arg1)
+}
+
+// DisableTotpTx mocks base method.
+func (m *MockStore) DisableTotpTx(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTotpTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTotpTx indicates an expected call of DisableTotpTx.
+func (mr *MockStoreMockRecorder) DisableTotpTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTotpTx", reflect.TypeOf((*MockStore)(nil).DisableTotpTx), arg0, arg1)
+}
+
+// EnableTotpTx mocks base method.
+func (m *MockStore) EnableTotpTx(arg0 context.Context, arg1 db.EnableTotpTxParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTotpTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTotpTx indicates an expected call of EnableTotpTx.
+func (mr *MockStoreMockRecorder) EnableTotpTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTotpTx", reflect.TypeOf((*MockStore)(nil).EnableTotpTx), arg0, arg1)
+}
+
+// EnableUserTotp mocks base method.
+func (m *MockStore) EnableUserTotp(arg0 context.Context, arg1 db.EnableUserTotpParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserTotp", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUserTotp indicates an expected call of EnableUserTotp.
+func (mr *MockStoreMockRecorder) EnableUserTotp(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserTotp", reflect.TypeOf((*MockStore)(nil).EnableUserTotp), arg0, arg1)
+}
+
+// GetApiKeyByHash mocks base method.
+func (m *MockStore) GetApiKeyByHash(arg0 context.Context, arg1 []byte) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeyByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeyByHash indicates an expected call of GetApiKeyByHash.
+func (mr *MockStoreMockRecorder) GetApiKeyByHash(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByHash", reflect.TypeOf((*MockStore)(nil).GetApiKeyByHash), arg0, arg1)
+}
+
+// GetArchiveRule mocks base method.
+func (m *MockStore) GetArchiveRule(arg0 context.Context, arg1 int64) (db.ArchiveRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchiveRule", arg0, arg1)
+	ret0, _ := ret[0].(db.ArchiveRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchiveRule indicates an expected call of GetArchiveRule.
+func (mr *MockStoreMockRecorder) GetArchiveRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchiveRule", reflect.TypeOf((*MockStore)(nil).GetArchiveRule), arg0, arg1)
+}
+
+// GetArchivedTaskList mocks base method.
+func (m *MockStore) GetArchivedTaskList(arg0 context.Context, arg1 db.GetArchivedTaskListParams) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedTaskList", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedTaskList indicates an expected call of GetArchivedTaskList.
+func (mr *MockStoreMockRecorder) GetArchivedTaskList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedTaskList", reflect.TypeOf((*MockStore)(nil).GetArchivedTaskList), arg0, arg1)
+}
+
+// GetCompletionStreaks mocks base method.
+func (m *MockStore) GetCompletionStreaks(arg0 context.Context, arg1 db.GetCompletionStreaksParams) (db.GetCompletionStreaksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletionStreaks", arg0, arg1)
+	ret0, _ := ret[0].(db.GetCompletionStreaksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletionStreaks indicates an expected call of GetCompletionStreaks.
+func (mr *MockStoreMockRecorder) GetCompletionStreaks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletionStreaks", reflect.TypeOf((*MockStore)(nil).GetCompletionStreaks), arg0, arg1)
+}
+
+// GetCustomField mocks base method.
+func (m *MockStore) GetCustomField(arg0 context.Context, arg1 int64) (db.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomField", arg0, arg1)
+	ret0, _ := ret[0].(db.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomField indicates an expected call of GetCustomField.
+func (mr *MockStoreMockRecorder) GetCustomField(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomField", reflect.TypeOf((*MockStore)(nil).GetCustomField), arg0, arg1)
+}
+
+// GetCustomFieldList mocks base method.
+func (m *MockStore) GetCustomFieldList(arg0 context.Context, arg1 int64) ([]db.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFieldList", arg0, arg1)
+	ret0, _ := ret[0].([]db.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFieldList indicates an expected call of GetCustomFieldList.
+func (mr *MockStoreMockRecorder) GetCustomFieldList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFieldList", reflect.TypeOf((*MockStore)(nil).GetCustomFieldList), arg0, arg1)
+}
+
+// GetCustomFieldListByOwner mocks base method.
+func (m *MockStore) GetCustomFieldListByOwner(arg0 context.Context, arg1 int64) ([]db.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFieldListByOwner", arg0, arg1)
+	ret0, _ := ret[0].([]db.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFieldListByOwner indicates an expected call of GetCustomFieldListByOwner.
+func (mr *MockStoreMockRecorder) GetCustomFieldListByOwner(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFieldListByOwner", reflect.TypeOf((*MockStore)(nil).GetCustomFieldListByOwner), arg0, arg1)
+}
+
+// GetCustomFieldValuesByTasks mocks base method.
+func (m *MockStore) GetCustomFieldValuesByTasks(arg0 context.Context, arg1 []int64) ([]db.TaskCustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFieldValuesByTasks", arg0, arg1)
+	ret0, _ := ret[0].([]db.TaskCustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFieldValuesByTasks indicates an expected call of GetCustomFieldValuesByTasks.
+func (mr *MockStoreMockRecorder) GetCustomFieldValuesByTasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFieldValuesByTasks", reflect.TypeOf((*MockStore)(nil).GetCustomFieldValuesByTasks), arg0, arg1)
+}
+
+// GetDigestPreference mocks base method.
+func (m *MockStore) GetDigestPreference(arg0 context.Context, arg1 int64) (db.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestPreference indicates an expected call of GetDigestPreference.
+func (mr *MockStoreMockRecorder) GetDigestPreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestPreference", reflect.TypeOf((*MockStore)(nil).GetDigestPreference), arg0, arg1)
+}
+
+// GetInbox mocks base method.
+func (m *MockStore) GetInbox(arg0 context.Context, arg1 int64) (db.Inbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", arg0, arg1)
+	ret0, _ := ret[0].(db.Inbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockStoreMockRecorder) GetInbox(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockStore)(nil).GetInbox), arg0, arg1)
+}
+
+// GetInboxByToken mocks base method.
+func (m *MockStore) GetInboxByToken(arg0 context.Context, arg1 string) (db.Inbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxByToken", arg0, arg1)
+	ret0, _ := ret[0].(db.Inbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxByToken indicates an expected call of GetInboxByToken.
+func (mr *MockStoreMockRecorder) GetInboxByToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxByToken", reflect.TypeOf((*MockStore)(nil).GetInboxByToken), arg0, arg1)
+}
+
+// GetLabelList mocks base method.
+func (m *MockStore) GetLabelList(arg0 context.Context, arg1 int64) ([]db.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelList", arg0, arg1)
+	ret0, _ := ret[0].([]db.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelList indicates an expected call of GetLabelList.
+func (mr *MockStoreMockRecorder) GetLabelList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelList", reflect.TypeOf((*MockStore)(nil).GetLabelList), arg0, arg1)
+}
+
+// GetLabelsByIds mocks base method.
+func (m *MockStore) GetLabelsByIds(arg0 context.Context, arg1 []int64) ([]db.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelsByIds", arg0, arg1)
+	ret0, _ := ret[0].([]db.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelsByIds indicates an expected call of GetLabelsByIds.
+func (mr *MockStoreMockRecorder) GetLabelsByIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelsByIds", reflect.TypeOf((*MockStore)(nil).GetLabelsByIds), arg0, arg1)
+}
+
+// GetLabelsByTasks mocks base method.
+func (m *MockStore) GetLabelsByTasks(arg0 context.Context, arg1 []int64) ([]db.GetLabelsByTasksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelsByTasks", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetLabelsByTasksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelsByTasks indicates an expected call of GetLabelsByTasks.
+func (mr *MockStoreMockRecorder) GetLabelsByTasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelsByTasks", reflect.TypeOf((*MockStore)(nil).GetLabelsByTasks), arg0, arg1)
+}
+
+// GetOverdueTasks mocks base method.
+func (m *MockStore) GetOverdueTasks(arg0 context.Context, arg1 db.GetOverdueTasksParams) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueTasks", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueTasks indicates an expected call of GetOverdueTasks.
+func (mr *MockStoreMockRecorder) GetOverdueTasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueTasks", reflect.TypeOf((*MockStore)(nil).GetOverdueTasks), arg0, arg1)
+}
+
+// GetPasswordResetSession mocks base method.
+func (m *MockStore) GetPasswordResetSession(arg0 context.Context, arg1 string) (db.PasswordResetSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordResetSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetSession indicates an expected call of GetPasswordResetSession.
+func (mr *MockStoreMockRecorder) GetPasswordResetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetSession", reflect.TypeOf((*MockStore)(nil).GetPasswordResetSession), arg0, arg1)
+}
+
+// GetProject mocks base method.
+func (m *MockStore) GetProject(arg0 context.Context, arg1 int64) (db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", arg0, arg1)
+	ret0, _ := ret[0].(db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockStoreMockRecorder) GetProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockStore)(nil).GetProject), arg0, arg1)
+}
+
+// GetProjectList mocks base method.
+func (m *MockStore) GetProjectList(arg0 context.Context, arg1 int64) ([]db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectList", arg0, arg1)
+	ret0, _ := ret[0].([]db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectList indicates an expected call of GetProjectList.
+func (mr *MockStoreMockRecorder) GetProjectList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectList", reflect.TypeOf((*MockStore)(nil).GetProjectList), arg0, arg1)
+}
+
+// GetProjectStatus mocks base method.
+func (m *MockStore) GetProjectStatus(arg0 context.Context, arg1 int64) (db.ProjectStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.ProjectStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectStatus indicates an expected call of GetProjectStatus.
+func (mr *MockStoreMockRecorder) GetProjectStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectStatus", reflect.TypeOf((*MockStore)(nil).GetProjectStatus), arg0, arg1)
+}
+
+// GetProjectStatusList mocks base method.
+func (m *MockStore) GetProjectStatusList(arg0 context.Context, arg1 int64) ([]db.ProjectStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectStatusList", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProjectStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectStatusList indicates an expected call of GetProjectStatusList.
+func (mr *MockStoreMockRecorder) GetProjectStatusList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectStatusList", reflect.TypeOf((*MockStore)(nil).GetProjectStatusList), arg0, arg1)
+}
+
+// GetProjectsByIds mocks base method.
+func (m *MockStore) GetProjectsByIds(arg0 context.Context, arg1 []int64) ([]db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectsByIds", arg0, arg1)
+	ret0, _ := ret[0].([]db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectsByIds indicates an expected call of GetProjectsByIds.
+func (mr *MockStoreMockRecorder) GetProjectsByIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectsByIds", reflect.TypeOf((*MockStore)(nil).GetProjectsByIds), arg0, arg1)
+}
+
+// GetReminder mocks base method.
+func (m *MockStore) GetReminder(arg0 context.Context, arg1 int64) (db.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminder", arg0, arg1)
+	ret0, _ := ret[0].(db.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReminder indicates an expected call of GetReminder.
+func (mr *MockStoreMockRecorder) GetReminder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminder", reflect.TypeOf((*MockStore)(nil).GetReminder), arg0, arg1)
+}
+
+// GetReminderListByTask mocks base method.
+func (m *MockStore) GetReminderListByTask(arg0 context.Context, arg1 int64) ([]db.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminderListByTask", arg0, arg1)
+	ret0, _ := ret[0].([]db.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReminderListByTask indicates an expected call of GetReminderListByTask.
+func (mr *MockStoreMockRecorder) GetReminderListByTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminderListByTask", reflect.TypeOf((*MockStore)(nil).GetReminderListByTask), arg0, arg1)
+}
+
+// GetRunningTimeEntry mocks base method.
+func (m *MockStore) GetRunningTimeEntry(arg0 context.Context, arg1 int64) (db.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimeEntry indicates an expected call of GetRunningTimeEntry.
+func (mr *MockStoreMockRecorder) GetRunningTimeEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimeEntry", reflect.TypeOf((*MockStore)(nil).GetRunningTimeEntry), arg0, arg1)
+}
+
+// GetSavedFilter mocks base method.
+func (m *MockStore) GetSavedFilter(arg0 context.Context, arg1 int64) (db.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedFilter", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedFilter indicates an expected call of GetSavedFilter.
+func (mr *MockStoreMockRecorder) GetSavedFilter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedFilter", reflect.TypeOf((*MockStore)(nil).GetSavedFilter), arg0, arg1)
+}
+
+// GetSavedFilterList mocks base method.
+func (m *MockStore) GetSavedFilterList(arg0 context.Context, arg1 int64) ([]db.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedFilterList", arg0, arg1)
+	ret0, _ := ret[0].([]db.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedFilterList indicates an expected call of GetSavedFilterList.
+func (mr *MockStoreMockRecorder) GetSavedFilterList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedFilterList", reflect.TypeOf((*MockStore)(nil).GetSavedFilterList), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetStatusTransitionList mocks base method.
+func (m *MockStore) GetStatusTransitionList(arg0 context.Context, arg1 int64) ([]db.StatusTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusTransitionList", arg0, arg1)
+	ret0, _ := ret[0].([]db.StatusTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusTransitionList indicates an expected call of GetStatusTransitionList.
+func (mr *MockStoreMockRecorder) GetStatusTransitionList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusTransitionList", reflect.TypeOf((*MockStore)(nil).GetStatusTransitionList), arg0, arg1)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockStore) GetSubtasks(arg0 context.Context, arg1 sql.NullInt64) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockStoreMockRecorder) GetSubtasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockStore)(nil).GetSubtasks), arg0, arg1)
+}
+
+// GetSyncChange mocks base method.
+func (m *MockStore) GetSyncChange(arg0 context.Context, arg1 db.GetSyncChangeParams) (db.GetSyncChangeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncChange", arg0, arg1)
+	ret0, _ := ret[0].(db.GetSyncChangeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncChange indicates an expected call of GetSyncChange.
+func (mr *MockStoreMockRecorder) GetSyncChange(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncChange", reflect.TypeOf((*MockStore)(nil).GetSyncChange), arg0, arg1)
+}
+
+// GetSyncChanges mocks base method.
+func (m *MockStore) GetSyncChanges(arg0 context.Context, arg1 db.GetSyncChangesParams) ([]db.GetSyncChangesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncChanges", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetSyncChangesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncChanges indicates an expected call of GetSyncChanges.
+func (mr *MockStoreMockRecorder) GetSyncChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncChanges", reflect.TypeOf((*MockStore)(nil).GetSyncChanges), arg0, arg1)
+}
+
+// GetSyncChangesTx mocks base method.
+func (m *MockStore) GetSyncChangesTx(arg0 context.Context, arg1 db.GetSyncChangesTxParams) (db.GetSyncChangesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncChangesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GetSyncChangesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncChangesTx indicates an expected call of GetSyncChangesTx.
+func (mr *MockStoreMockRecorder) GetSyncChangesTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncChangesTx", reflect.TypeOf((*MockStore)(nil).GetSyncChangesTx), arg0, arg1)
+}
+
+// GetSyncSnapshotXmin mocks base method.
+func (m *MockStore) GetSyncSnapshotXmin(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncSnapshotXmin", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncSnapshotXmin indicates an expected call of GetSyncSnapshotXmin.
+func (mr *MockStoreMockRecorder) GetSyncSnapshotXmin(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncSnapshotXmin", reflect.TypeOf((*MockStore)(nil).GetSyncSnapshotXmin), arg0)
+}
+
+// GetTask mocks base method.
+func (m *MockStore) GetTask(arg0 context.Context, arg1 int64) (db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockStoreMockRecorder) GetTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockStore)(nil).GetTask), arg0, arg1)
+}
+
+// GetTaskAttachment mocks base method.
+func (m *MockStore) GetTaskAttachment(arg0 context.Context, arg1 int64) (db.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskAttachment", arg0, arg1)
+	ret0, _ := ret[0].(db.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskAttachment indicates an expected call of GetTaskAttachment.
+func (mr *MockStoreMockRecorder) GetTaskAttachment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskAttachment", reflect.TypeOf((*MockStore)(nil).GetTaskAttachment), arg0, arg1)
+}
+
+// GetTaskAttachmentList mocks base method.
+func (m *MockStore) GetTaskAttachmentList(arg0 context.Context, arg1 int64) ([]db.GetTaskAttachmentListRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskAttachmentList", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetTaskAttachmentListRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskAttachmentList indicates an expected call of GetTaskAttachmentList.
+func (mr *MockStoreMockRecorder) GetTaskAttachmentList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskAttachmentList", reflect.TypeOf((*MockStore)(nil).GetTaskAttachmentList), arg0, arg1)
+}
+
+// GetTaskCountsByPeriod mocks base method.
+func (m *MockStore) GetTaskCountsByPeriod(arg0 context.Context, arg1 db.GetTaskCountsByPeriodParams) ([]db.GetTaskCountsByPeriodRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskCountsByPeriod", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetTaskCountsByPeriodRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskCountsByPeriod indicates an expected call of GetTaskCountsByPeriod.
+func (mr *MockStoreMockRecorder) GetTaskCountsByPeriod(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskCountsByPeriod", reflect.TypeOf((*MockStore)(nil).GetTaskCountsByPeriod), arg0, arg1)
+}
+
+// GetTaskCustomFieldValues mocks base method.
+func (m *MockStore) GetTaskCustomFieldValues(arg0 context.Context, arg1 int64) ([]db.TaskCustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskCustomFieldValues", arg0, arg1)
+	ret0, _ := ret[0].([]db.TaskCustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskCustomFieldValues indicates an expected call of GetTaskCustomFieldValues.
+func (mr *MockStoreMockRecorder) GetTaskCustomFieldValues(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskCustomFieldValues", reflect.TypeOf((*MockStore)(nil).GetTaskCustomFieldValues), arg0, arg1)
+}
+
+// GetTaskList mocks base method.
+func (m *MockStore) GetTaskList(arg0 context.Context, arg1 db.GetTaskListParams) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskList", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskList indicates an expected call of GetTaskList.
+func (mr *MockStoreMockRecorder) GetTaskList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskList", reflect.TypeOf((*MockStore)(nil).GetTaskList), arg0, arg1)
+}
+
+// GetTaskListByProject mocks base method.
+func (m *MockStore) GetTaskListByProject(arg0 context.Context, arg1 sql.NullInt64) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskListByProject", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskListByProject indicates an expected call of GetTaskListByProject.
+func (mr *MockStoreMockRecorder) GetTaskListByProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskListByProject", reflect.TypeOf((*MockStore)(nil).GetTaskListByProject), arg0, arg1)
+}
+
+// GetTaskStatsByLabel mocks base method.
+func (m *MockStore) GetTaskStatsByLabel(arg0 context.Context, arg1 db.GetTaskStatsByLabelParams) ([]db.GetTaskStatsByLabelRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStatsByLabel", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetTaskStatsByLabelRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStatsByLabel indicates an expected call of GetTaskStatsByLabel.
+func (mr *MockStoreMockRecorder) GetTaskStatsByLabel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStatsByLabel", reflect.TypeOf((*MockStore)(nil).GetTaskStatsByLabel), arg0, arg1)
+}
+
+// GetTaskStatsByProject mocks base method.
+func (m *MockStore) GetTaskStatsByProject(arg0 context.Context, arg1 db.GetTaskStatsByProjectParams) ([]db.GetTaskStatsByProjectRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStatsByProject", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetTaskStatsByProjectRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStatsByProject indicates an expected call of GetTaskStatsByProject.
+func (mr *MockStoreMockRecorder) GetTaskStatsByProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStatsByProject", reflect.TypeOf((*MockStore)(nil).GetTaskStatsByProject), arg0, arg1)
+}
+
+// GetTaskStatsSummary mocks base method.
+func (m *MockStore) GetTaskStatsSummary(arg0 context.Context, arg1 db.GetTaskStatsSummaryParams) (db.GetTaskStatsSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStatsSummary", arg0, arg1)
+	ret0, _ := ret[0].(db.GetTaskStatsSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStatsSummary indicates an expected call of GetTaskStatsSummary.
+func (mr *MockStoreMockRecorder) GetTaskStatsSummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStatsSummary", reflect.TypeOf((*MockStore)(nil).GetTaskStatsSummary), arg0, arg1)
+}
+
+// GetTaskTemplate mocks base method.
+func (m *MockStore) GetTaskTemplate(arg0 context.Context, arg1 int64) (db.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplate indicates an expected call of GetTaskTemplate.
+func (mr *MockStoreMockRecorder) GetTaskTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplate", reflect.TypeOf((*MockStore)(nil).GetTaskTemplate), arg0, arg1)
+}
+
+// GetTaskTemplateList mocks base method.
+func (m *MockStore) GetTaskTemplateList(arg0 context.Context, arg1 int64) ([]db.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplateList", arg0, arg1)
+	ret0, _ := ret[0].([]db.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplateList indicates an expected call of GetTaskTemplateList.
+func (mr *MockStoreMockRecorder) GetTaskTemplateList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplateList", reflect.TypeOf((*MockStore)(nil).GetTaskTemplateList), arg0, arg1)
+}
+
+// GetTasksByIds mocks base method.
+func (m *MockStore) GetTasksByIds(arg0 context.Context, arg1 []int64) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByIds", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByIds indicates an expected call of GetTasksByIds.
+func (mr *MockStoreMockRecorder) GetTasksByIds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByIds", reflect.TypeOf((*MockStore)(nil).GetTasksByIds), arg0, arg1)
+}
+
+// GetTasksCompletedBetween mocks base method.
+func (m *MockStore) GetTasksCompletedBetween(arg0 context.Context, arg1 db.GetTasksCompletedBetweenParams) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksCompletedBetween", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksCompletedBetween indicates an expected call of GetTasksCompletedBetween.
+func (mr *MockStoreMockRecorder) GetTasksCompletedBetween(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksCompletedBetween", reflect.TypeOf((*MockStore)(nil).GetTasksCompletedBetween), arg0, arg1)
+}
+
+// GetTasksDueBetween mocks base method.
+func (m *MockStore) GetTasksDueBetween(arg0 context.Context, arg1 db.GetTasksDueBetweenParams) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksDueBetween", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksDueBetween indicates an expected call of GetTasksDueBetween.
+func (mr *MockStoreMockRecorder) GetTasksDueBetween(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksDueBetween", reflect.TypeOf((*MockStore)(nil).GetTasksDueBetween), arg0, arg1)
+}
+
+// GetTimeEntriesBetween mocks base method.
+func (m *MockStore) GetTimeEntriesBetween(arg0 context.Context, arg1 db.GetTimeEntriesBetweenParams) ([]db.GetTimeEntriesBetweenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntriesBetween", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetTimeEntriesBetweenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntriesBetween indicates an expected call of GetTimeEntriesBetween.
+func (mr *MockStoreMockRecorder) GetTimeEntriesBetween(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntriesBetween", reflect.TypeOf((*MockStore)(nil).GetTimeEntriesBetween), arg0, arg1)
+}
+
+// GetTimeEntry mocks base method.
+func (m *MockStore) GetTimeEntry(arg0 context.Context, arg1 int64) (db.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntry indicates an expected call of GetTimeEntry.
+func (mr *MockStoreMockRecorder) GetTimeEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntry", reflect.TypeOf((*MockStore)(nil).GetTimeEntry), arg0, arg1)
+}
+
+// GetTimeEntryListByTask mocks base method.
+func (m *MockStore) GetTimeEntryListByTask(arg0 context.Context, arg1 int64) ([]db.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntryListByTask", arg0, arg1)
+	ret0, _ := ret[0].([]db.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntryListByTask indicates an expected call of GetTimeEntryListByTask.
+func (mr *MockStoreMockRecorder) GetTimeEntryListByTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntryListByTask", reflect.TypeOf((*MockStore)(nil).GetTimeEntryListByTask), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 db.GetUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserTotp mocks base method.
+func (m *MockStore) GetUserTotp(arg0 context.Context, arg1 int64) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTotp", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTotp indicates an expected call of GetUserTotp.
+func (mr *MockStoreMockRecorder) GetUserTotp(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTotp", reflect.TypeOf((*MockStore)(nil).GetUserTotp), arg0, arg1)
+}
+
+// GetWebauthnCredentialByCredentialID mocks base method.
+func (m *MockStore) GetWebauthnCredentialByCredentialID(arg0 context.Context, arg1 []byte) (db.WebauthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebauthnCredentialByCredentialID", arg0, arg1)
+	ret0, _ := ret[0].(db.WebauthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebauthnCredentialByCredentialID indicates an expected call of GetWebauthnCredentialByCredentialID.
+func (mr *MockStoreMockRecorder) GetWebauthnCredentialByCredentialID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebauthnCredentialByCredentialID", reflect.TypeOf((*MockStore)(nil).GetWebauthnCredentialByCredentialID), arg0, arg1)
+}
+
+// GetWebhook mocks base method.
+func (m *MockStore) GetWebhook(arg0 context.Context, arg1 int64) (db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", arg0, arg1)
+	ret0, _ := ret[0].(db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockStoreMockRecorder) GetWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockStore)(nil).GetWebhook), arg0, arg1)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(arg0 context.Context, arg1 int64) (db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), arg0, arg1)
+}
+
+// GetWebhookDeliveryList mocks base method.
+func (m *MockStore) GetWebhookDeliveryList(arg0 context.Context, arg1 db.GetWebhookDeliveryListParams) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryList", arg0, arg1)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryList indicates an expected call of GetWebhookDeliveryList.
+func (mr *MockStoreMockRecorder) GetWebhookDeliveryList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryList", reflect.TypeOf((*MockStore)(nil).GetWebhookDeliveryList), arg0, arg1)
+}
+
+// GetWebhookList mocks base method.
+func (m *MockStore) GetWebhookList(arg0 context.Context, arg1 int64) ([]db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookList", arg0, arg1)
+	ret0, _ := ret[0].([]db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookList indicates an expected call of GetWebhookList.
+func (mr *MockStoreMockRecorder) GetWebhookList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookList", reflect.TypeOf((*MockStore)(nil).GetWebhookList), arg0, arg1)
+}
+
+// GetWebhooksForEvent mocks base method.
+func (m *MockStore) GetWebhooksForEvent(arg0 context.Context, arg1 db.GetWebhooksForEventParams) ([]db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksForEvent", arg0, arg1)
+	ret0, _ := ret[0].([]db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksForEvent indicates an expected call of GetWebhooksForEvent.
+func (mr *MockStoreMockRecorder) GetWebhooksForEvent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksForEvent", reflect.TypeOf((*MockStore)(nil).GetWebhooksForEvent), arg0, arg1)
+}
+
+// InstantiateTemplateTx mocks base method.
+func (m *MockStore) InstantiateTemplateTx(arg0 context.Context, arg1 db.InstantiateTemplateTxParams) (db.InstantiateTemplateTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstantiateTemplateTx", arg0, arg1)
+	ret0, _ := ret[0].(db.InstantiateTemplateTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstantiateTemplateTx indicates an expected call of InstantiateTemplateTx.
+func (mr *MockStoreMockRecorder) InstantiateTemplateTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantiateTemplateTx", reflect.TypeOf((*MockStore)(nil).InstantiateTemplateTx), arg0, arg1)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockStore) IsTokenRevoked(arg0 context.Context, arg1 db.IsTokenRevokedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockStoreMockRecorder) IsTokenRevoked(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockStore)(nil).IsTokenRevoked), arg0, arg1)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockStore) ListApiKeys(arg0 context.Context, arg1 int64) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockStoreMockRecorder) ListApiKeys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockStore)(nil).ListApiKeys), arg0, arg1)
+}
+
+// ListSessionFamily mocks base method.
+func (m *MockStore) ListSessionFamily(arg0 context.Context, arg1 db.ListSessionFamilyParams) ([]db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionFamily", arg0, arg1)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionFamily indicates an expected call of ListSessionFamily.
+func (mr *MockStoreMockRecorder) ListSessionFamily(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionFamily", reflect.TypeOf((*MockStore)(nil).ListSessionFamily), arg0, arg1)
+}
+
+// ListSigningKeys mocks base method.
+func (m *MockStore) ListSigningKeys(arg0 context.Context, arg1 time.Time) ([]db.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSigningKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSigningKeys indicates an expected call of ListSigningKeys.
+func (mr *MockStoreMockRecorder) ListSigningKeys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSigningKeys", reflect.TypeOf((*MockStore)(nil).ListSigningKeys), arg0, arg1)
+}
+
+// ListUserSessions mocks base method.
+func (m *MockStore) ListUserSessions(arg0 context.Context, arg1 int64) ([]db.ListUserSessionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserSessionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MockStoreMockRecorder) ListUserSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockStore)(nil).ListUserSessions), arg0, arg1)
+}
+
+// ListWebauthnCredentials mocks base method.
+func (m *MockStore) ListWebauthnCredentials(arg0 context.Context, arg1 int64) ([]db.WebauthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebauthnCredentials", arg0, arg1)
+	ret0, _ := ret[0].([]db.WebauthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebauthnCredentials indicates an expected call of ListWebauthnCredentials.
+func (mr *MockStoreMockRecorder) ListWebauthnCredentials(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebauthnCredentials", reflect.TypeOf((*MockStore)(nil).ListWebauthnCredentials), arg0, arg1)
+}
+
+// LockProject mocks base method.
+func (m *MockStore) LockProject(arg0 context.Context, arg1 int64) (db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockProject", arg0, arg1)
+	ret0, _ := ret[0].(db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockProject indicates an expected call of LockProject.
+func (mr *MockStoreMockRecorder) LockProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockProject", reflect.TypeOf((*MockStore)(nil).LockProject), arg0, arg1)
+}
+
+// LockProjectForShare mocks base method.
+func (m *MockStore) LockProjectForShare(arg0 context.Context, arg1 int64) (db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockProjectForShare", arg0, arg1)
+	ret0, _ := ret[0].(db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockProjectForShare indicates an expected call of LockProjectForShare.
+func (mr *MockStoreMockRecorder) LockProjectForShare(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockProjectForShare", reflect.TypeOf((*MockStore)(nil).LockProjectForShare), arg0, arg1)
+}
+
+// LockProjectStatus mocks base method.
+func (m *MockStore) LockProjectStatus(arg0 context.Context, arg1 int64) (db.ProjectStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockProjectStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.ProjectStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockProjectStatus indicates an expected call of LockProjectStatus.
+func (mr *MockStoreMockRecorder) LockProjectStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockProjectStatus", reflect.TypeOf((*MockStore)(nil).LockProjectStatus), arg0, arg1)
+}
+
+// MarkReminderSent mocks base method.
+func (m *MockStore) MarkReminderSent(arg0 context.Context, arg1 db.MarkReminderSentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockStoreMockRecorder) MarkReminderSent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockStore)(nil).MarkReminderSent), arg0, arg1)
+}
+
+// RecordReminderFailure mocks base method.
+func (m *MockStore) RecordReminderFailure(arg0 context.Context, arg1 db.RecordReminderFailureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReminderFailure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReminderFailure indicates an expected call of RecordReminderFailure.
+func (mr *MockStoreMockRecorder) RecordReminderFailure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReminderFailure", reflect.TypeOf((*MockStore)(nil).RecordReminderFailure), arg0, arg1)
+}
+
+// RecordTotpFailure mocks base method.
+func (m *MockStore) RecordTotpFailure(arg0 context.Context, arg1 db.RecordTotpFailureParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTotpFailure", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordTotpFailure indicates an expected call of RecordTotpFailure.
+func (mr *MockStoreMockRecorder) RecordTotpFailure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTotpFailure", reflect.TypeOf((*MockStore)(nil).RecordTotpFailure), arg0, arg1)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) RecordWebhookDeliveryAttempt(arg0 context.Context, arg1 db.RecordWebhookDeliveryAttemptParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) RecordWebhookDeliveryAttempt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).RecordWebhookDeliveryAttempt), arg0, arg1)
+}
+
+// ReplaceRecoveryCodesTx mocks base method.
+func (m *MockStore) ReplaceRecoveryCodesTx(arg0 context.Context, arg1 db.ReplaceRecoveryCodesTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodesTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodesTx indicates an expected call of ReplaceRecoveryCodesTx.
+func (mr *MockStoreMockRecorder) ReplaceRecoveryCodesTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodesTx", reflect.TypeOf((*MockStore)(nil).ReplaceRecoveryCodesTx), arg0, arg1)
+}
+
+// ReplaceSession mocks base method.
+func (m *MockStore) ReplaceSession(arg0 context.Context, arg1 db.ReplaceSessionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceSession", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceSession indicates an expected call of ReplaceSession.
+func (mr *MockStoreMockRecorder) ReplaceSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSession", reflect.TypeOf((*MockStore)(nil).ReplaceSession), arg0, arg1)
+}
+
+// ReplaceStatusTransitionsTx mocks base method.
+func (m *MockStore) ReplaceStatusTransitionsTx(arg0 context.Context, arg1 db.ReplaceStatusTransitionsTxParams) ([]db.StatusTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceStatusTransitionsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.StatusTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceStatusTransitionsTx indicates an expected call of ReplaceStatusTransitionsTx.
+func (mr *MockStoreMockRecorder) ReplaceStatusTransitionsTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceStatusTransitionsTx", reflect.TypeOf((*MockStore)(nil).ReplaceStatusTransitionsTx), arg0, arg1)
+}
+
+// ResetRelativeReminders mocks base method.
+func (m *MockStore) ResetRelativeReminders(arg0 context.Context, arg1 db.ResetRelativeRemindersParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetRelativeReminders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetRelativeReminders indicates an expected call of ResetRelativeReminders.
+func (mr *MockStoreMockRecorder) ResetRelativeReminders(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetRelativeReminders", reflect.TypeOf((*MockStore)(nil).ResetRelativeReminders), arg0, arg1)
+}
+
+// RevokeToken mocks base method.
+func (m *MockStore) RevokeToken(arg0 context.Context, arg1 db.RevokeTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockStoreMockRecorder) RevokeToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockStore)(nil).RevokeToken), arg0, arg1)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockStore) RevokeUserTokens(arg0 context.Context, arg1 db.RevokeUserTokensParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockStoreMockRecorder) RevokeUserTokens(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockStore)(nil).RevokeUserTokens), arg0, arg1)
+}
+
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(arg0 context.Context, arg1 db.RotateSessionTxParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), arg0, arg1)
+}
+
+// SearchTasks mocks base method.
+func (m *MockStore) SearchTasks(arg0 context.Context, arg1 db.SearchTasksParams) ([]db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", arg0, arg1)
+	ret0, _ := ret[0].([]db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockStoreMockRecorder) SearchTasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockStore)(nil).SearchTasks), arg0, arg1)
+}
+
+// SetCustomFieldValuesTx mocks base method.
+func (m *MockStore) SetCustomFieldValuesTx(arg0 context.Context, arg1 db.SetCustomFieldValuesTxParams) ([]db.TaskCustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCustomFieldValuesTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.TaskCustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCustomFieldValuesTx indicates an expected call of SetCustomFieldValuesTx.
+func (mr *MockStoreMockRecorder) SetCustomFieldValuesTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCustomFieldValuesTx", reflect.TypeOf((*MockStore)(nil).SetCustomFieldValuesTx), arg0, arg1)
+}
+
+// SetDigestNextSendAt mocks base method.
+func (m *MockStore) SetDigestNextSendAt(arg0 context.Context, arg1 db.SetDigestNextSendAtParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDigestNextSendAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDigestNextSendAt indicates an expected call of SetDigestNextSendAt.
+func (mr *MockStoreMockRecorder) SetDigestNextSendAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDigestNextSendAt", reflect.TypeOf((*MockStore)(nil).SetDigestNextSendAt), arg0, arg1)
+}
+
+// SetLoginChallenge mocks base method.
+func (m *MockStore) SetLoginChallenge(arg0 context.Context, arg1 db.SetLoginChallengeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginChallenge indicates an expected call of SetLoginChallenge.
+func (mr *MockStoreMockRecorder) SetLoginChallenge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginChallenge", reflect.TypeOf((*MockStore)(nil).SetLoginChallenge), arg0, arg1)
+}
+
+// SetTaskDeferredUntil mocks base method.
+func (m *MockStore) SetTaskDeferredUntil(arg0 context.Context, arg1 db.SetTaskDeferredUntilParams) (db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskDeferredUntil", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTaskDeferredUntil indicates an expected call of SetTaskDeferredUntil.
+func (mr *MockStoreMockRecorder) SetTaskDeferredUntil(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskDeferredUntil", reflect.TypeOf((*MockStore)(nil).SetTaskDeferredUntil), arg0, arg1)
+}
+
+// SetTaskLabelsTx mocks base method.
+func (m *MockStore) SetTaskLabelsTx(arg0 context.Context, arg1 db.SetTaskLabelsTxParams) ([]db.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskLabelsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTaskLabelsTx indicates an expected call of SetTaskLabelsTx.
+func (mr *MockStoreMockRecorder) SetTaskLabelsTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskLabelsTx", reflect.TypeOf((*MockStore)(nil).SetTaskLabelsTx), arg0, arg1)
+}
+
+// SnoozeReminder mocks base method.
+func (m *MockStore) SnoozeReminder(arg0 context.Context, arg1 db.SnoozeReminderParams) (db.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeReminder", arg0, arg1)
+	ret0, _ := ret[0].(db.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeReminder indicates an expected call of SnoozeReminder.
+func (mr *MockStoreMockRecorder) SnoozeReminder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeReminder", reflect.TypeOf((*MockStore)(nil).SnoozeReminder), arg0, arg1)
+}
+
+// StartTimerTx mocks base method.
+func (m *MockStore) StartTimerTx(arg0 context.Context, arg1 db.StartTimerTxParams) (db.StartTimerTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimerTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StartTimerTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimerTx indicates an expected call of StartTimerTx.
+func (mr *MockStoreMockRecorder) StartTimerTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimerTx", reflect.TypeOf((*MockStore)(nil).StartTimerTx), arg0, arg1)
+}
+
+// StopTimeEntry mocks base method.
+func (m *MockStore) StopTimeEntry(arg0 context.Context, arg1 db.StopTimeEntryParams) (db.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimeEntry indicates an expected call of StopTimeEntry.
+func (mr *MockStoreMockRecorder) StopTimeEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimeEntry", reflect.TypeOf((*MockStore)(nil).StopTimeEntry), arg0, arg1)
+}
+
+// SyncTasksWithStatus mocks base method.
+func (m *MockStore) SyncTasksWithStatus(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncTasksWithStatus", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncTasksWithStatus indicates an expected call of SyncTasksWithStatus.
+func (mr *MockStoreMockRecorder) SyncTasksWithStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncTasksWithStatus", reflect.TypeOf((*MockStore)(nil).SyncTasksWithStatus), arg0, arg1)
+}
+
+// TakeLoginChallenge mocks base method.
+func (m *MockStore) TakeLoginChallenge(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeLoginChallenge", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeLoginChallenge indicates an expected call of TakeLoginChallenge.
+func (mr *MockStoreMockRecorder) TakeLoginChallenge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeLoginChallenge", reflect.TypeOf((*MockStore)(nil).TakeLoginChallenge), arg0, arg1)
+}
+
+// TouchApiKey mocks base method.
+func (m *MockStore) TouchApiKey(arg0 context.Context, arg1 db.TouchApiKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchApiKey indicates an expected call of TouchApiKey.
+func (mr *MockStoreMockRecorder) TouchApiKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchApiKey", reflect.TypeOf((*MockStore)(nil).TouchApiKey), arg0, arg1)
+}
+
+// UnarchiveTask mocks base method.
+func (m *MockStore) UnarchiveTask(arg0 context.Context, arg1 db.UnarchiveTaskParams) (db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveTask", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveTask indicates an expected call of UnarchiveTask.
+func (mr *MockStoreMockRecorder) UnarchiveTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveTask", reflect.TypeOf((*MockStore)(nil).UnarchiveTask), arg0, arg1)
+}
+
+// UnsubscribeDigest mocks base method.
+func (m *MockStore) UnsubscribeDigest(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeDigest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeDigest indicates an expected call of UnsubscribeDigest.
+func (mr *MockStoreMockRecorder) UnsubscribeDigest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeDigest", reflect.TypeOf((*MockStore)(nil).UnsubscribeDigest), arg0, arg1)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockStore) UpdateCustomField(arg0 context.Context, arg1 db.UpdateCustomFieldParams) (db.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", arg0, arg1)
+	ret0, _ := ret[0].(db.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockStoreMockRecorder) UpdateCustomField(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockStore)(nil).UpdateCustomField), arg0, arg1)
+}
+
+// UpdatePasswordResetSession mocks base method.
+func (m *MockStore) UpdatePasswordResetSession(arg0 context.Context, arg1 db.UpdatePasswordResetSessionParams) (db.PasswordResetSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordResetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordResetSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePasswordResetSession indicates an expected call of UpdatePasswordResetSession.
+func (mr *MockStoreMockRecorder) UpdatePasswordResetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordResetSession", reflect.TypeOf((*MockStore)(nil).UpdatePasswordResetSession), arg0, arg1)
+}
+
+// UpdateProject mocks base method.
+func (m *MockStore) UpdateProject(arg0 context.Context, arg1 db.UpdateProjectParams) (db.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", arg0, arg1)
+	ret0, _ := ret[0].(db.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockStoreMockRecorder) UpdateProject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockStore)(nil).UpdateProject), arg0, arg1)
+}
+
+// UpdateProjectStatus mocks base method.
+func (m *MockStore) UpdateProjectStatus(arg0 context.Context, arg1 db.UpdateProjectStatusParams) (db.ProjectStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.ProjectStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjectStatus indicates an expected call of UpdateProjectStatus.
+func (mr *MockStoreMockRecorder) UpdateProjectStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectStatus", reflect.TypeOf((*MockStore)(nil).UpdateProjectStatus), arg0, arg1)
+}
+
+// UpdateProjectStatusTx mocks base method.
+func (m *MockStore) UpdateProjectStatusTx(arg0 context.Context, arg1 db.UpdateProjectStatusParams) (db.ProjectStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectStatusTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ProjectStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjectStatusTx indicates an expected call of UpdateProjectStatusTx.
+func (mr *MockStoreMockRecorder) UpdateProjectStatusTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateProjectStatusTx), arg0, arg1)
+}
+
+// UpdateSavedFilter mocks base method.
+func (m *MockStore) UpdateSavedFilter(arg0 context.Context, arg1 db.UpdateSavedFilterParams) (db.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedFilter", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavedFilter indicates an expected call of UpdateSavedFilter.
+func (mr *MockStoreMockRecorder) UpdateSavedFilter(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedFilter", reflect.TypeOf((*MockStore)(nil).UpdateSavedFilter), arg0, arg1)
+}
+
+// UpdateTask mocks base method.
+func (m *MockStore) UpdateTask(arg0 context.Context, arg1 db.UpdateTaskParams) (db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockStoreMockRecorder) UpdateTask(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockStore)(nil).UpdateTask), arg0, arg1)
+}
+
+// UpdateTaskTemplate mocks base method.
+func (m *MockStore) UpdateTaskTemplate(arg0 context.Context, arg1 db.UpdateTaskTemplateParams) (db.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskTemplate indicates an expected call of UpdateTaskTemplate.
+func (mr *MockStoreMockRecorder) UpdateTaskTemplate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskTemplate", reflect.TypeOf((*MockStore)(nil).UpdateTaskTemplate), arg0, arg1)
+}
+
+// UpdateTaskTx mocks base method.
+func (m *MockStore) UpdateTaskTx(arg0 context.Context, arg1 db.UpdateTaskTxParams) (db.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskTx indicates an expected call of UpdateTaskTx.
+func (mr *MockStoreMockRecorder) UpdateTaskTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskTx", reflect.TypeOf((*MockStore)(nil).UpdateTaskTx), arg0, arg1)
+}
+
+// UpdateTimeEntry mocks base method.
+func (m *MockStore) UpdateTimeEntry(arg0 context.Context, arg1 db.UpdateTimeEntryParams) (db.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimeEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTimeEntry indicates an expected call of UpdateTimeEntry.
+func (mr *MockStoreMockRecorder) UpdateTimeEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeEntry", reflect.TypeOf((*MockStore)(nil).UpdateTimeEntry), arg0, arg1)
+}
+
+// UpdateUser mocks base method.
+func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
+}
+
+// UpdateWebauthnSignCount mocks base method.
+func (m *MockStore) UpdateWebauthnSignCount(arg0 context.Context, arg1 db.UpdateWebauthnSignCountParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebauthnSignCount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebauthnSignCount indicates an expected call of UpdateWebauthnSignCount.
+func (mr *MockStoreMockRecorder) UpdateWebauthnSignCount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebauthnSignCount", reflect.TypeOf((*MockStore)(nil).UpdateWebauthnSignCount), arg0, arg1)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockStore) UpdateWebhook(arg0 context.Context, arg1 db.UpdateWebhookParams) (db.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(db.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockStoreMockRecorder) UpdateWebhook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockStore)(nil).UpdateWebhook), arg0, arg1)
+}
+
+// UpsertArchiveRule mocks base method.
+func (m *MockStore) UpsertArchiveRule(arg0 context.Context, arg1 db.UpsertArchiveRuleParams) (db.ArchiveRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertArchiveRule", arg0, arg1)
+	ret0, _ := ret[0].(db.ArchiveRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertArchiveRule indicates an expected call of UpsertArchiveRule.
+func (mr *MockStoreMockRecorder) UpsertArchiveRule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertArchiveRule", reflect.TypeOf((*MockStore)(nil).UpsertArchiveRule), arg0, arg1)
+}
+
+// UpsertDigestPreference mocks base method.
+func (m *MockStore) UpsertDigestPreference(arg0 context.Context, arg1 db.UpsertDigestPreferenceParams) (db.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDigestPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertDigestPreference indicates an expected call of UpsertDigestPreference.
+func (mr *MockStoreMockRecorder) UpsertDigestPreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDigestPreference", reflect.TypeOf((*MockStore)(nil).UpsertDigestPreference), arg0, arg1)
+}
+
+// UpsertInbox mocks base method.
+func (m *MockStore) UpsertInbox(arg0 context.Context, arg1 db.UpsertInboxParams) (db.Inbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInbox", arg0, arg1)
+	ret0, _ := ret[0].(db.Inbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertInbox indicates an expected call of UpsertInbox.
+func (mr *MockStoreMockRecorder) UpsertInbox(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInbox", reflect.TypeOf((*MockStore)(nil).UpsertInbox), arg0, arg1)
+}
+
+// UpsertLabel mocks base method.
+func (m *MockStore) UpsertLabel(arg0 context.Context, arg1 db.UpsertLabelParams) (db.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLabel", arg0, arg1)
+	ret0, _ := ret[0].(db.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertLabel indicates an expected call of UpsertLabel.
+func (mr *MockStoreMockRecorder) UpsertLabel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLabel", reflect.TypeOf((*MockStore)(nil).UpsertLabel), arg0, arg1)
+}
+
+// UpsertPendingTotp mocks base method.
+func (m *MockStore) UpsertPendingTotp(arg0 context.Context, arg1 db.UpsertPendingTotpParams) (db.UserTotp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPendingTotp", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTotp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPendingTotp indicates an expected call of UpsertPendingTotp.
+func (mr *MockStoreMockRecorder) UpsertPendingTotp(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPendingTotp", reflect.TypeOf((*MockStore)(nil).UpsertPendingTotp), arg0, arg1)
+}
+
+// UpsertTaskCustomFieldValue mocks base method.
+func (m *MockStore) UpsertTaskCustomFieldValue(arg0 context.Context, arg1 db.UpsertTaskCustomFieldValueParams) (db.TaskCustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTaskCustomFieldValue", arg0, arg1)
+	ret0, _ := ret[0].(db.TaskCustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTaskCustomFieldValue indicates an expected call of UpsertTaskCustomFieldValue.
+func (mr *MockStoreMockRecorder) UpsertTaskCustomFieldValue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTaskCustomFieldValue", reflect.TypeOf((*MockStore)(nil).UpsertTaskCustomFieldValue), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockStore) UseRecoveryCode(arg0 context.Context, arg1 db.UseRecoveryCodeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockStoreMockRecorder) UseRecoveryCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseRecoveryCode), arg0, arg1)
+}
+
+// UseTotpStep mocks base method.
+func (m *MockStore) UseTotpStep(arg0 context.Context, arg1 db.UseTotpStepParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTotpStep", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTotpStep indicates an expected call of UseTotpStep.
+func (mr *MockStoreMockRecorder) UseTotpStep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTotpStep", reflect.TypeOf((*MockStore)(nil).UseTotpStep), arg0, arg1)
+}
+
+// VerifyUserEmail mocks base method.
+func (m *MockStore) VerifyUserEmail(arg0 context.Context, arg1 db.VerifyUserEmailParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockStoreMockRecorder) VerifyUserEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockStore)(nil).VerifyUserEmail), arg0, arg1)
 }
@@ -0,0 +1,31 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    user_id,
+    name,
+    prefix,
+    key_hash,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1 LIMIT 1;
+
+-- name: ListApiKeys :many
+SELECT * FROM api_keys
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = sqlc.arg(now)::timestamptz
+WHERE
+    id = sqlc.arg(id) AND
+    (last_used_at IS NULL OR last_used_at < sqlc.arg(used_before)::timestamptz);
+
+-- name: DeleteApiKey :execrows
+DELETE FROM api_keys
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,54 @@
+-- name: GetArchiveRule :one
+SELECT * FROM archive_rules
+WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertArchiveRule :one
+INSERT INTO archive_rules (
+    user_id,
+    after_days
+) VALUES (
+    $1, $2
+) ON CONFLICT (user_id) DO UPDATE
+SET
+    after_days = EXCLUDED.after_days,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteArchiveRule :exec
+DELETE FROM archive_rules
+WHERE user_id = $1;
+
+-- name: ArchiveDoneTasks :execrows
+UPDATE tasks
+SET archived_at = sqlc.arg(now)::timestamptz
+WHERE id IN (
+    SELECT tasks.id FROM tasks
+    JOIN archive_rules ON archive_rules.user_id = tasks.owner_id
+    WHERE
+        tasks.is_done AND
+        tasks.archived_at IS NULL AND
+        tasks.completed_at <= sqlc.arg(now)::timestamptz - make_interval(days => archive_rules.after_days)
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE OF tasks SKIP LOCKED
+);
+
+-- name: ArchiveTask :one
+UPDATE tasks
+SET archived_at = COALESCE(archived_at, now())
+WHERE id = $1 AND owner_id = $2 AND is_done
+RETURNING *;
+
+-- name: UnarchiveTask :one
+UPDATE tasks
+SET archived_at = NULL
+WHERE id = $1 AND owner_id = $2
+RETURNING *;
+
+-- name: GetArchivedTaskList :many
+SELECT * FROM tasks
+WHERE
+    owner_id = $1 AND
+    archived_at IS NOT NULL
+ORDER BY archived_at DESC, id DESC
+LIMIT $2
+OFFSET $3;
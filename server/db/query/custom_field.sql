@@ -0,0 +1,71 @@
+-- name: CreateCustomField :one
+INSERT INTO custom_fields (
+    project_id,
+    name,
+    field_type,
+    options,
+    rules,
+    position
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetCustomField :one
+SELECT * FROM custom_fields
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCustomFieldList :many
+SELECT * FROM custom_fields
+WHERE
+    project_id = $1
+ORDER BY position, id;
+
+-- name: GetCustomFieldListByOwner :many
+SELECT custom_fields.* FROM custom_fields
+JOIN projects ON projects.id = custom_fields.project_id
+WHERE
+    projects.owner_id = $1
+ORDER BY custom_fields.project_id, custom_fields.position, custom_fields.id;
+
+-- name: UpdateCustomField :one
+UPDATE custom_fields
+SET
+    name = $2,
+    options = $3,
+    rules = $4,
+    position = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCustomField :exec
+DELETE FROM custom_fields
+WHERE id = $1;
+
+-- name: UpsertTaskCustomFieldValue :one
+INSERT INTO task_custom_field_values (
+    task_id,
+    field_id,
+    value
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (task_id, field_id) DO UPDATE
+SET
+    value = EXCLUDED.value,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteTaskCustomFieldValue :exec
+DELETE FROM task_custom_field_values
+WHERE task_id = $1 AND field_id = $2;
+
+-- name: GetTaskCustomFieldValues :many
+SELECT * FROM task_custom_field_values
+WHERE
+    task_id = $1
+ORDER BY field_id;
+
+-- name: GetCustomFieldValuesByTasks :many
+SELECT * FROM task_custom_field_values
+WHERE
+    task_id = ANY(sqlc.arg(task_ids)::bigint[])
+ORDER BY task_id, field_id;
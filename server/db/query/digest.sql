@@ -0,0 +1,51 @@
+-- name: GetDigestPreference :one
+SELECT * FROM digest_preferences
+WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertDigestPreference :one
+INSERT INTO digest_preferences (
+    user_id,
+    frequency,
+    send_minute,
+    weekday,
+    timezone,
+    next_send_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) ON CONFLICT (user_id) DO UPDATE
+SET
+    frequency = EXCLUDED.frequency,
+    send_minute = EXCLUDED.send_minute,
+    weekday = EXCLUDED.weekday,
+    timezone = EXCLUDED.timezone,
+    next_send_at = EXCLUDED.next_send_at,
+    updated_at = now()
+RETURNING *;
+
+-- name: UnsubscribeDigest :exec
+UPDATE digest_preferences
+SET
+    frequency = 'off',
+    next_send_at = NULL,
+    updated_at = now()
+WHERE user_id = $1;
+
+-- name: ClaimDueDigest :one
+SELECT digest_preferences.user_id, digest_preferences.frequency, digest_preferences.send_minute,
+    digest_preferences.weekday, digest_preferences.timezone, digest_preferences.next_send_at,
+    digest_preferences.last_sent_at, users.username, users.email
+FROM digest_preferences
+JOIN users ON users.id = digest_preferences.user_id
+WHERE
+    digest_preferences.frequency <> 'off' AND
+    digest_preferences.next_send_at <= sqlc.arg(now)
+ORDER BY digest_preferences.next_send_at
+LIMIT 1
+FOR UPDATE OF digest_preferences SKIP LOCKED;
+
+-- name: SetDigestNextSendAt :exec
+UPDATE digest_preferences
+SET
+    next_send_at = $2,
+    last_sent_at = COALESCE(sqlc.narg(last_sent_at), last_sent_at)
+WHERE user_id = $1;
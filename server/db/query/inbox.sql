@@ -0,0 +1,19 @@
+-- name: GetInbox :one
+SELECT * FROM inboxes
+WHERE user_id = $1 LIMIT 1;
+
+-- name: GetInboxByToken :one
+SELECT * FROM inboxes
+WHERE token = $1 LIMIT 1;
+
+-- name: UpsertInbox :one
+INSERT INTO inboxes (
+    user_id,
+    token
+) VALUES (
+    $1, $2
+) ON CONFLICT (user_id) DO UPDATE
+SET
+    token = EXCLUDED.token,
+    created_at = now()
+RETURNING *;
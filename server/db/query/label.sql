@@ -0,0 +1,45 @@
+-- name: UpsertLabel :one
+INSERT INTO labels (
+    owner_id,
+    name
+) VALUES (
+    $1, $2
+) ON CONFLICT (owner_id, name) DO UPDATE
+SET
+    name = EXCLUDED.name
+RETURNING *;
+
+-- name: GetLabelList :many
+SELECT * FROM labels
+WHERE
+    owner_id = $1
+ORDER BY name;
+
+-- name: DeleteLabel :exec
+DELETE FROM labels
+WHERE id = $1 AND owner_id = $2;
+
+-- name: AddTaskLabel :exec
+INSERT INTO task_labels (
+    task_id,
+    label_id
+) VALUES (
+    $1, $2
+) ON CONFLICT DO NOTHING;
+
+-- name: DeleteTaskLabels :exec
+DELETE FROM task_labels
+WHERE task_id = $1;
+
+-- name: GetLabelsByTasks :many
+SELECT task_labels.task_id, labels.id, labels.name FROM task_labels
+JOIN labels ON labels.id = task_labels.label_id
+WHERE
+    task_labels.task_id = ANY(sqlc.arg(task_ids)::bigint[])
+ORDER BY task_labels.task_id, labels.name;
+
+-- name: GetLabelsByIds :many
+SELECT * FROM labels
+WHERE
+    id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY id;
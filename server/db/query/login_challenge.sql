@@ -0,0 +1,20 @@
+-- name: SetLoginChallenge :exec
+INSERT INTO login_challenges (
+    key,
+    value,
+    expires_at
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (key) DO UPDATE
+SET
+    value = EXCLUDED.value,
+    expires_at = EXCLUDED.expires_at;
+
+-- name: TakeLoginChallenge :one
+DELETE FROM login_challenges
+WHERE key = $1 AND expires_at > now()
+RETURNING value;
+
+-- name: DeleteExpiredLoginChallenges :execrows
+DELETE FROM login_challenges
+WHERE expires_at < $1;
@@ -0,0 +1,67 @@
+-- name: GetUserTotp :one
+SELECT * FROM user_totp
+WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertPendingTotp :one
+INSERT INTO user_totp (
+    user_id,
+    secret
+) VALUES (
+    $1, $2
+) ON CONFLICT (user_id) DO UPDATE
+SET
+    secret = EXCLUDED.secret,
+    last_step = NULL,
+    failed_attempts = 0,
+    locked_until = NULL,
+    created_at = now()
+WHERE user_totp.enabled_at IS NULL
+RETURNING *;
+
+-- name: EnableUserTotp :one
+UPDATE user_totp
+SET
+    enabled_at = now(),
+    last_step = $2
+WHERE user_id = $1 AND enabled_at IS NULL
+RETURNING *;
+
+-- name: DeleteUserTotp :exec
+DELETE FROM user_totp
+WHERE user_id = $1;
+
+-- name: UseTotpStep :execrows
+UPDATE user_totp
+SET
+    last_step = sqlc.arg(step),
+    failed_attempts = 0
+WHERE
+    user_id = sqlc.arg(user_id) AND
+    (last_step IS NULL OR last_step < sqlc.arg(step));
+
+-- name: RecordTotpFailure :one
+UPDATE user_totp
+SET
+    failed_attempts = CASE WHEN failed_attempts + 1 >= sqlc.arg(max_attempts)::int THEN 0 ELSE failed_attempts + 1 END,
+    locked_until = CASE WHEN failed_attempts + 1 >= sqlc.arg(max_attempts)::int THEN sqlc.arg(locked_until)::timestamptz ELSE locked_until END
+WHERE user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: CreateRecoveryCodes :exec
+INSERT INTO mfa_recovery_codes (
+    user_id,
+    code_hash
+) SELECT sqlc.arg(user_id), unnest(sqlc.arg(code_hashes)::bytea[]);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = $1;
+
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountRecoveryCodes :one
+SELECT count(*) FROM mfa_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
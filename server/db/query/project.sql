@@ -0,0 +1,46 @@
+-- name: CreateProject :one
+INSERT INTO projects (
+    name,
+    owner_id
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetProject :one
+SELECT * FROM projects
+WHERE id = $1 LIMIT 1;
+
+-- name: LockProject :one
+-- taken by transactions that change the workflow of a project
+SELECT * FROM projects
+WHERE id = $1
+FOR NO KEY UPDATE;
+
+-- name: LockProjectForShare :one
+-- taken by transactions that move tasks along the workflow of a project
+SELECT * FROM projects
+WHERE id = $1
+FOR SHARE;
+
+-- name: GetProjectList :many
+SELECT * FROM projects
+WHERE
+    owner_id = $1
+ORDER BY id;
+
+-- name: UpdateProject :one
+UPDATE projects
+SET
+    name = $3
+WHERE id = $1 AND owner_id = $2
+RETURNING *;
+
+-- name: DeleteProject :exec
+DELETE FROM projects
+WHERE id = $1 AND owner_id = $2;
+
+-- name: GetProjectsByIds :many
+SELECT * FROM projects
+WHERE
+    id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY id;
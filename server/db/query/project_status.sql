@@ -0,0 +1,68 @@
+-- name: CreateProjectStatus :one
+INSERT INTO project_statuses (
+    project_id,
+    name,
+    category,
+    position,
+    wip_limit
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetProjectStatus :one
+SELECT * FROM project_statuses
+WHERE id = $1 LIMIT 1;
+
+-- name: LockProjectStatus :one
+SELECT * FROM project_statuses
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetProjectStatusList :many
+SELECT * FROM project_statuses
+WHERE
+    project_id = $1
+ORDER BY position, id;
+
+-- name: UpdateProjectStatus :one
+UPDATE project_statuses
+SET
+    name = $2,
+    category = $3,
+    position = $4,
+    wip_limit = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: SyncTasksWithStatus :execrows
+-- the tasks of a status are done exactly when the status is in the done category
+UPDATE tasks t
+SET
+    is_done = (s.category = 'done'),
+    completed_at = CASE WHEN s.category = 'done' THEN COALESCE(t.completed_at, now()) END,
+    archived_at = CASE WHEN s.category = 'done' THEN t.archived_at END
+FROM project_statuses s
+WHERE s.id = $1 AND t.status_id = s.id AND t.is_done <> (s.category = 'done');
+
+-- name: DeleteProjectStatus :exec
+DELETE FROM project_statuses
+WHERE id = $1;
+
+-- name: CreateStatusTransition :one
+INSERT INTO status_transitions (
+    project_id,
+    from_status_id,
+    to_status_id
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetStatusTransitionList :many
+SELECT * FROM status_transitions
+WHERE
+    project_id = $1
+ORDER BY from_status_id, to_status_id;
+
+-- name: DeleteStatusTransitions :exec
+DELETE FROM status_transitions
+WHERE project_id = $1;
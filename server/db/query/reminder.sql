@@ -0,0 +1,73 @@
+-- name: CreateReminder :one
+INSERT INTO reminders (
+    task_id,
+    owner_id,
+    remind_at,
+    offset_minutes,
+    fire_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetReminder :one
+SELECT * FROM reminders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetReminderListByTask :many
+SELECT * FROM reminders
+WHERE
+    task_id = $1
+ORDER BY id;
+
+-- name: DeleteReminder :exec
+DELETE FROM reminders
+WHERE id = $1 AND owner_id = $2;
+
+-- name: SnoozeReminder :one
+UPDATE reminders
+SET
+    fire_at = $2,
+    sent_at = NULL,
+    attempts = 0,
+    last_error = ''
+WHERE id = $1
+RETURNING *;
+
+-- name: ResetRelativeReminders :exec
+UPDATE reminders
+SET
+    fire_at = sqlc.narg(due_at)::timestamptz - make_interval(mins => offset_minutes)
+WHERE
+    task_id = sqlc.arg(task_id) AND
+    offset_minutes IS NOT NULL AND
+    sent_at IS NULL;
+
+-- name: ClaimDueReminder :one
+SELECT reminders.id, reminders.task_id, reminders.fire_at, reminders.attempts,
+    tasks.body, tasks.due_at, users.username, users.email
+FROM reminders
+JOIN tasks ON tasks.id = reminders.task_id
+JOIN users ON users.id = reminders.owner_id
+WHERE
+    reminders.sent_at IS NULL AND
+    reminders.fire_at <= sqlc.arg(now) AND
+    reminders.attempts < sqlc.arg(max_attempts) AND
+    NOT tasks.is_done AND
+    tasks.archived_at IS NULL
+ORDER BY reminders.fire_at
+LIMIT 1
+FOR UPDATE OF reminders SKIP LOCKED;
+
+-- name: MarkReminderSent :exec
+UPDATE reminders
+SET
+    sent_at = $2
+WHERE id = $1;
+
+-- name: RecordReminderFailure :exec
+UPDATE reminders
+SET
+    attempts = attempts + 1,
+    last_error = $2,
+    fire_at = $3
+WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: CreateSavedFilter :one
+INSERT INTO saved_filters (
+    owner_id,
+    name,
+    query
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetSavedFilter :one
+SELECT * FROM saved_filters
+WHERE id = $1 LIMIT 1;
+
+-- name: GetSavedFilterList :many
+SELECT * FROM saved_filters
+WHERE
+    owner_id = $1
+ORDER BY name;
+
+-- name: UpdateSavedFilter :one
+UPDATE saved_filters
+SET
+    name = $3,
+    query = $4
+WHERE id = $1 AND owner_id = $2
+RETURNING *;
+
+-- name: DeleteSavedFilter :exec
+DELETE FROM saved_filters
+WHERE id = $1 AND owner_id = $2;
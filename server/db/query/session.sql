@@ -1,14 +1,17 @@
 -- name: CreateSession :one
 INSERT INTO sessions (
     id,
+    family_id,
     user_id,
     refresh_token,
     user_agent,
     client_ip,
     is_blocked,
-    expires_at
+    expires_at,
+    access_token_id,
+    access_token_expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetSession :one
@@ -19,4 +22,56 @@ LIMIT 1;
 
 -- name: DeleteSession :exec
 DELETE FROM sessions
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ReplaceSession :execrows
+UPDATE sessions
+SET replaced_by = sqlc.arg(replaced_by)::uuid
+WHERE
+    id = sqlc.arg(id) AND
+    replaced_by IS NULL;
+
+-- name: BlockSession :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1;
+
+-- name: DeleteCurrentSession :execrows
+DELETE FROM sessions
+WHERE
+    family_id = $1 AND
+    user_id = $2 AND
+    replaced_by IS NULL;
+
+-- name: ListSessionFamily :many
+SELECT * FROM sessions
+WHERE family_id = $1 AND user_id = $2
+ORDER BY created_at;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM sessions
+WHERE user_id = $1;
+
+-- name: ListUserSessions :many
+SELECT
+    s.family_id,
+    s.id,
+    s.access_token_id,
+    s.access_token_expires_at,
+    f.user_agent,
+    s.client_ip,
+    f.created_at,
+    s.created_at AS last_used_at,
+    s.expires_at
+FROM sessions s
+JOIN sessions f ON f.id = s.family_id
+WHERE
+    s.user_id = $1 AND
+    s.replaced_by IS NULL AND
+    NOT s.is_blocked AND
+    s.expires_at > now()
+ORDER BY s.created_at DESC;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < $1;
@@ -0,0 +1,19 @@
+-- name: CreateSigningKey :one
+INSERT INTO signing_keys (
+    id,
+    private_key,
+    not_before,
+    not_after
+) VALUES (
+    $1, $2, $3, $4
+) ON CONFLICT (not_before) DO NOTHING
+RETURNING *;
+
+-- name: ListSigningKeys :many
+SELECT * FROM signing_keys
+WHERE not_after > $1
+ORDER BY not_before;
+
+-- name: DeleteExpiredSigningKeys :execrows
+DELETE FROM signing_keys
+WHERE not_after < $1;
@@ -0,0 +1,110 @@
+-- name: GetTaskCountsByPeriod :many
+WITH created AS (
+    SELECT date_trunc(sqlc.arg(period)::text, created_at AT TIME ZONE sqlc.arg(timezone)::text)::date AS period_start, count(*) AS tasks
+    FROM tasks
+    WHERE
+        owner_id = sqlc.arg(owner_id) AND
+        created_at >= sqlc.arg(from_time)::timestamptz AND
+        created_at < sqlc.arg(to_time)::timestamptz
+    GROUP BY 1
+), completed AS (
+    SELECT date_trunc(sqlc.arg(period)::text, completed_at AT TIME ZONE sqlc.arg(timezone)::text)::date AS period_start, count(*) AS tasks
+    FROM tasks
+    WHERE
+        owner_id = sqlc.arg(owner_id) AND
+        completed_at >= sqlc.arg(from_time)::timestamptz AND
+        completed_at < sqlc.arg(to_time)::timestamptz
+    GROUP BY 1
+)
+SELECT
+    COALESCE(created.period_start, completed.period_start)::date AS period_start,
+    COALESCE(created.tasks, 0)::bigint AS created,
+    COALESCE(completed.tasks, 0)::bigint AS completed
+FROM created
+FULL JOIN completed ON completed.period_start = created.period_start
+ORDER BY 1;
+
+-- name: GetCompletionStreaks :one
+WITH days AS (
+    SELECT DISTINCT (completed_at AT TIME ZONE sqlc.arg(timezone)::text)::date AS day
+    FROM tasks
+    WHERE owner_id = sqlc.arg(owner_id) AND completed_at IS NOT NULL
+), streaks AS (
+    -- consecutive days share day - row_number
+    SELECT max(day) AS last_day, count(*) AS days
+    FROM (
+        SELECT day, day - (row_number() OVER (ORDER BY day))::int AS streak
+        FROM days
+    ) numbered
+    GROUP BY streak
+)
+SELECT
+    COALESCE(max(days) FILTER (WHERE last_day >= sqlc.arg(today)::date - 1), 0)::bigint AS current_streak,
+    COALESCE(max(days), 0)::bigint AS longest_streak
+FROM streaks;
+
+-- name: GetTaskStatsSummary :one
+SELECT
+    count(*) FILTER (WHERE created_at >= sqlc.arg(from_time)::timestamptz AND created_at < sqlc.arg(to_time)::timestamptz) AS created,
+    count(*) FILTER (WHERE completed_at >= sqlc.arg(from_time)::timestamptz AND completed_at < sqlc.arg(to_time)::timestamptz) AS completed,
+    COALESCE(avg(EXTRACT(EPOCH FROM completed_at - created_at)) FILTER (
+        WHERE completed_at >= sqlc.arg(from_time)::timestamptz AND completed_at < sqlc.arg(to_time)::timestamptz
+    ), 0)::float8 AS avg_completion_seconds,
+    count(*) FILTER (WHERE due_at >= sqlc.arg(from_time)::timestamptz AND due_at < LEAST(sqlc.arg(to_time)::timestamptz, now())) AS due,
+    count(*) FILTER (
+        WHERE due_at >= sqlc.arg(from_time)::timestamptz AND due_at < LEAST(sqlc.arg(to_time)::timestamptz, now()) AND
+        (completed_at IS NULL OR completed_at > due_at)
+    ) AS overdue
+FROM tasks
+WHERE owner_id = sqlc.arg(owner_id);
+
+-- name: GetTaskStatsByProject :many
+SELECT
+    tasks.project_id,
+    COALESCE(projects.name, '')::text AS name,
+    count(*) FILTER (WHERE tasks.created_at >= sqlc.arg(from_time)::timestamptz AND tasks.created_at < sqlc.arg(to_time)::timestamptz) AS created,
+    count(*) FILTER (WHERE tasks.completed_at >= sqlc.arg(from_time)::timestamptz AND tasks.completed_at < sqlc.arg(to_time)::timestamptz) AS completed,
+    COALESCE(avg(EXTRACT(EPOCH FROM tasks.completed_at - tasks.created_at)) FILTER (
+        WHERE tasks.completed_at >= sqlc.arg(from_time)::timestamptz AND tasks.completed_at < sqlc.arg(to_time)::timestamptz
+    ), 0)::float8 AS avg_completion_seconds,
+    count(*) FILTER (WHERE tasks.due_at >= sqlc.arg(from_time)::timestamptz AND tasks.due_at < LEAST(sqlc.arg(to_time)::timestamptz, now())) AS due,
+    count(*) FILTER (
+        WHERE tasks.due_at >= sqlc.arg(from_time)::timestamptz AND tasks.due_at < LEAST(sqlc.arg(to_time)::timestamptz, now()) AND
+        (tasks.completed_at IS NULL OR tasks.completed_at > tasks.due_at)
+    ) AS overdue
+FROM tasks
+LEFT JOIN projects ON projects.id = tasks.project_id
+WHERE
+    tasks.owner_id = sqlc.arg(owner_id) AND (
+        (tasks.created_at >= sqlc.arg(from_time)::timestamptz AND tasks.created_at < sqlc.arg(to_time)::timestamptz) OR
+        (tasks.completed_at >= sqlc.arg(from_time)::timestamptz AND tasks.completed_at < sqlc.arg(to_time)::timestamptz) OR
+        (tasks.due_at >= sqlc.arg(from_time)::timestamptz AND tasks.due_at < sqlc.arg(to_time)::timestamptz)
+    )
+GROUP BY tasks.project_id, projects.name
+ORDER BY tasks.project_id NULLS FIRST;
+
+-- name: GetTaskStatsByLabel :many
+SELECT
+    labels.id AS label_id,
+    labels.name,
+    count(*) FILTER (WHERE tasks.created_at >= sqlc.arg(from_time)::timestamptz AND tasks.created_at < sqlc.arg(to_time)::timestamptz) AS created,
+    count(*) FILTER (WHERE tasks.completed_at >= sqlc.arg(from_time)::timestamptz AND tasks.completed_at < sqlc.arg(to_time)::timestamptz) AS completed,
+    COALESCE(avg(EXTRACT(EPOCH FROM tasks.completed_at - tasks.created_at)) FILTER (
+        WHERE tasks.completed_at >= sqlc.arg(from_time)::timestamptz AND tasks.completed_at < sqlc.arg(to_time)::timestamptz
+    ), 0)::float8 AS avg_completion_seconds,
+    count(*) FILTER (WHERE tasks.due_at >= sqlc.arg(from_time)::timestamptz AND tasks.due_at < LEAST(sqlc.arg(to_time)::timestamptz, now())) AS due,
+    count(*) FILTER (
+        WHERE tasks.due_at >= sqlc.arg(from_time)::timestamptz AND tasks.due_at < LEAST(sqlc.arg(to_time)::timestamptz, now()) AND
+        (tasks.completed_at IS NULL OR tasks.completed_at > tasks.due_at)
+    ) AS overdue
+FROM tasks
+JOIN task_labels ON task_labels.task_id = tasks.id
+JOIN labels ON labels.id = task_labels.label_id
+WHERE
+    tasks.owner_id = sqlc.arg(owner_id) AND (
+        (tasks.created_at >= sqlc.arg(from_time)::timestamptz AND tasks.created_at < sqlc.arg(to_time)::timestamptz) OR
+        (tasks.completed_at >= sqlc.arg(from_time)::timestamptz AND tasks.completed_at < sqlc.arg(to_time)::timestamptz) OR
+        (tasks.due_at >= sqlc.arg(from_time)::timestamptz AND tasks.due_at < sqlc.arg(to_time)::timestamptz)
+    )
+GROUP BY labels.id, labels.name
+ORDER BY labels.name;
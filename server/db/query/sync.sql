@@ -0,0 +1,15 @@
+-- name: GetSyncSnapshotXmin :one
+SELECT pg_snapshot_xmin(pg_current_snapshot())::text AS xmin;
+
+-- name: GetSyncChanges :many
+SELECT entity, entity_id, owner_id, seq, changed_at, deleted FROM sync_changes
+WHERE
+    owner_id = sqlc.arg(owner_id) AND
+    change_xid >= sqlc.arg(since)::text::xid8 AND
+    seq > sqlc.arg(after_seq)
+ORDER BY seq
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetSyncChange :one
+SELECT entity, entity_id, owner_id, seq, changed_at, deleted FROM sync_changes
+WHERE entity = $1 AND entity_id = $2 LIMIT 1;
@@ -1,31 +1,105 @@
 -- name: CreateTask :one
 INSERT INTO tasks (
     body,
-    owner_id
+    owner_id,
+    project_id,
+    status_id,
+    is_done,
+    due_at,
+    priority,
+    estimate_minutes,
+    parent_id,
+    completed_at
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $5::boolean THEN now() END
 ) RETURNING *;
 
 -- name: GetTask :one
 SELECT * FROM tasks
 WHERE id = $1 LIMIT 1;
 
+-- name: GetSubtasks :many
+SELECT * FROM tasks
+WHERE parent_id = $1
+ORDER BY id;
+
 -- name: GetTaskList :many
 SELECT * FROM tasks
 WHERE
-    owner_id = $1
+    owner_id = $1 AND
+    archived_at IS NULL AND
+    (deferred_until IS NULL OR deferred_until <= now())
 ORDER BY id
 LIMIT $2
 OFFSET $3;
 
+-- name: GetTaskListByProject :many
+SELECT * FROM tasks
+WHERE
+    project_id = $1 AND
+    archived_at IS NULL
+ORDER BY id;
+
+-- name: CountTasksByStatus :one
+SELECT count(*) FROM tasks
+WHERE status_id = $1;
+
 -- name: UpdateTask :one
+-- priority is kept when it is null, due_at and estimate_minutes unless they are set (to clear them)
 UPDATE tasks
 SET 
-    body = $3,
-    is_done = $4
+    body = sqlc.arg(body),
+    is_done = sqlc.arg(is_done),
+    status_id = sqlc.arg(status_id),
+    due_at = CASE WHEN sqlc.arg(set_due_at)::boolean THEN sqlc.narg(due_at) ELSE due_at END,
+    priority = COALESCE(sqlc.narg(priority), priority),
+    estimate_minutes = CASE WHEN sqlc.arg(set_estimate_minutes)::boolean THEN sqlc.narg(estimate_minutes) ELSE estimate_minutes END,
+    completed_at = CASE WHEN sqlc.arg(is_done)::boolean THEN COALESCE(completed_at, now()) END,
+    archived_at = CASE WHEN sqlc.arg(is_done)::boolean THEN archived_at END
+WHERE id = sqlc.arg(id) AND owner_id = sqlc.arg(owner_id)
+RETURNING *;
+
+-- name: SetTaskDeferredUntil :one
+UPDATE tasks
+SET deferred_until = $3
 WHERE id = $1 AND owner_id = $2
 RETURNING *;
 
 -- name: DeleteTask :exec
 DELETE FROM tasks
-WHERE id = $1 AND owner_id = $2;
\ No newline at end of file
+WHERE id = $1 AND owner_id = $2;
+
+-- name: GetTasksDueBetween :many
+SELECT * FROM tasks
+WHERE
+    owner_id = sqlc.arg(owner_id) AND
+    NOT is_done AND
+    due_at >= sqlc.arg(from_time) AND
+    due_at < sqlc.arg(to_time)
+ORDER BY due_at, id
+LIMIT 100;
+
+-- name: GetOverdueTasks :many
+SELECT * FROM tasks
+WHERE
+    owner_id = sqlc.arg(owner_id) AND
+    NOT is_done AND
+    due_at < sqlc.arg(before)
+ORDER BY due_at, id
+LIMIT 100;
+
+-- name: GetTasksCompletedBetween :many
+SELECT * FROM tasks
+WHERE
+    owner_id = sqlc.arg(owner_id) AND
+    is_done AND
+    completed_at >= sqlc.arg(from_time) AND
+    completed_at < sqlc.arg(to_time)
+ORDER BY completed_at, id
+LIMIT 100;
+
+-- name: GetTasksByIds :many
+SELECT * FROM tasks
+WHERE
+    id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY id;
@@ -0,0 +1,20 @@
+-- name: CreateTaskAttachment :one
+INSERT INTO task_attachments (
+    task_id,
+    filename,
+    content_type,
+    size,
+    content
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, task_id, filename, content_type, size, created_at;
+
+-- name: GetTaskAttachment :one
+SELECT * FROM task_attachments
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTaskAttachmentList :many
+SELECT id, task_id, filename, content_type, size, created_at FROM task_attachments
+WHERE
+    task_id = $1
+ORDER BY id;
@@ -0,0 +1,38 @@
+-- name: CreateTaskTemplate :one
+INSERT INTO task_templates (
+    owner_id,
+    name,
+    body,
+    priority,
+    labels,
+    due_offset_minutes,
+    subtasks
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetTaskTemplate :one
+SELECT * FROM task_templates
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTaskTemplateList :many
+SELECT * FROM task_templates
+WHERE
+    owner_id = $1
+ORDER BY name, id;
+
+-- name: UpdateTaskTemplate :one
+UPDATE task_templates
+SET
+    name = $3,
+    body = $4,
+    priority = $5,
+    labels = $6,
+    due_offset_minutes = $7,
+    subtasks = $8
+WHERE id = $1 AND owner_id = $2
+RETURNING *;
+
+-- name: DeleteTaskTemplate :exec
+DELETE FROM task_templates
+WHERE id = $1 AND owner_id = $2;
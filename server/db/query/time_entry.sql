@@ -0,0 +1,56 @@
+-- name: CreateTimeEntry :one
+INSERT INTO time_entries (
+    owner_id,
+    task_id,
+    started_at,
+    ended_at,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetTimeEntry :one
+SELECT * FROM time_entries
+WHERE id = $1 LIMIT 1;
+
+-- name: GetRunningTimeEntry :one
+SELECT * FROM time_entries
+WHERE owner_id = $1 AND ended_at IS NULL
+LIMIT 1;
+
+-- name: GetTimeEntryListByTask :many
+SELECT * FROM time_entries
+WHERE
+    task_id = $1
+ORDER BY started_at, id;
+
+-- name: GetTimeEntriesBetween :many
+SELECT time_entries.id, time_entries.task_id, time_entries.started_at, time_entries.ended_at,
+    time_entries.note, tasks.body, tasks.project_id, tasks.estimate_minutes
+FROM time_entries
+JOIN tasks ON tasks.id = time_entries.task_id
+WHERE
+    time_entries.owner_id = sqlc.arg(owner_id) AND
+    (time_entries.ended_at IS NULL OR time_entries.ended_at > sqlc.arg(from_time)::timestamptz) AND
+    time_entries.started_at < sqlc.arg(to_time)::timestamptz
+ORDER BY time_entries.started_at, time_entries.id;
+
+-- name: StopTimeEntry :one
+UPDATE time_entries
+SET
+    ended_at = GREATEST(sqlc.arg(ended_at)::timestamptz, started_at)
+WHERE owner_id = sqlc.arg(owner_id) AND ended_at IS NULL
+RETURNING *;
+
+-- name: UpdateTimeEntry :one
+UPDATE time_entries
+SET
+    started_at = $3,
+    ended_at = $4,
+    note = $5
+WHERE id = $1 AND owner_id = $2
+RETURNING *;
+
+-- name: DeleteTimeEntry :exec
+DELETE FROM time_entries
+WHERE id = $1 AND owner_id = $2;
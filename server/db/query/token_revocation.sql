@@ -0,0 +1,40 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (
+    id,
+    expires_at
+) VALUES (
+    $1, $2
+) ON CONFLICT (id) DO NOTHING;
+
+-- name: RevokeUserTokens :exec
+INSERT INTO user_token_revocations (
+    user_id,
+    revoked_before,
+    expires_at
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (user_id) DO UPDATE
+SET
+    revoked_before = EXCLUDED.revoked_before,
+    expires_at = EXCLUDED.expires_at;
+
+-- name: IsTokenRevoked :one
+SELECT
+    EXISTS (
+        SELECT 1 FROM revoked_tokens
+        WHERE id = sqlc.arg(id) AND expires_at > now()
+    ) OR EXISTS (
+        SELECT 1 FROM user_token_revocations
+        WHERE
+            user_id = sqlc.arg(user_id) AND
+            expires_at > now() AND
+            revoked_before >= sqlc.arg(issued_at)::timestamptz
+    ) AS revoked;
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at < $1;
+
+-- name: DeleteExpiredUserTokenRevocations :execrows
+DELETE FROM user_token_revocations
+WHERE expires_at < $1;
@@ -24,3 +24,18 @@ SET
 WHERE id = $1 OR email = $2
 RETURNING *;
 
+
+-- name: ClaimVerificationEmail :one
+UPDATE users
+SET verification_sent_at = sqlc.arg(now)::timestamptz
+WHERE
+    id = sqlc.arg(id) AND
+    email_verified_at IS NULL AND
+    (verification_sent_at IS NULL OR verification_sent_at <= sqlc.arg(resend_after)::timestamptz)
+RETURNING *;
+
+-- name: VerifyUserEmail :one
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, now())
+WHERE id = $1 AND email = $2
+RETURNING *;
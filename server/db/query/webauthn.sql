@@ -0,0 +1,36 @@
+-- name: CreateWebauthnCredential :one
+INSERT INTO webauthn_credentials (
+    user_id,
+    credential_id,
+    public_key,
+    sign_count,
+    name
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetWebauthnCredentialByCredentialID :one
+SELECT * FROM webauthn_credentials
+WHERE credential_id = $1 LIMIT 1;
+
+-- name: ListWebauthnCredentials :many
+SELECT * FROM webauthn_credentials
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: CountWebauthnCredentials :one
+SELECT count(*) FROM webauthn_credentials
+WHERE user_id = $1;
+
+-- name: UpdateWebauthnSignCount :execrows
+UPDATE webauthn_credentials
+SET
+    sign_count = sqlc.arg(sign_count),
+    last_used_at = now()
+WHERE
+    id = sqlc.arg(id) AND
+    (sign_count < sqlc.arg(sign_count) OR (sign_count = 0 AND sqlc.arg(sign_count) = 0));
+
+-- name: DeleteWebauthnCredential :execrows
+DELETE FROM webauthn_credentials
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,87 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+    owner_id,
+    project_id,
+    url,
+    events,
+    secret
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE id = $1 LIMIT 1;
+
+-- name: GetWebhookList :many
+SELECT * FROM webhooks
+WHERE
+    owner_id = $1
+ORDER BY id;
+
+-- name: GetWebhooksForEvent :many
+SELECT * FROM webhooks
+WHERE
+    owner_id = sqlc.arg(owner_id) AND
+    active AND
+    (project_id IS NULL OR project_id = sqlc.narg(project_id)) AND
+    (cardinality(events) = 0 OR sqlc.arg(event)::varchar = ANY(events))
+ORDER BY id;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks
+SET
+    project_id = $3,
+    url = $4,
+    events = $5,
+    active = $6
+WHERE id = $1 AND owner_id = $2
+RETURNING *;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks
+WHERE id = $1 AND owner_id = $2;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    webhook_id,
+    event,
+    payload,
+    next_attempt_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: GetWebhookDeliveryList :many
+SELECT * FROM webhook_deliveries
+WHERE
+    webhook_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ClaimDueWebhookDelivery :one
+SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event,
+    webhook_deliveries.payload, webhook_deliveries.attempts, webhooks.url, webhooks.secret
+FROM webhook_deliveries
+JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE
+    webhook_deliveries.next_attempt_at <= sqlc.arg(now) AND
+    webhooks.active
+ORDER BY webhook_deliveries.next_attempt_at
+LIMIT 1
+FOR UPDATE OF webhook_deliveries SKIP LOCKED;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET
+    attempts = attempts + 1,
+    response_status = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    delivered_at = $5
+WHERE id = $1;
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKey(t *testing.T) {
+	user := CreateRandomUser(t)
+	apiKey, err := testQueries.CreateApiKey(context.Background(), CreateApiKeyParams{
+		UserID:    user.ID,
+		Name:      "ci",
+		Prefix:    "todo_abcdefgh",
+		KeyHash:   []byte(user.Email),
+		Scopes:    []string{"tasks:read", "tasks:write"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tasks:read", "tasks:write"}, apiKey.Scopes)
+
+	now := time.Now()
+	err = testQueries.TouchApiKey(context.Background(), TouchApiKeyParams{Now: now, ID: apiKey.ID, UsedBefore: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	//used again within the minute, last used stays
+	err = testQueries.TouchApiKey(context.Background(), TouchApiKeyParams{Now: now.Add(time.Second), ID: apiKey.ID, UsedBefore: now.Add(-time.Minute + time.Second)})
+	require.NoError(t, err)
+
+	found, err := testQueries.GetApiKeyByHash(context.Background(), []byte(user.Email))
+	require.NoError(t, err)
+	require.WithinDuration(t, now, found.LastUsedAt.Time, time.Millisecond)
+
+	list, err := testQueries.ListApiKeys(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	deleted, err := testQueries.DeleteApiKey(context.Background(), DeleteApiKeyParams{ID: apiKey.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+}
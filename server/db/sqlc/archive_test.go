@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveDoneTasks(t *testing.T) {
+	user := CreateRandomUser(t)
+	done, err := testQueries.CreateTask(context.Background(), CreateTaskParams{Body: "done", OwnerID: user.ID, IsDone: true})
+	require.NoError(t, err)
+	open, err := testQueries.CreateTask(context.Background(), CreateTaskParams{Body: "open", OwnerID: user.ID})
+	require.NoError(t, err)
+
+	rule, err := testQueries.UpsertArchiveRule(context.Background(), UpsertArchiveRuleParams{UserID: user.ID, AfterDays: 7})
+	require.NoError(t, err)
+	require.Equal(t, int32(7), rule.AfterDays)
+
+	//not old enough yet
+	_, err = testQueries.ArchiveDoneTasks(context.Background(), ArchiveDoneTasksParams{Now: time.Now(), BatchSize: 100})
+	require.NoError(t, err)
+	task, err := testQueries.GetTask(context.Background(), done.ID)
+	require.NoError(t, err)
+	require.False(t, task.ArchivedAt.Valid)
+
+	_, err = testQueries.ArchiveDoneTasks(context.Background(), ArchiveDoneTasksParams{Now: time.Now().AddDate(0, 0, 8), BatchSize: 100})
+	require.NoError(t, err)
+	task, err = testQueries.GetTask(context.Background(), done.ID)
+	require.NoError(t, err)
+	require.True(t, task.ArchivedAt.Valid)
+	task, err = testQueries.GetTask(context.Background(), open.ID)
+	require.NoError(t, err)
+	require.False(t, task.ArchivedAt.Valid)
+
+	archived, err := testQueries.GetArchivedTaskList(context.Background(), GetArchivedTaskListParams{OwnerID: user.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	listed, err := testQueries.GetTaskList(context.Background(), GetTaskListParams{OwnerID: user.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, open.ID, listed[0].ID)
+
+	//reopening a task unarchives it
+	reopened, err := testQueries.UpdateTask(context.Background(), UpdateTaskParams{ID: done.ID, OwnerID: user.ID, Body: done.Body})
+	require.NoError(t, err)
+	require.False(t, reopened.ArchivedAt.Valid)
+}
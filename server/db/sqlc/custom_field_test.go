@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomCustomField(t *testing.T, project Project) CustomField {
+	arg := CreateCustomFieldParams{
+		ProjectID: project.ID,
+		Name:      util.RandomString(6),
+		FieldType: "number",
+		Options:   json.RawMessage(`[]`),
+		Rules:     json.RawMessage(`{}`),
+	}
+	field, err := testQueries.CreateCustomField(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ProjectID, field.ProjectID)
+	require.Equal(t, arg.Name, field.Name)
+	require.Equal(t, arg.FieldType, field.FieldType)
+	return field
+}
+
+func TestSetCustomFieldValuesTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	project := CreateRandomProject(t, user)
+	field := CreateRandomCustomField(t, project.Project)
+	task, err := testQueries.CreateTask(context.Background(), CreateTaskParams{
+		Body:      util.RandomString(10),
+		OwnerID:   user.ID,
+		ProjectID: sql.NullInt64{Int64: project.Project.ID, Valid: true},
+		StatusID:  sql.NullInt64{Int64: project.Statuses[0].ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	store := NewStore(testDB)
+	values, err := store.SetCustomFieldValuesTx(context.Background(), SetCustomFieldValuesTxParams{
+		TaskID: task.ID,
+		Values: map[int64]json.RawMessage{field.ID: json.RawMessage(`5`)},
+	})
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	require.JSONEq(t, `5`, string(values[0].Value))
+
+	//overwrite
+	values, err = store.SetCustomFieldValuesTx(context.Background(), SetCustomFieldValuesTxParams{
+		TaskID: task.ID,
+		Values: map[int64]json.RawMessage{field.ID: json.RawMessage(`8`)},
+	})
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	require.JSONEq(t, `8`, string(values[0].Value))
+
+	byTasks, err := testQueries.GetCustomFieldValuesByTasks(context.Background(), []int64{task.ID})
+	require.NoError(t, err)
+	require.Len(t, byTasks, 1)
+
+	//nil removes the value
+	values, err = store.SetCustomFieldValuesTx(context.Background(), SetCustomFieldValuesTxParams{
+		TaskID: task.ID,
+		Values: map[int64]json.RawMessage{field.ID: nil},
+	})
+	require.NoError(t, err)
+	require.Empty(t, values)
+}
+
+func TestSearchTasks(t *testing.T) {
+	user := CreateRandomUser(t)
+	for i := 0; i < 3; i++ {
+		CreateRandomTask(t, user)
+	}
+	store := NewStore(testDB)
+
+	tasks, err := store.SearchTasks(context.Background(), SearchTasksParams{
+		Filter:  FilterCompare{Column: TaskColumnOwnerID, Op: "=", Value: user.ID},
+		OrderBy: []TaskOrder{{Column: TaskColumnID, Desc: true}},
+		Limit:   2,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.Greater(t, tasks[0].ID, tasks[1].ID)
+}
@@ -24,131 +24,1315 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addTaskLabelStmt, err = db.PrepareContext(ctx, addTaskLabel); err != nil {
+		return nil, fmt.Errorf("error preparing query AddTaskLabel: %w", err)
+	}
+	if q.archiveDoneTasksStmt, err = db.PrepareContext(ctx, archiveDoneTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query ArchiveDoneTasks: %w", err)
+	}
+	if q.archiveTaskStmt, err = db.PrepareContext(ctx, archiveTask); err != nil {
+		return nil, fmt.Errorf("error preparing query ArchiveTask: %w", err)
+	}
+	if q.blockSessionStmt, err = db.PrepareContext(ctx, blockSession); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockSession: %w", err)
+	}
+	if q.claimDueDigestStmt, err = db.PrepareContext(ctx, claimDueDigest); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueDigest: %w", err)
+	}
+	if q.claimDueReminderStmt, err = db.PrepareContext(ctx, claimDueReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueReminder: %w", err)
+	}
+	if q.claimDueWebhookDeliveryStmt, err = db.PrepareContext(ctx, claimDueWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueWebhookDelivery: %w", err)
+	}
+	if q.claimVerificationEmailStmt, err = db.PrepareContext(ctx, claimVerificationEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimVerificationEmail: %w", err)
+	}
+	if q.countRecoveryCodesStmt, err = db.PrepareContext(ctx, countRecoveryCodes); err != nil {
+		return nil, fmt.Errorf("error preparing query CountRecoveryCodes: %w", err)
+	}
+	if q.countTasksByStatusStmt, err = db.PrepareContext(ctx, countTasksByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query CountTasksByStatus: %w", err)
+	}
+	if q.countWebauthnCredentialsStmt, err = db.PrepareContext(ctx, countWebauthnCredentials); err != nil {
+		return nil, fmt.Errorf("error preparing query CountWebauthnCredentials: %w", err)
+	}
+	if q.createApiKeyStmt, err = db.PrepareContext(ctx, createApiKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateApiKey: %w", err)
+	}
+	if q.createCustomFieldStmt, err = db.PrepareContext(ctx, createCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCustomField: %w", err)
+	}
 	if q.createPasswordResetSessionStmt, err = db.PrepareContext(ctx, createPasswordResetSession); err != nil {
 		return nil, fmt.Errorf("error preparing query CreatePasswordResetSession: %w", err)
 	}
+	if q.createProjectStmt, err = db.PrepareContext(ctx, createProject); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateProject: %w", err)
+	}
+	if q.createProjectStatusStmt, err = db.PrepareContext(ctx, createProjectStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateProjectStatus: %w", err)
+	}
+	if q.createRecoveryCodesStmt, err = db.PrepareContext(ctx, createRecoveryCodes); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRecoveryCodes: %w", err)
+	}
+	if q.createReminderStmt, err = db.PrepareContext(ctx, createReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReminder: %w", err)
+	}
+	if q.createSavedFilterStmt, err = db.PrepareContext(ctx, createSavedFilter); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSavedFilter: %w", err)
+	}
 	if q.createSessionStmt, err = db.PrepareContext(ctx, createSession); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateSession: %w", err)
 	}
+	if q.createSigningKeyStmt, err = db.PrepareContext(ctx, createSigningKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSigningKey: %w", err)
+	}
+	if q.createStatusTransitionStmt, err = db.PrepareContext(ctx, createStatusTransition); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStatusTransition: %w", err)
+	}
 	if q.createTaskStmt, err = db.PrepareContext(ctx, createTask); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTask: %w", err)
 	}
+	if q.createTaskAttachmentStmt, err = db.PrepareContext(ctx, createTaskAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTaskAttachment: %w", err)
+	}
+	if q.createTaskTemplateStmt, err = db.PrepareContext(ctx, createTaskTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTaskTemplate: %w", err)
+	}
+	if q.createTimeEntryStmt, err = db.PrepareContext(ctx, createTimeEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTimeEntry: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createWebauthnCredentialStmt, err = db.PrepareContext(ctx, createWebauthnCredential); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebauthnCredential: %w", err)
+	}
+	if q.createWebhookStmt, err = db.PrepareContext(ctx, createWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhook: %w", err)
+	}
+	if q.createWebhookDeliveryStmt, err = db.PrepareContext(ctx, createWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDelivery: %w", err)
+	}
+	if q.deleteApiKeyStmt, err = db.PrepareContext(ctx, deleteApiKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteApiKey: %w", err)
+	}
+	if q.deleteArchiveRuleStmt, err = db.PrepareContext(ctx, deleteArchiveRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteArchiveRule: %w", err)
+	}
+	if q.deleteCurrentSessionStmt, err = db.PrepareContext(ctx, deleteCurrentSession); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCurrentSession: %w", err)
+	}
+	if q.deleteCustomFieldStmt, err = db.PrepareContext(ctx, deleteCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCustomField: %w", err)
+	}
+	if q.deleteExpiredLoginChallengesStmt, err = db.PrepareContext(ctx, deleteExpiredLoginChallenges); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredLoginChallenges: %w", err)
+	}
+	if q.deleteExpiredRevokedTokensStmt, err = db.PrepareContext(ctx, deleteExpiredRevokedTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredRevokedTokens: %w", err)
+	}
+	if q.deleteExpiredSessionsStmt, err = db.PrepareContext(ctx, deleteExpiredSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredSessions: %w", err)
+	}
+	if q.deleteExpiredSigningKeysStmt, err = db.PrepareContext(ctx, deleteExpiredSigningKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredSigningKeys: %w", err)
+	}
+	if q.deleteExpiredUserTokenRevocationsStmt, err = db.PrepareContext(ctx, deleteExpiredUserTokenRevocations); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredUserTokenRevocations: %w", err)
+	}
+	if q.deleteLabelStmt, err = db.PrepareContext(ctx, deleteLabel); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteLabel: %w", err)
+	}
 	if q.deletePasswordResetSessionStmt, err = db.PrepareContext(ctx, deletePasswordResetSession); err != nil {
 		return nil, fmt.Errorf("error preparing query DeletePasswordResetSession: %w", err)
 	}
+	if q.deleteProjectStmt, err = db.PrepareContext(ctx, deleteProject); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteProject: %w", err)
+	}
+	if q.deleteProjectStatusStmt, err = db.PrepareContext(ctx, deleteProjectStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteProjectStatus: %w", err)
+	}
+	if q.deleteRecoveryCodesStmt, err = db.PrepareContext(ctx, deleteRecoveryCodes); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRecoveryCodes: %w", err)
+	}
+	if q.deleteReminderStmt, err = db.PrepareContext(ctx, deleteReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReminder: %w", err)
+	}
+	if q.deleteSavedFilterStmt, err = db.PrepareContext(ctx, deleteSavedFilter); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSavedFilter: %w", err)
+	}
 	if q.deleteSessionStmt, err = db.PrepareContext(ctx, deleteSession); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteSession: %w", err)
 	}
+	if q.deleteStatusTransitionsStmt, err = db.PrepareContext(ctx, deleteStatusTransitions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStatusTransitions: %w", err)
+	}
 	if q.deleteTaskStmt, err = db.PrepareContext(ctx, deleteTask); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteTask: %w", err)
 	}
+	if q.deleteTaskCustomFieldValueStmt, err = db.PrepareContext(ctx, deleteTaskCustomFieldValue); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTaskCustomFieldValue: %w", err)
+	}
+	if q.deleteTaskLabelsStmt, err = db.PrepareContext(ctx, deleteTaskLabels); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTaskLabels: %w", err)
+	}
+	if q.deleteTaskTemplateStmt, err = db.PrepareContext(ctx, deleteTaskTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTaskTemplate: %w", err)
+	}
+	if q.deleteTimeEntryStmt, err = db.PrepareContext(ctx, deleteTimeEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTimeEntry: %w", err)
+	}
+	if q.deleteUserSessionsStmt, err = db.PrepareContext(ctx, deleteUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserSessions: %w", err)
+	}
+	if q.deleteUserTotpStmt, err = db.PrepareContext(ctx, deleteUserTotp); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserTotp: %w", err)
+	}
+	if q.deleteWebauthnCredentialStmt, err = db.PrepareContext(ctx, deleteWebauthnCredential); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebauthnCredential: %w", err)
+	}
+	if q.deleteWebhookStmt, err = db.PrepareContext(ctx, deleteWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebhook: %w", err)
+	}
+	if q.enableUserTotpStmt, err = db.PrepareContext(ctx, enableUserTotp); err != nil {
+		return nil, fmt.Errorf("error preparing query EnableUserTotp: %w", err)
+	}
+	if q.getApiKeyByHashStmt, err = db.PrepareContext(ctx, getApiKeyByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetApiKeyByHash: %w", err)
+	}
+	if q.getArchiveRuleStmt, err = db.PrepareContext(ctx, getArchiveRule); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArchiveRule: %w", err)
+	}
+	if q.getArchivedTaskListStmt, err = db.PrepareContext(ctx, getArchivedTaskList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArchivedTaskList: %w", err)
+	}
+	if q.getCompletionStreaksStmt, err = db.PrepareContext(ctx, getCompletionStreaks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCompletionStreaks: %w", err)
+	}
+	if q.getCustomFieldStmt, err = db.PrepareContext(ctx, getCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCustomField: %w", err)
+	}
+	if q.getCustomFieldListStmt, err = db.PrepareContext(ctx, getCustomFieldList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCustomFieldList: %w", err)
+	}
+	if q.getCustomFieldListByOwnerStmt, err = db.PrepareContext(ctx, getCustomFieldListByOwner); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCustomFieldListByOwner: %w", err)
+	}
+	if q.getCustomFieldValuesByTasksStmt, err = db.PrepareContext(ctx, getCustomFieldValuesByTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCustomFieldValuesByTasks: %w", err)
+	}
+	if q.getDigestPreferenceStmt, err = db.PrepareContext(ctx, getDigestPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDigestPreference: %w", err)
+	}
+	if q.getInboxStmt, err = db.PrepareContext(ctx, getInbox); err != nil {
+		return nil, fmt.Errorf("error preparing query GetInbox: %w", err)
+	}
+	if q.getInboxByTokenStmt, err = db.PrepareContext(ctx, getInboxByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetInboxByToken: %w", err)
+	}
+	if q.getLabelListStmt, err = db.PrepareContext(ctx, getLabelList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLabelList: %w", err)
+	}
+	if q.getLabelsByIdsStmt, err = db.PrepareContext(ctx, getLabelsByIds); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLabelsByIds: %w", err)
+	}
+	if q.getLabelsByTasksStmt, err = db.PrepareContext(ctx, getLabelsByTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLabelsByTasks: %w", err)
+	}
+	if q.getOverdueTasksStmt, err = db.PrepareContext(ctx, getOverdueTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOverdueTasks: %w", err)
+	}
 	if q.getPasswordResetSessionStmt, err = db.PrepareContext(ctx, getPasswordResetSession); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPasswordResetSession: %w", err)
 	}
+	if q.getProjectStmt, err = db.PrepareContext(ctx, getProject); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProject: %w", err)
+	}
+	if q.getProjectListStmt, err = db.PrepareContext(ctx, getProjectList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProjectList: %w", err)
+	}
+	if q.getProjectStatusStmt, err = db.PrepareContext(ctx, getProjectStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProjectStatus: %w", err)
+	}
+	if q.getProjectStatusListStmt, err = db.PrepareContext(ctx, getProjectStatusList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProjectStatusList: %w", err)
+	}
+	if q.getProjectsByIdsStmt, err = db.PrepareContext(ctx, getProjectsByIds); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProjectsByIds: %w", err)
+	}
+	if q.getReminderStmt, err = db.PrepareContext(ctx, getReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReminder: %w", err)
+	}
+	if q.getReminderListByTaskStmt, err = db.PrepareContext(ctx, getReminderListByTask); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReminderListByTask: %w", err)
+	}
+	if q.getRunningTimeEntryStmt, err = db.PrepareContext(ctx, getRunningTimeEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRunningTimeEntry: %w", err)
+	}
+	if q.getSavedFilterStmt, err = db.PrepareContext(ctx, getSavedFilter); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSavedFilter: %w", err)
+	}
+	if q.getSavedFilterListStmt, err = db.PrepareContext(ctx, getSavedFilterList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSavedFilterList: %w", err)
+	}
 	if q.getSessionStmt, err = db.PrepareContext(ctx, getSession); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSession: %w", err)
 	}
+	if q.getStatusTransitionListStmt, err = db.PrepareContext(ctx, getStatusTransitionList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStatusTransitionList: %w", err)
+	}
+	if q.getSubtasksStmt, err = db.PrepareContext(ctx, getSubtasks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSubtasks: %w", err)
+	}
+	if q.getSyncChangeStmt, err = db.PrepareContext(ctx, getSyncChange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSyncChange: %w", err)
+	}
+	if q.getSyncChangesStmt, err = db.PrepareContext(ctx, getSyncChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSyncChanges: %w", err)
+	}
+	if q.getSyncSnapshotXminStmt, err = db.PrepareContext(ctx, getSyncSnapshotXmin); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSyncSnapshotXmin: %w", err)
+	}
 	if q.getTaskStmt, err = db.PrepareContext(ctx, getTask); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTask: %w", err)
 	}
+	if q.getTaskAttachmentStmt, err = db.PrepareContext(ctx, getTaskAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskAttachment: %w", err)
+	}
+	if q.getTaskAttachmentListStmt, err = db.PrepareContext(ctx, getTaskAttachmentList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskAttachmentList: %w", err)
+	}
+	if q.getTaskCountsByPeriodStmt, err = db.PrepareContext(ctx, getTaskCountsByPeriod); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskCountsByPeriod: %w", err)
+	}
+	if q.getTaskCustomFieldValuesStmt, err = db.PrepareContext(ctx, getTaskCustomFieldValues); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskCustomFieldValues: %w", err)
+	}
 	if q.getTaskListStmt, err = db.PrepareContext(ctx, getTaskList); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTaskList: %w", err)
 	}
+	if q.getTaskListByProjectStmt, err = db.PrepareContext(ctx, getTaskListByProject); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskListByProject: %w", err)
+	}
+	if q.getTaskStatsByLabelStmt, err = db.PrepareContext(ctx, getTaskStatsByLabel); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskStatsByLabel: %w", err)
+	}
+	if q.getTaskStatsByProjectStmt, err = db.PrepareContext(ctx, getTaskStatsByProject); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskStatsByProject: %w", err)
+	}
+	if q.getTaskStatsSummaryStmt, err = db.PrepareContext(ctx, getTaskStatsSummary); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskStatsSummary: %w", err)
+	}
+	if q.getTaskTemplateStmt, err = db.PrepareContext(ctx, getTaskTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskTemplate: %w", err)
+	}
+	if q.getTaskTemplateListStmt, err = db.PrepareContext(ctx, getTaskTemplateList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTaskTemplateList: %w", err)
+	}
+	if q.getTasksByIdsStmt, err = db.PrepareContext(ctx, getTasksByIds); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTasksByIds: %w", err)
+	}
+	if q.getTasksCompletedBetweenStmt, err = db.PrepareContext(ctx, getTasksCompletedBetween); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTasksCompletedBetween: %w", err)
+	}
+	if q.getTasksDueBetweenStmt, err = db.PrepareContext(ctx, getTasksDueBetween); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTasksDueBetween: %w", err)
+	}
+	if q.getTimeEntriesBetweenStmt, err = db.PrepareContext(ctx, getTimeEntriesBetween); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTimeEntriesBetween: %w", err)
+	}
+	if q.getTimeEntryStmt, err = db.PrepareContext(ctx, getTimeEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTimeEntry: %w", err)
+	}
+	if q.getTimeEntryListByTaskStmt, err = db.PrepareContext(ctx, getTimeEntryListByTask); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTimeEntryListByTask: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
+	if q.getUserTotpStmt, err = db.PrepareContext(ctx, getUserTotp); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserTotp: %w", err)
+	}
+	if q.getWebauthnCredentialByCredentialIDStmt, err = db.PrepareContext(ctx, getWebauthnCredentialByCredentialID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebauthnCredentialByCredentialID: %w", err)
+	}
+	if q.getWebhookStmt, err = db.PrepareContext(ctx, getWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhook: %w", err)
+	}
+	if q.getWebhookDeliveryStmt, err = db.PrepareContext(ctx, getWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDelivery: %w", err)
+	}
+	if q.getWebhookDeliveryListStmt, err = db.PrepareContext(ctx, getWebhookDeliveryList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDeliveryList: %w", err)
+	}
+	if q.getWebhookListStmt, err = db.PrepareContext(ctx, getWebhookList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookList: %w", err)
+	}
+	if q.getWebhooksForEventStmt, err = db.PrepareContext(ctx, getWebhooksForEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhooksForEvent: %w", err)
+	}
+	if q.isTokenRevokedStmt, err = db.PrepareContext(ctx, isTokenRevoked); err != nil {
+		return nil, fmt.Errorf("error preparing query IsTokenRevoked: %w", err)
+	}
+	if q.listApiKeysStmt, err = db.PrepareContext(ctx, listApiKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query ListApiKeys: %w", err)
+	}
+	if q.listSessionFamilyStmt, err = db.PrepareContext(ctx, listSessionFamily); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSessionFamily: %w", err)
+	}
+	if q.listSigningKeysStmt, err = db.PrepareContext(ctx, listSigningKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSigningKeys: %w", err)
+	}
+	if q.listUserSessionsStmt, err = db.PrepareContext(ctx, listUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserSessions: %w", err)
+	}
+	if q.listWebauthnCredentialsStmt, err = db.PrepareContext(ctx, listWebauthnCredentials); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebauthnCredentials: %w", err)
+	}
+	if q.lockProjectStmt, err = db.PrepareContext(ctx, lockProject); err != nil {
+		return nil, fmt.Errorf("error preparing query LockProject: %w", err)
+	}
+	if q.lockProjectForShareStmt, err = db.PrepareContext(ctx, lockProjectForShare); err != nil {
+		return nil, fmt.Errorf("error preparing query LockProjectForShare: %w", err)
+	}
+	if q.lockProjectStatusStmt, err = db.PrepareContext(ctx, lockProjectStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query LockProjectStatus: %w", err)
+	}
+	if q.markReminderSentStmt, err = db.PrepareContext(ctx, markReminderSent); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkReminderSent: %w", err)
+	}
+	if q.recordReminderFailureStmt, err = db.PrepareContext(ctx, recordReminderFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordReminderFailure: %w", err)
+	}
+	if q.recordTotpFailureStmt, err = db.PrepareContext(ctx, recordTotpFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordTotpFailure: %w", err)
+	}
+	if q.recordWebhookDeliveryAttemptStmt, err = db.PrepareContext(ctx, recordWebhookDeliveryAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordWebhookDeliveryAttempt: %w", err)
+	}
+	if q.replaceSessionStmt, err = db.PrepareContext(ctx, replaceSession); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplaceSession: %w", err)
+	}
+	if q.resetRelativeRemindersStmt, err = db.PrepareContext(ctx, resetRelativeReminders); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetRelativeReminders: %w", err)
+	}
+	if q.revokeTokenStmt, err = db.PrepareContext(ctx, revokeToken); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeToken: %w", err)
+	}
+	if q.revokeUserTokensStmt, err = db.PrepareContext(ctx, revokeUserTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeUserTokens: %w", err)
+	}
+	if q.setDigestNextSendAtStmt, err = db.PrepareContext(ctx, setDigestNextSendAt); err != nil {
+		return nil, fmt.Errorf("error preparing query SetDigestNextSendAt: %w", err)
+	}
+	if q.setLoginChallengeStmt, err = db.PrepareContext(ctx, setLoginChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query SetLoginChallenge: %w", err)
+	}
+	if q.setTaskDeferredUntilStmt, err = db.PrepareContext(ctx, setTaskDeferredUntil); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTaskDeferredUntil: %w", err)
+	}
+	if q.snoozeReminderStmt, err = db.PrepareContext(ctx, snoozeReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query SnoozeReminder: %w", err)
+	}
+	if q.stopTimeEntryStmt, err = db.PrepareContext(ctx, stopTimeEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query StopTimeEntry: %w", err)
+	}
+	if q.syncTasksWithStatusStmt, err = db.PrepareContext(ctx, syncTasksWithStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query SyncTasksWithStatus: %w", err)
+	}
+	if q.takeLoginChallengeStmt, err = db.PrepareContext(ctx, takeLoginChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query TakeLoginChallenge: %w", err)
+	}
+	if q.touchApiKeyStmt, err = db.PrepareContext(ctx, touchApiKey); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchApiKey: %w", err)
+	}
+	if q.unarchiveTaskStmt, err = db.PrepareContext(ctx, unarchiveTask); err != nil {
+		return nil, fmt.Errorf("error preparing query UnarchiveTask: %w", err)
+	}
+	if q.unsubscribeDigestStmt, err = db.PrepareContext(ctx, unsubscribeDigest); err != nil {
+		return nil, fmt.Errorf("error preparing query UnsubscribeDigest: %w", err)
+	}
+	if q.updateCustomFieldStmt, err = db.PrepareContext(ctx, updateCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCustomField: %w", err)
+	}
 	if q.updatePasswordResetSessionStmt, err = db.PrepareContext(ctx, updatePasswordResetSession); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdatePasswordResetSession: %w", err)
 	}
+	if q.updateProjectStmt, err = db.PrepareContext(ctx, updateProject); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateProject: %w", err)
+	}
+	if q.updateProjectStatusStmt, err = db.PrepareContext(ctx, updateProjectStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateProjectStatus: %w", err)
+	}
+	if q.updateSavedFilterStmt, err = db.PrepareContext(ctx, updateSavedFilter); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateSavedFilter: %w", err)
+	}
 	if q.updateTaskStmt, err = db.PrepareContext(ctx, updateTask); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateTask: %w", err)
 	}
+	if q.updateTaskTemplateStmt, err = db.PrepareContext(ctx, updateTaskTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTaskTemplate: %w", err)
+	}
+	if q.updateTimeEntryStmt, err = db.PrepareContext(ctx, updateTimeEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTimeEntry: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
+	if q.updateWebauthnSignCountStmt, err = db.PrepareContext(ctx, updateWebauthnSignCount); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWebauthnSignCount: %w", err)
+	}
+	if q.updateWebhookStmt, err = db.PrepareContext(ctx, updateWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWebhook: %w", err)
+	}
+	if q.upsertArchiveRuleStmt, err = db.PrepareContext(ctx, upsertArchiveRule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertArchiveRule: %w", err)
+	}
+	if q.upsertDigestPreferenceStmt, err = db.PrepareContext(ctx, upsertDigestPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertDigestPreference: %w", err)
+	}
+	if q.upsertInboxStmt, err = db.PrepareContext(ctx, upsertInbox); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertInbox: %w", err)
+	}
+	if q.upsertLabelStmt, err = db.PrepareContext(ctx, upsertLabel); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertLabel: %w", err)
+	}
+	if q.upsertPendingTotpStmt, err = db.PrepareContext(ctx, upsertPendingTotp); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertPendingTotp: %w", err)
+	}
+	if q.upsertTaskCustomFieldValueStmt, err = db.PrepareContext(ctx, upsertTaskCustomFieldValue); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertTaskCustomFieldValue: %w", err)
+	}
+	if q.useRecoveryCodeStmt, err = db.PrepareContext(ctx, useRecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query UseRecoveryCode: %w", err)
+	}
+	if q.useTotpStepStmt, err = db.PrepareContext(ctx, useTotpStep); err != nil {
+		return nil, fmt.Errorf("error preparing query UseTotpStep: %w", err)
+	}
+	if q.verifyUserEmailStmt, err = db.PrepareContext(ctx, verifyUserEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query VerifyUserEmail: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addTaskLabelStmt != nil {
+		if cerr := q.addTaskLabelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addTaskLabelStmt: %w", cerr)
+		}
+	}
+	if q.archiveDoneTasksStmt != nil {
+		if cerr := q.archiveDoneTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing archiveDoneTasksStmt: %w", cerr)
+		}
+	}
+	if q.archiveTaskStmt != nil {
+		if cerr := q.archiveTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing archiveTaskStmt: %w", cerr)
+		}
+	}
+	if q.blockSessionStmt != nil {
+		if cerr := q.blockSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockSessionStmt: %w", cerr)
+		}
+	}
+	if q.claimDueDigestStmt != nil {
+		if cerr := q.claimDueDigestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueDigestStmt: %w", cerr)
+		}
+	}
+	if q.claimDueReminderStmt != nil {
+		if cerr := q.claimDueReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueReminderStmt: %w", cerr)
+		}
+	}
+	if q.claimDueWebhookDeliveryStmt != nil {
+		if cerr := q.claimDueWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.claimVerificationEmailStmt != nil {
+		if cerr := q.claimVerificationEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimVerificationEmailStmt: %w", cerr)
+		}
+	}
+	if q.countRecoveryCodesStmt != nil {
+		if cerr := q.countRecoveryCodesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countRecoveryCodesStmt: %w", cerr)
+		}
+	}
+	if q.countTasksByStatusStmt != nil {
+		if cerr := q.countTasksByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countTasksByStatusStmt: %w", cerr)
+		}
+	}
+	if q.countWebauthnCredentialsStmt != nil {
+		if cerr := q.countWebauthnCredentialsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countWebauthnCredentialsStmt: %w", cerr)
+		}
+	}
+	if q.createApiKeyStmt != nil {
+		if cerr := q.createApiKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createApiKeyStmt: %w", cerr)
+		}
+	}
+	if q.createCustomFieldStmt != nil {
+		if cerr := q.createCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCustomFieldStmt: %w", cerr)
+		}
+	}
 	if q.createPasswordResetSessionStmt != nil {
 		if cerr := q.createPasswordResetSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createPasswordResetSessionStmt: %w", cerr)
 		}
 	}
+	if q.createProjectStmt != nil {
+		if cerr := q.createProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createProjectStmt: %w", cerr)
+		}
+	}
+	if q.createProjectStatusStmt != nil {
+		if cerr := q.createProjectStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createProjectStatusStmt: %w", cerr)
+		}
+	}
+	if q.createRecoveryCodesStmt != nil {
+		if cerr := q.createRecoveryCodesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRecoveryCodesStmt: %w", cerr)
+		}
+	}
+	if q.createReminderStmt != nil {
+		if cerr := q.createReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReminderStmt: %w", cerr)
+		}
+	}
+	if q.createSavedFilterStmt != nil {
+		if cerr := q.createSavedFilterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSavedFilterStmt: %w", cerr)
+		}
+	}
 	if q.createSessionStmt != nil {
 		if cerr := q.createSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createSessionStmt: %w", cerr)
 		}
 	}
+	if q.createSigningKeyStmt != nil {
+		if cerr := q.createSigningKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSigningKeyStmt: %w", cerr)
+		}
+	}
+	if q.createStatusTransitionStmt != nil {
+		if cerr := q.createStatusTransitionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStatusTransitionStmt: %w", cerr)
+		}
+	}
 	if q.createTaskStmt != nil {
 		if cerr := q.createTaskStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTaskStmt: %w", cerr)
 		}
 	}
+	if q.createTaskAttachmentStmt != nil {
+		if cerr := q.createTaskAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTaskAttachmentStmt: %w", cerr)
+		}
+	}
+	if q.createTaskTemplateStmt != nil {
+		if cerr := q.createTaskTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTaskTemplateStmt: %w", cerr)
+		}
+	}
+	if q.createTimeEntryStmt != nil {
+		if cerr := q.createTimeEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTimeEntryStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.createWebauthnCredentialStmt != nil {
+		if cerr := q.createWebauthnCredentialStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebauthnCredentialStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookStmt != nil {
+		if cerr := q.createWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookDeliveryStmt != nil {
+		if cerr := q.createWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.deleteApiKeyStmt != nil {
+		if cerr := q.deleteApiKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteApiKeyStmt: %w", cerr)
+		}
+	}
+	if q.deleteArchiveRuleStmt != nil {
+		if cerr := q.deleteArchiveRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteArchiveRuleStmt: %w", cerr)
+		}
+	}
+	if q.deleteCurrentSessionStmt != nil {
+		if cerr := q.deleteCurrentSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCurrentSessionStmt: %w", cerr)
+		}
+	}
+	if q.deleteCustomFieldStmt != nil {
+		if cerr := q.deleteCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCustomFieldStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredLoginChallengesStmt != nil {
+		if cerr := q.deleteExpiredLoginChallengesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredLoginChallengesStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredRevokedTokensStmt != nil {
+		if cerr := q.deleteExpiredRevokedTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredRevokedTokensStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredSessionsStmt != nil {
+		if cerr := q.deleteExpiredSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredSessionsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredSigningKeysStmt != nil {
+		if cerr := q.deleteExpiredSigningKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredSigningKeysStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredUserTokenRevocationsStmt != nil {
+		if cerr := q.deleteExpiredUserTokenRevocationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredUserTokenRevocationsStmt: %w", cerr)
+		}
+	}
+	if q.deleteLabelStmt != nil {
+		if cerr := q.deleteLabelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteLabelStmt: %w", cerr)
+		}
+	}
 	if q.deletePasswordResetSessionStmt != nil {
 		if cerr := q.deletePasswordResetSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deletePasswordResetSessionStmt: %w", cerr)
 		}
 	}
+	if q.deleteProjectStmt != nil {
+		if cerr := q.deleteProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteProjectStmt: %w", cerr)
+		}
+	}
+	if q.deleteProjectStatusStmt != nil {
+		if cerr := q.deleteProjectStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteProjectStatusStmt: %w", cerr)
+		}
+	}
+	if q.deleteRecoveryCodesStmt != nil {
+		if cerr := q.deleteRecoveryCodesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRecoveryCodesStmt: %w", cerr)
+		}
+	}
+	if q.deleteReminderStmt != nil {
+		if cerr := q.deleteReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReminderStmt: %w", cerr)
+		}
+	}
+	if q.deleteSavedFilterStmt != nil {
+		if cerr := q.deleteSavedFilterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSavedFilterStmt: %w", cerr)
+		}
+	}
 	if q.deleteSessionStmt != nil {
 		if cerr := q.deleteSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteSessionStmt: %w", cerr)
 		}
 	}
+	if q.deleteStatusTransitionsStmt != nil {
+		if cerr := q.deleteStatusTransitionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStatusTransitionsStmt: %w", cerr)
+		}
+	}
 	if q.deleteTaskStmt != nil {
 		if cerr := q.deleteTaskStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteTaskStmt: %w", cerr)
 		}
 	}
+	if q.deleteTaskCustomFieldValueStmt != nil {
+		if cerr := q.deleteTaskCustomFieldValueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTaskCustomFieldValueStmt: %w", cerr)
+		}
+	}
+	if q.deleteTaskLabelsStmt != nil {
+		if cerr := q.deleteTaskLabelsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTaskLabelsStmt: %w", cerr)
+		}
+	}
+	if q.deleteTaskTemplateStmt != nil {
+		if cerr := q.deleteTaskTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTaskTemplateStmt: %w", cerr)
+		}
+	}
+	if q.deleteTimeEntryStmt != nil {
+		if cerr := q.deleteTimeEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTimeEntryStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserSessionsStmt != nil {
+		if cerr := q.deleteUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserTotpStmt != nil {
+		if cerr := q.deleteUserTotpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserTotpStmt: %w", cerr)
+		}
+	}
+	if q.deleteWebauthnCredentialStmt != nil {
+		if cerr := q.deleteWebauthnCredentialStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebauthnCredentialStmt: %w", cerr)
+		}
+	}
+	if q.deleteWebhookStmt != nil {
+		if cerr := q.deleteWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebhookStmt: %w", cerr)
+		}
+	}
+	if q.enableUserTotpStmt != nil {
+		if cerr := q.enableUserTotpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing enableUserTotpStmt: %w", cerr)
+		}
+	}
+	if q.getApiKeyByHashStmt != nil {
+		if cerr := q.getApiKeyByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getApiKeyByHashStmt: %w", cerr)
+		}
+	}
+	if q.getArchiveRuleStmt != nil {
+		if cerr := q.getArchiveRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArchiveRuleStmt: %w", cerr)
+		}
+	}
+	if q.getArchivedTaskListStmt != nil {
+		if cerr := q.getArchivedTaskListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArchivedTaskListStmt: %w", cerr)
+		}
+	}
+	if q.getCompletionStreaksStmt != nil {
+		if cerr := q.getCompletionStreaksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCompletionStreaksStmt: %w", cerr)
+		}
+	}
+	if q.getCustomFieldStmt != nil {
+		if cerr := q.getCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCustomFieldStmt: %w", cerr)
+		}
+	}
+	if q.getCustomFieldListStmt != nil {
+		if cerr := q.getCustomFieldListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCustomFieldListStmt: %w", cerr)
+		}
+	}
+	if q.getCustomFieldListByOwnerStmt != nil {
+		if cerr := q.getCustomFieldListByOwnerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCustomFieldListByOwnerStmt: %w", cerr)
+		}
+	}
+	if q.getCustomFieldValuesByTasksStmt != nil {
+		if cerr := q.getCustomFieldValuesByTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCustomFieldValuesByTasksStmt: %w", cerr)
+		}
+	}
+	if q.getDigestPreferenceStmt != nil {
+		if cerr := q.getDigestPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDigestPreferenceStmt: %w", cerr)
+		}
+	}
+	if q.getInboxStmt != nil {
+		if cerr := q.getInboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getInboxStmt: %w", cerr)
+		}
+	}
+	if q.getInboxByTokenStmt != nil {
+		if cerr := q.getInboxByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getInboxByTokenStmt: %w", cerr)
+		}
+	}
+	if q.getLabelListStmt != nil {
+		if cerr := q.getLabelListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLabelListStmt: %w", cerr)
+		}
+	}
+	if q.getLabelsByIdsStmt != nil {
+		if cerr := q.getLabelsByIdsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLabelsByIdsStmt: %w", cerr)
+		}
+	}
+	if q.getLabelsByTasksStmt != nil {
+		if cerr := q.getLabelsByTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLabelsByTasksStmt: %w", cerr)
+		}
+	}
+	if q.getOverdueTasksStmt != nil {
+		if cerr := q.getOverdueTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOverdueTasksStmt: %w", cerr)
+		}
+	}
 	if q.getPasswordResetSessionStmt != nil {
 		if cerr := q.getPasswordResetSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getPasswordResetSessionStmt: %w", cerr)
 		}
 	}
+	if q.getProjectStmt != nil {
+		if cerr := q.getProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProjectStmt: %w", cerr)
+		}
+	}
+	if q.getProjectListStmt != nil {
+		if cerr := q.getProjectListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProjectListStmt: %w", cerr)
+		}
+	}
+	if q.getProjectStatusStmt != nil {
+		if cerr := q.getProjectStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProjectStatusStmt: %w", cerr)
+		}
+	}
+	if q.getProjectStatusListStmt != nil {
+		if cerr := q.getProjectStatusListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProjectStatusListStmt: %w", cerr)
+		}
+	}
+	if q.getProjectsByIdsStmt != nil {
+		if cerr := q.getProjectsByIdsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProjectsByIdsStmt: %w", cerr)
+		}
+	}
+	if q.getReminderStmt != nil {
+		if cerr := q.getReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReminderStmt: %w", cerr)
+		}
+	}
+	if q.getReminderListByTaskStmt != nil {
+		if cerr := q.getReminderListByTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReminderListByTaskStmt: %w", cerr)
+		}
+	}
+	if q.getRunningTimeEntryStmt != nil {
+		if cerr := q.getRunningTimeEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRunningTimeEntryStmt: %w", cerr)
+		}
+	}
+	if q.getSavedFilterStmt != nil {
+		if cerr := q.getSavedFilterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSavedFilterStmt: %w", cerr)
+		}
+	}
+	if q.getSavedFilterListStmt != nil {
+		if cerr := q.getSavedFilterListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSavedFilterListStmt: %w", cerr)
+		}
+	}
 	if q.getSessionStmt != nil {
 		if cerr := q.getSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSessionStmt: %w", cerr)
 		}
 	}
+	if q.getStatusTransitionListStmt != nil {
+		if cerr := q.getStatusTransitionListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStatusTransitionListStmt: %w", cerr)
+		}
+	}
+	if q.getSubtasksStmt != nil {
+		if cerr := q.getSubtasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSubtasksStmt: %w", cerr)
+		}
+	}
+	if q.getSyncChangeStmt != nil {
+		if cerr := q.getSyncChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSyncChangeStmt: %w", cerr)
+		}
+	}
+	if q.getSyncChangesStmt != nil {
+		if cerr := q.getSyncChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSyncChangesStmt: %w", cerr)
+		}
+	}
+	if q.getSyncSnapshotXminStmt != nil {
+		if cerr := q.getSyncSnapshotXminStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSyncSnapshotXminStmt: %w", cerr)
+		}
+	}
 	if q.getTaskStmt != nil {
 		if cerr := q.getTaskStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTaskStmt: %w", cerr)
 		}
 	}
+	if q.getTaskAttachmentStmt != nil {
+		if cerr := q.getTaskAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskAttachmentStmt: %w", cerr)
+		}
+	}
+	if q.getTaskAttachmentListStmt != nil {
+		if cerr := q.getTaskAttachmentListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskAttachmentListStmt: %w", cerr)
+		}
+	}
+	if q.getTaskCountsByPeriodStmt != nil {
+		if cerr := q.getTaskCountsByPeriodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskCountsByPeriodStmt: %w", cerr)
+		}
+	}
+	if q.getTaskCustomFieldValuesStmt != nil {
+		if cerr := q.getTaskCustomFieldValuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskCustomFieldValuesStmt: %w", cerr)
+		}
+	}
 	if q.getTaskListStmt != nil {
 		if cerr := q.getTaskListStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTaskListStmt: %w", cerr)
 		}
 	}
+	if q.getTaskListByProjectStmt != nil {
+		if cerr := q.getTaskListByProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskListByProjectStmt: %w", cerr)
+		}
+	}
+	if q.getTaskStatsByLabelStmt != nil {
+		if cerr := q.getTaskStatsByLabelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskStatsByLabelStmt: %w", cerr)
+		}
+	}
+	if q.getTaskStatsByProjectStmt != nil {
+		if cerr := q.getTaskStatsByProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskStatsByProjectStmt: %w", cerr)
+		}
+	}
+	if q.getTaskStatsSummaryStmt != nil {
+		if cerr := q.getTaskStatsSummaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskStatsSummaryStmt: %w", cerr)
+		}
+	}
+	if q.getTaskTemplateStmt != nil {
+		if cerr := q.getTaskTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskTemplateStmt: %w", cerr)
+		}
+	}
+	if q.getTaskTemplateListStmt != nil {
+		if cerr := q.getTaskTemplateListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTaskTemplateListStmt: %w", cerr)
+		}
+	}
+	if q.getTasksByIdsStmt != nil {
+		if cerr := q.getTasksByIdsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTasksByIdsStmt: %w", cerr)
+		}
+	}
+	if q.getTasksCompletedBetweenStmt != nil {
+		if cerr := q.getTasksCompletedBetweenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTasksCompletedBetweenStmt: %w", cerr)
+		}
+	}
+	if q.getTasksDueBetweenStmt != nil {
+		if cerr := q.getTasksDueBetweenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTasksDueBetweenStmt: %w", cerr)
+		}
+	}
+	if q.getTimeEntriesBetweenStmt != nil {
+		if cerr := q.getTimeEntriesBetweenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTimeEntriesBetweenStmt: %w", cerr)
+		}
+	}
+	if q.getTimeEntryStmt != nil {
+		if cerr := q.getTimeEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTimeEntryStmt: %w", cerr)
+		}
+	}
+	if q.getTimeEntryListByTaskStmt != nil {
+		if cerr := q.getTimeEntryListByTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTimeEntryListByTaskStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
 		}
 	}
+	if q.getUserTotpStmt != nil {
+		if cerr := q.getUserTotpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserTotpStmt: %w", cerr)
+		}
+	}
+	if q.getWebauthnCredentialByCredentialIDStmt != nil {
+		if cerr := q.getWebauthnCredentialByCredentialIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebauthnCredentialByCredentialIDStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookStmt != nil {
+		if cerr := q.getWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookDeliveryStmt != nil {
+		if cerr := q.getWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookDeliveryListStmt != nil {
+		if cerr := q.getWebhookDeliveryListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryListStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookListStmt != nil {
+		if cerr := q.getWebhookListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookListStmt: %w", cerr)
+		}
+	}
+	if q.getWebhooksForEventStmt != nil {
+		if cerr := q.getWebhooksForEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhooksForEventStmt: %w", cerr)
+		}
+	}
+	if q.isTokenRevokedStmt != nil {
+		if cerr := q.isTokenRevokedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isTokenRevokedStmt: %w", cerr)
+		}
+	}
+	if q.listApiKeysStmt != nil {
+		if cerr := q.listApiKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listApiKeysStmt: %w", cerr)
+		}
+	}
+	if q.listSessionFamilyStmt != nil {
+		if cerr := q.listSessionFamilyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSessionFamilyStmt: %w", cerr)
+		}
+	}
+	if q.listSigningKeysStmt != nil {
+		if cerr := q.listSigningKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSigningKeysStmt: %w", cerr)
+		}
+	}
+	if q.listUserSessionsStmt != nil {
+		if cerr := q.listUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.listWebauthnCredentialsStmt != nil {
+		if cerr := q.listWebauthnCredentialsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebauthnCredentialsStmt: %w", cerr)
+		}
+	}
+	if q.lockProjectStmt != nil {
+		if cerr := q.lockProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockProjectStmt: %w", cerr)
+		}
+	}
+	if q.lockProjectForShareStmt != nil {
+		if cerr := q.lockProjectForShareStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockProjectForShareStmt: %w", cerr)
+		}
+	}
+	if q.lockProjectStatusStmt != nil {
+		if cerr := q.lockProjectStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockProjectStatusStmt: %w", cerr)
+		}
+	}
+	if q.markReminderSentStmt != nil {
+		if cerr := q.markReminderSentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReminderSentStmt: %w", cerr)
+		}
+	}
+	if q.recordReminderFailureStmt != nil {
+		if cerr := q.recordReminderFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordReminderFailureStmt: %w", cerr)
+		}
+	}
+	if q.recordTotpFailureStmt != nil {
+		if cerr := q.recordTotpFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordTotpFailureStmt: %w", cerr)
+		}
+	}
+	if q.recordWebhookDeliveryAttemptStmt != nil {
+		if cerr := q.recordWebhookDeliveryAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordWebhookDeliveryAttemptStmt: %w", cerr)
+		}
+	}
+	if q.replaceSessionStmt != nil {
+		if cerr := q.replaceSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replaceSessionStmt: %w", cerr)
+		}
+	}
+	if q.resetRelativeRemindersStmt != nil {
+		if cerr := q.resetRelativeRemindersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetRelativeRemindersStmt: %w", cerr)
+		}
+	}
+	if q.revokeTokenStmt != nil {
+		if cerr := q.revokeTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeTokenStmt: %w", cerr)
+		}
+	}
+	if q.revokeUserTokensStmt != nil {
+		if cerr := q.revokeUserTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeUserTokensStmt: %w", cerr)
+		}
+	}
+	if q.setDigestNextSendAtStmt != nil {
+		if cerr := q.setDigestNextSendAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setDigestNextSendAtStmt: %w", cerr)
+		}
+	}
+	if q.setLoginChallengeStmt != nil {
+		if cerr := q.setLoginChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setLoginChallengeStmt: %w", cerr)
+		}
+	}
+	if q.setTaskDeferredUntilStmt != nil {
+		if cerr := q.setTaskDeferredUntilStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTaskDeferredUntilStmt: %w", cerr)
+		}
+	}
+	if q.snoozeReminderStmt != nil {
+		if cerr := q.snoozeReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing snoozeReminderStmt: %w", cerr)
+		}
+	}
+	if q.stopTimeEntryStmt != nil {
+		if cerr := q.stopTimeEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing stopTimeEntryStmt: %w", cerr)
+		}
+	}
+	if q.syncTasksWithStatusStmt != nil {
+		if cerr := q.syncTasksWithStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing syncTasksWithStatusStmt: %w", cerr)
+		}
+	}
+	if q.takeLoginChallengeStmt != nil {
+		if cerr := q.takeLoginChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing takeLoginChallengeStmt: %w", cerr)
+		}
+	}
+	if q.touchApiKeyStmt != nil {
+		if cerr := q.touchApiKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchApiKeyStmt: %w", cerr)
+		}
+	}
+	if q.unarchiveTaskStmt != nil {
+		if cerr := q.unarchiveTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unarchiveTaskStmt: %w", cerr)
+		}
+	}
+	if q.unsubscribeDigestStmt != nil {
+		if cerr := q.unsubscribeDigestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unsubscribeDigestStmt: %w", cerr)
+		}
+	}
+	if q.updateCustomFieldStmt != nil {
+		if cerr := q.updateCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCustomFieldStmt: %w", cerr)
+		}
+	}
 	if q.updatePasswordResetSessionStmt != nil {
 		if cerr := q.updatePasswordResetSessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updatePasswordResetSessionStmt: %w", cerr)
 		}
 	}
+	if q.updateProjectStmt != nil {
+		if cerr := q.updateProjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateProjectStmt: %w", cerr)
+		}
+	}
+	if q.updateProjectStatusStmt != nil {
+		if cerr := q.updateProjectStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateProjectStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateSavedFilterStmt != nil {
+		if cerr := q.updateSavedFilterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateSavedFilterStmt: %w", cerr)
+		}
+	}
 	if q.updateTaskStmt != nil {
 		if cerr := q.updateTaskStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateTaskStmt: %w", cerr)
 		}
 	}
+	if q.updateTaskTemplateStmt != nil {
+		if cerr := q.updateTaskTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTaskTemplateStmt: %w", cerr)
+		}
+	}
+	if q.updateTimeEntryStmt != nil {
+		if cerr := q.updateTimeEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTimeEntryStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
 		}
 	}
+	if q.updateWebauthnSignCountStmt != nil {
+		if cerr := q.updateWebauthnSignCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWebauthnSignCountStmt: %w", cerr)
+		}
+	}
+	if q.updateWebhookStmt != nil {
+		if cerr := q.updateWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWebhookStmt: %w", cerr)
+		}
+	}
+	if q.upsertArchiveRuleStmt != nil {
+		if cerr := q.upsertArchiveRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertArchiveRuleStmt: %w", cerr)
+		}
+	}
+	if q.upsertDigestPreferenceStmt != nil {
+		if cerr := q.upsertDigestPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertDigestPreferenceStmt: %w", cerr)
+		}
+	}
+	if q.upsertInboxStmt != nil {
+		if cerr := q.upsertInboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertInboxStmt: %w", cerr)
+		}
+	}
+	if q.upsertLabelStmt != nil {
+		if cerr := q.upsertLabelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertLabelStmt: %w", cerr)
+		}
+	}
+	if q.upsertPendingTotpStmt != nil {
+		if cerr := q.upsertPendingTotpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertPendingTotpStmt: %w", cerr)
+		}
+	}
+	if q.upsertTaskCustomFieldValueStmt != nil {
+		if cerr := q.upsertTaskCustomFieldValueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertTaskCustomFieldValueStmt: %w", cerr)
+		}
+	}
+	if q.useRecoveryCodeStmt != nil {
+		if cerr := q.useRecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useRecoveryCodeStmt: %w", cerr)
+		}
+	}
+	if q.useTotpStepStmt != nil {
+		if cerr := q.useTotpStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useTotpStepStmt: %w", cerr)
+		}
+	}
+	if q.verifyUserEmailStmt != nil {
+		if cerr := q.verifyUserEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing verifyUserEmailStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -186,43 +1370,339 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                             DBTX
-	tx                             *sql.Tx
-	createPasswordResetSessionStmt *sql.Stmt
-	createSessionStmt              *sql.Stmt
-	createTaskStmt                 *sql.Stmt
-	createUserStmt                 *sql.Stmt
-	deletePasswordResetSessionStmt *sql.Stmt
-	deleteSessionStmt              *sql.Stmt
-	deleteTaskStmt                 *sql.Stmt
-	getPasswordResetSessionStmt    *sql.Stmt
-	getSessionStmt                 *sql.Stmt
-	getTaskStmt                    *sql.Stmt
-	getTaskListStmt                *sql.Stmt
-	getUserStmt                    *sql.Stmt
-	updatePasswordResetSessionStmt *sql.Stmt
-	updateTaskStmt                 *sql.Stmt
-	updateUserStmt                 *sql.Stmt
+	db                                      DBTX
+	tx                                      *sql.Tx
+	addTaskLabelStmt                        *sql.Stmt
+	archiveDoneTasksStmt                    *sql.Stmt
+	archiveTaskStmt                         *sql.Stmt
+	blockSessionStmt                        *sql.Stmt
+	claimDueDigestStmt                      *sql.Stmt
+	claimDueReminderStmt                    *sql.Stmt
+	claimDueWebhookDeliveryStmt             *sql.Stmt
+	claimVerificationEmailStmt              *sql.Stmt
+	countRecoveryCodesStmt                  *sql.Stmt
+	countTasksByStatusStmt                  *sql.Stmt
+	countWebauthnCredentialsStmt            *sql.Stmt
+	createApiKeyStmt                        *sql.Stmt
+	createCustomFieldStmt                   *sql.Stmt
+	createPasswordResetSessionStmt          *sql.Stmt
+	createProjectStmt                       *sql.Stmt
+	createProjectStatusStmt                 *sql.Stmt
+	createRecoveryCodesStmt                 *sql.Stmt
+	createReminderStmt                      *sql.Stmt
+	createSavedFilterStmt                   *sql.Stmt
+	createSessionStmt                       *sql.Stmt
+	createSigningKeyStmt                    *sql.Stmt
+	createStatusTransitionStmt              *sql.Stmt
+	createTaskStmt                          *sql.Stmt
+	createTaskAttachmentStmt                *sql.Stmt
+	createTaskTemplateStmt                  *sql.Stmt
+	createTimeEntryStmt                     *sql.Stmt
+	createUserStmt                          *sql.Stmt
+	createWebauthnCredentialStmt            *sql.Stmt
+	createWebhookStmt                       *sql.Stmt
+	createWebhookDeliveryStmt               *sql.Stmt
+	deleteApiKeyStmt                        *sql.Stmt
+	deleteArchiveRuleStmt                   *sql.Stmt
+	deleteCurrentSessionStmt                *sql.Stmt
+	deleteCustomFieldStmt                   *sql.Stmt
+	deleteExpiredLoginChallengesStmt        *sql.Stmt
+	deleteExpiredRevokedTokensStmt          *sql.Stmt
+	deleteExpiredSessionsStmt               *sql.Stmt
+	deleteExpiredSigningKeysStmt            *sql.Stmt
+	deleteExpiredUserTokenRevocationsStmt   *sql.Stmt
+	deleteLabelStmt                         *sql.Stmt
+	deletePasswordResetSessionStmt          *sql.Stmt
+	deleteProjectStmt                       *sql.Stmt
+	deleteProjectStatusStmt                 *sql.Stmt
+	deleteRecoveryCodesStmt                 *sql.Stmt
+	deleteReminderStmt                      *sql.Stmt
+	deleteSavedFilterStmt                   *sql.Stmt
+	deleteSessionStmt                       *sql.Stmt
+	deleteStatusTransitionsStmt             *sql.Stmt
+	deleteTaskStmt                          *sql.Stmt
+	deleteTaskCustomFieldValueStmt          *sql.Stmt
+	deleteTaskLabelsStmt                    *sql.Stmt
+	deleteTaskTemplateStmt                  *sql.Stmt
+	deleteTimeEntryStmt                     *sql.Stmt
+	deleteUserSessionsStmt                  *sql.Stmt
+	deleteUserTotpStmt                      *sql.Stmt
+	deleteWebauthnCredentialStmt            *sql.Stmt
+	deleteWebhookStmt                       *sql.Stmt
+	enableUserTotpStmt                      *sql.Stmt
+	getApiKeyByHashStmt                     *sql.Stmt
+	getArchiveRuleStmt                      *sql.Stmt
+	getArchivedTaskListStmt                 *sql.Stmt
+	getCompletionStreaksStmt                *sql.Stmt
+	getCustomFieldStmt                      *sql.Stmt
+	getCustomFieldListStmt                  *sql.Stmt
+	getCustomFieldListByOwnerStmt           *sql.Stmt
+	getCustomFieldValuesByTasksStmt         *sql.Stmt
+	getDigestPreferenceStmt                 *sql.Stmt
+	getInboxStmt                            *sql.Stmt
+	getInboxByTokenStmt                     *sql.Stmt
+	getLabelListStmt                        *sql.Stmt
+	getLabelsByIdsStmt                      *sql.Stmt
+	getLabelsByTasksStmt                    *sql.Stmt
+	getOverdueTasksStmt                     *sql.Stmt
+	getPasswordResetSessionStmt             *sql.Stmt
+	getProjectStmt                          *sql.Stmt
+	getProjectListStmt                      *sql.Stmt
+	getProjectStatusStmt                    *sql.Stmt
+	getProjectStatusListStmt                *sql.Stmt
+	getProjectsByIdsStmt                    *sql.Stmt
+	getReminderStmt                         *sql.Stmt
+	getReminderListByTaskStmt               *sql.Stmt
+	getRunningTimeEntryStmt                 *sql.Stmt
+	getSavedFilterStmt                      *sql.Stmt
+	getSavedFilterListStmt                  *sql.Stmt
+	getSessionStmt                          *sql.Stmt
+	getStatusTransitionListStmt             *sql.Stmt
+	getSubtasksStmt                         *sql.Stmt
+	getSyncChangeStmt                       *sql.Stmt
+	getSyncChangesStmt                      *sql.Stmt
+	getSyncSnapshotXminStmt                 *sql.Stmt
+	getTaskStmt                             *sql.Stmt
+	getTaskAttachmentStmt                   *sql.Stmt
+	getTaskAttachmentListStmt               *sql.Stmt
+	getTaskCountsByPeriodStmt               *sql.Stmt
+	getTaskCustomFieldValuesStmt            *sql.Stmt
+	getTaskListStmt                         *sql.Stmt
+	getTaskListByProjectStmt                *sql.Stmt
+	getTaskStatsByLabelStmt                 *sql.Stmt
+	getTaskStatsByProjectStmt               *sql.Stmt
+	getTaskStatsSummaryStmt                 *sql.Stmt
+	getTaskTemplateStmt                     *sql.Stmt
+	getTaskTemplateListStmt                 *sql.Stmt
+	getTasksByIdsStmt                       *sql.Stmt
+	getTasksCompletedBetweenStmt            *sql.Stmt
+	getTasksDueBetweenStmt                  *sql.Stmt
+	getTimeEntriesBetweenStmt               *sql.Stmt
+	getTimeEntryStmt                        *sql.Stmt
+	getTimeEntryListByTaskStmt              *sql.Stmt
+	getUserStmt                             *sql.Stmt
+	getUserTotpStmt                         *sql.Stmt
+	getWebauthnCredentialByCredentialIDStmt *sql.Stmt
+	getWebhookStmt                          *sql.Stmt
+	getWebhookDeliveryStmt                  *sql.Stmt
+	getWebhookDeliveryListStmt              *sql.Stmt
+	getWebhookListStmt                      *sql.Stmt
+	getWebhooksForEventStmt                 *sql.Stmt
+	isTokenRevokedStmt                      *sql.Stmt
+	listApiKeysStmt                         *sql.Stmt
+	listSessionFamilyStmt                   *sql.Stmt
+	listSigningKeysStmt                     *sql.Stmt
+	listUserSessionsStmt                    *sql.Stmt
+	listWebauthnCredentialsStmt             *sql.Stmt
+	lockProjectStmt                         *sql.Stmt
+	lockProjectForShareStmt                 *sql.Stmt
+	lockProjectStatusStmt                   *sql.Stmt
+	markReminderSentStmt                    *sql.Stmt
+	recordReminderFailureStmt               *sql.Stmt
+	recordTotpFailureStmt                   *sql.Stmt
+	recordWebhookDeliveryAttemptStmt        *sql.Stmt
+	replaceSessionStmt                      *sql.Stmt
+	resetRelativeRemindersStmt              *sql.Stmt
+	revokeTokenStmt                         *sql.Stmt
+	revokeUserTokensStmt                    *sql.Stmt
+	setDigestNextSendAtStmt                 *sql.Stmt
+	setLoginChallengeStmt                   *sql.Stmt
+	setTaskDeferredUntilStmt                *sql.Stmt
+	snoozeReminderStmt                      *sql.Stmt
+	stopTimeEntryStmt                       *sql.Stmt
+	syncTasksWithStatusStmt                 *sql.Stmt
+	takeLoginChallengeStmt                  *sql.Stmt
+	touchApiKeyStmt                         *sql.Stmt
+	unarchiveTaskStmt                       *sql.Stmt
+	unsubscribeDigestStmt                   *sql.Stmt
+	updateCustomFieldStmt                   *sql.Stmt
+	updatePasswordResetSessionStmt          *sql.Stmt
+	updateProjectStmt                       *sql.Stmt
+	updateProjectStatusStmt                 *sql.Stmt
+	updateSavedFilterStmt                   *sql.Stmt
+	updateTaskStmt                          *sql.Stmt
+	updateTaskTemplateStmt                  *sql.Stmt
+	updateTimeEntryStmt                     *sql.Stmt
+	updateUserStmt                          *sql.Stmt
+	updateWebauthnSignCountStmt             *sql.Stmt
+	updateWebhookStmt                       *sql.Stmt
+	upsertArchiveRuleStmt                   *sql.Stmt
+	upsertDigestPreferenceStmt              *sql.Stmt
+	upsertInboxStmt                         *sql.Stmt
+	upsertLabelStmt                         *sql.Stmt
+	upsertPendingTotpStmt                   *sql.Stmt
+	upsertTaskCustomFieldValueStmt          *sql.Stmt
+	useRecoveryCodeStmt                     *sql.Stmt
+	useTotpStepStmt                         *sql.Stmt
+	verifyUserEmailStmt                     *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                             tx,
-		tx:                             tx,
-		createPasswordResetSessionStmt: q.createPasswordResetSessionStmt,
-		createSessionStmt:              q.createSessionStmt,
-		createTaskStmt:                 q.createTaskStmt,
-		createUserStmt:                 q.createUserStmt,
-		deletePasswordResetSessionStmt: q.deletePasswordResetSessionStmt,
-		deleteSessionStmt:              q.deleteSessionStmt,
-		deleteTaskStmt:                 q.deleteTaskStmt,
-		getPasswordResetSessionStmt:    q.getPasswordResetSessionStmt,
-		getSessionStmt:                 q.getSessionStmt,
-		getTaskStmt:                    q.getTaskStmt,
-		getTaskListStmt:                q.getTaskListStmt,
-		getUserStmt:                    q.getUserStmt,
-		updatePasswordResetSessionStmt: q.updatePasswordResetSessionStmt,
-		updateTaskStmt:                 q.updateTaskStmt,
-		updateUserStmt:                 q.updateUserStmt,
+		db:                                      tx,
+		tx:                                      tx,
+		addTaskLabelStmt:                        q.addTaskLabelStmt,
+		archiveDoneTasksStmt:                    q.archiveDoneTasksStmt,
+		archiveTaskStmt:                         q.archiveTaskStmt,
+		blockSessionStmt:                        q.blockSessionStmt,
+		claimDueDigestStmt:                      q.claimDueDigestStmt,
+		claimDueReminderStmt:                    q.claimDueReminderStmt,
+		claimDueWebhookDeliveryStmt:             q.claimDueWebhookDeliveryStmt,
+		claimVerificationEmailStmt:              q.claimVerificationEmailStmt,
+		countRecoveryCodesStmt:                  q.countRecoveryCodesStmt,
+		countTasksByStatusStmt:                  q.countTasksByStatusStmt,
+		countWebauthnCredentialsStmt:            q.countWebauthnCredentialsStmt,
+		createApiKeyStmt:                        q.createApiKeyStmt,
+		createCustomFieldStmt:                   q.createCustomFieldStmt,
+		createPasswordResetSessionStmt:          q.createPasswordResetSessionStmt,
+		createProjectStmt:                       q.createProjectStmt,
+		createProjectStatusStmt:                 q.createProjectStatusStmt,
+		createRecoveryCodesStmt:                 q.createRecoveryCodesStmt,
+		createReminderStmt:                      q.createReminderStmt,
+		createSavedFilterStmt:                   q.createSavedFilterStmt,
+		createSessionStmt:                       q.createSessionStmt,
+		createSigningKeyStmt:                    q.createSigningKeyStmt,
+		createStatusTransitionStmt:              q.createStatusTransitionStmt,
+		createTaskStmt:                          q.createTaskStmt,
+		createTaskAttachmentStmt:                q.createTaskAttachmentStmt,
+		createTaskTemplateStmt:                  q.createTaskTemplateStmt,
+		createTimeEntryStmt:                     q.createTimeEntryStmt,
+		createUserStmt:                          q.createUserStmt,
+		createWebauthnCredentialStmt:            q.createWebauthnCredentialStmt,
+		createWebhookStmt:                       q.createWebhookStmt,
+		createWebhookDeliveryStmt:               q.createWebhookDeliveryStmt,
+		deleteApiKeyStmt:                        q.deleteApiKeyStmt,
+		deleteArchiveRuleStmt:                   q.deleteArchiveRuleStmt,
+		deleteCurrentSessionStmt:                q.deleteCurrentSessionStmt,
+		deleteCustomFieldStmt:                   q.deleteCustomFieldStmt,
+		deleteExpiredLoginChallengesStmt:        q.deleteExpiredLoginChallengesStmt,
+		deleteExpiredRevokedTokensStmt:          q.deleteExpiredRevokedTokensStmt,
+		deleteExpiredSessionsStmt:               q.deleteExpiredSessionsStmt,
+		deleteExpiredSigningKeysStmt:            q.deleteExpiredSigningKeysStmt,
+		deleteExpiredUserTokenRevocationsStmt:   q.deleteExpiredUserTokenRevocationsStmt,
+		deleteLabelStmt:                         q.deleteLabelStmt,
+		deletePasswordResetSessionStmt:          q.deletePasswordResetSessionStmt,
+		deleteProjectStmt:                       q.deleteProjectStmt,
+		deleteProjectStatusStmt:                 q.deleteProjectStatusStmt,
+		deleteRecoveryCodesStmt:                 q.deleteRecoveryCodesStmt,
+		deleteReminderStmt:                      q.deleteReminderStmt,
+		deleteSavedFilterStmt:                   q.deleteSavedFilterStmt,
+		deleteSessionStmt:                       q.deleteSessionStmt,
+		deleteStatusTransitionsStmt:             q.deleteStatusTransitionsStmt,
+		deleteTaskStmt:                          q.deleteTaskStmt,
+		deleteTaskCustomFieldValueStmt:          q.deleteTaskCustomFieldValueStmt,
+		deleteTaskLabelsStmt:                    q.deleteTaskLabelsStmt,
+		deleteTaskTemplateStmt:                  q.deleteTaskTemplateStmt,
+		deleteTimeEntryStmt:                     q.deleteTimeEntryStmt,
+		deleteUserSessionsStmt:                  q.deleteUserSessionsStmt,
+		deleteUserTotpStmt:                      q.deleteUserTotpStmt,
+		deleteWebauthnCredentialStmt:            q.deleteWebauthnCredentialStmt,
+		deleteWebhookStmt:                       q.deleteWebhookStmt,
+		enableUserTotpStmt:                      q.enableUserTotpStmt,
+		getApiKeyByHashStmt:                     q.getApiKeyByHashStmt,
+		getArchiveRuleStmt:                      q.getArchiveRuleStmt,
+		getArchivedTaskListStmt:                 q.getArchivedTaskListStmt,
+		getCompletionStreaksStmt:                q.getCompletionStreaksStmt,
+		getCustomFieldStmt:                      q.getCustomFieldStmt,
+		getCustomFieldListStmt:                  q.getCustomFieldListStmt,
+		getCustomFieldListByOwnerStmt:           q.getCustomFieldListByOwnerStmt,
+		getCustomFieldValuesByTasksStmt:         q.getCustomFieldValuesByTasksStmt,
+		getDigestPreferenceStmt:                 q.getDigestPreferenceStmt,
+		getInboxStmt:                            q.getInboxStmt,
+		getInboxByTokenStmt:                     q.getInboxByTokenStmt,
+		getLabelListStmt:                        q.getLabelListStmt,
+		getLabelsByIdsStmt:                      q.getLabelsByIdsStmt,
+		getLabelsByTasksStmt:                    q.getLabelsByTasksStmt,
+		getOverdueTasksStmt:                     q.getOverdueTasksStmt,
+		getPasswordResetSessionStmt:             q.getPasswordResetSessionStmt,
+		getProjectStmt:                          q.getProjectStmt,
+		getProjectListStmt:                      q.getProjectListStmt,
+		getProjectStatusStmt:                    q.getProjectStatusStmt,
+		getProjectStatusListStmt:                q.getProjectStatusListStmt,
+		getProjectsByIdsStmt:                    q.getProjectsByIdsStmt,
+		getReminderStmt:                         q.getReminderStmt,
+		getReminderListByTaskStmt:               q.getReminderListByTaskStmt,
+		getRunningTimeEntryStmt:                 q.getRunningTimeEntryStmt,
+		getSavedFilterStmt:                      q.getSavedFilterStmt,
+		getSavedFilterListStmt:                  q.getSavedFilterListStmt,
+		getSessionStmt:                          q.getSessionStmt,
+		getStatusTransitionListStmt:             q.getStatusTransitionListStmt,
+		getSubtasksStmt:                         q.getSubtasksStmt,
+		getSyncChangeStmt:                       q.getSyncChangeStmt,
+		getSyncChangesStmt:                      q.getSyncChangesStmt,
+		getSyncSnapshotXminStmt:                 q.getSyncSnapshotXminStmt,
+		getTaskStmt:                             q.getTaskStmt,
+		getTaskAttachmentStmt:                   q.getTaskAttachmentStmt,
+		getTaskAttachmentListStmt:               q.getTaskAttachmentListStmt,
+		getTaskCountsByPeriodStmt:               q.getTaskCountsByPeriodStmt,
+		getTaskCustomFieldValuesStmt:            q.getTaskCustomFieldValuesStmt,
+		getTaskListStmt:                         q.getTaskListStmt,
+		getTaskListByProjectStmt:                q.getTaskListByProjectStmt,
+		getTaskStatsByLabelStmt:                 q.getTaskStatsByLabelStmt,
+		getTaskStatsByProjectStmt:               q.getTaskStatsByProjectStmt,
+		getTaskStatsSummaryStmt:                 q.getTaskStatsSummaryStmt,
+		getTaskTemplateStmt:                     q.getTaskTemplateStmt,
+		getTaskTemplateListStmt:                 q.getTaskTemplateListStmt,
+		getTasksByIdsStmt:                       q.getTasksByIdsStmt,
+		getTasksCompletedBetweenStmt:            q.getTasksCompletedBetweenStmt,
+		getTasksDueBetweenStmt:                  q.getTasksDueBetweenStmt,
+		getTimeEntriesBetweenStmt:               q.getTimeEntriesBetweenStmt,
+		getTimeEntryStmt:                        q.getTimeEntryStmt,
+		getTimeEntryListByTaskStmt:              q.getTimeEntryListByTaskStmt,
+		getUserStmt:                             q.getUserStmt,
+		getUserTotpStmt:                         q.getUserTotpStmt,
+		getWebauthnCredentialByCredentialIDStmt: q.getWebauthnCredentialByCredentialIDStmt,
+		getWebhookStmt:                          q.getWebhookStmt,
+		getWebhookDeliveryStmt:                  q.getWebhookDeliveryStmt,
+		getWebhookDeliveryListStmt:              q.getWebhookDeliveryListStmt,
+		getWebhookListStmt:                      q.getWebhookListStmt,
+		getWebhooksForEventStmt:                 q.getWebhooksForEventStmt,
+		isTokenRevokedStmt:                      q.isTokenRevokedStmt,
+		listApiKeysStmt:                         q.listApiKeysStmt,
+		listSessionFamilyStmt:                   q.listSessionFamilyStmt,
+		listSigningKeysStmt:                     q.listSigningKeysStmt,
+		listUserSessionsStmt:                    q.listUserSessionsStmt,
+		listWebauthnCredentialsStmt:             q.listWebauthnCredentialsStmt,
+		lockProjectStmt:                         q.lockProjectStmt,
+		lockProjectForShareStmt:                 q.lockProjectForShareStmt,
+		lockProjectStatusStmt:                   q.lockProjectStatusStmt,
+		markReminderSentStmt:                    q.markReminderSentStmt,
+		recordReminderFailureStmt:               q.recordReminderFailureStmt,
+		recordTotpFailureStmt:                   q.recordTotpFailureStmt,
+		recordWebhookDeliveryAttemptStmt:        q.recordWebhookDeliveryAttemptStmt,
+		replaceSessionStmt:                      q.replaceSessionStmt,
+		resetRelativeRemindersStmt:              q.resetRelativeRemindersStmt,
+		revokeTokenStmt:                         q.revokeTokenStmt,
+		revokeUserTokensStmt:                    q.revokeUserTokensStmt,
+		setDigestNextSendAtStmt:                 q.setDigestNextSendAtStmt,
+		setLoginChallengeStmt:                   q.setLoginChallengeStmt,
+		setTaskDeferredUntilStmt:                q.setTaskDeferredUntilStmt,
+		snoozeReminderStmt:                      q.snoozeReminderStmt,
+		stopTimeEntryStmt:                       q.stopTimeEntryStmt,
+		syncTasksWithStatusStmt:                 q.syncTasksWithStatusStmt,
+		takeLoginChallengeStmt:                  q.takeLoginChallengeStmt,
+		touchApiKeyStmt:                         q.touchApiKeyStmt,
+		unarchiveTaskStmt:                       q.unarchiveTaskStmt,
+		unsubscribeDigestStmt:                   q.unsubscribeDigestStmt,
+		updateCustomFieldStmt:                   q.updateCustomFieldStmt,
+		updatePasswordResetSessionStmt:          q.updatePasswordResetSessionStmt,
+		updateProjectStmt:                       q.updateProjectStmt,
+		updateProjectStatusStmt:                 q.updateProjectStatusStmt,
+		updateSavedFilterStmt:                   q.updateSavedFilterStmt,
+		updateTaskStmt:                          q.updateTaskStmt,
+		updateTaskTemplateStmt:                  q.updateTaskTemplateStmt,
+		updateTimeEntryStmt:                     q.updateTimeEntryStmt,
+		updateUserStmt:                          q.updateUserStmt,
+		updateWebauthnSignCountStmt:             q.updateWebauthnSignCountStmt,
+		updateWebhookStmt:                       q.updateWebhookStmt,
+		upsertArchiveRuleStmt:                   q.upsertArchiveRuleStmt,
+		upsertDigestPreferenceStmt:              q.upsertDigestPreferenceStmt,
+		upsertInboxStmt:                         q.upsertInboxStmt,
+		upsertLabelStmt:                         q.upsertLabelStmt,
+		upsertPendingTotpStmt:                   q.upsertPendingTotpStmt,
+		upsertTaskCustomFieldValueStmt:          q.upsertTaskCustomFieldValueStmt,
+		useRecoveryCodeStmt:                     q.useRecoveryCodeStmt,
+		useTotpStepStmt:                         q.useTotpStepStmt,
+		verifyUserEmailStmt:                     q.verifyUserEmailStmt,
 	}
 }
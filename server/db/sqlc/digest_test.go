@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletedAt(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	require.False(t, task.CompletedAt.Valid)
+
+	done, err := testQueries.UpdateTask(context.Background(), UpdateTaskParams{
+		ID:      task.ID,
+		OwnerID: user.ID,
+		Body:    task.Body,
+		IsDone:  true,
+	})
+	require.NoError(t, err)
+	require.True(t, done.CompletedAt.Valid)
+
+	completed, err := testQueries.GetTasksCompletedBetween(context.Background(), GetTasksCompletedBetweenParams{
+		OwnerID:  user.ID,
+		FromTime: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		ToTime:   sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+
+	//completing a task again keeps the first completion time
+	again, err := testQueries.UpdateTask(context.Background(), UpdateTaskParams{
+		ID:      task.ID,
+		OwnerID: user.ID,
+		Body:    "edited",
+		IsDone:  true,
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, done.CompletedAt.Time, again.CompletedAt.Time, 0)
+
+	undone, err := testQueries.UpdateTask(context.Background(), UpdateTaskParams{
+		ID:      task.ID,
+		OwnerID: user.ID,
+		Body:    task.Body,
+	})
+	require.NoError(t, err)
+	require.False(t, undone.CompletedAt.Valid)
+}
+
+func TestDeliverDigestTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	store := NewStore(testDB)
+	now := time.Now()
+
+	_, err := testQueries.UpsertDigestPreference(context.Background(), UpsertDigestPreferenceParams{
+		UserID:     user.ID,
+		Frequency:  "daily",
+		SendMinute: 420,
+		Weekday:    1,
+		Timezone:   "UTC",
+		NextSendAt: sql.NullTime{Time: now.Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+
+	next := now.Add(24 * time.Hour)
+	deliveries := 0
+	for {
+		result, err := store.DeliverDigestTx(context.Background(), DeliverDigestTxParams{
+			Now: now,
+			Deliver: func(digest ClaimDueDigestRow) error {
+				if digest.UserID == user.ID {
+					deliveries++
+				}
+				return nil
+			},
+			NextSendAt: func(digest ClaimDueDigestRow) time.Time { return next },
+			RetryAt:    now.Add(15 * time.Minute),
+		})
+		require.NoError(t, err)
+		if !result.Found {
+			break
+		}
+	}
+	require.Equal(t, 1, deliveries)
+
+	pref, err := testQueries.GetDigestPreference(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, next, pref.NextSendAt.Time, time.Second)
+	require.True(t, pref.LastSentAt.Valid)
+
+	err = testQueries.UnsubscribeDigest(context.Background(), user.ID)
+	require.NoError(t, err)
+	pref, err = testQueries.GetDigestPreference(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "off", pref.Frequency)
+	require.False(t, pref.NextSendAt.Valid)
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertInbox(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	inbox, err := testQueries.UpsertInbox(context.Background(), UpsertInboxParams{
+		UserID: user.ID,
+		Token:  util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	rotated, err := testQueries.UpsertInbox(context.Background(), UpsertInboxParams{
+		UserID: user.ID,
+		Token:  util.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, inbox.Token, rotated.Token)
+
+	found, err := testQueries.GetInboxByToken(context.Background(), rotated.Token)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, found.UserID)
+	_, err = testQueries.GetInboxByToken(context.Background(), inbox.Token)
+	require.Error(t, err)
+}
+
+func TestTaskAttachment(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+
+	created, err := testQueries.CreateTaskAttachment(context.Background(), CreateTaskAttachmentParams{
+		TaskID:      task.ID,
+		Filename:    "notes.txt",
+		ContentType: "text/plain",
+		Size:        5,
+		Content:     []byte("hello"),
+	})
+	require.NoError(t, err)
+
+	list, err := testQueries.GetTaskAttachmentList(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, created.ID, list[0].ID)
+
+	attachment, err := testQueries.GetTaskAttachment(context.Background(), created.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), attachment.Content)
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTaskLabelsTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	store := NewStore(testDB)
+
+	labels, err := store.SetTaskLabelsTx(context.Background(), SetTaskLabelsTxParams{
+		TaskID:  task.ID,
+		OwnerID: user.ID,
+		Names:   []string{"backend", "bug"},
+	})
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+
+	//existing labels are reused, labels not listed are removed from the task
+	labels2, err := store.SetTaskLabelsTx(context.Background(), SetTaskLabelsTxParams{
+		TaskID:  task.ID,
+		OwnerID: user.ID,
+		Names:   []string{"bug"},
+	})
+	require.NoError(t, err)
+	require.Len(t, labels2, 1)
+	require.Equal(t, labels[1].ID, labels2[0].ID)
+
+	rows, err := testQueries.GetLabelsByTasks(context.Background(), []int64{task.ID})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, "bug", rows[0].Name)
+
+	list, err := testQueries.GetLabelList(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+}
+
+func TestSavedFilter(t *testing.T) {
+	user := CreateRandomUser(t)
+	arg := CreateSavedFilterParams{
+		OwnerID: user.ID,
+		Name:    util.RandomString(6),
+		Query:   "due < +7d and not done",
+	}
+	filter, err := testQueries.CreateSavedFilter(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, filter.Name)
+	require.Equal(t, arg.Query, filter.Query)
+
+	updated, err := testQueries.UpdateSavedFilter(context.Background(), UpdateSavedFilterParams{
+		ID:      filter.ID,
+		OwnerID: user.ID,
+		Name:    filter.Name,
+		Query:   "overdue",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "overdue", updated.Query)
+
+	//names are unique per owner
+	_, err = testQueries.CreateSavedFilter(context.Background(), arg)
+	require.Error(t, err)
+
+	err = testQueries.DeleteSavedFilter(context.Background(), DeleteSavedFilterParams{
+		ID:      filter.ID,
+		OwnerID: user.ID,
+	})
+	require.NoError(t, err)
+	list, err := testQueries.GetSavedFilterList(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
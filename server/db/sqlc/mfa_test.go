@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableTotp(t *testing.T) {
+	store := NewStore(testDB)
+	user := CreateRandomUser(t)
+
+	pending, err := store.UpsertPendingTotp(context.Background(), UpsertPendingTotpParams{UserID: user.ID, Secret: []byte("secret")})
+	require.NoError(t, err)
+	require.False(t, pending.EnabledAt.Valid)
+
+	enabled, err := store.EnableTotpTx(context.Background(), EnableTotpTxParams{
+		UserID:     user.ID,
+		Step:       100,
+		CodeHashes: [][]byte{[]byte("a"), []byte("b")},
+	})
+	require.NoError(t, err)
+	require.True(t, enabled.EnabledAt.Valid)
+	count, err := store.CountRecoveryCodes(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	//an enabled secret can't be replaced by starting over
+	_, err = store.UpsertPendingTotp(context.Background(), UpsertPendingTotpParams{UserID: user.ID, Secret: []byte("other")})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	//each step is used once
+	used, err := store.UseTotpStep(context.Background(), UseTotpStepParams{Step: sql.NullInt64{Int64: 100, Valid: true}, UserID: user.ID})
+	require.NoError(t, err)
+	require.Zero(t, used)
+	used, err = store.UseTotpStep(context.Background(), UseTotpStepParams{Step: sql.NullInt64{Int64: 101, Valid: true}, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), used)
+
+	used, err = store.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{UserID: user.ID, CodeHash: []byte("a")})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), used)
+	used, err = store.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{UserID: user.ID, CodeHash: []byte("a")})
+	require.NoError(t, err)
+	require.Zero(t, used)
+
+	require.NoError(t, store.DisableTotpTx(context.Background(), user.ID))
+	_, err = store.GetUserTotp(context.Background(), user.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRecordTotpFailure(t *testing.T) {
+	user := CreateRandomUser(t)
+	_, err := testQueries.UpsertPendingTotp(context.Background(), UpsertPendingTotpParams{UserID: user.ID, Secret: []byte("secret")})
+	require.NoError(t, err)
+
+	lockedUntil := time.Now().Add(time.Minute)
+	arg := RecordTotpFailureParams{MaxAttempts: 2, LockedUntil: lockedUntil, UserID: user.ID}
+	totp, err := testQueries.RecordTotpFailure(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), totp.FailedAttempts)
+	require.False(t, totp.LockedUntil.Valid)
+
+	totp, err = testQueries.RecordTotpFailure(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, totp.FailedAttempts)
+	require.WithinDuration(t, lockedUntil, totp.LockedUntil.Time, time.Second)
+}
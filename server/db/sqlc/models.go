@@ -5,11 +5,87 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type ApiKey struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"userId"`
+	Name   string `json:"name"`
+	// start of the key so users can tell their keys apart
+	Prefix string `json:"prefix"`
+	// sha256 of the key, the key itself is only shown once
+	KeyHash []byte `json:"keyHash"`
+	// tasks:read, tasks:write or admin
+	Scopes     []string     `json:"scopes"`
+	ExpiresAt  time.Time    `json:"expiresAt"`
+	LastUsedAt sql.NullTime `json:"lastUsedAt"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+type ArchiveRule struct {
+	UserID int64 `json:"userId"`
+	// done tasks are archived this many days after they were completed
+	AfterDays int32     `json:"afterDays"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CustomField struct {
+	ID        int64           `json:"id"`
+	ProjectID int64           `json:"projectId"`
+	Name      string          `json:"name"`
+	FieldType string          `json:"fieldType"`
+	Options   json.RawMessage `json:"options"`
+	Rules     json.RawMessage `json:"rules"`
+	Position  int32           `json:"position"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type DigestPreference struct {
+	UserID    int64  `json:"userId"`
+	Frequency string `json:"frequency"`
+	// minutes after midnight in timezone
+	SendMinute int32 `json:"sendMinute"`
+	// day of weekly digests, 0 is Sunday
+	Weekday    int16        `json:"weekday"`
+	Timezone   string       `json:"timezone"`
+	NextSendAt sql.NullTime `json:"nextSendAt"`
+	LastSentAt sql.NullTime `json:"lastSentAt"`
+	UpdatedAt  time.Time    `json:"updatedAt"`
+}
+
+type Inbox struct {
+	UserID    int64     `json:"userId"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Label struct {
+	ID        int64     `json:"id"`
+	OwnerID   int64     `json:"ownerId"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type LoginChallenge struct {
+	Key       string    `json:"key"`
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type MfaRecoveryCode struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+	// sha256 of the recovery code, the code itself is only shown once
+	CodeHash  []byte       `json:"codeHash"`
+	UsedAt    sql.NullTime `json:"usedAt"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
 type PasswordResetSession struct {
 	Email     string    `json:"email"`
 	Otp       string    `json:"otp"`
@@ -17,6 +93,52 @@ type PasswordResetSession struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+type Project struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   int64     `json:"ownerId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ProjectStatus struct {
+	ID        int64     `json:"id"`
+	ProjectID int64     `json:"projectId"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Position  int32     `json:"position"`
+	WipLimit  int32     `json:"wipLimit"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Reminder struct {
+	ID       int64        `json:"id"`
+	TaskID   int64        `json:"taskId"`
+	OwnerID  int64        `json:"ownerId"`
+	RemindAt sql.NullTime `json:"remindAt"`
+	// minutes before the due date of the task
+	OffsetMinutes sql.NullInt32 `json:"offsetMinutes"`
+	// next delivery time, null while a relative reminder has no due date
+	FireAt    sql.NullTime `json:"fireAt"`
+	SentAt    sql.NullTime `json:"sentAt"`
+	Attempts  int32        `json:"attempts"`
+	LastError string       `json:"lastError"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+type RevokedToken struct {
+	ID uuid.UUID `json:"id"`
+	// when the token expires, the row is of no use after
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type SavedFilter struct {
+	ID        int64     `json:"id"`
+	OwnerID   int64     `json:"ownerId"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	UserID       int64     `json:"userId"`
@@ -26,21 +148,177 @@ type Session struct {
 	IsBlocked    bool      `json:"isBlocked"`
 	ExpiresAt    time.Time `json:"expiresAt"`
 	CreatedAt    time.Time `json:"createdAt"`
+	// first session of the login, sessions rotated from it share it
+	FamilyID uuid.UUID `json:"familyId"`
+	// session that rotated this one, kept until it expires to detect reuse
+	ReplacedBy uuid.NullUUID `json:"replacedBy"`
+	// access token issued with the refresh token
+	AccessTokenID        uuid.NullUUID `json:"accessTokenId"`
+	AccessTokenExpiresAt sql.NullTime  `json:"accessTokenExpiresAt"`
+}
+
+type SigningKey struct {
+	// RFC 7638 thumbprint of the public key, the kid of tokens
+	ID string `json:"id"`
+	// seed of the Ed25519 private key encrypted with TOKEN_KEY_SECRET, the XChaCha20-Poly1305 nonce goes first
+	PrivateKey []byte `json:"privateKey"`
+	// the key signs tokens from then, servers creating the same key race on it
+	NotBefore time.Time `json:"notBefore"`
+	// until then, its tokens verify for the grace period after
+	NotAfter  time.Time `json:"notAfter"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type StatusTransition struct {
+	ProjectID    int64 `json:"projectId"`
+	FromStatusID int64 `json:"fromStatusId"`
+	ToStatusID   int64 `json:"toStatusId"`
+}
+
+type SyncChange struct {
+	Entity   string `json:"entity"`
+	EntityID int64  `json:"entityId"`
+	OwnerID  int64  `json:"ownerId"`
+	// increases with every change, used to order changes and detect conflicts
+	Seq int64 `json:"seq"`
+	// transaction of the last change, sync tokens hold the oldest transaction still running
+	ChangeXid interface{} `json:"changeXid"`
+	ChangedAt time.Time   `json:"changedAt"`
+	Deleted   bool        `json:"deleted"`
 }
 
 type Task struct {
+	ID              int64         `json:"id"`
+	Body            string        `json:"body"`
+	IsDone          bool          `json:"isDone"`
+	OwnerID         int64         `json:"ownerId"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	ProjectID       sql.NullInt64 `json:"projectId"`
+	StatusID        sql.NullInt64 `json:"statusId"`
+	DueAt           sql.NullTime  `json:"dueAt"`
+	Priority        int16         `json:"priority"`
+	CompletedAt     sql.NullTime  `json:"completedAt"`
+	EstimateMinutes sql.NullInt32 `json:"estimateMinutes"`
+	ParentID        sql.NullInt64 `json:"parentId"`
+	// archived tasks are hidden from the task list
+	ArchivedAt sql.NullTime `json:"archivedAt"`
+	// the task is hidden from the task list until then
+	DeferredUntil sql.NullTime `json:"deferredUntil"`
+}
+
+type TaskAttachment struct {
+	ID          int64     `json:"id"`
+	TaskID      int64     `json:"taskId"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Content     []byte    `json:"content"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type TaskCustomFieldValue struct {
+	TaskID    int64           `json:"taskId"`
+	FieldID   int64           `json:"fieldId"`
+	Value     json.RawMessage `json:"value"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+type TaskLabel struct {
+	TaskID  int64 `json:"taskId"`
+	LabelID int64 `json:"labelId"`
+}
+
+type TaskTemplate struct {
+	ID       int64    `json:"id"`
+	OwnerID  int64    `json:"ownerId"`
+	Name     string   `json:"name"`
+	Body     string   `json:"body"`
+	Priority int16    `json:"priority"`
+	Labels   []string `json:"labels"`
+	// due date of created tasks relative to when the template is used
+	DueOffsetMinutes sql.NullInt32   `json:"dueOffsetMinutes"`
+	Subtasks         json.RawMessage `json:"subtasks"`
+	CreatedAt        time.Time       `json:"createdAt"`
+}
+
+type TimeEntry struct {
 	ID        int64     `json:"id"`
-	Body      string    `json:"body"`
-	IsDone    bool      `json:"isDone"`
 	OwnerID   int64     `json:"ownerId"`
-	CreatedAt time.Time `json:"createdAt"`
+	TaskID    int64     `json:"taskId"`
+	StartedAt time.Time `json:"startedAt"`
+	// null while the timer is running
+	EndedAt   sql.NullTime `json:"endedAt"`
+	Note      string       `json:"note"`
+	CreatedAt time.Time    `json:"createdAt"`
 }
 
 type User struct {
-	ID                int64     `json:"id"`
-	Username          string    `json:"username"`
-	Email             string    `json:"email"`
-	HashedPassword    string    `json:"hashedPassword"`
-	PasswordChangedAt time.Time `json:"passwordChangedAt"`
-	CreatedAt         time.Time `json:"createdAt"`
+	ID                int64        `json:"id"`
+	Username          string       `json:"username"`
+	Email             string       `json:"email"`
+	HashedPassword    string       `json:"hashedPassword"`
+	PasswordChangedAt time.Time    `json:"passwordChangedAt"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	EmailVerifiedAt   sql.NullTime `json:"emailVerifiedAt"`
+	// when the last verification email was sent, resends are throttled by it
+	VerificationSentAt sql.NullTime `json:"verificationSentAt"`
+}
+
+type UserTokenRevocation struct {
+	UserID int64 `json:"userId"`
+	// tokens of the user issued until then are rejected
+	RevokedBefore time.Time `json:"revokedBefore"`
+	// when the last of those tokens expires
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type UserTotp struct {
+	UserID int64  `json:"userId"`
+	Secret []byte `json:"secret"`
+	// null until the user confirmed the secret with a first code
+	EnabledAt sql.NullTime `json:"enabledAt"`
+	// time step of the last accepted code, older steps are rejected so codes cannot be replayed
+	LastStep       sql.NullInt64 `json:"lastStep"`
+	FailedAttempts int32         `json:"failedAttempts"`
+	// codes are not checked until then after too many failed attempts
+	LockedUntil sql.NullTime `json:"lockedUntil"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+type WebauthnCredential struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+	// raw id the authenticator chose for the credential
+	CredentialID []byte `json:"credentialId"`
+	// COSE encoded public key from the attested credential data
+	PublicKey []byte `json:"publicKey"`
+	// last signature counter, a counter that does not grow hints at a cloned authenticator
+	SignCount  int64        `json:"signCount"`
+	Name       string       `json:"name"`
+	LastUsedAt sql.NullTime `json:"lastUsedAt"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+type Webhook struct {
+	ID        int64         `json:"id"`
+	OwnerID   int64         `json:"ownerId"`
+	ProjectID sql.NullInt64 `json:"projectId"`
+	Url       string        `json:"url"`
+	Events    []string      `json:"events"`
+	Secret    string        `json:"secret"`
+	Active    bool          `json:"active"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhookId"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  sql.NullTime    `json:"nextAttemptAt"`
+	DeliveredAt    sql.NullTime    `json:"deliveredAt"`
+	ResponseStatus sql.NullInt32   `json:"responseStatus"`
+	LastError      string          `json:"lastError"`
+	CreatedAt      time.Time       `json:"createdAt"`
 }
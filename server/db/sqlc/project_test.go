@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomProject(t *testing.T, user User) CreateProjectTxResult {
+	store := NewStore(testDB)
+	arg := CreateProjectTxParams{
+		CreateProjectParams: CreateProjectParams{
+			Name:    util.RandomString(8),
+			OwnerID: user.ID,
+		},
+		Statuses: []CreateProjectStatusParams{
+			{Name: "Todo", Category: "todo", Position: 0},
+			{Name: "Doing", Category: "in_progress", Position: 1, WipLimit: 1},
+			{Name: "Done", Category: "done", Position: 2},
+		},
+	}
+	result, err := store.CreateProjectTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Name, result.Project.Name)
+	require.Equal(t, user.ID, result.Project.OwnerID)
+	require.NotZero(t, result.Project.CreatedAt)
+	require.Len(t, result.Statuses, len(arg.Statuses))
+	for i, status := range result.Statuses {
+		require.Equal(t, result.Project.ID, status.ProjectID)
+		require.Equal(t, arg.Statuses[i].Name, status.Name)
+		require.Equal(t, arg.Statuses[i].Category, status.Category)
+		require.Equal(t, arg.Statuses[i].WipLimit, status.WipLimit)
+	}
+	return result
+}
+
+func TestCreateProjectTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	CreateRandomProject(t, user)
+}
+
+func TestGetProjectStatusList(t *testing.T) {
+	user := CreateRandomUser(t)
+	project := CreateRandomProject(t, user)
+
+	statuses, err := testQueries.GetProjectStatusList(context.Background(), project.Project.ID)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(project.Statuses))
+	for i := 1; i < len(statuses); i++ {
+		require.LessOrEqual(t, statuses[i-1].Position, statuses[i].Position)
+	}
+}
+
+func TestReplaceStatusTransitionsTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	project := CreateRandomProject(t, user)
+	store := NewStore(testDB)
+	todo, doing, done := project.Statuses[0], project.Statuses[1], project.Statuses[2]
+
+	transitions, err := store.ReplaceStatusTransitionsTx(context.Background(), ReplaceStatusTransitionsTxParams{
+		ProjectID: project.Project.ID,
+		Transitions: []CreateStatusTransitionParams{
+			{FromStatusID: todo.ID, ToStatusID: doing.ID},
+			{FromStatusID: doing.ID, ToStatusID: done.ID},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+
+	//replacing again drops the old transitions
+	transitions, err = store.ReplaceStatusTransitionsTx(context.Background(), ReplaceStatusTransitionsTxParams{
+		ProjectID: project.Project.ID,
+		Transitions: []CreateStatusTransitionParams{
+			{FromStatusID: todo.ID, ToStatusID: done.ID},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, transitions, 1)
+
+	list, err := testQueries.GetStatusTransitionList(context.Background(), project.Project.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, todo.ID, list[0].FromStatusID)
+	require.Equal(t, done.ID, list[0].ToStatusID)
+}
+
+func TestCountTasksByStatus(t *testing.T) {
+	user := CreateRandomUser(t)
+	project := CreateRandomProject(t, user)
+	statusID := sql.NullInt64{Int64: project.Statuses[0].ID, Valid: true}
+
+	for i := 0; i < 3; i++ {
+		_, err := testQueries.CreateTask(context.Background(), CreateTaskParams{
+			Body:      util.RandomString(10),
+			OwnerID:   user.ID,
+			ProjectID: sql.NullInt64{Int64: project.Project.ID, Valid: true},
+			StatusID:  statusID,
+		})
+		require.NoError(t, err)
+	}
+
+	count, err := testQueries.CountTasksByStatus(context.Background(), statusID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	tasks, err := testQueries.GetTaskListByProject(context.Background(), sql.NullInt64{Int64: project.Project.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+}
+
+func TestCreateTaskTxWipLimit(t *testing.T) {
+	store := NewStore(testDB)
+	user := CreateRandomUser(t)
+	project := CreateRandomProject(t, user)
+	//Doing has a wip limit of 1, concurrent creates wait for the lock on the status
+	doing := sql.NullInt64{Int64: project.Statuses[1].ID, Valid: true}
+
+	n := 5
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.CreateTaskTx(context.Background(), CreateTaskParams{
+				Body:      util.RandomString(10),
+				OwnerID:   user.ID,
+				ProjectID: sql.NullInt64{Int64: project.Project.ID, Valid: true},
+				StatusID:  doing,
+			})
+			errs <- err
+		}()
+	}
+	created := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			created++
+			continue
+		}
+		require.ErrorIs(t, err, ErrWipLimitReached)
+	}
+	require.Equal(t, 1, created)
+
+	count, err := testQueries.CountTasksByStatus(context.Background(), doing)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
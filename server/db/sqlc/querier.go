@@ -6,26 +6,180 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddTaskLabel(ctx context.Context, arg AddTaskLabelParams) error
+	ArchiveDoneTasks(ctx context.Context, arg ArchiveDoneTasksParams) (int64, error)
+	ArchiveTask(ctx context.Context, arg ArchiveTaskParams) (Task, error)
+	BlockSession(ctx context.Context, id uuid.UUID) (int64, error)
+	ClaimDueDigest(ctx context.Context, now sql.NullTime) (ClaimDueDigestRow, error)
+	ClaimDueReminder(ctx context.Context, arg ClaimDueReminderParams) (ClaimDueReminderRow, error)
+	ClaimDueWebhookDelivery(ctx context.Context, now sql.NullTime) (ClaimDueWebhookDeliveryRow, error)
+	ClaimVerificationEmail(ctx context.Context, arg ClaimVerificationEmailParams) (User, error)
+	CountRecoveryCodes(ctx context.Context, userID int64) (int64, error)
+	CountTasksByStatus(ctx context.Context, statusID sql.NullInt64) (int64, error)
+	CountWebauthnCredentials(ctx context.Context, userID int64) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error)
 	CreatePasswordResetSession(ctx context.Context, arg CreatePasswordResetSessionParams) (PasswordResetSession, error)
+	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
+	CreateProjectStatus(ctx context.Context, arg CreateProjectStatusParams) (ProjectStatus, error)
+	CreateRecoveryCodes(ctx context.Context, arg CreateRecoveryCodesParams) error
+	CreateReminder(ctx context.Context, arg CreateReminderParams) (Reminder, error)
+	CreateSavedFilter(ctx context.Context, arg CreateSavedFilterParams) (SavedFilter, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (SigningKey, error)
+	CreateStatusTransition(ctx context.Context, arg CreateStatusTransitionParams) (StatusTransition, error)
 	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
+	CreateTaskAttachment(ctx context.Context, arg CreateTaskAttachmentParams) (CreateTaskAttachmentRow, error)
+	CreateTaskTemplate(ctx context.Context, arg CreateTaskTemplateParams) (TaskTemplate, error)
+	CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (TimeEntry, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebauthnCredential(ctx context.Context, arg CreateWebauthnCredentialParams) (WebauthnCredential, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	DeleteApiKey(ctx context.Context, arg DeleteApiKeyParams) (int64, error)
+	DeleteArchiveRule(ctx context.Context, userID int64) error
+	DeleteCurrentSession(ctx context.Context, arg DeleteCurrentSessionParams) (int64, error)
+	DeleteCustomField(ctx context.Context, id int64) error
+	DeleteExpiredLoginChallenges(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredRevokedTokens(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredSigningKeys(ctx context.Context, notAfter time.Time) (int64, error)
+	DeleteExpiredUserTokenRevocations(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteLabel(ctx context.Context, arg DeleteLabelParams) error
 	DeletePasswordResetSession(ctx context.Context, email string) error
+	DeleteProject(ctx context.Context, arg DeleteProjectParams) error
+	DeleteProjectStatus(ctx context.Context, id int64) error
+	DeleteRecoveryCodes(ctx context.Context, userID int64) error
+	DeleteReminder(ctx context.Context, arg DeleteReminderParams) error
+	DeleteSavedFilter(ctx context.Context, arg DeleteSavedFilterParams) error
 	DeleteSession(ctx context.Context, id uuid.UUID) error
+	DeleteStatusTransitions(ctx context.Context, projectID int64) error
 	DeleteTask(ctx context.Context, arg DeleteTaskParams) error
+	DeleteTaskCustomFieldValue(ctx context.Context, arg DeleteTaskCustomFieldValueParams) error
+	DeleteTaskLabels(ctx context.Context, taskID int64) error
+	DeleteTaskTemplate(ctx context.Context, arg DeleteTaskTemplateParams) error
+	DeleteTimeEntry(ctx context.Context, arg DeleteTimeEntryParams) error
+	DeleteUserSessions(ctx context.Context, userID int64) error
+	DeleteUserTotp(ctx context.Context, userID int64) error
+	DeleteWebauthnCredential(ctx context.Context, arg DeleteWebauthnCredentialParams) (int64, error)
+	DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) error
+	EnableUserTotp(ctx context.Context, arg EnableUserTotpParams) (UserTotp, error)
+	GetApiKeyByHash(ctx context.Context, keyHash []byte) (ApiKey, error)
+	GetArchiveRule(ctx context.Context, userID int64) (ArchiveRule, error)
+	GetArchivedTaskList(ctx context.Context, arg GetArchivedTaskListParams) ([]Task, error)
+	GetCompletionStreaks(ctx context.Context, arg GetCompletionStreaksParams) (GetCompletionStreaksRow, error)
+	GetCustomField(ctx context.Context, id int64) (CustomField, error)
+	GetCustomFieldList(ctx context.Context, projectID int64) ([]CustomField, error)
+	GetCustomFieldListByOwner(ctx context.Context, ownerID int64) ([]CustomField, error)
+	GetCustomFieldValuesByTasks(ctx context.Context, taskIds []int64) ([]TaskCustomFieldValue, error)
+	GetDigestPreference(ctx context.Context, userID int64) (DigestPreference, error)
+	GetInbox(ctx context.Context, userID int64) (Inbox, error)
+	GetInboxByToken(ctx context.Context, token string) (Inbox, error)
+	GetLabelList(ctx context.Context, ownerID int64) ([]Label, error)
+	GetLabelsByIds(ctx context.Context, ids []int64) ([]Label, error)
+	GetLabelsByTasks(ctx context.Context, taskIds []int64) ([]GetLabelsByTasksRow, error)
+	GetOverdueTasks(ctx context.Context, arg GetOverdueTasksParams) ([]Task, error)
 	GetPasswordResetSession(ctx context.Context, email string) (PasswordResetSession, error)
+	GetProject(ctx context.Context, id int64) (Project, error)
+	GetProjectList(ctx context.Context, ownerID int64) ([]Project, error)
+	GetProjectStatus(ctx context.Context, id int64) (ProjectStatus, error)
+	GetProjectStatusList(ctx context.Context, projectID int64) ([]ProjectStatus, error)
+	GetProjectsByIds(ctx context.Context, ids []int64) ([]Project, error)
+	GetReminder(ctx context.Context, id int64) (Reminder, error)
+	GetReminderListByTask(ctx context.Context, taskID int64) ([]Reminder, error)
+	GetRunningTimeEntry(ctx context.Context, ownerID int64) (TimeEntry, error)
+	GetSavedFilter(ctx context.Context, id int64) (SavedFilter, error)
+	GetSavedFilterList(ctx context.Context, ownerID int64) ([]SavedFilter, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStatusTransitionList(ctx context.Context, projectID int64) ([]StatusTransition, error)
+	GetSubtasks(ctx context.Context, parentID sql.NullInt64) ([]Task, error)
+	GetSyncChange(ctx context.Context, arg GetSyncChangeParams) (GetSyncChangeRow, error)
+	GetSyncChanges(ctx context.Context, arg GetSyncChangesParams) ([]GetSyncChangesRow, error)
+	GetSyncSnapshotXmin(ctx context.Context) (string, error)
 	GetTask(ctx context.Context, id int64) (Task, error)
+	GetTaskAttachment(ctx context.Context, id int64) (TaskAttachment, error)
+	GetTaskAttachmentList(ctx context.Context, taskID int64) ([]GetTaskAttachmentListRow, error)
+	GetTaskCountsByPeriod(ctx context.Context, arg GetTaskCountsByPeriodParams) ([]GetTaskCountsByPeriodRow, error)
+	GetTaskCustomFieldValues(ctx context.Context, taskID int64) ([]TaskCustomFieldValue, error)
 	GetTaskList(ctx context.Context, arg GetTaskListParams) ([]Task, error)
+	GetTaskListByProject(ctx context.Context, projectID sql.NullInt64) ([]Task, error)
+	GetTaskStatsByLabel(ctx context.Context, arg GetTaskStatsByLabelParams) ([]GetTaskStatsByLabelRow, error)
+	GetTaskStatsByProject(ctx context.Context, arg GetTaskStatsByProjectParams) ([]GetTaskStatsByProjectRow, error)
+	GetTaskStatsSummary(ctx context.Context, arg GetTaskStatsSummaryParams) (GetTaskStatsSummaryRow, error)
+	GetTaskTemplate(ctx context.Context, id int64) (TaskTemplate, error)
+	GetTaskTemplateList(ctx context.Context, ownerID int64) ([]TaskTemplate, error)
+	GetTasksByIds(ctx context.Context, ids []int64) ([]Task, error)
+	GetTasksCompletedBetween(ctx context.Context, arg GetTasksCompletedBetweenParams) ([]Task, error)
+	GetTasksDueBetween(ctx context.Context, arg GetTasksDueBetweenParams) ([]Task, error)
+	GetTimeEntriesBetween(ctx context.Context, arg GetTimeEntriesBetweenParams) ([]GetTimeEntriesBetweenRow, error)
+	GetTimeEntry(ctx context.Context, id int64) (TimeEntry, error)
+	GetTimeEntryListByTask(ctx context.Context, taskID int64) ([]TimeEntry, error)
 	GetUser(ctx context.Context, arg GetUserParams) (User, error)
+	GetUserTotp(ctx context.Context, userID int64) (UserTotp, error)
+	GetWebauthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (WebauthnCredential, error)
+	GetWebhook(ctx context.Context, id int64) (Webhook, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookDeliveryList(ctx context.Context, arg GetWebhookDeliveryListParams) ([]WebhookDelivery, error)
+	GetWebhookList(ctx context.Context, ownerID int64) ([]Webhook, error)
+	GetWebhooksForEvent(ctx context.Context, arg GetWebhooksForEventParams) ([]Webhook, error)
+	IsTokenRevoked(ctx context.Context, arg IsTokenRevokedParams) (bool, error)
+	ListApiKeys(ctx context.Context, userID int64) ([]ApiKey, error)
+	ListSessionFamily(ctx context.Context, arg ListSessionFamilyParams) ([]Session, error)
+	ListSigningKeys(ctx context.Context, notAfter time.Time) ([]SigningKey, error)
+	ListUserSessions(ctx context.Context, userID int64) ([]ListUserSessionsRow, error)
+	ListWebauthnCredentials(ctx context.Context, userID int64) ([]WebauthnCredential, error)
+	// taken by transactions that change the workflow of a project
+	LockProject(ctx context.Context, id int64) (Project, error)
+	// taken by transactions that move tasks along the workflow of a project
+	LockProjectForShare(ctx context.Context, id int64) (Project, error)
+	LockProjectStatus(ctx context.Context, id int64) (ProjectStatus, error)
+	MarkReminderSent(ctx context.Context, arg MarkReminderSentParams) error
+	RecordReminderFailure(ctx context.Context, arg RecordReminderFailureParams) error
+	RecordTotpFailure(ctx context.Context, arg RecordTotpFailureParams) (UserTotp, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error
+	ReplaceSession(ctx context.Context, arg ReplaceSessionParams) (int64, error)
+	ResetRelativeReminders(ctx context.Context, arg ResetRelativeRemindersParams) error
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error
+	SetDigestNextSendAt(ctx context.Context, arg SetDigestNextSendAtParams) error
+	SetLoginChallenge(ctx context.Context, arg SetLoginChallengeParams) error
+	SetTaskDeferredUntil(ctx context.Context, arg SetTaskDeferredUntilParams) (Task, error)
+	SnoozeReminder(ctx context.Context, arg SnoozeReminderParams) (Reminder, error)
+	StopTimeEntry(ctx context.Context, arg StopTimeEntryParams) (TimeEntry, error)
+	// the tasks of a status are done exactly when the status is in the done category
+	SyncTasksWithStatus(ctx context.Context, id int64) (int64, error)
+	TakeLoginChallenge(ctx context.Context, key string) ([]byte, error)
+	TouchApiKey(ctx context.Context, arg TouchApiKeyParams) error
+	UnarchiveTask(ctx context.Context, arg UnarchiveTaskParams) (Task, error)
+	UnsubscribeDigest(ctx context.Context, userID int64) error
+	UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) (CustomField, error)
 	UpdatePasswordResetSession(ctx context.Context, arg UpdatePasswordResetSessionParams) (PasswordResetSession, error)
+	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
+	UpdateProjectStatus(ctx context.Context, arg UpdateProjectStatusParams) (ProjectStatus, error)
+	UpdateSavedFilter(ctx context.Context, arg UpdateSavedFilterParams) (SavedFilter, error)
+	// priority is kept when it is null, due_at and estimate_minutes unless they are set (to clear them)
 	UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error)
+	UpdateTaskTemplate(ctx context.Context, arg UpdateTaskTemplateParams) (TaskTemplate, error)
+	UpdateTimeEntry(ctx context.Context, arg UpdateTimeEntryParams) (TimeEntry, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWebauthnSignCount(ctx context.Context, arg UpdateWebauthnSignCountParams) (int64, error)
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
+	UpsertArchiveRule(ctx context.Context, arg UpsertArchiveRuleParams) (ArchiveRule, error)
+	UpsertDigestPreference(ctx context.Context, arg UpsertDigestPreferenceParams) (DigestPreference, error)
+	UpsertInbox(ctx context.Context, arg UpsertInboxParams) (Inbox, error)
+	UpsertLabel(ctx context.Context, arg UpsertLabelParams) (Label, error)
+	UpsertPendingTotp(ctx context.Context, arg UpsertPendingTotpParams) (UserTotp, error)
+	UpsertTaskCustomFieldValue(ctx context.Context, arg UpsertTaskCustomFieldValueParams) (TaskCustomFieldValue, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseTotpStep(ctx context.Context, arg UseTotpStepParams) (int64, error)
+	VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomReminder(t *testing.T, task Task, fireAt time.Time) Reminder {
+	arg := CreateReminderParams{
+		TaskID:   task.ID,
+		OwnerID:  task.OwnerID,
+		RemindAt: sql.NullTime{Time: fireAt, Valid: true},
+		FireAt:   sql.NullTime{Time: fireAt, Valid: true},
+	}
+	reminder, err := testQueries.CreateReminder(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.TaskID, reminder.TaskID)
+	require.False(t, reminder.SentAt.Valid)
+	return reminder
+}
+
+func TestDeliverReminderTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	//far in the past so that only this reminder is due at now
+	fireAt := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := fireAt.Add(time.Minute)
+	reminder := CreateRandomReminder(t, task, fireAt)
+
+	//several servers look for due reminders at the same time
+	n := 5
+	var mu sync.Mutex
+	delivered := 0
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := NewStore(testDB)
+			_, err := store.DeliverReminderTx(context.Background(), DeliverReminderTxParams{
+				Now:         now,
+				MaxAttempts: 5,
+				Deliver: func(r ClaimDueReminderRow) error {
+					if r.ID == reminder.ID {
+						mu.Lock()
+						delivered++
+						mu.Unlock()
+					}
+					time.Sleep(50 * time.Millisecond)
+					return nil
+				},
+				RetryAt: func(attempts int32) time.Time { return now },
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, delivered)
+
+	sent, err := testQueries.GetReminder(context.Background(), reminder.ID)
+	require.NoError(t, err)
+	require.True(t, sent.SentAt.Valid)
+}
+
+func TestDeliverReminderTxFailure(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	fireAt := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := fireAt.Add(time.Minute)
+	reminder := CreateRandomReminder(t, task, fireAt)
+	retryAt := now.Add(time.Hour)
+
+	store := NewStore(testDB)
+	result, err := store.DeliverReminderTx(context.Background(), DeliverReminderTxParams{
+		Now:         now,
+		MaxAttempts: 5,
+		Deliver: func(r ClaimDueReminderRow) error {
+			return fmt.Errorf("mail server down")
+		},
+		RetryAt: func(attempts int32) time.Time { return retryAt },
+	})
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	require.Error(t, result.DeliveryErr)
+
+	failed, err := testQueries.GetReminder(context.Background(), reminder.ID)
+	require.NoError(t, err)
+	require.False(t, failed.SentAt.Valid)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, "mail server down", failed.LastError)
+	require.WithinDuration(t, retryAt, failed.FireAt.Time, time.Second)
+
+	//snoozing re-arms the reminder
+	snoozed, err := testQueries.SnoozeReminder(context.Background(), SnoozeReminderParams{
+		ID:     reminder.ID,
+		FireAt: sql.NullTime{Time: now, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Zero(t, snoozed.Attempts)
+	require.Empty(t, snoozed.LastError)
+}
+
+func TestResetRelativeReminders(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	reminder, err := testQueries.CreateReminder(context.Background(), CreateReminderParams{
+		TaskID:        task.ID,
+		OwnerID:       user.ID,
+		OffsetMinutes: sql.NullInt32{Int32: 30, Valid: true},
+	})
+	require.NoError(t, err)
+	require.False(t, reminder.FireAt.Valid)
+
+	dueAt := time.Date(2030, 5, 1, 12, 0, 0, 0, time.UTC)
+	err = testQueries.ResetRelativeReminders(context.Background(), ResetRelativeRemindersParams{
+		DueAt:  sql.NullTime{Time: dueAt, Valid: true},
+		TaskID: task.ID,
+	})
+	require.NoError(t, err)
+	moved, err := testQueries.GetReminder(context.Background(), reminder.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, dueAt.Add(-30*time.Minute), moved.FireAt.Time, time.Second)
+}
@@ -7,44 +7,68 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const blockSession = `-- name: BlockSession :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1
+`
+
+func (q *Queries) BlockSession(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.blockSessionStmt, blockSession, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
     id,
+    family_id,
     user_id,
     refresh_token,
     user_agent,
     client_ip,
     is_blocked,
-    expires_at
+    expires_at,
+    access_token_id,
+    access_token_expires_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, replaced_by, access_token_id, access_token_expires_at
 `
 
 type CreateSessionParams struct {
-	ID           uuid.UUID `json:"id"`
-	UserID       int64     `json:"userId"`
-	RefreshToken string    `json:"refreshToken"`
-	UserAgent    string    `json:"userAgent"`
-	ClientIp     string    `json:"clientIp"`
-	IsBlocked    bool      `json:"isBlocked"`
-	ExpiresAt    time.Time `json:"expiresAt"`
+	ID                   uuid.UUID     `json:"id"`
+	FamilyID             uuid.UUID     `json:"familyId"`
+	UserID               int64         `json:"userId"`
+	RefreshToken         string        `json:"refreshToken"`
+	UserAgent            string        `json:"userAgent"`
+	ClientIp             string        `json:"clientIp"`
+	IsBlocked            bool          `json:"isBlocked"`
+	ExpiresAt            time.Time     `json:"expiresAt"`
+	AccessTokenID        uuid.NullUUID `json:"accessTokenId"`
+	AccessTokenExpiresAt sql.NullTime  `json:"accessTokenExpiresAt"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
 	row := q.queryRow(ctx, q.createSessionStmt, createSession,
 		arg.ID,
+		arg.FamilyID,
 		arg.UserID,
 		arg.RefreshToken,
 		arg.UserAgent,
 		arg.ClientIp,
 		arg.IsBlocked,
 		arg.ExpiresAt,
+		arg.AccessTokenID,
+		arg.AccessTokenExpiresAt,
 	)
 	var i Session
 	err := row.Scan(
@@ -56,10 +80,48 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.ReplacedBy,
+		&i.AccessTokenID,
+		&i.AccessTokenExpiresAt,
 	)
 	return i, err
 }
 
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < $1
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) (int64, error) {
+	result, err := q.exec(ctx, q.deleteExpiredSessionsStmt, deleteExpiredSessions, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCurrentSession = `-- name: DeleteCurrentSession :execrows
+DELETE FROM sessions
+WHERE
+    family_id = $1 AND
+    user_id = $2 AND
+    replaced_by IS NULL
+`
+
+type DeleteCurrentSessionParams struct {
+	FamilyID uuid.UUID `json:"familyId"`
+	UserID   int64     `json:"userId"`
+}
+
+func (q *Queries) DeleteCurrentSession(ctx context.Context, arg DeleteCurrentSessionParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCurrentSessionStmt, deleteCurrentSession, arg.FamilyID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE id = $1
@@ -70,8 +132,18 @@ func (q *Queries) DeleteSession(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteUserSessions = `-- name: DeleteUserSessions :exec
+DELETE FROM sessions
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID int64) error {
+	_, err := q.exec(ctx, q.deleteUserSessionsStmt, deleteUserSessions, userID)
+	return err
+}
+
 const getSession = `-- name: GetSession :one
-SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at FROM sessions
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, replaced_by, access_token_id, access_token_expires_at FROM sessions
 WHERE
     id = $1
 LIMIT 1
@@ -89,6 +161,144 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.ReplacedBy,
+		&i.AccessTokenID,
+		&i.AccessTokenExpiresAt,
 	)
 	return i, err
 }
+
+const listSessionFamily = `-- name: ListSessionFamily :many
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at, family_id, replaced_by, access_token_id, access_token_expires_at FROM sessions
+WHERE family_id = $1 AND user_id = $2
+ORDER BY created_at
+`
+
+type ListSessionFamilyParams struct {
+	FamilyID uuid.UUID `json:"familyId"`
+	UserID   int64     `json:"userId"`
+}
+
+func (q *Queries) ListSessionFamily(ctx context.Context, arg ListSessionFamilyParams) ([]Session, error) {
+	rows, err := q.query(ctx, q.listSessionFamilyStmt, listSessionFamily, arg.FamilyID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.FamilyID,
+			&i.ReplacedBy,
+			&i.AccessTokenID,
+			&i.AccessTokenExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserSessions = `-- name: ListUserSessions :many
+SELECT
+    s.family_id,
+    s.id,
+    s.access_token_id,
+    s.access_token_expires_at,
+    f.user_agent,
+    s.client_ip,
+    f.created_at,
+    s.created_at AS last_used_at,
+    s.expires_at
+FROM sessions s
+JOIN sessions f ON f.id = s.family_id
+WHERE
+    s.user_id = $1 AND
+    s.replaced_by IS NULL AND
+    NOT s.is_blocked AND
+    s.expires_at > now()
+ORDER BY s.created_at DESC
+`
+
+type ListUserSessionsRow struct {
+	FamilyID             uuid.UUID     `json:"familyId"`
+	ID                   uuid.UUID     `json:"id"`
+	AccessTokenID        uuid.NullUUID `json:"accessTokenId"`
+	AccessTokenExpiresAt sql.NullTime  `json:"accessTokenExpiresAt"`
+	UserAgent            string        `json:"userAgent"`
+	ClientIp             string        `json:"clientIp"`
+	CreatedAt            time.Time     `json:"createdAt"`
+	LastUsedAt           time.Time     `json:"lastUsedAt"`
+	ExpiresAt            time.Time     `json:"expiresAt"`
+}
+
+func (q *Queries) ListUserSessions(ctx context.Context, userID int64) ([]ListUserSessionsRow, error) {
+	rows, err := q.query(ctx, q.listUserSessionsStmt, listUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserSessionsRow{}
+	for rows.Next() {
+		var i ListUserSessionsRow
+		if err := rows.Scan(
+			&i.FamilyID,
+			&i.ID,
+			&i.AccessTokenID,
+			&i.AccessTokenExpiresAt,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replaceSession = `-- name: ReplaceSession :execrows
+UPDATE sessions
+SET replaced_by = $1::uuid
+WHERE
+    id = $2 AND
+    replaced_by IS NULL
+`
+
+type ReplaceSessionParams struct {
+	ReplacedBy uuid.UUID `json:"replacedBy"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func (q *Queries) ReplaceSession(ctx context.Context, arg ReplaceSessionParams) (int64, error) {
+	result, err := q.exec(ctx, q.replaceSessionStmt, replaceSession, arg.ReplacedBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
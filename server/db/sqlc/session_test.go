@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, userId int64, familyId uuid.UUID) Session {
+	id := uuid.New()
+	if familyId == uuid.Nil {
+		familyId = id
+	}
+	session, err := testQueries.CreateSession(context.Background(), CreateSessionParams{
+		ID:           id,
+		FamilyID:     familyId,
+		UserID:       userId,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "curl/8.4.0",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, familyId, session.FamilyID)
+	require.False(t, session.ReplacedBy.Valid)
+	return session
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := CreateRandomUser(t)
+	first := createRandomSession(t, user.ID, uuid.Nil)
+
+	next := CreateSessionParams{
+		ID:           uuid.New(),
+		FamilyID:     first.FamilyID,
+		UserID:       user.ID,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    first.UserAgent,
+		ClientIp:     "10.0.0.1",
+		ExpiresAt:    first.ExpiresAt,
+	}
+	second, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{ID: first.ID, Next: next})
+	require.NoError(t, err)
+	require.Equal(t, first.FamilyID, second.FamilyID)
+
+	//a session rotates once
+	next.ID = uuid.New()
+	next.RefreshToken = util.RandomString(32)
+	_, err = store.RotateSessionTx(context.Background(), RotateSessionTxParams{ID: first.ID, Next: next})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	sessions, err := testQueries.ListUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, second.ID, sessions[0].ID)
+	require.Equal(t, "10.0.0.1", sessions[0].ClientIp)
+	require.WithinDuration(t, first.CreatedAt, sessions[0].CreatedAt, time.Millisecond)
+
+	family, err := testQueries.ListSessionFamily(context.Background(), ListSessionFamilyParams{FamilyID: first.FamilyID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Len(t, family, 2)
+	require.Equal(t, first.ID, family[0].ID)
+
+	//logging out keeps the rotated session
+	deleted, err := testQueries.DeleteCurrentSession(context.Background(), DeleteCurrentSessionParams{FamilyID: first.FamilyID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	_, err = testQueries.GetSession(context.Background(), second.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = testQueries.GetSession(context.Background(), first.ID)
+	require.NoError(t, err)
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	user := CreateRandomUser(t)
+	other := CreateRandomUser(t)
+	createRandomSession(t, user.ID, uuid.Nil)
+	createRandomSession(t, user.ID, uuid.Nil)
+	createRandomSession(t, other.ID, uuid.Nil)
+
+	err := testQueries.DeleteUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	sessions, err := testQueries.ListUserSessions(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+	sessions, err = testQueries.ListUserSessions(context.Background(), other.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
+
+func TestRevokeTokens(t *testing.T) {
+	user := CreateRandomUser(t)
+	tokenId := uuid.New()
+	issuedAt := time.Now()
+
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{ID: tokenId, UserID: user.ID, IssuedAt: issuedAt})
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	err = testQueries.RevokeToken(context.Background(), RevokeTokenParams{ID: tokenId, ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	revoked, err = testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{ID: tokenId, UserID: user.ID, IssuedAt: issuedAt})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	err = testQueries.RevokeUserTokens(context.Background(), RevokeUserTokensParams{UserID: user.ID, RevokedBefore: issuedAt, ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	revoked, err = testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{ID: uuid.New(), UserID: user.ID, IssuedAt: issuedAt.Add(-time.Second)})
+	require.NoError(t, err)
+	require.True(t, revoked)
+	//issued after logging out everywhere
+	revoked, err = testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{ID: uuid.New(), UserID: user.ID, IssuedAt: issuedAt.Add(time.Second)})
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
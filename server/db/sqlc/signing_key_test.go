@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningKeys(t *testing.T) {
+	ctx := context.Background()
+	//far from the keys of other tests and runs
+	notBefore := time.Now().AddDate(100+int(util.RandomInt(0, 1000)), 0, 0).Truncate(time.Hour)
+	arg := CreateSigningKeyParams{
+		ID:         util.RandomString(43),
+		PrivateKey: []byte(util.RandomString(32)),
+		NotBefore:  notBefore,
+		NotAfter:   notBefore.Add(time.Hour),
+	}
+	key, err := testQueries.CreateSigningKey(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, key.ID)
+	require.Equal(t, arg.PrivateKey, key.PrivateKey)
+	require.WithinDuration(t, arg.NotBefore, key.NotBefore, time.Second)
+
+	//another server created the key of the period first
+	arg.ID = util.RandomString(43)
+	_, err = testQueries.CreateSigningKey(ctx, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	keys, err := testQueries.ListSigningKeys(ctx, notBefore)
+	require.NoError(t, err)
+	require.Contains(t, signingKeyIDs(keys), key.ID)
+
+	deleted, err := testQueries.DeleteExpiredSigningKeys(ctx, key.NotAfter.Add(time.Second))
+	require.NoError(t, err)
+	require.Positive(t, deleted)
+	keys, err = testQueries.ListSigningKeys(ctx, notBefore)
+	require.NoError(t, err)
+	require.NotContains(t, signingKeyIDs(keys), key.ID)
+}
+
+func signingKeyIDs(keys []SigningKey) []string {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	return ids
+}
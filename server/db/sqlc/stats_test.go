@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStats(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	CreateRandomTask(t, user)
+	_, err := testQueries.UpdateTask(context.Background(), UpdateTaskParams{
+		ID:      task.ID,
+		OwnerID: user.ID,
+		Body:    task.Body,
+		IsDone:  true,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	from := now.Add(-24 * time.Hour)
+	to := now.Add(time.Hour)
+	summary, err := testQueries.GetTaskStatsSummary(context.Background(), GetTaskStatsSummaryParams{
+		FromTime: from,
+		ToTime:   to,
+		OwnerID:  user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), summary.Created)
+	require.Equal(t, int64(1), summary.Completed)
+
+	periods, err := testQueries.GetTaskCountsByPeriod(context.Background(), GetTaskCountsByPeriodParams{
+		Period:   "day",
+		Timezone: "UTC",
+		OwnerID:  user.ID,
+		FromTime: from,
+		ToTime:   to,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, periods)
+
+	y, m, d := now.UTC().Date()
+	streaks, err := testQueries.GetCompletionStreaks(context.Background(), GetCompletionStreaksParams{
+		Timezone: "UTC",
+		OwnerID:  user.ID,
+		Today:    time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), streaks.CurrentStreak)
+	require.Equal(t, int64(1), streaks.LongestStreak)
+
+	projects, err := testQueries.GetTaskStatsByProject(context.Background(), GetTaskStatsByProjectParams{
+		FromTime: from,
+		ToTime:   to,
+		OwnerID:  user.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	require.False(t, projects[0].ProjectID.Valid)
+}
@@ -1,9 +1,31 @@
 package db
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
 
 type Store interface {
 	Querier
+	CreateTaskTx(ctx context.Context, arg CreateTaskParams) (Task, error)
+	UpdateTaskTx(ctx context.Context, arg UpdateTaskTxParams) (Task, error)
+	CreateProjectTx(ctx context.Context, arg CreateProjectTxParams) (CreateProjectTxResult, error)
+	UpdateProjectStatusTx(ctx context.Context, arg UpdateProjectStatusParams) (ProjectStatus, error)
+	ReplaceStatusTransitionsTx(ctx context.Context, arg ReplaceStatusTransitionsTxParams) ([]StatusTransition, error)
+	SetCustomFieldValuesTx(ctx context.Context, arg SetCustomFieldValuesTxParams) ([]TaskCustomFieldValue, error)
+	SetTaskLabelsTx(ctx context.Context, arg SetTaskLabelsTxParams) ([]Label, error)
+	DeliverReminderTx(ctx context.Context, arg DeliverReminderTxParams) (DeliverReminderTxResult, error)
+	DeliverDigestTx(ctx context.Context, arg DeliverDigestTxParams) (DeliverDigestTxResult, error)
+	GetSyncChangesTx(ctx context.Context, arg GetSyncChangesTxParams) (GetSyncChangesTxResult, error)
+	DeliverWebhookTx(ctx context.Context, arg DeliverWebhookTxParams) (DeliverWebhookTxResult, error)
+	StartTimerTx(ctx context.Context, arg StartTimerTxParams) (StartTimerTxResult, error)
+	InstantiateTemplateTx(ctx context.Context, arg InstantiateTemplateTxParams) (InstantiateTemplateTxResult, error)
+	EnableTotpTx(ctx context.Context, arg EnableTotpTxParams) (UserTotp, error)
+	ReplaceRecoveryCodesTx(ctx context.Context, arg ReplaceRecoveryCodesTxParams) error
+	DisableTotpTx(ctx context.Context, userId int64) error
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
+	SearchTasks(ctx context.Context, arg SearchTasksParams) ([]Task, error)
 }
 
 type SQLStore struct {
@@ -18,3 +40,31 @@ func NewStore(db *sql.DB) Store {
 		Queries: New(db),
 	}
 }
+
+// execTx executes a function within a database transaction
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	return store.execTxWithOptions(ctx, nil, fn)
+}
+
+// execSnapshotTx executes a read only function that sees a single snapshot of the database
+func (store *SQLStore) execSnapshotTx(ctx context.Context, fn func(*Queries) error) error {
+	return store.execTxWithOptions(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, fn)
+}
+
+func (store *SQLStore) execTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx)
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
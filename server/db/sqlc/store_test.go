@@ -0,0 +1,16 @@
+package db_test
+
+import (
+	"testing"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/db/storetest"
+)
+
+func TestStoreSearchTasks(t *testing.T) {
+	storetest.SearchTasks(t, db.NewTestStore())
+}
+
+func TestStoreWorkflow(t *testing.T) {
+	storetest.Workflow(t, db.NewTestStore())
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncChanges(t *testing.T) {
+	user := CreateRandomUser(t)
+	store := NewStore(testDB)
+
+	xmin, err := testQueries.GetSyncSnapshotXmin(context.Background())
+	require.NoError(t, err)
+
+	task := CreateRandomTask(t, user)
+	created, err := testQueries.GetSyncChange(context.Background(), GetSyncChangeParams{
+		Entity:   "task",
+		EntityID: task.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, created.OwnerID)
+	require.False(t, created.Deleted)
+
+	_, err = testQueries.UpdateTask(context.Background(), UpdateTaskParams{
+		ID:      task.ID,
+		OwnerID: user.ID,
+		Body:    "edited",
+	})
+	require.NoError(t, err)
+	updated, err := testQueries.GetSyncChange(context.Background(), GetSyncChangeParams{
+		Entity:   "task",
+		EntityID: task.ID,
+	})
+	require.NoError(t, err)
+	require.Greater(t, updated.Seq, created.Seq)
+
+	err = testQueries.DeleteTask(context.Background(), DeleteTaskParams{
+		ID:      task.ID,
+		OwnerID: user.ID,
+	})
+	require.NoError(t, err)
+
+	//the deleted task is kept as a tombstone
+	result, err := store.GetSyncChangesTx(context.Background(), GetSyncChangesTxParams{
+		OwnerID: user.ID,
+		Since:   xmin,
+		Limit:   100,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Xmin)
+	var tombstone *GetSyncChangesRow
+	for i := range result.Changes {
+		if result.Changes[i].Entity == "task" && result.Changes[i].EntityID == task.ID {
+			tombstone = &result.Changes[i]
+		}
+	}
+	require.NotNil(t, tombstone)
+	require.True(t, tombstone.Deleted)
+	require.Greater(t, tombstone.Seq, updated.Seq)
+	for _, changed := range result.Tasks {
+		require.NotEqual(t, task.ID, changed.ID)
+	}
+}
@@ -7,24 +7,64 @@ package db
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
+const countTasksByStatus = `-- name: CountTasksByStatus :one
+SELECT count(*) FROM tasks
+WHERE status_id = $1
+`
+
+func (q *Queries) CountTasksByStatus(ctx context.Context, statusID sql.NullInt64) (int64, error) {
+	row := q.queryRow(ctx, q.countTasksByStatusStmt, countTasksByStatus, statusID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTask = `-- name: CreateTask :one
 INSERT INTO tasks (
     body,
-    owner_id
+    owner_id,
+    project_id,
+    status_id,
+    is_done,
+    due_at,
+    priority,
+    estimate_minutes,
+    parent_id,
+    completed_at
 ) VALUES (
-    $1, $2
-) RETURNING id, body, is_done, owner_id, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $5::boolean THEN now() END
+) RETURNING id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until
 `
 
 type CreateTaskParams struct {
-	Body    string `json:"body"`
-	OwnerID int64  `json:"ownerId"`
+	Body            string        `json:"body"`
+	OwnerID         int64         `json:"ownerId"`
+	ProjectID       sql.NullInt64 `json:"projectId"`
+	StatusID        sql.NullInt64 `json:"statusId"`
+	IsDone          bool          `json:"isDone"`
+	DueAt           sql.NullTime  `json:"dueAt"`
+	Priority        int16         `json:"priority"`
+	EstimateMinutes sql.NullInt32 `json:"estimateMinutes"`
+	ParentID        sql.NullInt64 `json:"parentId"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
-	row := q.queryRow(ctx, q.createTaskStmt, createTask, arg.Body, arg.OwnerID)
+	row := q.queryRow(ctx, q.createTaskStmt, createTask,
+		arg.Body,
+		arg.OwnerID,
+		arg.ProjectID,
+		arg.StatusID,
+		arg.IsDone,
+		arg.DueAt,
+		arg.Priority,
+		arg.EstimateMinutes,
+		arg.ParentID,
+	)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -32,6 +72,15 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.IsDone,
 		&i.OwnerID,
 		&i.CreatedAt,
+		&i.ProjectID,
+		&i.StatusID,
+		&i.DueAt,
+		&i.Priority,
+		&i.CompletedAt,
+		&i.EstimateMinutes,
+		&i.ParentID,
+		&i.ArchivedAt,
+		&i.DeferredUntil,
 	)
 	return i, err
 }
@@ -51,8 +100,105 @@ func (q *Queries) DeleteTask(ctx context.Context, arg DeleteTaskParams) error {
 	return err
 }
 
+const getOverdueTasks = `-- name: GetOverdueTasks :many
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE
+    owner_id = $1 AND
+    NOT is_done AND
+    due_at < $2
+ORDER BY due_at, id
+LIMIT 100
+`
+
+type GetOverdueTasksParams struct {
+	OwnerID int64        `json:"ownerId"`
+	Before  sql.NullTime `json:"before"`
+}
+
+func (q *Queries) GetOverdueTasks(ctx context.Context, arg GetOverdueTasksParams) ([]Task, error) {
+	rows, err := q.query(ctx, q.getOverdueTasksStmt, getOverdueTasks, arg.OwnerID, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSubtasks = `-- name: GetSubtasks :many
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE parent_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetSubtasks(ctx context.Context, parentID sql.NullInt64) ([]Task, error) {
+	rows, err := q.query(ctx, q.getSubtasksStmt, getSubtasks, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTask = `-- name: GetTask :one
-SELECT id, body, is_done, owner_id, created_at FROM tasks
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
 WHERE id = $1 LIMIT 1
 `
 
@@ -65,14 +211,25 @@ func (q *Queries) GetTask(ctx context.Context, id int64) (Task, error) {
 		&i.IsDone,
 		&i.OwnerID,
 		&i.CreatedAt,
+		&i.ProjectID,
+		&i.StatusID,
+		&i.DueAt,
+		&i.Priority,
+		&i.CompletedAt,
+		&i.EstimateMinutes,
+		&i.ParentID,
+		&i.ArchivedAt,
+		&i.DeferredUntil,
 	)
 	return i, err
 }
 
 const getTaskList = `-- name: GetTaskList :many
-SELECT id, body, is_done, owner_id, created_at FROM tasks
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
 WHERE
-    owner_id = $1
+    owner_id = $1 AND
+    archived_at IS NULL AND
+    (deferred_until IS NULL OR deferred_until <= now())
 ORDER BY id
 LIMIT $2
 OFFSET $3
@@ -99,6 +256,15 @@ func (q *Queries) GetTaskList(ctx context.Context, arg GetTaskListParams) ([]Tas
 			&i.IsDone,
 			&i.OwnerID,
 			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -113,28 +279,283 @@ func (q *Queries) GetTaskList(ctx context.Context, arg GetTaskListParams) ([]Tas
 	return items, nil
 }
 
+const getTaskListByProject = `-- name: GetTaskListByProject :many
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE
+    project_id = $1 AND
+    archived_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) GetTaskListByProject(ctx context.Context, projectID sql.NullInt64) ([]Task, error) {
+	rows, err := q.query(ctx, q.getTaskListByProjectStmt, getTaskListByProject, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksByIds = `-- name: GetTasksByIds :many
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE
+    id = ANY($1::bigint[])
+ORDER BY id
+`
+
+func (q *Queries) GetTasksByIds(ctx context.Context, ids []int64) ([]Task, error) {
+	rows, err := q.query(ctx, q.getTasksByIdsStmt, getTasksByIds, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksCompletedBetween = `-- name: GetTasksCompletedBetween :many
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE
+    owner_id = $1 AND
+    is_done AND
+    completed_at >= $2 AND
+    completed_at < $3
+ORDER BY completed_at, id
+LIMIT 100
+`
+
+type GetTasksCompletedBetweenParams struct {
+	OwnerID  int64        `json:"ownerId"`
+	FromTime sql.NullTime `json:"fromTime"`
+	ToTime   sql.NullTime `json:"toTime"`
+}
+
+func (q *Queries) GetTasksCompletedBetween(ctx context.Context, arg GetTasksCompletedBetweenParams) ([]Task, error) {
+	rows, err := q.query(ctx, q.getTasksCompletedBetweenStmt, getTasksCompletedBetween, arg.OwnerID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksDueBetween = `-- name: GetTasksDueBetween :many
+SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE
+    owner_id = $1 AND
+    NOT is_done AND
+    due_at >= $2 AND
+    due_at < $3
+ORDER BY due_at, id
+LIMIT 100
+`
+
+type GetTasksDueBetweenParams struct {
+	OwnerID  int64        `json:"ownerId"`
+	FromTime sql.NullTime `json:"fromTime"`
+	ToTime   sql.NullTime `json:"toTime"`
+}
+
+func (q *Queries) GetTasksDueBetween(ctx context.Context, arg GetTasksDueBetweenParams) ([]Task, error) {
+	rows, err := q.query(ctx, q.getTasksDueBetweenStmt, getTasksDueBetween, arg.OwnerID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setTaskDeferredUntil = `-- name: SetTaskDeferredUntil :one
+UPDATE tasks
+SET deferred_until = $3
+WHERE id = $1 AND owner_id = $2
+RETURNING id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until
+`
+
+type SetTaskDeferredUntilParams struct {
+	ID            int64        `json:"id"`
+	OwnerID       int64        `json:"ownerId"`
+	DeferredUntil sql.NullTime `json:"deferredUntil"`
+}
+
+func (q *Queries) SetTaskDeferredUntil(ctx context.Context, arg SetTaskDeferredUntilParams) (Task, error) {
+	row := q.queryRow(ctx, q.setTaskDeferredUntilStmt, setTaskDeferredUntil, arg.ID, arg.OwnerID, arg.DeferredUntil)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Body,
+		&i.IsDone,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.ProjectID,
+		&i.StatusID,
+		&i.DueAt,
+		&i.Priority,
+		&i.CompletedAt,
+		&i.EstimateMinutes,
+		&i.ParentID,
+		&i.ArchivedAt,
+		&i.DeferredUntil,
+	)
+	return i, err
+}
+
 const updateTask = `-- name: UpdateTask :one
 UPDATE tasks
 SET 
-    body = $3,
-    is_done = $4
-WHERE id = $1 AND owner_id = $2
-RETURNING id, body, is_done, owner_id, created_at
+    body = $1,
+    is_done = $2,
+    status_id = $3,
+    due_at = CASE WHEN $4::boolean THEN $5 ELSE due_at END,
+    priority = COALESCE($6, priority),
+    estimate_minutes = CASE WHEN $7::boolean THEN $8 ELSE estimate_minutes END,
+    completed_at = CASE WHEN $2::boolean THEN COALESCE(completed_at, now()) END,
+    archived_at = CASE WHEN $2::boolean THEN archived_at END
+WHERE id = $9 AND owner_id = $10
+RETURNING id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until
 `
 
 type UpdateTaskParams struct {
-	ID      int64  `json:"id"`
-	OwnerID int64  `json:"ownerId"`
-	Body    string `json:"body"`
-	IsDone  bool   `json:"isDone"`
+	Body               string        `json:"body"`
+	IsDone             bool          `json:"isDone"`
+	StatusID           sql.NullInt64 `json:"statusId"`
+	SetDueAt           bool          `json:"setDueAt"`
+	DueAt              sql.NullTime  `json:"dueAt"`
+	Priority           sql.NullInt16 `json:"priority"`
+	SetEstimateMinutes bool          `json:"setEstimateMinutes"`
+	EstimateMinutes    sql.NullInt32 `json:"estimateMinutes"`
+	ID                 int64         `json:"id"`
+	OwnerID            int64         `json:"ownerId"`
 }
 
+// priority is kept when it is null, due_at and estimate_minutes unless they are set (to clear them)
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error) {
 	row := q.queryRow(ctx, q.updateTaskStmt, updateTask,
-		arg.ID,
-		arg.OwnerID,
 		arg.Body,
 		arg.IsDone,
+		arg.StatusID,
+		arg.SetDueAt,
+		arg.DueAt,
+		arg.Priority,
+		arg.SetEstimateMinutes,
+		arg.EstimateMinutes,
+		arg.ID,
+		arg.OwnerID,
 	)
 	var i Task
 	err := row.Scan(
@@ -143,6 +564,15 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		&i.IsDone,
 		&i.OwnerID,
 		&i.CreatedAt,
+		&i.ProjectID,
+		&i.StatusID,
+		&i.DueAt,
+		&i.Priority,
+		&i.CompletedAt,
+		&i.EstimateMinutes,
+		&i.ParentID,
+		&i.ArchivedAt,
+		&i.DeferredUntil,
 	)
 	return i, err
 }
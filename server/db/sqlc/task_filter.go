@@ -0,0 +1,265 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TaskFilter is a condition on tasks. service/task builds it from the list parameters and the
+// query language, SQLStore runs it as SQL and the in-memory store evaluates it in Go, so both
+// stores answer a search the same way. A comparison with a NULL column is neither true nor
+// false, like in SQL, so NOT of it does not match either
+type TaskFilter interface {
+	taskFilter()
+}
+
+// TaskColumn is a column of tasks a filter can compare or sort by
+type TaskColumn string
+
+const (
+	TaskColumnID            TaskColumn = "id"
+	TaskColumnOwnerID       TaskColumn = "owner_id"
+	TaskColumnProjectID     TaskColumn = "project_id"
+	TaskColumnStatusID      TaskColumn = "status_id"
+	TaskColumnIsDone        TaskColumn = "is_done"
+	TaskColumnPriority      TaskColumn = "priority"
+	TaskColumnDueAt         TaskColumn = "due_at"
+	TaskColumnCreatedAt     TaskColumn = "created_at"
+	TaskColumnArchivedAt    TaskColumn = "archived_at"
+	TaskColumnDeferredUntil TaskColumn = "deferred_until"
+)
+
+var taskColumns = map[TaskColumn]bool{
+	TaskColumnID:            true,
+	TaskColumnOwnerID:       true,
+	TaskColumnProjectID:     true,
+	TaskColumnStatusID:      true,
+	TaskColumnIsDone:        true,
+	TaskColumnPriority:      true,
+	TaskColumnDueAt:         true,
+	TaskColumnCreatedAt:     true,
+	TaskColumnArchivedAt:    true,
+	TaskColumnDeferredUntil: true,
+}
+
+// comparison operators of FilterCompare and FilterFieldCompare
+var filterOps = map[string]bool{"=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true}
+
+type (
+	// FilterAnd matches the tasks all of its filters match
+	FilterAnd []TaskFilter
+	// FilterOr matches the tasks any of its filters matches
+	FilterOr []TaskFilter
+	// FilterNot matches the tasks Filter does not match
+	FilterNot struct{ Filter TaskFilter }
+	// FilterCompare compares a column with Value, Op is =, <>, <, <=, > or >=
+	FilterCompare struct {
+		Column TaskColumn
+		Op     string
+		Value  interface{}
+	}
+	// FilterIsNull matches the tasks without a value in Column
+	FilterIsNull struct{ Column TaskColumn }
+	// FilterBodyContains matches the tasks whose body contains Text, ignoring case
+	FilterBodyContains struct{ Text string }
+	// FilterBodyEquals matches the tasks whose body is Text, ignoring case
+	FilterBodyEquals struct{ Text string }
+	// FilterStatusName matches the tasks in a status called Name, ignoring case, of a project of OwnerID
+	FilterStatusName struct {
+		OwnerID int64
+		Name    string
+	}
+	// FilterProjectName matches the tasks in a project of OwnerID called Name, ignoring case
+	FilterProjectName struct {
+		OwnerID int64
+		Name    string
+	}
+	// FilterLabel matches the tasks labeled Name, ignoring case, or with any label when Name is empty
+	FilterLabel struct{ Name string }
+	// FilterFieldSet matches the tasks with a value for a custom field
+	FilterFieldSet struct{ FieldID int64 }
+	// FilterFieldEquals matches the tasks whose value of a custom field is Value,
+	// or contains it when Contains is set, like the jsonb = and @> operators
+	FilterFieldEquals struct {
+		FieldID  int64
+		Value    json.RawMessage
+		Contains bool
+	}
+	// FilterFieldContains matches the tasks whose text value of a custom field contains Text, ignoring case
+	FilterFieldContains struct {
+		FieldID int64
+		Text    string
+	}
+	// FilterFieldCompare compares the value of a custom field with Value, a float64 for
+	// number fields or a 2006-01-02 string for date fields, which compare like dates
+	FilterFieldCompare struct {
+		FieldID int64
+		Op      string
+		Value   interface{}
+	}
+)
+
+func (FilterAnd) taskFilter()           {}
+func (FilterOr) taskFilter()            {}
+func (FilterNot) taskFilter()           {}
+func (FilterCompare) taskFilter()       {}
+func (FilterIsNull) taskFilter()        {}
+func (FilterBodyContains) taskFilter()  {}
+func (FilterBodyEquals) taskFilter()    {}
+func (FilterStatusName) taskFilter()    {}
+func (FilterProjectName) taskFilter()   {}
+func (FilterLabel) taskFilter()         {}
+func (FilterFieldSet) taskFilter()      {}
+func (FilterFieldEquals) taskFilter()   {}
+func (FilterFieldContains) taskFilter() {}
+func (FilterFieldCompare) taskFilter()  {}
+
+// TaskOrder sorts tasks by Column, or by the value of a custom field when FieldID is set.
+// Tasks without a value come last in ascending order and first in descending order
+// unless NullsLast is set
+type TaskOrder struct {
+	Column    TaskColumn
+	FieldID   int64
+	Desc      bool
+	NullsLast bool
+}
+
+// filterBuilder turns filters into SQL, values are passed as arguments
+type filterBuilder struct {
+	args []interface{}
+}
+
+func (b *filterBuilder) arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *filterBuilder) column(column TaskColumn) (string, error) {
+	if !taskColumns[column] {
+		return "", fmt.Errorf("unknown task column %q", column)
+	}
+	return string(column), nil
+}
+
+func (b *filterBuilder) where(filter TaskFilter) (string, error) {
+	switch f := filter.(type) {
+	case FilterAnd:
+		return b.join(f, " AND ", "TRUE")
+	case FilterOr:
+		return b.join(f, " OR ", "FALSE")
+	case FilterNot:
+		cond, err := b.where(f.Filter)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + cond + ")", nil
+	case FilterCompare:
+		column, err := b.column(f.Column)
+		if err != nil {
+			return "", err
+		}
+		if !filterOps[f.Op] {
+			return "", fmt.Errorf("unknown operator %q", f.Op)
+		}
+		return column + " " + f.Op + " " + b.arg(f.Value), nil
+	case FilterIsNull:
+		column, err := b.column(f.Column)
+		if err != nil {
+			return "", err
+		}
+		return column + " IS NULL", nil
+	case FilterBodyContains:
+		return "body ILIKE " + b.arg(likePattern(f.Text)), nil
+	case FilterBodyEquals:
+		return "lower(body) = lower(" + b.arg(f.Text) + ")", nil
+	case FilterStatusName:
+		return "status_id IN (SELECT s.id FROM project_statuses s JOIN projects p ON p.id = s.project_id " +
+			"WHERE p.owner_id = " + b.arg(f.OwnerID) + " AND lower(s.name) = lower(" + b.arg(f.Name) + "))", nil
+	case FilterProjectName:
+		return "project_id IN (SELECT id FROM projects WHERE owner_id = " + b.arg(f.OwnerID) +
+			" AND lower(name) = lower(" + b.arg(f.Name) + "))", nil
+	case FilterLabel:
+		if f.Name == "" {
+			return "EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id)", nil
+		}
+		return "EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id " +
+			"WHERE tl.task_id = tasks.id AND lower(l.name) = lower(" + b.arg(f.Name) + "))", nil
+	case FilterFieldSet:
+		return b.fieldValue(f.FieldID) + ")", nil
+	case FilterFieldEquals:
+		op := "="
+		if f.Contains {
+			op = "@>"
+		}
+		return b.fieldValue(f.FieldID) + " AND v.value " + op + " " + b.arg(string(f.Value)) + "::jsonb)", nil
+	case FilterFieldContains:
+		return b.fieldValue(f.FieldID) + " AND v.value #>> '{}' ILIKE " + b.arg(likePattern(f.Text)) + ")", nil
+	case FilterFieldCompare:
+		if !filterOps[f.Op] {
+			return "", fmt.Errorf("unknown operator %q", f.Op)
+		}
+		switch f.Value.(type) {
+		case float64:
+			return b.fieldValue(f.FieldID) + " AND (v.value #>> '{}')::numeric " + f.Op + " " + b.arg(f.Value) + ")", nil
+		case string:
+			return b.fieldValue(f.FieldID) + " AND v.value #>> '{}' " + f.Op + " " + b.arg(f.Value) + ")", nil
+		}
+		return "", fmt.Errorf("cannot compare custom field values with %T", f.Value)
+	}
+	return "", fmt.Errorf("unsupported task filter %T", filter)
+}
+
+func (b *filterBuilder) join(filters []TaskFilter, joiner string, empty string) (string, error) {
+	if len(filters) == 0 {
+		return empty, nil
+	}
+	conds := make([]string, len(filters))
+	for i, filter := range filters {
+		cond, err := b.where(filter)
+		if err != nil {
+			return "", err
+		}
+		conds[i] = cond
+	}
+	return "(" + strings.Join(conds, joiner) + ")", nil
+}
+
+// fieldValue opens the condition that a task has a value v for a field, the caller adds
+// conditions on v and closes it. It is called first so placeholders are numbered in order
+func (b *filterBuilder) fieldValue(fieldId int64) string {
+	return "EXISTS (SELECT 1 FROM task_custom_field_values v WHERE v.task_id = tasks.id AND v.field_id = " + b.arg(fieldId)
+}
+
+func (b *filterBuilder) orderBy(orders []TaskOrder) (string, error) {
+	terms := []string{}
+	for _, order := range orders {
+		term := ""
+		if order.FieldID != 0 {
+			term = "(SELECT v.value FROM task_custom_field_values v WHERE v.task_id = tasks.id AND v.field_id = " + b.arg(order.FieldID) + ")"
+		} else {
+			column, err := b.column(order.Column)
+			if err != nil {
+				return "", err
+			}
+			term = column
+		}
+		if order.Desc {
+			term += " DESC"
+		} else {
+			term += " ASC"
+		}
+		if order.NullsLast {
+			term += " NULLS LAST"
+		}
+		terms = append(terms, term)
+	}
+	//the id breaks ties so pages don't overlap
+	return strings.Join(append(terms, "id"), ", "), nil
+}
+
+// likePattern matches s anywhere, with % and _ taken literally
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
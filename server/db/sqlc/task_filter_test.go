@@ -0,0 +1,38 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	b := &filterBuilder{}
+	where, err := b.where(FilterAnd{
+		FilterCompare{Column: TaskColumnOwnerID, Op: "=", Value: int64(7)},
+		FilterOr{
+			FilterIsNull{Column: TaskColumnStatusID},
+			FilterNot{Filter: FilterStatusName{OwnerID: 7, Name: "Done"}},
+		},
+		FilterFieldCompare{FieldID: 4, Op: ">=", Value: 3.0},
+		FilterFieldEquals{FieldID: 5, Value: json.RawMessage(`["ios"]`), Contains: true},
+		FilterBodyContains{Text: "100%"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "(owner_id = $1"+
+		" AND (status_id IS NULL OR NOT (status_id IN (SELECT s.id FROM project_statuses s JOIN projects p ON p.id = s.project_id WHERE p.owner_id = $2 AND lower(s.name) = lower($3))))"+
+		" AND EXISTS (SELECT 1 FROM task_custom_field_values v WHERE v.task_id = tasks.id AND v.field_id = $4 AND (v.value #>> '{}')::numeric >= $5)"+
+		" AND EXISTS (SELECT 1 FROM task_custom_field_values v WHERE v.task_id = tasks.id AND v.field_id = $6 AND v.value @> $7::jsonb)"+
+		" AND body ILIKE $8)", where)
+
+	orderBy, err := b.orderBy([]TaskOrder{{FieldID: 4, Desc: true, NullsLast: true}, {Column: TaskColumnPriority}})
+	require.NoError(t, err)
+	require.Equal(t, "(SELECT v.value FROM task_custom_field_values v WHERE v.task_id = tasks.id AND v.field_id = $9) DESC NULLS LAST, priority ASC, id", orderBy)
+	require.Equal(t, []interface{}{int64(7), int64(7), "Done", int64(4), 3.0, int64(5), `["ios"]`, `%100\%%`, int64(4)}, b.args)
+
+	_, err = b.where(FilterCompare{Column: "body; DROP TABLE tasks", Op: "=", Value: 1})
+	require.Error(t, err)
+	_, err = b.where(FilterCompare{Column: TaskColumnID, Op: "LIKE", Value: 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+const searchTasks = `SELECT id, body, is_done, owner_id, created_at, project_id, status_id, due_at, priority, completed_at, estimate_minutes, parent_id, archived_at, deferred_until FROM tasks
+WHERE `
+
+// SearchTasksParams describes a task query that cannot be expressed as a static sqlc query,
+// tasks are sorted by OrderBy and then by id
+type SearchTasksParams struct {
+	Filter  TaskFilter
+	OrderBy []TaskOrder
+	Limit   int32
+	Offset  int32
+}
+
+// SearchTasks runs a task filter as a parameterized query
+func (store *SQLStore) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]Task, error) {
+	b := &filterBuilder{}
+	where, err := b.where(arg.Filter)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := b.orderBy(arg.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	args := append(b.args, arg.Limit, arg.Offset)
+	query := fmt.Sprintf("%s%s\nORDER BY %s\nLIMIT $%d\nOFFSET $%d", searchTasks, where, orderBy, len(args)-1, len(args))
+
+	rows, err := store.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Body,
+			&i.IsDone,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ProjectID,
+			&i.StatusID,
+			&i.DueAt,
+			&i.Priority,
+			&i.CompletedAt,
+			&i.EstimateMinutes,
+			&i.ParentID,
+			&i.ArchivedAt,
+			&i.DeferredUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
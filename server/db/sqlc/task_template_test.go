@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskTemplateList(t *testing.T) {
+	owner := CreateRandomUser(t)
+	other := CreateRandomUser(t)
+
+	template, err := testQueries.CreateTaskTemplate(context.Background(), CreateTaskTemplateParams{
+		OwnerID:  owner.ID,
+		Name:     util.RandomString(8),
+		Body:     "Release {{version}}",
+		Labels:   []string{"release"},
+		Subtasks: []byte(`[{"body":"Tag"}]`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"release"}, template.Labels)
+	require.JSONEq(t, `[{"body":"Tag"}]`, string(template.Subtasks))
+
+	templates, err := testQueries.GetTaskTemplateList(context.Background(), owner.ID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, template.ID, templates[0].ID)
+
+	//templates are personal
+	templates, err = testQueries.GetTaskTemplateList(context.Background(), other.ID)
+	require.NoError(t, err)
+	require.Empty(t, templates)
+}
+
+func TestInstantiateTemplateTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	store := NewStore(testDB)
+
+	result, err := store.InstantiateTemplateTx(context.Background(), InstantiateTemplateTxParams{
+		OwnerID: user.ID,
+		Root: TemplateTask{
+			Task:   CreateTaskParams{Body: "Release 1.4"},
+			Labels: []string{"release"},
+			Subtasks: []TemplateTask{
+				{Task: CreateTaskParams{Body: "Freeze"}, Subtasks: []TemplateTask{{Task: CreateTaskParams{Body: "Notify"}}}},
+				{Task: CreateTaskParams{Body: "Tag"}, Labels: []string{"release", "git"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 4)
+	root := result.Tasks[0]
+	require.False(t, root.ParentID.Valid)
+	require.Equal(t, root.ID, result.Tasks[1].ParentID.Int64)
+	require.Equal(t, result.Tasks[1].ID, result.Tasks[2].ParentID.Int64)
+	require.Equal(t, root.ID, result.Tasks[3].ParentID.Int64)
+	require.Equal(t, []string{"release", "git"}, result.Labels[result.Tasks[3].ID])
+
+	subtasks, err := testQueries.GetSubtasks(context.Background(), sql.NullInt64{Int64: root.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, subtasks, 2)
+
+	//subtasks go with their parent
+	err = testQueries.DeleteTask(context.Background(), DeleteTaskParams{ID: root.ID, OwnerID: user.ID})
+	require.NoError(t, err)
+	_, err = testQueries.GetTask(context.Background(), result.Tasks[2].ID)
+	require.Error(t, err)
+}
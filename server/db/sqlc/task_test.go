@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 	"testing"
 	"time"
@@ -75,3 +76,36 @@ func TestGetTaskList(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestDeferredTasksLeftOutOfList(t *testing.T) {
+	user := CreateRandomUser(t)
+	deferred, err := testQueries.CreateTask(context.Background(), CreateTaskParams{Body: "later", OwnerID: user.ID})
+	require.NoError(t, err)
+	visible, err := testQueries.CreateTask(context.Background(), CreateTaskParams{Body: "now", OwnerID: user.ID})
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour)
+	deferred, err = testQueries.SetTaskDeferredUntil(context.Background(), SetTaskDeferredUntilParams{
+		ID:            deferred.ID,
+		OwnerID:       user.ID,
+		DeferredUntil: sql.NullTime{Time: until, Valid: true},
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, until, deferred.DeferredUntil.Time, time.Second)
+
+	listed, err := testQueries.GetTaskList(context.Background(), GetTaskListParams{OwnerID: user.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, visible.ID, listed[0].ID)
+
+	//a task deferred until a past time is listed again
+	_, err = testQueries.SetTaskDeferredUntil(context.Background(), SetTaskDeferredUntilParams{
+		ID:            deferred.ID,
+		OwnerID:       user.ID,
+		DeferredUntil: sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+	listed, err = testQueries.GetTaskList(context.Background(), GetTaskListParams{OwnerID: user.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+}
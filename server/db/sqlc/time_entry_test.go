@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTimerTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	task := CreateRandomTask(t, user)
+	store := NewStore(testDB)
+	now := time.Now().Truncate(time.Second)
+
+	first, err := store.StartTimerTx(context.Background(), StartTimerTxParams{
+		OwnerID: user.ID,
+		TaskID:  task.ID,
+		Now:     now,
+	})
+	require.NoError(t, err)
+	require.Nil(t, first.Stopped)
+	require.False(t, first.Started.EndedAt.Valid)
+
+	//starting another timer stops the running one
+	second, err := store.StartTimerTx(context.Background(), StartTimerTxParams{
+		OwnerID: user.ID,
+		TaskID:  task.ID,
+		Note:    "second",
+		Now:     now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, second.Stopped)
+	require.Equal(t, first.Started.ID, second.Stopped.ID)
+	require.WithinDuration(t, now.Add(time.Minute), second.Stopped.EndedAt.Time, time.Second)
+
+	//a second running timer breaks the unique index
+	_, err = testQueries.CreateTimeEntry(context.Background(), CreateTimeEntryParams{
+		OwnerID:   user.ID,
+		TaskID:    task.ID,
+		StartedAt: now,
+	})
+	require.Error(t, err)
+
+	running, err := testQueries.GetRunningTimeEntry(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.Started.ID, running.ID)
+
+	entries, err := testQueries.GetTimeEntriesBetween(context.Background(), GetTimeEntriesBetweenParams{
+		OwnerID:  user.ID,
+		FromTime: now.Add(30 * time.Second),
+		ToTime:   now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, task.Body, entries[0].Body)
+
+	stopped, err := testQueries.StopTimeEntry(context.Background(), StopTimeEntryParams{
+		EndedAt: now.Add(2 * time.Minute),
+		OwnerID: user.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, stopped.EndedAt.Valid)
+}
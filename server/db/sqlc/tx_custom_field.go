@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SetCustomFieldValuesTxParams contains the input parameters of the set custom field values transaction.
+// A nil Value removes the value of that field from the task.
+type SetCustomFieldValuesTxParams struct {
+	TaskID int64
+	Values map[int64]json.RawMessage
+}
+
+// SetCustomFieldValuesTx writes several custom field values of one task atomically
+// and returns all values the task has afterwards
+func (store *SQLStore) SetCustomFieldValuesTx(ctx context.Context, arg SetCustomFieldValuesTxParams) ([]TaskCustomFieldValue, error) {
+	var values []TaskCustomFieldValue
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for fieldID, value := range arg.Values {
+			if value == nil {
+				err := q.DeleteTaskCustomFieldValue(ctx, DeleteTaskCustomFieldValueParams{
+					TaskID:  arg.TaskID,
+					FieldID: fieldID,
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			_, err := q.UpsertTaskCustomFieldValue(ctx, UpsertTaskCustomFieldValueParams{
+				TaskID:  arg.TaskID,
+				FieldID: fieldID,
+				Value:   value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		values, err = q.GetTaskCustomFieldValues(ctx, arg.TaskID)
+		return err
+	})
+
+	return values, err
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// DeliverDigestTxParams contains the input parameters of the deliver digest transaction
+type DeliverDigestTxParams struct {
+	Now time.Time
+	// Deliver sends the digest, it runs while the preference row is locked
+	Deliver func(digest ClaimDueDigestRow) error
+	// NextSendAt is when the following digest is due
+	NextSendAt func(digest ClaimDueDigestRow) time.Time
+	// RetryAt is when a failed digest is tried again
+	RetryAt time.Time
+}
+
+// DeliverDigestTxResult is the result of the deliver digest transaction
+type DeliverDigestTxResult struct {
+	// Found is false when no digest was due
+	Found  bool
+	Digest ClaimDueDigestRow
+	// DeliveryErr is the error returned by Deliver
+	DeliveryErr error
+}
+
+// DeliverDigestTx claims one due digest with FOR UPDATE SKIP LOCKED, sends it
+// and schedules the next one in the same transaction, like DeliverReminderTx
+func (store *SQLStore) DeliverDigestTx(ctx context.Context, arg DeliverDigestTxParams) (DeliverDigestTxResult, error) {
+	var result DeliverDigestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		digest, err := q.ClaimDueDigest(ctx, sql.NullTime{Time: arg.Now, Valid: true})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.Found = true
+		result.Digest = digest
+
+		if result.DeliveryErr = arg.Deliver(digest); result.DeliveryErr != nil {
+			return q.SetDigestNextSendAt(ctx, SetDigestNextSendAtParams{
+				UserID:     digest.UserID,
+				NextSendAt: sql.NullTime{Time: arg.RetryAt, Valid: true},
+			})
+		}
+		return q.SetDigestNextSendAt(ctx, SetDigestNextSendAtParams{
+			UserID:     digest.UserID,
+			NextSendAt: sql.NullTime{Time: arg.NextSendAt(digest), Valid: true},
+			LastSentAt: sql.NullTime{Time: arg.Now, Valid: true},
+		})
+	})
+
+	return result, err
+}
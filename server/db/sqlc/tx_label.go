@@ -0,0 +1,42 @@
+package db
+
+import "context"
+
+// SetTaskLabelsTxParams contains the input parameters of the set task labels transaction
+type SetTaskLabelsTxParams struct {
+	TaskID  int64
+	OwnerID int64
+	Names   []string
+}
+
+// SetTaskLabelsTx replaces the labels of a task,
+// labels the owner doesn't have yet are created on the way
+func (store *SQLStore) SetTaskLabelsTx(ctx context.Context, arg SetTaskLabelsTxParams) ([]Label, error) {
+	labels := make([]Label, 0, len(arg.Names))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteTaskLabels(ctx, arg.TaskID); err != nil {
+			return err
+		}
+		for _, name := range arg.Names {
+			label, err := q.UpsertLabel(ctx, UpsertLabelParams{
+				OwnerID: arg.OwnerID,
+				Name:    name,
+			})
+			if err != nil {
+				return err
+			}
+			err = q.AddTaskLabel(ctx, AddTaskLabelParams{
+				TaskID:  arg.TaskID,
+				LabelID: label.ID,
+			})
+			if err != nil {
+				return err
+			}
+			labels = append(labels, label)
+		}
+		return nil
+	})
+
+	return labels, err
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// EnableTotpTxParams contains the input parameters of the enable totp transaction
+type EnableTotpTxParams struct {
+	UserID int64
+	// Step is the time step of the code that confirmed the secret
+	Step       int64
+	CodeHashes [][]byte
+}
+
+// EnableTotpTx turns on the pending totp secret of a user and replaces the recovery codes in the same transaction
+func (store *SQLStore) EnableTotpTx(ctx context.Context, arg EnableTotpTxParams) (UserTotp, error) {
+	var result UserTotp
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.EnableUserTotp(ctx, EnableUserTotpParams{
+			UserID:   arg.UserID,
+			LastStep: sql.NullInt64{Int64: arg.Step, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(ctx, q, arg.UserID, arg.CodeHashes)
+	})
+
+	return result, err
+}
+
+// ReplaceRecoveryCodesTxParams contains the input parameters of the replace recovery codes transaction
+type ReplaceRecoveryCodesTxParams struct {
+	UserID     int64
+	CodeHashes [][]byte
+}
+
+// ReplaceRecoveryCodesTx deletes the recovery codes of a user, used or not, and stores new ones
+func (store *SQLStore) ReplaceRecoveryCodesTx(ctx context.Context, arg ReplaceRecoveryCodesTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		return replaceRecoveryCodes(ctx, q, arg.UserID, arg.CodeHashes)
+	})
+}
+
+func replaceRecoveryCodes(ctx context.Context, q *Queries, userId int64, codeHashes [][]byte) error {
+	if err := q.DeleteRecoveryCodes(ctx, userId); err != nil {
+		return err
+	}
+	return q.CreateRecoveryCodes(ctx, CreateRecoveryCodesParams{
+		UserID:     userId,
+		CodeHashes: codeHashes,
+	})
+}
+
+// DisableTotpTx removes the totp secret and the recovery codes of a user
+func (store *SQLStore) DisableTotpTx(ctx context.Context, userId int64) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteUserTotp(ctx, userId); err != nil {
+			return err
+		}
+		return q.DeleteRecoveryCodes(ctx, userId)
+	})
+}
@@ -0,0 +1,93 @@
+package db
+
+import "context"
+
+// CreateProjectTxParams contains the input parameters of the create project transaction
+type CreateProjectTxParams struct {
+	CreateProjectParams
+	Statuses []CreateProjectStatusParams
+}
+
+// CreateProjectTxResult is the result of the create project transaction
+type CreateProjectTxResult struct {
+	Project  Project         `json:"project"`
+	Statuses []ProjectStatus `json:"statuses"`
+}
+
+// CreateProjectTx creates a project together with its initial status columns.
+// ProjectID of each status param is ignored and set to the new project's id.
+func (store *SQLStore) CreateProjectTx(ctx context.Context, arg CreateProjectTxParams) (CreateProjectTxResult, error) {
+	var result CreateProjectTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Project, err = q.CreateProject(ctx, arg.CreateProjectParams)
+		if err != nil {
+			return err
+		}
+
+		result.Statuses = make([]ProjectStatus, 0, len(arg.Statuses))
+		for _, statusArg := range arg.Statuses {
+			statusArg.ProjectID = result.Project.ID
+			status, err := q.CreateProjectStatus(ctx, statusArg)
+			if err != nil {
+				return err
+			}
+			result.Statuses = append(result.Statuses, status)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// UpdateProjectStatusTx updates a status column, when its category changes the tasks
+// in the column are marked done or not done in the same transaction
+func (store *SQLStore) UpdateProjectStatusTx(ctx context.Context, arg UpdateProjectStatusParams) (ProjectStatus, error) {
+	var result ProjectStatus
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.UpdateProjectStatus(ctx, arg)
+		if err != nil {
+			return err
+		}
+		_, err = q.SyncTasksWithStatus(ctx, result.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// ReplaceStatusTransitionsTxParams contains the input parameters of the replace transitions transaction
+type ReplaceStatusTransitionsTxParams struct {
+	ProjectID   int64
+	Transitions []CreateStatusTransitionParams
+}
+
+// ReplaceStatusTransitionsTx swaps the whole transition table of a project in one go,
+// so a workflow is never observed half-updated. The project row is locked so moves
+// checked against the old workflow finish first, see UpdateTaskTx.
+func (store *SQLStore) ReplaceStatusTransitionsTx(ctx context.Context, arg ReplaceStatusTransitionsTxParams) ([]StatusTransition, error) {
+	transitions := make([]StatusTransition, 0, len(arg.Transitions))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.LockProject(ctx, arg.ProjectID); err != nil {
+			return err
+		}
+		if err := q.DeleteStatusTransitions(ctx, arg.ProjectID); err != nil {
+			return err
+		}
+		for _, transitionArg := range arg.Transitions {
+			transitionArg.ProjectID = arg.ProjectID
+			transition, err := q.CreateStatusTransition(ctx, transitionArg)
+			if err != nil {
+				return err
+			}
+			transitions = append(transitions, transition)
+		}
+		return nil
+	})
+
+	return transitions, err
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// DeliverReminderTxParams contains the input parameters of the deliver reminder transaction
+type DeliverReminderTxParams struct {
+	Now         time.Time
+	MaxAttempts int32
+	// Deliver sends the reminder, it runs while the reminder row is locked
+	Deliver func(reminder ClaimDueReminderRow) error
+	// RetryAt is when a failed delivery is tried again, given the number of failed attempts
+	RetryAt func(attempts int32) time.Time
+}
+
+// DeliverReminderTxResult is the result of the deliver reminder transaction
+type DeliverReminderTxResult struct {
+	// Found is false when no reminder was due
+	Found    bool
+	Reminder ClaimDueReminderRow
+	// DeliveryErr is the error returned by Deliver, it is recorded on the reminder
+	DeliveryErr error
+}
+
+// DeliverReminderTx claims one due reminder with FOR UPDATE SKIP LOCKED, delivers it
+// and marks it as sent in the same transaction.
+// Other servers skip the locked row, so a reminder is delivered by one server only,
+// and a reminder whose transaction did not commit is picked up again after a restart.
+// The only duplicate left is a crash between the mail server accepting the email
+// and the commit, which is as close to exactly once as email allows.
+func (store *SQLStore) DeliverReminderTx(ctx context.Context, arg DeliverReminderTxParams) (DeliverReminderTxResult, error) {
+	var result DeliverReminderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		reminder, err := q.ClaimDueReminder(ctx, ClaimDueReminderParams{
+			Now:         sql.NullTime{Time: arg.Now, Valid: true},
+			MaxAttempts: arg.MaxAttempts,
+		})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.Found = true
+		result.Reminder = reminder
+
+		if result.DeliveryErr = arg.Deliver(reminder); result.DeliveryErr != nil {
+			return q.RecordReminderFailure(ctx, RecordReminderFailureParams{
+				ID:        reminder.ID,
+				LastError: result.DeliveryErr.Error(),
+				FireAt:    sql.NullTime{Time: arg.RetryAt(reminder.Attempts + 1), Valid: true},
+			})
+		}
+		return q.MarkReminderSent(ctx, MarkReminderSentParams{
+			ID:     reminder.ID,
+			SentAt: sql.NullTime{Time: arg.Now, Valid: true},
+		})
+	})
+
+	return result, err
+}
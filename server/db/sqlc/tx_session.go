@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// RotateSessionTxParams contains the input parameters of the rotate session transaction
+type RotateSessionTxParams struct {
+	ID   uuid.UUID
+	Next CreateSessionParams
+}
+
+// RotateSessionTx marks a session as replaced by the next one and creates the next one in the same transaction.
+// It fails with sql.ErrNoRows when the session doesn't exist or was replaced already
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var result Session
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		replaced, err := q.ReplaceSession(ctx, ReplaceSessionParams{
+			ReplacedBy: arg.Next.ID,
+			ID:         arg.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if replaced == 0 {
+			return sql.ErrNoRows
+		}
+		result, err = q.CreateSession(ctx, arg.Next)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+)
+
+// GetSyncChangesTxParams contains the input parameters of the get sync changes transaction
+type GetSyncChangesTxParams struct {
+	OwnerID int64
+	// Since is the oldest transaction whose changes are returned
+	Since    string
+	AfterSeq int64
+	Limit    int32
+}
+
+// GetSyncChangesTxResult is the result of the get sync changes transaction
+type GetSyncChangesTxResult struct {
+	// Xmin is the oldest transaction that was still running,
+	// changes of later transactions are not included
+	Xmin     string
+	Changes  []GetSyncChangesRow
+	Tasks    []Task
+	Labels   []Label
+	Projects []Project
+}
+
+// GetSyncChangesTx reads the changes of a user and the changed entities
+// from one snapshot, so Xmin is exact for what is returned
+func (store *SQLStore) GetSyncChangesTx(ctx context.Context, arg GetSyncChangesTxParams) (GetSyncChangesTxResult, error) {
+	var result GetSyncChangesTxResult
+
+	err := store.execSnapshotTx(ctx, func(q *Queries) error {
+		var err error
+		result.Xmin, err = q.GetSyncSnapshotXmin(ctx)
+		if err != nil {
+			return err
+		}
+		result.Changes, err = q.GetSyncChanges(ctx, GetSyncChangesParams{
+			OwnerID:  arg.OwnerID,
+			Since:    arg.Since,
+			AfterSeq: arg.AfterSeq,
+			RowLimit: arg.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		ids := map[string][]int64{}
+		for _, change := range result.Changes {
+			if !change.Deleted {
+				ids[change.Entity] = append(ids[change.Entity], change.EntityID)
+			}
+		}
+		if result.Tasks, err = q.GetTasksByIds(ctx, ids["task"]); err != nil {
+			return err
+		}
+		if result.Labels, err = q.GetLabelsByIds(ctx, ids["label"]); err != nil {
+			return err
+		}
+		result.Projects, err = q.GetProjectsByIds(ctx, ids["project"])
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrWipLimitReached      = errors.New("status has reached its wip limit")
+	ErrTransitionNotAllowed = errors.New("status transition is not allowed by the project workflow")
+)
+
+// UpdateTaskTxParams contains the input parameters of the update task transaction
+type UpdateTaskTxParams struct {
+	UpdateTaskParams
+	// CheckWipLimit is set when the task moves to StatusID
+	CheckWipLimit bool
+	// Transition is set when the task moves between two statuses of a project,
+	// the workflow of the project has to allow it
+	Transition *CreateStatusTransitionParams
+}
+
+// CreateTaskTx creates a task, the wip limit of its status is checked in the same transaction
+func (store *SQLStore) CreateTaskTx(ctx context.Context, arg CreateTaskParams) (Task, error) {
+	var result Task
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.checkWipLimit(ctx, arg.StatusID); err != nil {
+			return err
+		}
+		var err error
+		result, err = q.CreateTask(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateTaskTx updates a task, a task moving to another status is checked against
+// the workflow of its project and the wip limit of that status in the same transaction
+func (store *SQLStore) UpdateTaskTx(ctx context.Context, arg UpdateTaskTxParams) (Task, error) {
+	var result Task
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.Transition != nil {
+			if err := q.checkTransition(ctx, *arg.Transition); err != nil {
+				return err
+			}
+		}
+		if arg.CheckWipLimit {
+			if err := q.checkWipLimit(ctx, arg.StatusID); err != nil {
+				return err
+			}
+		}
+		var err error
+		result, err = q.UpdateTask(ctx, arg.UpdateTaskParams)
+		return err
+	})
+
+	return result, err
+}
+
+// checkWipLimit locks the status row so concurrent writes to the same status wait for
+// each other, then counts its tasks. A wip limit of 0 means the column is unlimited
+func (q *Queries) checkWipLimit(ctx context.Context, statusId sql.NullInt64) error {
+	if !statusId.Valid {
+		return nil
+	}
+	status, err := q.LockProjectStatus(ctx, statusId.Int64)
+	if err != nil {
+		return err
+	}
+	if status.WipLimit == 0 {
+		return nil
+	}
+	count, err := q.CountTasksByStatus(ctx, statusId)
+	if err != nil {
+		return err
+	}
+	if count >= int64(status.WipLimit) {
+		return ErrWipLimitReached
+	}
+	return nil
+}
+
+// checkTransition share locks the project so its workflow cannot be replaced until the
+// transaction ends, then looks for the transition.
+// A status without any configured outgoing transition may move anywhere
+func (q *Queries) checkTransition(ctx context.Context, arg CreateStatusTransitionParams) error {
+	if _, err := q.LockProjectForShare(ctx, arg.ProjectID); err != nil {
+		return err
+	}
+	transitions, err := q.GetStatusTransitionList(ctx, arg.ProjectID)
+	if err != nil {
+		return err
+	}
+	restricted := false
+	for _, transition := range transitions {
+		if transition.FromStatusID != arg.FromStatusID {
+			continue
+		}
+		if transition.ToStatusID == arg.ToStatusID {
+			return nil
+		}
+		restricted = true
+	}
+	if restricted {
+		return ErrTransitionNotAllowed
+	}
+	return nil
+}
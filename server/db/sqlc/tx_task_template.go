@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// TemplateTask is a task created from a template with the subtasks created under it
+type TemplateTask struct {
+	Task     CreateTaskParams
+	Labels   []string
+	Subtasks []TemplateTask
+}
+
+// InstantiateTemplateTxParams contains the input parameters of the instantiate template transaction
+type InstantiateTemplateTxParams struct {
+	OwnerID int64
+	Root    TemplateTask
+}
+
+// InstantiateTemplateTxResult is the result of the instantiate template transaction
+type InstantiateTemplateTxResult struct {
+	// Tasks are the created tasks, each parent before its subtasks
+	Tasks  []Task
+	Labels map[int64][]string
+}
+
+// InstantiateTemplateTx creates a task tree with its labels in one transaction,
+// labels the owner doesn't have yet are created on the way. Every task is checked
+// against the wip limit of its status, counting the tasks created before it
+func (store *SQLStore) InstantiateTemplateTx(ctx context.Context, arg InstantiateTemplateTxParams) (InstantiateTemplateTxResult, error) {
+	result := InstantiateTemplateTxResult{Labels: map[int64][]string{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		return createTemplateTask(ctx, q, arg.OwnerID, arg.Root, sql.NullInt64{}, &result)
+	})
+
+	return result, err
+}
+
+func createTemplateTask(ctx context.Context, q *Queries, ownerId int64, t TemplateTask, parentId sql.NullInt64, result *InstantiateTemplateTxResult) error {
+	params := t.Task
+	params.OwnerID = ownerId
+	params.ParentID = parentId
+	if err := q.checkWipLimit(ctx, params.StatusID); err != nil {
+		return err
+	}
+	task, err := q.CreateTask(ctx, params)
+	if err != nil {
+		return err
+	}
+	result.Tasks = append(result.Tasks, task)
+
+	for _, name := range t.Labels {
+		label, err := q.UpsertLabel(ctx, UpsertLabelParams{
+			OwnerID: ownerId,
+			Name:    name,
+		})
+		if err != nil {
+			return err
+		}
+		err = q.AddTaskLabel(ctx, AddTaskLabelParams{
+			TaskID:  task.ID,
+			LabelID: label.ID,
+		})
+		if err != nil {
+			return err
+		}
+		result.Labels[task.ID] = append(result.Labels[task.ID], label.Name)
+	}
+
+	for _, subtask := range t.Subtasks {
+		err := createTemplateTask(ctx, q, ownerId, subtask, sql.NullInt64{Int64: task.ID, Valid: true}, result)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// StartTimerTxParams contains the input parameters of the start timer transaction
+type StartTimerTxParams struct {
+	OwnerID int64
+	TaskID  int64
+	Note    string
+	Now     time.Time
+}
+
+// StartTimerTxResult is the result of the start timer transaction
+type StartTimerTxResult struct {
+	// Stopped is the timer that was running before, nil when there was none
+	Stopped *TimeEntry
+	Started TimeEntry
+}
+
+// StartTimerTx stops the running timer of the user, if any, and starts a new one in the same transaction.
+// A concurrent start fails on the unique index of running timers.
+func (store *SQLStore) StartTimerTx(ctx context.Context, arg StartTimerTxParams) (StartTimerTxResult, error) {
+	var result StartTimerTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		stopped, err := q.StopTimeEntry(ctx, StopTimeEntryParams{
+			EndedAt: arg.Now,
+			OwnerID: arg.OwnerID,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil {
+			result.Stopped = &stopped
+		}
+
+		result.Started, err = q.CreateTimeEntry(ctx, CreateTimeEntryParams{
+			OwnerID:   arg.OwnerID,
+			TaskID:    arg.TaskID,
+			StartedAt: arg.Now,
+			Note:      arg.Note,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// DeliverWebhookTxParams contains the input parameters of the deliver webhook transaction
+type DeliverWebhookTxParams struct {
+	Now time.Time
+	// Deliver sends the delivery and returns the HTTP status, 0 when the request failed.
+	// It runs while the delivery row is locked
+	Deliver func(delivery ClaimDueWebhookDeliveryRow) (status int, err error)
+	// RetryAt is when a failed delivery is tried again, given the number of failed attempts.
+	// The delivery is given up when it returns false
+	RetryAt func(attempts int32) (time.Time, bool)
+}
+
+// DeliverWebhookTxResult is the result of the deliver webhook transaction
+type DeliverWebhookTxResult struct {
+	// Found is false when no delivery was due
+	Found    bool
+	Delivery ClaimDueWebhookDeliveryRow
+	Status   int
+	// DeliveryErr is the error returned by Deliver, it is recorded on the delivery
+	DeliveryErr error
+}
+
+// DeliverWebhookTx claims one due webhook delivery with FOR UPDATE SKIP LOCKED,
+// sends it and records the attempt in the same transaction,
+// so that each delivery is sent by one server at a time.
+func (store *SQLStore) DeliverWebhookTx(ctx context.Context, arg DeliverWebhookTxParams) (DeliverWebhookTxResult, error) {
+	var result DeliverWebhookTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		delivery, err := q.ClaimDueWebhookDelivery(ctx, sql.NullTime{Time: arg.Now, Valid: true})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result.Found = true
+		result.Delivery = delivery
+
+		result.Status, result.DeliveryErr = arg.Deliver(delivery)
+		attempt := RecordWebhookDeliveryAttemptParams{
+			ID:             delivery.ID,
+			ResponseStatus: sql.NullInt32{Int32: int32(result.Status), Valid: result.Status != 0},
+		}
+		if result.DeliveryErr != nil {
+			attempt.LastError = result.DeliveryErr.Error()
+			retryAt, retry := arg.RetryAt(delivery.Attempts + 1)
+			attempt.NextAttemptAt = sql.NullTime{Time: retryAt, Valid: retry}
+		} else {
+			attempt.DeliveredAt = sql.NullTime{Time: arg.Now, Valid: true}
+		}
+		return q.RecordWebhookDeliveryAttempt(ctx, attempt)
+	})
+
+	return result, err
+}
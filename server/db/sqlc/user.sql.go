@@ -8,8 +8,41 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
+const claimVerificationEmail = `-- name: ClaimVerificationEmail :one
+UPDATE users
+SET verification_sent_at = $1::timestamptz
+WHERE
+    id = $2 AND
+    email_verified_at IS NULL AND
+    (verification_sent_at IS NULL OR verification_sent_at <= $3::timestamptz)
+RETURNING id, username, email, hashed_password, password_changed_at, created_at, email_verified_at, verification_sent_at
+`
+
+type ClaimVerificationEmailParams struct {
+	Now         time.Time `json:"now"`
+	ID          int64     `json:"id"`
+	ResendAfter time.Time `json:"resendAfter"`
+}
+
+func (q *Queries) ClaimVerificationEmail(ctx context.Context, arg ClaimVerificationEmailParams) (User, error) {
+	row := q.queryRow(ctx, q.claimVerificationEmailStmt, claimVerificationEmail, arg.Now, arg.ID, arg.ResendAfter)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     username,
@@ -17,7 +50,7 @@ INSERT INTO users (
     email
 ) VALUES (
     $1, $2, $3
-) RETURNING id, username, email, hashed_password, password_changed_at, created_at
+) RETURNING id, username, email, hashed_password, password_changed_at, created_at, email_verified_at, verification_sent_at
 `
 
 type CreateUserParams struct {
@@ -36,12 +69,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, email, hashed_password, password_changed_at, created_at FROM users
+SELECT id, username, email, hashed_password, password_changed_at, created_at, email_verified_at, verification_sent_at FROM users
 WHERE
     id = $1 OR
     email = $2
@@ -63,6 +98,8 @@ func (q *Queries) GetUser(ctx context.Context, arg GetUserParams) (User, error)
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
 	)
 	return i, err
 }
@@ -75,7 +112,7 @@ SET
     password_changed_at = COALESCE($5, password_changed_at),
     email= COALESCE($6, email)           
 WHERE id = $1 OR email = $2
-RETURNING id, username, email, hashed_password, password_changed_at, created_at
+RETURNING id, username, email, hashed_password, password_changed_at, created_at, email_verified_at, verification_sent_at
 `
 
 type UpdateUserParams struct {
@@ -104,6 +141,36 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
+	)
+	return i, err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :one
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, now())
+WHERE id = $1 AND email = $2
+RETURNING id, username, email, hashed_password, password_changed_at, created_at, email_verified_at, verification_sent_at
+`
+
+type VerifyUserEmailParams struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error) {
+	row := q.queryRow(ctx, q.verifyUserEmailStmt, verifyUserEmail, arg.ID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
 	)
 	return i, err
 }
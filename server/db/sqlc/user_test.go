@@ -90,3 +90,36 @@ func TestUpdateUser(t *testing.T) {
 	require.NotEqual(t, user1.HashedPassword, user2.HashedPassword)
 	require.WithinDuration(t, arg.PasswordChangedAt.Time, user2.PasswordChangedAt, time.Second)
 }
+
+func TestClaimVerificationEmail(t *testing.T) {
+	user := CreateRandomUser(t)
+	require.False(t, user.EmailVerifiedAt.Valid)
+
+	now := time.Now()
+	claimed, err := testQueries.ClaimVerificationEmail(context.Background(), ClaimVerificationEmailParams{
+		Now:         now,
+		ID:          user.ID,
+		ResendAfter: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, now, claimed.VerificationSentAt.Time, time.Second)
+
+	//a second email within the minute is throttled
+	_, err = testQueries.ClaimVerificationEmail(context.Background(), ClaimVerificationEmailParams{
+		Now:         now.Add(time.Second),
+		ID:          user.ID,
+		ResendAfter: now.Add(-time.Minute + time.Second),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	verified, err := testQueries.VerifyUserEmail(context.Background(), VerifyUserEmailParams{ID: user.ID, Email: user.Email})
+	require.NoError(t, err)
+	require.True(t, verified.EmailVerifiedAt.Valid)
+
+	_, err = testQueries.ClaimVerificationEmail(context.Background(), ClaimVerificationEmailParams{
+		Now:         now.Add(time.Hour),
+		ID:          user.ID,
+		ResendAfter: now.Add(time.Hour - time.Minute),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebauthnCredential(t *testing.T) {
+	user := CreateRandomUser(t)
+	credential, err := testQueries.CreateWebauthnCredential(context.Background(), CreateWebauthnCredentialParams{
+		UserID:       user.ID,
+		CredentialID: []byte(user.Email),
+		PublicKey:    []byte("key"),
+		SignCount:    3,
+		Name:         "laptop",
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateWebauthnCredential(context.Background(), CreateWebauthnCredentialParams{
+		UserID:       user.ID,
+		CredentialID: []byte(user.Email),
+		PublicKey:    []byte("key"),
+		Name:         "copy",
+	})
+	require.Equal(t, "unique_violation", err.(*pq.Error).Code.Name())
+
+	//the counter only moves forward
+	updated, err := testQueries.UpdateWebauthnSignCount(context.Background(), UpdateWebauthnSignCountParams{SignCount: 3, ID: credential.ID})
+	require.NoError(t, err)
+	require.Zero(t, updated)
+	updated, err = testQueries.UpdateWebauthnSignCount(context.Background(), UpdateWebauthnSignCountParams{SignCount: 4, ID: credential.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), updated)
+
+	found, err := testQueries.GetWebauthnCredentialByCredentialID(context.Background(), []byte(user.Email))
+	require.NoError(t, err)
+	require.Equal(t, int64(4), found.SignCount)
+	require.True(t, found.LastUsedAt.Valid)
+
+	count, err := testQueries.CountWebauthnCredentials(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	deleted, err := testQueries.DeleteWebauthnCredential(context.Background(), DeleteWebauthnCredentialParams{ID: credential.ID, UserID: user.ID + 1})
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+	deleted, err = testQueries.DeleteWebauthnCredential(context.Background(), DeleteWebauthnCredentialParams{ID: credential.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomWebhook(t *testing.T, user User, events []string) Webhook {
+	arg := CreateWebhookParams{
+		OwnerID: user.ID,
+		Url:     "http://localhost:9000/" + util.RandomString(6),
+		Events:  events,
+		Secret:  util.RandomString(32),
+	}
+	webhook, err := testQueries.CreateWebhook(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Url, webhook.Url)
+	require.Equal(t, events, webhook.Events)
+	require.True(t, webhook.Active)
+	return webhook
+}
+
+func TestGetWebhooksForEvent(t *testing.T) {
+	user := CreateRandomUser(t)
+	all := CreateRandomWebhook(t, user, []string{})
+	created := CreateRandomWebhook(t, user, []string{"task.created"})
+
+	webhooks, err := testQueries.GetWebhooksForEvent(context.Background(), GetWebhooksForEventParams{
+		OwnerID: user.ID,
+		Event:   "task.deleted",
+	})
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, all.ID, webhooks[0].ID)
+
+	webhooks, err = testQueries.GetWebhooksForEvent(context.Background(), GetWebhooksForEventParams{
+		OwnerID: user.ID,
+		Event:   "task.created",
+	})
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	require.Equal(t, created.ID, webhooks[1].ID)
+}
+
+func TestDeliverWebhookTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	webhook := CreateRandomWebhook(t, user, []string{})
+	store := NewStore(testDB)
+	now := time.Now()
+
+	delivery, err := testQueries.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		WebhookID:     webhook.ID,
+		Event:         "task.created",
+		Payload:       json.RawMessage(`{"event":"task.created"}`),
+		NextAttemptAt: sql.NullTime{Time: now.Add(-time.Second), Valid: true},
+	})
+	require.NoError(t, err)
+
+	//the first attempt fails and is retried later
+	result, err := store.DeliverWebhookTx(context.Background(), DeliverWebhookTxParams{
+		Now: now,
+		Deliver: func(claimed ClaimDueWebhookDeliveryRow) (int, error) {
+			return 500, sql.ErrConnDone
+		},
+		RetryAt: func(attempts int32) (time.Time, bool) {
+			return now.Add(time.Hour), true
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	require.Equal(t, delivery.ID, result.Delivery.ID)
+
+	failed, err := testQueries.GetWebhookDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, int32(500), failed.ResponseStatus.Int32)
+	require.NotEmpty(t, failed.LastError)
+	require.False(t, failed.DeliveredAt.Valid)
+	require.WithinDuration(t, now.Add(time.Hour), failed.NextAttemptAt.Time, time.Second)
+
+	result, err = store.DeliverWebhookTx(context.Background(), DeliverWebhookTxParams{
+		Now: now.Add(2 * time.Hour),
+		Deliver: func(claimed ClaimDueWebhookDeliveryRow) (int, error) {
+			require.Equal(t, webhook.Secret, claimed.Secret)
+			return 200, nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Found)
+
+	delivered, err := testQueries.GetWebhookDelivery(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), delivered.Attempts)
+	require.True(t, delivered.DeliveredAt.Valid)
+	require.False(t, delivered.NextAttemptAt.Valid)
+}
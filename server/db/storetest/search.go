@@ -0,0 +1,132 @@
+// Package storetest holds tests every db.Store has to pass, they are run against Postgres
+// in db/sqlc and against the in-memory store in db/memory
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomUser(t *testing.T, store db.Store) db.User {
+	user, err := store.CreateUser(context.Background(), db.CreateUserParams{
+		Username:       util.RandomString(6),
+		HashedPassword: util.RandomString(20),
+		Email:          util.RandomString(8) + "@email.com",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+// SearchTasks runs task filters through service/task, so both stores are checked with the
+// filters the query language compiles to
+func SearchTasks(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	user := createRandomUser(t, store)
+	other := createRandomUser(t, store)
+	tasks := task.Task{Store: store}
+
+	result, err := store.CreateProjectTx(ctx, db.CreateProjectTxParams{
+		CreateProjectParams: db.CreateProjectParams{Name: "Website", OwnerID: user.ID},
+		Statuses: []db.CreateProjectStatusParams{
+			{Name: "To Do", Category: "todo"},
+			{Name: "In Progress", Category: "in_progress", Position: 1},
+			{Name: "Done", Category: "done", Position: 2},
+		},
+	})
+	require.NoError(t, err)
+	field, err := store.CreateCustomField(ctx, db.CreateCustomFieldParams{
+		ProjectID: result.Project.ID,
+		Name:      "points",
+		FieldType: "number",
+		Options:   json.RawMessage(`[]`),
+		Rules:     json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	create := func(arg db.CreateTaskParams, labels ...string) db.Task {
+		arg.OwnerID = user.ID
+		created, err := tasks.CreateTask(ctx, arg)
+		require.NoError(t, err)
+		if len(labels) > 0 {
+			_, err = tasks.SetLabels(ctx, created.ID, user.ID, labels)
+			require.NoError(t, err)
+		}
+		return *created
+	}
+	login := create(db.CreateTaskParams{Body: "Fix login 100%", Priority: task.PriorityHigh, DueAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}}, "backend")
+	docs := create(db.CreateTaskParams{Body: "Write docs", DueAt: sql.NullTime{Time: now.Add(48 * time.Hour), Valid: true}})
+	page := create(db.CreateTaskParams{Body: "Landing page", ProjectID: sql.NullInt64{Int64: result.Project.ID, Valid: true}, StatusID: sql.NullInt64{Int64: result.Statuses[1].ID, Valid: true}}, "frontend")
+	done := create(db.CreateTaskParams{Body: "Old page", ProjectID: sql.NullInt64{Int64: result.Project.ID, Valid: true}, StatusID: sql.NullInt64{Int64: result.Statuses[2].ID, Valid: true}})
+	_, err = store.SetCustomFieldValuesTx(ctx, db.SetCustomFieldValuesTxParams{TaskID: page.ID, Values: map[int64]json.RawMessage{field.ID: json.RawMessage(`5`)}})
+	require.NoError(t, err)
+	_, err = store.SetCustomFieldValuesTx(ctx, db.SetCustomFieldValuesTxParams{TaskID: done.ID, Values: map[int64]json.RawMessage{field.ID: json.RawMessage(`2`)}})
+	require.NoError(t, err)
+	_, err = store.CreateTask(ctx, db.CreateTaskParams{Body: "Fix login", OwnerID: other.ID})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		filter string
+		sort   string
+		tasks  []db.Task
+	}{
+		{"", "", []db.Task{login, docs, page, done}},
+		{"not done", "-id", []db.Task{page, docs, login}},
+		{"overdue", "", []db.Task{login}},
+		{"due < +7d and priority >= high and label:backend", "", []db.Task{login}},
+		{"due = none", "", []db.Task{page, done}},
+		{"due != none", "due", []db.Task{login, docs}},
+		{`body ~ "100%"`, "", []db.Task{login}},
+		{"body ~ PAGE", "", []db.Task{page, done}},
+		{`status = "in progress"`, "", []db.Task{page}},
+		{`status != "In Progress"`, "", []db.Task{login, docs, done}},
+		{"project = Website", "", []db.Task{page, done}},
+		{"label = none", "", []db.Task{docs, done}},
+		{"label:frontend or label:backend", "", []db.Task{login, page}},
+		{"cf." + strconv.FormatInt(field.ID, 10) + " >= 3", "", []db.Task{page}},
+		{"cf." + strconv.FormatInt(field.ID, 10) + " = none", "", []db.Task{login, docs}},
+		{"", "cf." + strconv.FormatInt(field.ID, 10), []db.Task{done, page, login, docs}},
+		{"", "-cf." + strconv.FormatInt(field.ID, 10), []db.Task{page, done, login, docs}},
+		{"", "-priority", []db.Task{login, docs, page, done}},
+		//a comparison with a missing value is neither true nor false, so not of it does not match either
+		{"not due < +1d", "", []db.Task{docs}},
+		{"not project = Website", "", []db.Task{}},
+		{"project != Website", "", []db.Task{login, docs}},
+		{"cf." + strconv.FormatInt(field.ID, 10) + " != 5", "", []db.Task{login, docs, done}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.filter+" "+tc.sort, func(t *testing.T) {
+			list, err := tasks.FilterTaskList(ctx, task.ListParams{
+				OwnerID: user.ID,
+				Filter:  tc.filter,
+				Sort:    tc.sort,
+				View:    task.ViewAll,
+				Limit:   10,
+				PageID:  1,
+			})
+			require.NoError(t, err)
+			require.Equal(t, ids(tc.tasks), ids(list))
+		})
+	}
+
+	list, err := tasks.FilterTaskList(ctx, task.ListParams{OwnerID: user.ID, Sort: "-id", View: task.ViewAll, Limit: 2, PageID: 2})
+	require.NoError(t, err)
+	require.Equal(t, ids([]db.Task{docs, login}), ids(list))
+}
+
+func ids(tasks []db.Task) []int64 {
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
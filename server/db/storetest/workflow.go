@@ -0,0 +1,116 @@
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/stretchr/testify/require"
+)
+
+// Workflow moves tasks through service/task and changes the columns of a project through
+// service/project, the transactions of the store check the transitions and keep is_done
+// in line with the category of a task's status
+func Workflow(t *testing.T, store db.Store) {
+	ctx := context.Background()
+	user := createRandomUser(t, store)
+	projects := project.Project{Store: store}
+	tasks := task.Task{Store: store}
+
+	result, err := projects.CreateProject(ctx, db.CreateProjectParams{Name: "Website", OwnerID: user.ID})
+	require.NoError(t, err)
+	projectId := result.Project.ID
+	todo, doing, review, done := result.Statuses[0], result.Statuses[1], result.Statuses[2], result.Statuses[3]
+	_, err = projects.ReplaceTransitions(ctx, user.ID, projectId, []db.CreateStatusTransitionParams{
+		{FromStatusID: todo.ID, ToStatusID: doing.ID},
+		{FromStatusID: doing.ID, ToStatusID: review.ID},
+		{FromStatusID: review.ID, ToStatusID: done.ID},
+	})
+	require.NoError(t, err)
+
+	create := func(status db.ProjectStatus) *db.Task {
+		created, err := tasks.CreateTask(ctx, db.CreateTaskParams{
+			Body:      "task",
+			OwnerID:   user.ID,
+			ProjectID: sql.NullInt64{Int64: projectId, Valid: true},
+			StatusID:  sql.NullInt64{Int64: status.ID, Valid: true},
+		})
+		require.NoError(t, err)
+		return created
+	}
+	move := func(task *db.Task, status db.ProjectStatus) (*db.Task, error) {
+		return tasks.UpdateTask(ctx, db.UpdateTaskParams{
+			ID:       task.ID,
+			OwnerID:  user.ID,
+			Body:     task.Body,
+			IsDone:   task.IsDone,
+			StatusID: sql.NullInt64{Int64: status.ID, Valid: true},
+		})
+	}
+	get := func(task *db.Task) db.Task {
+		got, err := store.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("Transitions", func(t *testing.T) {
+		first := create(todo)
+		_, err := move(first, review)
+		require.ErrorIs(t, err, task.ErrTransitionNotAllowed)
+		require.Equal(t, todo.ID, get(first).StatusID.Int64)
+
+		first, err = move(first, doing)
+		require.NoError(t, err)
+		require.Equal(t, doing.ID, first.StatusID.Int64)
+
+		//done has no outgoing transition, a task may leave it for any column
+		last := create(done)
+		require.True(t, last.IsDone)
+		last, err = move(last, todo)
+		require.NoError(t, err)
+		require.False(t, last.IsDone)
+
+		//once the workflow is replaced the move that was denied goes through
+		_, err = projects.ReplaceTransitions(ctx, user.ID, projectId, []db.CreateStatusTransitionParams{
+			{FromStatusID: todo.ID, ToStatusID: review.ID},
+		})
+		require.NoError(t, err)
+		last, err = move(last, review)
+		require.NoError(t, err)
+		require.Equal(t, review.ID, last.StatusID.Int64)
+	})
+
+	t.Run("CategoryChange", func(t *testing.T) {
+		inReview := []*db.Task{create(review), create(review)}
+		other := create(todo)
+
+		update := func(category string) {
+			_, err := projects.UpdateStatus(ctx, user.ID, projectId, db.UpdateProjectStatusParams{
+				ID:       review.ID,
+				Name:     review.Name,
+				Category: category,
+				Position: review.Position,
+				WipLimit: review.WipLimit,
+			})
+			require.NoError(t, err)
+		}
+
+		update(project.CategoryDone)
+		for _, reviewed := range inReview {
+			got := get(reviewed)
+			require.True(t, got.IsDone)
+			require.True(t, got.CompletedAt.Valid)
+		}
+		require.False(t, get(other).IsDone)
+
+		update(project.CategoryInProgress)
+		for _, reviewed := range inReview {
+			got := get(reviewed)
+			require.False(t, got.IsDone)
+			require.False(t, got.CompletedAt.Valid)
+		}
+	})
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,16 +14,74 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/punkzberryz/todo/api"
 	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/archive"
+	"github.com/punkzberryz/todo/service/digest"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/punkzberryz/todo/service/inbox"
+	"github.com/punkzberryz/todo/service/mail"
+	"github.com/punkzberryz/todo/service/reminder"
+	"github.com/punkzberryz/todo/service/token"
+	"github.com/punkzberryz/todo/service/webhook"
 	"github.com/punkzberryz/todo/session"
 	"github.com/punkzberryz/todo/util"
 )
 
 func main() {
-	config, err := util.LoadConfig(".")
-	if err != nil {
-		log.Fatal("cannot load config:", err)
+	dev := flag.Bool("dev", false, "keep data in memory and log emails, no database, redis or smtp account needed")
+	flag.Parse()
+
+	var config util.Config
+	var server *api.Server
+	var store db.Store
+	var mailSender mail.EmailSender
+	var err error
+	if *dev {
+		config = util.DevConfig()
+		server, store, err = api.NewDevServer(config)
+		if err != nil {
+			log.Fatal("cannot create server:", err)
+		}
+		mailSender = mail.NewLogSender()
+		log.Println("dev mode: data is lost on exit, emails are written to the log")
+	} else {
+		config, err = util.LoadConfig(".")
+		if err != nil {
+			log.Fatal("cannot load config:", err)
+		}
+		mailSender = mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+		server, store = newServer(config, mailSender)
+	}
+
+	reminderWorker := reminder.NewWorker(store, mailSender, config.ReminderInterval)
+	go reminderWorker.Run(context.Background())
+	digestWorker := digest.NewWorker(store, mailSender, digest.UnsubscribeKey(config.TokenSymmetricKey), config.PublicURL, digest.DefaultInterval)
+	go digestWorker.Run(context.Background())
+	webhookWorker := webhook.NewWorker(store, webhook.DefaultInterval)
+	webhookWorker.Client = webhook.NewClient(config.AllowPrivateWebhooks)
+	go webhookWorker.Run(context.Background())
+	archiveWorker := archive.NewWorker(store, archive.DefaultInterval)
+	go archiveWorker.Run(context.Background())
+	if keyring := server.Keyring(); keyring != nil {
+		rotationWorker := token.NewRotationWorker(keyring, token.DefaultRotationCheckInterval)
+		go rotationWorker.Run(context.Background())
+	}
+	if config.InboxSMTPAddress != "" {
+		smtpServer := inbox.NewSMTPServer(config.InboxSMTPAddress, config.InboxDomain, server.CreateInboxTask)
+		go func() {
+			log.Printf("start email-to-task server at: %s", config.InboxSMTPAddress)
+			if err := smtpServer.ListenAndServe(); err != nil {
+				log.Println("email-to-task server stopped:", err)
+			}
+		}()
 	}
 
+	addr := fmt.Sprintf(":%s", config.ServerPort)
+	log.Printf("start listening to: http://%s", config.ServerAddress)
+	http.ListenAndServe(addr, server.Router)
+}
+
+// newServer connects to Postgres, and Redis unless sessions are kept in Postgres
+func newServer(config util.Config, mailSender mail.EmailSender) (*api.Server, db.Store) {
 	dbConn, err := sql.Open(config.DBDriver, config.DBSource)
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
@@ -30,19 +90,39 @@ func main() {
 	runDBMigration(config.MigrationURL, config.DBSource)
 
 	store := db.NewStore(dbConn)
-	sessionConn, err := session.NewSession(config.RedisAddress)
-	if err != nil {
-		log.Fatal("cannot connect to redis client:", err)
+	var sessionConn session.Store
+	var challengeStore session.ChallengeStore
+	var eventBroker event.Broker
+	switch config.SessionStore {
+	case util.SessionStorePostgres:
+		sessionConn = session.NewPostgresStore(store)
+		challengeStore = session.NewPostgresChallengeStore(store)
+		//without redis events only reach the streams of this server
+		eventBroker = event.NewMemoryBroker()
+		cleanupWorker := session.NewCleanupWorker(store, session.DefaultCleanupInterval)
+		go cleanupWorker.Run(context.Background())
+	default:
+		sessionConn, err = session.NewSession(config.RedisAddress)
+		if err != nil {
+			log.Fatal("cannot connect to redis client:", err)
+		}
+
+		challengeStore, err = session.NewChallengeStore(config.RedisAddress)
+		if err != nil {
+			log.Fatal("cannot connect to redis client:", err)
+		}
+
+		eventBroker, err = event.NewRedisBroker(config.RedisAddress)
+		if err != nil {
+			log.Fatal("cannot connect to redis client:", err)
+		}
 	}
 
-	server, err := api.NewServer(config, &store, &sessionConn)
+	server, err := api.NewServer(config, &store, &sessionConn, challengeStore, eventBroker, mailSender)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
-
-	addr := fmt.Sprintf(":%s", config.ServerPort)
-	log.Printf("start listening to: http://%s", config.ServerAddress)
-	http.ListenAndServe(addr, server.Router)
+	return server, store
 }
 
 func runDBMigration(migrationURL string, dbSource string) {
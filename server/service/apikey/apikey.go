@@ -0,0 +1,196 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// Scopes an api key can have
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	// admin is every scope, including the account settings under /me and webhooks
+	ScopeAdmin = "admin"
+)
+
+const (
+	// every key starts with it, so they can be told apart from login tokens and found by secret scanners
+	KeyPrefix          = "todo_"
+	DefaultExpiresIn   = 30 * 24 * time.Hour
+	MaxExpiresIn       = 365 * 24 * time.Hour
+	MaxNameLength      = 64
+	displayPrefixChars = 8
+	// last used is written at most this often
+	touchInterval = time.Minute
+)
+
+var (
+	ErrInvalidScope   = fmt.Errorf("scopes must be %s, %s or %s", ScopeTasksRead, ScopeTasksWrite, ScopeAdmin)
+	ErrInvalidName    = fmt.Errorf("name must be 1 to %d characters", MaxNameLength)
+	ErrInvalidExpiry  = fmt.Errorf("expiry must be in the future and at most a year away")
+	ErrInvalidApiKey  = fmt.Errorf("api key is invalid")
+	ErrExpiredApiKey  = fmt.Errorf("api key has expired")
+	ErrApiKeyNotFound = fmt.Errorf("api key not found")
+)
+
+type ApiKey struct {
+	Store db.Store
+}
+
+// HasScope tells whether scopes allow scope, admin allows everything and tasks:write allows reading
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope || s == ScopeAdmin || (s == ScopeTasksWrite && scope == ScopeTasksRead) {
+			return true
+		}
+	}
+	return false
+}
+
+func isScope(scope string) bool {
+	return scope == ScopeTasksRead || scope == ScopeTasksWrite || scope == ScopeAdmin
+}
+
+func hashKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+type CreateApiKeyParams struct {
+	Name   string
+	Scopes []string
+	// ExpiresAt is DefaultExpiresIn from now when nil
+	ExpiresAt *time.Time
+}
+
+// CreateApiKey makes a new key for a user, the key is returned only here and only its hash is kept
+func (a *ApiKey) CreateApiKey(ctx context.Context, userId int64, arg CreateApiKeyParams) (string, *db.ApiKey, error) {
+	name := strings.TrimSpace(arg.Name)
+	if name == "" || len(name) > MaxNameLength {
+		return "", nil, ErrInvalidName
+	}
+	if len(arg.Scopes) == 0 {
+		return "", nil, ErrInvalidScope
+	}
+	scopes := []string{}
+	for _, scope := range arg.Scopes {
+		if !isScope(scope) {
+			return "", nil, ErrInvalidScope
+		}
+		if !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	now := time.Now()
+	expiresAt := now.Add(DefaultExpiresIn)
+	if arg.ExpiresAt != nil {
+		expiresAt = *arg.ExpiresAt
+		if !expiresAt.After(now) || expiresAt.After(now.Add(MaxExpiresIn)) {
+			return "", nil, ErrInvalidExpiry
+		}
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+	key := KeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+	apiKey, err := a.Store.CreateApiKey(ctx, db.CreateApiKeyParams{
+		UserID:    userId,
+		Name:      name,
+		Prefix:    key[:len(KeyPrefix)+displayPrefixChars],
+		KeyHash:   hashKey(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return key, &apiKey, nil
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ApiKey) GetApiKeyList(ctx context.Context, userId int64) ([]db.ApiKey, error) {
+	return a.Store.ListApiKeys(ctx, userId)
+}
+
+// DeleteApiKey revokes a key, requests with it fail from then on
+func (a *ApiKey) DeleteApiKey(ctx context.Context, userId int64, id int64) error {
+	deleted, err := a.Store.DeleteApiKey(ctx, db.DeleteApiKeyParams{
+		ID:     id,
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrApiKeyNotFound
+	}
+	return nil
+}
+
+// CheckApiKey fails with ErrInvalidApiKey once a key is deleted and with ErrExpiredApiKey
+// once it expired, for connections that outlive the request they were authenticated with
+func (a *ApiKey) CheckApiKey(ctx context.Context, userId int64, id int64) error {
+	keys, err := a.Store.ListApiKeys(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, apiKey := range keys {
+		if apiKey.ID != id {
+			continue
+		}
+		if time.Now().After(apiKey.ExpiresAt) {
+			return ErrExpiredApiKey
+		}
+		return nil
+	}
+	return ErrInvalidApiKey
+}
+
+// Authenticate returns the key and its user for a key from a request and records that it was used
+func (a *ApiKey) Authenticate(ctx context.Context, key string) (*db.ApiKey, *db.User, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return nil, nil, ErrInvalidApiKey
+	}
+	apiKey, err := a.Store.GetApiKeyByHash(ctx, hashKey(key))
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrInvalidApiKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if now.After(apiKey.ExpiresAt) {
+		return nil, nil, ErrExpiredApiKey
+	}
+	err = a.Store.TouchApiKey(ctx, db.TouchApiKeyParams{
+		Now:        now,
+		ID:         apiKey.ID,
+		UsedBefore: now.Add(-touchInterval),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := a.Store.GetUser(ctx, db.GetUserParams{ID: apiKey.UserID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &apiKey, &user, nil
+}
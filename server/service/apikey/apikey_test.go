@@ -0,0 +1,97 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHasScope(t *testing.T) {
+	require.True(t, HasScope([]string{ScopeTasksRead}, ScopeTasksRead))
+	require.False(t, HasScope([]string{ScopeTasksRead}, ScopeTasksWrite))
+	require.True(t, HasScope([]string{ScopeTasksWrite}, ScopeTasksRead))
+	require.False(t, HasScope([]string{ScopeTasksWrite}, ScopeAdmin))
+	require.True(t, HasScope([]string{ScopeAdmin}, ScopeTasksWrite))
+	require.False(t, HasScope(nil, ScopeTasksRead))
+}
+
+func TestCreateApiKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := ApiKey{Store: store}
+
+	var stored db.CreateApiKeyParams
+	store.EXPECT().
+		CreateApiKey(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+			stored = arg
+			return db.ApiKey{ID: 1, UserID: arg.UserID, Scopes: arg.Scopes, KeyHash: arg.KeyHash}, nil
+		})
+	key, apiKey, err := a.CreateApiKey(context.Background(), 7, CreateApiKeyParams{
+		Name:   " ci ",
+		Scopes: []string{ScopeTasksRead, ScopeTasksRead},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, KeyPrefix))
+	require.True(t, strings.HasPrefix(key, stored.Prefix))
+	require.Equal(t, "ci", stored.Name)
+	require.Equal(t, []string{ScopeTasksRead}, apiKey.Scopes)
+	require.Equal(t, hashKey(key), stored.KeyHash)
+	require.WithinDuration(t, time.Now().Add(DefaultExpiresIn), stored.ExpiresAt, time.Second)
+
+	_, _, err = a.CreateApiKey(context.Background(), 7, CreateApiKeyParams{Name: "ci", Scopes: []string{"tasks:delete"}})
+	require.ErrorIs(t, err, ErrInvalidScope)
+	_, _, err = a.CreateApiKey(context.Background(), 7, CreateApiKeyParams{Name: "ci"})
+	require.ErrorIs(t, err, ErrInvalidScope)
+	_, _, err = a.CreateApiKey(context.Background(), 7, CreateApiKeyParams{Scopes: []string{ScopeAdmin}})
+	require.ErrorIs(t, err, ErrInvalidName)
+	past := time.Now().Add(-time.Minute)
+	_, _, err = a.CreateApiKey(context.Background(), 7, CreateApiKeyParams{Name: "ci", Scopes: []string{ScopeAdmin}, ExpiresAt: &past})
+	require.ErrorIs(t, err, ErrInvalidExpiry)
+	far := time.Now().Add(MaxExpiresIn + time.Hour)
+	_, _, err = a.CreateApiKey(context.Background(), 7, CreateApiKeyParams{Name: "ci", Scopes: []string{ScopeAdmin}, ExpiresAt: &far})
+	require.ErrorIs(t, err, ErrInvalidExpiry)
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := ApiKey{Store: store}
+	key := KeyPrefix + "abc"
+
+	store.EXPECT().
+		GetApiKeyByHash(gomock.Any(), hashKey(key)).
+		Return(db.ApiKey{ID: 1, UserID: 7, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	store.EXPECT().
+		TouchApiKey(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.TouchApiKeyParams) error {
+			require.Equal(t, int64(1), arg.ID)
+			require.Equal(t, touchInterval, arg.Now.Sub(arg.UsedBefore))
+			return nil
+		})
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(db.User{ID: 7}, nil)
+	apiKey, user, err := a.Authenticate(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), apiKey.ID)
+	require.Equal(t, int64(7), user.ID)
+
+	store.EXPECT().
+		GetApiKeyByHash(gomock.Any(), hashKey(key)).
+		Return(db.ApiKey{ID: 1, UserID: 7, ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+	_, _, err = a.Authenticate(context.Background(), key)
+	require.ErrorIs(t, err, ErrExpiredApiKey)
+
+	store.EXPECT().GetApiKeyByHash(gomock.Any(), gomock.Any()).Return(db.ApiKey{}, sql.ErrNoRows)
+	_, _, err = a.Authenticate(context.Background(), KeyPrefix+"unknown")
+	require.ErrorIs(t, err, ErrInvalidApiKey)
+
+	_, _, err = a.Authenticate(context.Background(), "v2.local.token")
+	require.ErrorIs(t, err, ErrInvalidApiKey)
+}
@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+)
+
+// longest archive rule, about ten years
+const MaxAfterDays = 3650
+
+var (
+	ErrInvalidAfterDays = fmt.Errorf("afterDays must be between 1 and %d", MaxAfterDays)
+	ErrTaskNotDone      = fmt.Errorf("only done tasks can be archived")
+)
+
+type Archive struct {
+	Store db.Store
+	Task  task.Task
+}
+
+// Get the archive rule of a user, it is nil when done tasks are never archived automatically
+func (a *Archive) GetRule(ctx context.Context, userId int64) (*db.ArchiveRule, error) {
+	rule, err := a.Store.GetArchiveRule(ctx, userId)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Update the archive rule of a user, done tasks are archived afterDays days after
+// they were completed, a nil afterDays turns automatic archiving off
+func (a *Archive) UpdateRule(ctx context.Context, userId int64, afterDays *int32) (*db.ArchiveRule, error) {
+	if afterDays == nil {
+		return nil, a.Store.DeleteArchiveRule(ctx, userId)
+	}
+	if *afterDays < 1 || *afterDays > MaxAfterDays {
+		return nil, ErrInvalidAfterDays
+	}
+	rule, err := a.Store.UpsertArchiveRule(ctx, db.UpsertArchiveRuleParams{
+		UserID:    userId,
+		AfterDays: *afterDays,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Get archived tasks of a user, the most recently archived first
+func (a *Archive) GetArchivedTaskList(ctx context.Context, ownerId int64, limit int32, pageId int32) ([]db.Task, error) {
+	return a.Store.GetArchivedTaskList(ctx, db.GetArchivedTaskListParams{
+		OwnerID: ownerId,
+		Limit:   limit,
+		Offset:  (pageId - 1) * limit,
+	})
+}
+
+// Archive a done task right away
+func (a *Archive) ArchiveTask(ctx context.Context, id int64, ownerId int64) (*db.Task, error) {
+	current, err := a.Task.GetTaskById(ctx, id, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	if !current.IsDone {
+		return nil, ErrTaskNotDone
+	}
+	archived, err := a.Store.ArchiveTask(ctx, db.ArchiveTaskParams{
+		ID:      id,
+		OwnerID: ownerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &archived, nil
+}
+
+// Unarchive moves a task back to the task list, reopening a task unarchives it as well
+func (a *Archive) UnarchiveTask(ctx context.Context, id int64, ownerId int64) (*db.Task, error) {
+	if _, err := a.Task.GetTaskById(ctx, id, ownerId); err != nil {
+		return nil, err
+	}
+	unarchived, err := a.Store.UnarchiveTask(ctx, db.UnarchiveTaskParams{
+		ID:      id,
+		OwnerID: ownerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &unarchived, nil
+}
@@ -0,0 +1,65 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Archive{Store: store, Task: task.Task{Store: store}}
+
+	for _, days := range []int32{0, -3, MaxAfterDays + 1} {
+		_, err := a.UpdateRule(context.Background(), 1, &days)
+		require.ErrorIs(t, err, ErrInvalidAfterDays)
+	}
+
+	days := int32(14)
+	store.EXPECT().
+		UpsertArchiveRule(gomock.Any(), db.UpsertArchiveRuleParams{UserID: 1, AfterDays: 14}).
+		Return(db.ArchiveRule{UserID: 1, AfterDays: 14}, nil)
+	rule, err := a.UpdateRule(context.Background(), 1, &days)
+	require.NoError(t, err)
+	require.Equal(t, int32(14), rule.AfterDays)
+
+	//no days turns the rule off
+	store.EXPECT().DeleteArchiveRule(gomock.Any(), int64(1)).Return(nil)
+	rule, err = a.UpdateRule(context.Background(), 1, nil)
+	require.NoError(t, err)
+	require.Nil(t, rule)
+
+	store.EXPECT().GetArchiveRule(gomock.Any(), int64(1)).Return(db.ArchiveRule{}, sql.ErrNoRows)
+	rule, err = a.GetRule(context.Background(), 1)
+	require.NoError(t, err)
+	require.Nil(t, rule)
+}
+
+func TestArchiveTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Archive{Store: store, Task: task.Task{Store: store}}
+
+	store.EXPECT().GetTask(gomock.Any(), int64(5)).Return(db.Task{ID: 5, OwnerID: 2, IsDone: true}, nil)
+	_, err := a.ArchiveTask(context.Background(), 5, 1)
+	require.ErrorIs(t, err, task.ErrOwnerNotMatched)
+
+	store.EXPECT().GetTask(gomock.Any(), int64(6)).Return(db.Task{ID: 6, OwnerID: 1}, nil)
+	_, err = a.ArchiveTask(context.Background(), 6, 1)
+	require.ErrorIs(t, err, ErrTaskNotDone)
+
+	store.EXPECT().GetTask(gomock.Any(), int64(7)).Return(db.Task{ID: 7, OwnerID: 1, IsDone: true}, nil)
+	store.EXPECT().
+		ArchiveTask(gomock.Any(), db.ArchiveTaskParams{ID: 7, OwnerID: 1}).
+		Return(db.Task{ID: 7, OwnerID: 1, IsDone: true, ArchivedAt: sql.NullTime{Valid: true}}, nil)
+	archived, err := a.ArchiveTask(context.Background(), 7, 1)
+	require.NoError(t, err)
+	require.True(t, archived.ArchivedAt.Valid)
+}
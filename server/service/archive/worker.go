@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	DefaultInterval = 10 * time.Minute
+	// tasks archived per statement, so a large backlog doesn't lock many rows at once
+	DefaultBatchSize = 500
+)
+
+// Worker archives done tasks by the archive rules of their owners.
+// Every API server runs one, tasks are claimed with row locks
+// so servers don't wait on each other.
+type Worker struct {
+	Store db.Store
+	// how often tasks are looked for
+	Interval  time.Duration
+	BatchSize int32
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewWorker(store db.Store, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Worker{
+		Store:     store,
+		Interval:  interval,
+		BatchSize: DefaultBatchSize,
+		now:       time.Now,
+	}
+}
+
+// Run archives tasks every Interval until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.ArchiveDue(ctx); err != nil && ctx.Err() == nil {
+			log.Println("cannot archive tasks:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveDue archives every done task that is past its owner's rule
+// and returns how many were archived
+func (w *Worker) ArchiveDue(ctx context.Context) (int64, error) {
+	var archived int64
+	now := w.now()
+	for ctx.Err() == nil {
+		n, err := w.Store.ArchiveDoneTasks(ctx, db.ArchiveDoneTasksParams{
+			Now:       now,
+			BatchSize: w.BatchSize,
+		})
+		if err != nil {
+			return archived, err
+		}
+		archived += n
+		if n < int64(w.BatchSize) {
+			return archived, nil
+		}
+	}
+	return archived, ctx.Err()
+}
@@ -0,0 +1,48 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArchiveDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	worker := NewWorker(store, time.Minute)
+	worker.BatchSize = 2
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	//batches are archived until one is not full
+	arg := db.ArchiveDoneTasksParams{Now: now, BatchSize: 2}
+	gomock.InOrder(
+		store.EXPECT().ArchiveDoneTasks(gomock.Any(), arg).Return(int64(2), nil),
+		store.EXPECT().ArchiveDoneTasks(gomock.Any(), arg).Return(int64(2), nil),
+		store.EXPECT().ArchiveDoneTasks(gomock.Any(), arg).Return(int64(1), nil),
+	)
+	archived, err := worker.ArchiveDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(5), archived)
+}
+
+func TestArchiveDueError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	worker := NewWorker(store, time.Minute)
+	worker.BatchSize = 2
+
+	gomock.InOrder(
+		store.EXPECT().ArchiveDoneTasks(gomock.Any(), gomock.Any()).Return(int64(2), nil),
+		store.EXPECT().ArchiveDoneTasks(gomock.Any(), gomock.Any()).Return(int64(0), fmt.Errorf("connection lost")),
+	)
+	archived, err := worker.ArchiveDue(context.Background())
+	require.Error(t, err)
+	require.Equal(t, int64(2), archived)
+}
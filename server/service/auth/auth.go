@@ -21,6 +21,17 @@ var (
 
 type Auth struct {
 	Store db.Store
+	// VerificationKey signs the links in verification emails
+	VerificationKey []byte
+	// ChallengeKey signs the challenges of two-step logins
+	ChallengeKey []byte
+	// Sessions ends the sessions of a user whose password was reset
+	Sessions SessionRevoker
+}
+
+// SessionRevoker logs a user out everywhere, token.Token is one
+type SessionRevoker interface {
+	RevokeUserTokens(ctx context.Context, userId int64) error
 }
 
 // Create user in database
@@ -142,11 +153,18 @@ func (a *Auth) UpdateUserPassword(ctx context.Context, arg *UpdateUserPasswordPa
 		return err
 	}
 
-	_, err = a.Store.UpdateUser(ctx, db.UpdateUserParams{
+	user, err := a.Store.UpdateUser(ctx, db.UpdateUserParams{
 		HashedPassword:    sql.NullString{String: hashedPassword, Valid: true},
 		PasswordChangedAt: sql.NullTime{Time: time.Now(), Valid: true},
 		Email:             session.Email,
 	})
+	if err != nil {
+		return err
+	}
+	//whoever knew the old password is logged out
+	if err := a.Sessions.RevokeUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
 
 	//run go-routine to remove session
 	go func(store db.Store, email string) {
@@ -155,5 +173,5 @@ func (a *Auth) UpdateUserPassword(ctx context.Context, arg *UpdateUserPasswordPa
 			return
 		}
 	}(a.Store, session.Email)
-	return err
+	return nil
 }
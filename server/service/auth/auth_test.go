@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type revokedUsers []int64
+
+func (r *revokedUsers) RevokeUserTokens(ctx context.Context, userId int64) error {
+	*r = append(*r, userId)
+	return nil
+}
+
+func TestUpdateUserPasswordRevokesSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	revoked := &revokedUsers{}
+	a := Auth{Store: store, Sessions: revoked}
+
+	store.EXPECT().
+		GetPasswordResetSession(gomock.Any(), "a@email.com").
+		Return(db.PasswordResetSession{Email: "a@email.com", Otp: "123456", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(db.User{ID: 7, Email: "a@email.com"}, nil)
+	store.EXPECT().DeletePasswordResetSession(gomock.Any(), "a@email.com").AnyTimes().Return(nil)
+
+	err := a.UpdateUserPassword(context.Background(), &UpdateUserPasswordParam{
+		Email:       "a@email.com",
+		Otp:         "123456",
+		NewPassword: "new password",
+	})
+	require.NoError(t, err)
+	require.Equal(t, revokedUsers{7}, *revoked)
+
+	store.EXPECT().
+		GetPasswordResetSession(gomock.Any(), "a@email.com").
+		Return(db.PasswordResetSession{Email: "a@email.com", Otp: "123456", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	err = a.UpdateUserPassword(context.Background(), &UpdateUserPasswordParam{
+		Email:       "a@email.com",
+		Otp:         "654321",
+		NewPassword: "new password",
+	})
+	require.ErrorIs(t, err, ErrOtpNotMatched)
+	require.Len(t, *revoked, 1)
+}
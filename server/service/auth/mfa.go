@@ -0,0 +1,321 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	// issuer shown in authenticator apps
+	TotpIssuer = "Todo"
+	// how long the second login step can be completed after the password was checked
+	MfaChallengeDuration = 5 * time.Minute
+	RecoveryCodeCount    = 10
+	// after this many wrong codes in a row codes are not checked for MfaLockDuration
+	MaxMfaAttempts  = 5
+	MfaLockDuration = 15 * time.Minute
+)
+
+var (
+	ErrMfaAlreadyEnabled   = fmt.Errorf("two-factor authentication is already enabled")
+	ErrMfaNotEnabled       = fmt.Errorf("two-factor authentication is not enabled")
+	ErrMfaNotStarted       = fmt.Errorf("start the two-factor setup first")
+	ErrInvalidMfaCode      = fmt.Errorf("invalid two-factor code")
+	ErrMfaLocked           = fmt.Errorf("too many invalid two-factor codes, try again later")
+	ErrInvalidMfaChallenge = fmt.Errorf("login challenge is invalid or has expired")
+)
+
+// recovery codes are 10 characters of this alphabet shown as xxxxx-xxxxx
+var recoveryEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// MfaChallengeKey derives the key that signs login challenges from the token secret
+func MfaChallengeKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("mfa-challenge"))
+	return mac.Sum(nil)
+}
+
+// MfaStatus tells which second factors a user has
+type MfaStatus struct {
+	TotpEnabled         bool  `json:"totpEnabled"`
+	RecoveryCodesLeft   int64 `json:"recoveryCodesLeft"`
+	WebauthnCredentials int64 `json:"webauthnCredentials"`
+}
+
+func (a *Auth) GetMfaStatus(ctx context.Context, userId int64) (*MfaStatus, error) {
+	status := &MfaStatus{}
+	totp, err := a.Store.GetUserTotp(ctx, userId)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	status.TotpEnabled = err == nil && totp.EnabledAt.Valid
+	if status.TotpEnabled {
+		status.RecoveryCodesLeft, err = a.Store.CountRecoveryCodes(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	status.WebauthnCredentials, err = a.Store.CountWebauthnCredentials(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// TotpEnrollment is the secret to add to an authenticator app, as text and as otpauth:// URI
+type TotpEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// EnrollTotp creates a new secret for a user, it is used once ConfirmTotp accepted a first code.
+// account names the user in the authenticator app
+func (a *Auth) EnrollTotp(ctx context.Context, userId int64, account string) (*TotpEnrollment, error) {
+	secret, err := NewTotpSecret()
+	if err != nil {
+		return nil, err
+	}
+	_, err = a.Store.UpsertPendingTotp(ctx, db.UpsertPendingTotpParams{
+		UserID: userId,
+		Secret: secret,
+	})
+	if err == sql.ErrNoRows {
+		return nil, ErrMfaAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &TotpEnrollment{
+		Secret: EncodeTotpSecret(secret),
+		URI:    TotpURI(TotpIssuer, account, secret),
+	}, nil
+}
+
+// ConfirmTotp turns on two-factor authentication when code matches the new secret
+// and returns recovery codes, they are only shown this once
+func (a *Auth) ConfirmTotp(ctx context.Context, userId int64, code string) ([]string, error) {
+	totp, err := a.Store.GetUserTotp(ctx, userId)
+	if err == sql.ErrNoRows {
+		return nil, ErrMfaNotStarted
+	}
+	if err != nil {
+		return nil, err
+	}
+	if totp.EnabledAt.Valid {
+		return nil, ErrMfaAlreadyEnabled
+	}
+	step, ok := ValidateTotp(totp.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMfaCode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	_, err = a.Store.EnableTotpTx(ctx, db.EnableTotpTxParams{
+		UserID:     userId,
+		Step:       step,
+		CodeHashes: hashes,
+	})
+	if err == sql.ErrNoRows {
+		return nil, ErrMfaAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTotp turns two-factor authentication off, code is a current or a recovery code
+func (a *Auth) DisableTotp(ctx context.Context, userId int64, code string) error {
+	if err := a.checkSecondFactor(ctx, userId, code); err != nil {
+		return err
+	}
+	return a.Store.DisableTotpTx(ctx, userId)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of a user, code is a current or a recovery code
+func (a *Auth) RegenerateRecoveryCodes(ctx context.Context, userId int64, code string) ([]string, error) {
+	if err := a.checkSecondFactor(ctx, userId, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = a.Store.ReplaceRecoveryCodesTx(ctx, db.ReplaceRecoveryCodesTxParams{
+		UserID:     userId,
+		CodeHashes: hashes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// second factors a user can log in with
+const (
+	MfaMethodTotp     = "totp"
+	MfaMethodWebauthn = "webauthn"
+)
+
+// MfaMethods lists the second factors a user has, none means the password is enough
+func (a *Auth) MfaMethods(ctx context.Context, userId int64) ([]string, error) {
+	methods := []string{}
+	totp, err := a.Store.GetUserTotp(ctx, userId)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if err == nil && totp.EnabledAt.Valid {
+		methods = append(methods, MfaMethodTotp)
+	}
+	credentials, err := a.Store.CountWebauthnCredentials(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if credentials > 0 {
+		methods = append(methods, MfaMethodWebauthn)
+	}
+	return methods, nil
+}
+
+// NewMfaChallenge is the token a user exchanges together with a code for access tokens,
+// it is "<user id>.<expiry unix time>.<signature>" and can't be used as an access token
+func (a *Auth) NewMfaChallenge(userId int64) (string, time.Time) {
+	expiresAt := time.Now().Add(MfaChallengeDuration)
+	id := strconv.FormatInt(userId, 10)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return id + "." + expires + "." + base64.RawURLEncoding.EncodeToString(challengeSignature(a.ChallengeKey, id, expires)), expiresAt
+}
+
+func challengeSignature(key []byte, id string, expires string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + expires))
+	return mac.Sum(nil)
+}
+
+// ParseMfaChallenge returns the user a challenge from NewMfaChallenge was made for
+func (a *Auth) ParseMfaChallenge(challenge string) (int64, error) {
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 3 {
+		return 0, ErrInvalidMfaChallenge
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, challengeSignature(a.ChallengeKey, parts[0], parts[1])) {
+		return 0, ErrInvalidMfaChallenge
+	}
+	userId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidMfaChallenge
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().After(time.Unix(expires, 0)) {
+		return 0, ErrInvalidMfaChallenge
+	}
+	return userId, nil
+}
+
+// CompleteMfaLogin checks the challenge from the first login step and the code of the second,
+// code is a current totp code or an unused recovery code
+func (a *Auth) CompleteMfaLogin(ctx context.Context, challenge string, code string) (*db.User, error) {
+	userId, err := a.ParseMfaChallenge(challenge)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkSecondFactor(ctx, userId, code); err != nil {
+		return nil, err
+	}
+	user, err := a.Store.GetUser(ctx, db.GetUserParams{ID: userId})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// checkSecondFactor accepts a totp code, each time step only once, or an unused recovery code.
+// Wrong codes count towards locking the second factor for a while
+func (a *Auth) checkSecondFactor(ctx context.Context, userId int64, code string) error {
+	totp, err := a.Store.GetUserTotp(ctx, userId)
+	if err == sql.ErrNoRows {
+		return ErrMfaNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !totp.EnabledAt.Valid {
+		return ErrMfaNotEnabled
+	}
+	if totp.LockedUntil.Valid && now.Before(totp.LockedUntil.Time) {
+		return ErrMfaLocked
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := ValidateTotp(totp.Secret, code, now); ok {
+		used, err := a.Store.UseTotpStep(ctx, db.UseTotpStepParams{
+			Step:   sql.NullInt64{Int64: step, Valid: true},
+			UserID: userId,
+		})
+		if err != nil {
+			return err
+		}
+		if used > 0 {
+			return nil
+		}
+	} else if len(code) != TotpDigits {
+		used, err := a.Store.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+			UserID:   userId,
+			CodeHash: hashRecoveryCode(code),
+		})
+		if err != nil {
+			return err
+		}
+		if used > 0 {
+			return nil
+		}
+	}
+
+	if _, err := a.Store.RecordTotpFailure(ctx, db.RecordTotpFailureParams{
+		MaxAttempts: MaxMfaAttempts,
+		LockedUntil: now.Add(MfaLockDuration),
+		UserID:      userId,
+	}); err != nil {
+		return err
+	}
+	return ErrInvalidMfaCode
+}
+
+// newRecoveryCodes returns RecoveryCodeCount codes and their hashes
+func newRecoveryCodes() ([]string, [][]byte, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([][]byte, RecoveryCodeCount)
+	for i := range codes {
+		random := make([]byte, 7)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		code := recoveryEncoding.EncodeToString(random)[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, dashes and spaces the user may type
+func hashRecoveryCode(code string) []byte {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	sum := sha256.Sum256([]byte(code))
+	return sum[:]
+}
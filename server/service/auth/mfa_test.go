@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func enabledTotp(secret []byte) db.UserTotp {
+	return db.UserTotp{
+		UserID:    7,
+		Secret:    secret,
+		EnabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+}
+
+// wrongTotpCode is a code no step around now accepts
+func wrongTotpCode(secret []byte) string {
+	for _, code := range []string{"000000", "111111", "222222"} {
+		if _, ok := ValidateTotp(secret, code, time.Now()); !ok {
+			return code
+		}
+	}
+	return "333333"
+}
+
+func TestConfirmTotp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Auth{Store: store}
+	secret, err := NewTotpSecret()
+	require.NoError(t, err)
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(db.UserTotp{UserID: 7, Secret: secret}, nil)
+	_, err = a.ConfirmTotp(context.Background(), 7, wrongTotpCode(secret))
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(db.UserTotp{UserID: 7, Secret: secret}, nil)
+	store.EXPECT().
+		EnableTotpTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.EnableTotpTxParams) (db.UserTotp, error) {
+			require.Equal(t, int64(7), arg.UserID)
+			require.Equal(t, totpStep(time.Now(), TotpPeriod), arg.Step)
+			require.Len(t, arg.CodeHashes, RecoveryCodeCount)
+			return enabledTotp(secret), nil
+		})
+	codes, err := a.ConfirmTotp(context.Background(), 7, TotpCode(secret, time.Now()))
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	for _, code := range codes {
+		require.Len(t, code, 11)
+		require.Equal(t, "-", code[5:6])
+	}
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(enabledTotp(secret), nil)
+	_, err = a.ConfirmTotp(context.Background(), 7, TotpCode(secret, time.Now()))
+	require.ErrorIs(t, err, ErrMfaAlreadyEnabled)
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(db.UserTotp{}, sql.ErrNoRows)
+	_, err = a.ConfirmTotp(context.Background(), 7, "123456")
+	require.ErrorIs(t, err, ErrMfaNotStarted)
+}
+
+func TestCompleteMfaLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Auth{Store: store, ChallengeKey: MfaChallengeKey("secret")}
+	secret, err := NewTotpSecret()
+	require.NoError(t, err)
+	challenge, expiresAt := a.NewMfaChallenge(7)
+	require.WithinDuration(t, time.Now().Add(MfaChallengeDuration), expiresAt, time.Second)
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(enabledTotp(secret), nil)
+	store.EXPECT().
+		UseTotpStep(gomock.Any(), db.UseTotpStepParams{
+			Step:   sql.NullInt64{Int64: totpStep(time.Now(), TotpPeriod), Valid: true},
+			UserID: 7,
+		}).
+		Return(int64(1), nil)
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(db.User{ID: 7}, nil)
+	user, err := a.CompleteMfaLogin(context.Background(), challenge, TotpCode(secret, time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), user.ID)
+
+	//the same code can't be replayed
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(enabledTotp(secret), nil)
+	store.EXPECT().UseTotpStep(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	store.EXPECT().RecordTotpFailure(gomock.Any(), gomock.Any()).Return(enabledTotp(secret), nil)
+	_, err = a.CompleteMfaLogin(context.Background(), challenge, TotpCode(secret, time.Now()))
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	//recovery codes are matched by hash, ignoring case and dashes
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(enabledTotp(secret), nil)
+	store.EXPECT().
+		UseRecoveryCode(gomock.Any(), db.UseRecoveryCodeParams{UserID: 7, CodeHash: hashRecoveryCode("abcde-fghij")}).
+		Return(int64(1), nil)
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(db.User{ID: 7}, nil)
+	_, err = a.CompleteMfaLogin(context.Background(), challenge, "ABCDEFGHIJ")
+	require.NoError(t, err)
+
+	locked := enabledTotp(secret)
+	locked.LockedUntil = sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true}
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(locked, nil)
+	_, err = a.CompleteMfaLogin(context.Background(), challenge, TotpCode(secret, time.Now()))
+	require.ErrorIs(t, err, ErrMfaLocked)
+
+	other := Auth{Store: store, ChallengeKey: MfaChallengeKey("other secret")}
+	forged, _ := other.NewMfaChallenge(7)
+	_, err = a.CompleteMfaLogin(context.Background(), forged, "123456")
+	require.ErrorIs(t, err, ErrInvalidMfaChallenge)
+
+	parts := strings.Split(challenge, ".")
+	_, err = a.CompleteMfaLogin(context.Background(), "8."+parts[1]+"."+parts[2], "123456")
+	require.ErrorIs(t, err, ErrInvalidMfaChallenge)
+}
+
+func TestMfaMethods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Auth{Store: store}
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(db.UserTotp{}, sql.ErrNoRows)
+	store.EXPECT().CountWebauthnCredentials(gomock.Any(), int64(7)).Return(int64(0), nil)
+	methods, err := a.MfaMethods(context.Background(), 7)
+	require.NoError(t, err)
+	require.Empty(t, methods)
+
+	//a pending secret is not a second factor yet
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(db.UserTotp{UserID: 7}, nil)
+	store.EXPECT().CountWebauthnCredentials(gomock.Any(), int64(7)).Return(int64(2), nil)
+	methods, err = a.MfaMethods(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, []string{MfaMethodWebauthn}, methods)
+
+	store.EXPECT().GetUserTotp(gomock.Any(), int64(7)).Return(enabledTotp(nil), nil)
+	store.EXPECT().CountWebauthnCredentials(gomock.Any(), int64(7)).Return(int64(1), nil)
+	methods, err = a.MfaMethods(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, []string{MfaMethodTotp, MfaMethodWebauthn}, methods)
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"time"
+)
+
+// TOTP as in RFC 6238 with the settings authenticator apps expect
+const (
+	TotpDigits = 6
+	TotpPeriod = 30 * time.Second
+	// codes of this many steps before and after now are accepted for clock drift
+	totpSkew = 1
+	// RFC 4226 asks for at least 128 bits, 160 matches the SHA-1 block
+	totpSecretSize = 20
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// hotp is the HMAC-based one-time password of RFC 4226 for counter
+func hotp(key []byte, counter uint64, digits int, h func() hash.Hash) string {
+	mac := hmac.New(h, key)
+	binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+
+	//dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, code%mod)
+}
+
+// totpStep is the number of periods since the unix epoch
+func totpStep(t time.Time, period time.Duration) int64 {
+	return t.Unix() / int64(period/time.Second)
+}
+
+// TotpCode is the 6 digit SHA-1 code of secret at t
+func TotpCode(secret []byte, t time.Time) string {
+	return hotp(secret, uint64(totpStep(t, TotpPeriod)), TotpDigits, sha1.New)
+}
+
+// ValidateTotp checks code against the steps around now and returns the step it matched
+func ValidateTotp(secret []byte, code string, now time.Time) (int64, bool) {
+	if len(code) != TotpDigits {
+		return 0, false
+	}
+	current := totpStep(now, TotpPeriod)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		want := hotp(secret, uint64(step), TotpDigits, sha1.New)
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// NewTotpSecret returns a random secret
+func NewTotpSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// EncodeTotpSecret is the base32 form users type into authenticator apps
+func EncodeTotpSecret(secret []byte) string {
+	return base32NoPadding.EncodeToString(secret)
+}
+
+// TotpURI is the otpauth:// URI authenticator apps read from a QR code
+func TotpURI(issuer string, account string, secret []byte) string {
+	query := url.Values{}
+	query.Set("secret", EncodeTotpSecret(secret))
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TotpDigits))
+	query.Set("period", fmt.Sprint(int(TotpPeriod/time.Second)))
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}).String()
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 4226 appendix D
+func TestHotp(t *testing.T) {
+	key := []byte("12345678901234567890")
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, code := range want {
+		require.Equal(t, code, hotp(key, uint64(counter), 6, sha1.New))
+	}
+}
+
+// RFC 6238 appendix B
+func TestTotpVectors(t *testing.T) {
+	keys := map[string][]byte{
+		"SHA1":   []byte("12345678901234567890"),
+		"SHA256": []byte("12345678901234567890123456789012"),
+		"SHA512": []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	hashes := map[string]func() hash.Hash{"SHA1": sha1.New, "SHA256": sha256.New, "SHA512": sha512.New}
+	testCases := []struct {
+		unix int64
+		want map[string]string
+	}{
+		{59, map[string]string{"SHA1": "94287082", "SHA256": "46119246", "SHA512": "90693936"}},
+		{1111111109, map[string]string{"SHA1": "07081804", "SHA256": "68084774", "SHA512": "25091201"}},
+		{1111111111, map[string]string{"SHA1": "14050471", "SHA256": "67062674", "SHA512": "99943326"}},
+		{1234567890, map[string]string{"SHA1": "89005924", "SHA256": "91819424", "SHA512": "93441116"}},
+		{2000000000, map[string]string{"SHA1": "69279037", "SHA256": "90698825", "SHA512": "38618901"}},
+		{20000000000, map[string]string{"SHA1": "65353130", "SHA256": "77737706", "SHA512": "47863826"}},
+	}
+	for _, tc := range testCases {
+		step := totpStep(time.Unix(tc.unix, 0), 30*time.Second)
+		for name, want := range tc.want {
+			require.Equal(t, want, hotp(keys[name], uint64(step), 8, hashes[name]), "%s at %d", name, tc.unix)
+		}
+	}
+}
+
+func TestValidateTotp(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1111111111, 0)
+
+	step, ok := ValidateTotp(secret, "050471", now)
+	require.True(t, ok)
+	require.Equal(t, totpStep(now, TotpPeriod), step)
+
+	//the code of the step before is still accepted, two steps before is not
+	_, ok = ValidateTotp(secret, TotpCode(secret, now.Add(-TotpPeriod)), now)
+	require.True(t, ok)
+	_, ok = ValidateTotp(secret, TotpCode(secret, now.Add(-2*TotpPeriod)), now)
+	require.False(t, ok)
+	_, ok = ValidateTotp(secret, "50471", now)
+	require.False(t, ok)
+}
+
+func TestTotpURI(t *testing.T) {
+	uri, err := url.Parse(TotpURI("Todo", "a@email.com", []byte("12345678901234567890")))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Todo:a@email.com", uri.Path)
+	require.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", uri.Query().Get("secret"))
+	require.Equal(t, "Todo", uri.Query().Get("issuer"))
+}
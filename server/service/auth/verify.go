@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	// how long the link in a verification email works
+	VerificationTokenDuration = 48 * time.Hour
+	// shortest time between two verification emails of a user
+	VerificationResendInterval = time.Minute
+)
+
+var (
+	ErrInvalidVerificationToken = fmt.Errorf("verification link is invalid or has expired")
+	ErrEmailAlreadyVerified     = fmt.Errorf("email is already verified")
+	ErrVerificationThrottled    = fmt.Errorf("a verification email was sent less than a minute ago")
+	ErrEmailNotVerified         = fmt.Errorf("email address is not verified")
+)
+
+// VerificationKey derives the key that signs verification links from the token secret,
+// so a verification signature can't be used as anything else
+func VerificationKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("email-verification"))
+	return mac.Sum(nil)
+}
+
+// VerificationToken is "<user id>.<expiry unix time>.<signature>", the signature
+// covers the email address so the link stops working when the address changes
+func VerificationToken(key []byte, user *db.User, expiresAt time.Time) string {
+	id := strconv.FormatInt(user.ID, 10)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return id + "." + expires + "." + base64.RawURLEncoding.EncodeToString(verificationSignature(key, id, expires, user.Email))
+}
+
+func verificationSignature(key []byte, id string, expires string, email string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + expires + "." + email))
+	return mac.Sum(nil)
+}
+
+// SendVerification marks that a verification email is sent to a user and returns the token
+// for its link. Users who are verified already or got an email within
+// VerificationResendInterval don't get another one
+func (a *Auth) SendVerification(ctx context.Context, userId int64) (*db.User, string, error) {
+	now := time.Now()
+	user, err := a.Store.ClaimVerificationEmail(ctx, db.ClaimVerificationEmailParams{
+		Now:         now,
+		ID:          userId,
+		ResendAfter: now.Add(-VerificationResendInterval),
+	})
+	if err == sql.ErrNoRows {
+		current, err := a.Store.GetUser(ctx, db.GetUserParams{ID: userId})
+		if err != nil {
+			return nil, "", err
+		}
+		if current.EmailVerifiedAt.Valid {
+			return nil, "", ErrEmailAlreadyVerified
+		}
+		return nil, "", ErrVerificationThrottled
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &user, VerificationToken(a.VerificationKey, &user, now.Add(VerificationTokenDuration)), nil
+}
+
+// VerifyEmail checks a token from a verification link and marks the email of its user verified
+func (a *Auth) VerifyEmail(ctx context.Context, token string) (*db.User, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidVerificationToken
+	}
+	userId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().After(time.Unix(expires, 0)) {
+		return nil, ErrInvalidVerificationToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := a.Store.GetUser(ctx, db.GetUserParams{ID: userId})
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, verificationSignature(a.VerificationKey, parts[0], parts[1], user.Email)) {
+		return nil, ErrInvalidVerificationToken
+	}
+	verified, err := a.Store.VerifyUserEmail(ctx, db.VerifyUserEmailParams{
+		ID:    user.ID,
+		Email: user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &verified, nil
+}
+
+// RequireVerifiedEmail returns ErrEmailNotVerified when the user has not verified their email yet
+func (a *Auth) RequireVerifiedEmail(ctx context.Context, userId int64) error {
+	user, err := a.Store.GetUser(ctx, db.GetUserParams{ID: userId})
+	if err != nil {
+		return err
+	}
+	if !user.EmailVerifiedAt.Valid {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Auth{Store: store, VerificationKey: VerificationKey("secret")}
+	user := db.User{ID: 7, Email: "a@email.com"}
+	token := VerificationToken(a.VerificationKey, &user, time.Now().Add(time.Hour))
+
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(user, nil)
+	store.EXPECT().
+		VerifyUserEmail(gomock.Any(), db.VerifyUserEmailParams{ID: 7, Email: "a@email.com"}).
+		Return(db.User{ID: 7, Email: "a@email.com", EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+	verified, err := a.VerifyEmail(context.Background(), token)
+	require.NoError(t, err)
+	require.True(t, verified.EmailVerifiedAt.Valid)
+
+	//the link stops working when the email changed
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(db.User{ID: 7, Email: "b@email.com"}, nil)
+	_, err = a.VerifyEmail(context.Background(), token)
+	require.ErrorIs(t, err, ErrInvalidVerificationToken)
+
+	expired := VerificationToken(a.VerificationKey, &user, time.Now().Add(-time.Minute))
+	_, err = a.VerifyEmail(context.Background(), expired)
+	require.ErrorIs(t, err, ErrInvalidVerificationToken)
+
+	other := VerificationToken(VerificationKey("other secret"), &user, time.Now().Add(time.Hour))
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(user, nil)
+	_, err = a.VerifyEmail(context.Background(), other)
+	require.ErrorIs(t, err, ErrInvalidVerificationToken)
+
+	_, err = a.VerifyEmail(context.Background(), "7.abc")
+	require.ErrorIs(t, err, ErrInvalidVerificationToken)
+}
+
+func TestSendVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	a := Auth{Store: store, VerificationKey: VerificationKey("secret")}
+
+	store.EXPECT().
+		ClaimVerificationEmail(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.ClaimVerificationEmailParams) (db.User, error) {
+			require.Equal(t, VerificationResendInterval, arg.Now.Sub(arg.ResendAfter))
+			return db.User{ID: arg.ID, Email: "a@email.com"}, nil
+		})
+	user, token, err := a.SendVerification(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), user.ID)
+	require.True(t, strings.HasPrefix(token, "7."))
+
+	//nothing was claimed, either verified already or sent a moment ago
+	store.EXPECT().ClaimVerificationEmail(gomock.Any(), gomock.Any()).Times(2).Return(db.User{}, sql.ErrNoRows)
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(db.User{ID: 7}, nil)
+	_, _, err = a.SendVerification(context.Background(), 7)
+	require.ErrorIs(t, err, ErrVerificationThrottled)
+	store.EXPECT().GetUser(gomock.Any(), db.GetUserParams{ID: 7}).Return(db.User{ID: 7, EmailVerifiedAt: sql.NullTime{Valid: true}}, nil)
+	_, _, err = a.SendVerification(context.Background(), 7)
+	require.ErrorIs(t, err, ErrEmailAlreadyVerified)
+}
@@ -0,0 +1,204 @@
+package delta
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// Synced entities
+const (
+	EntityTask    = "task"
+	EntityLabel   = "label"
+	EntityProject = "project"
+)
+
+// Conflict resolution strategies of client changes
+const (
+	// StrategyServerWins skips a client change when the entity changed since the client saw it
+	StrategyServerWins = "server-wins"
+	// StrategyLastWriterWins applies a client change made after the last change on the server
+	StrategyLastWriterWins = "last-writer-wins"
+)
+
+// PageSize is the most changes returned at once, clients ask again while HasMore is set
+const PageSize = 500
+
+var (
+	ErrInvalidSyncToken = fmt.Errorf("invalid sync token")
+	ErrInvalidStrategy  = fmt.Errorf("strategy must be server-wins or last-writer-wins")
+)
+
+type Delta struct {
+	Store db.Store
+}
+
+// Change is a changed entity, one of Task, Label or Project is set unless Deleted
+type Change struct {
+	Entity    string
+	ID        int64
+	Seq       int64
+	ChangedAt time.Time
+	Deleted   bool
+	Task      *db.Task
+	Label     *db.Label
+	Project   *db.Project
+}
+
+// Changes is a page of changes and the token to get the next one
+type Changes struct {
+	Changes []Change
+	Token   string
+	HasMore bool
+}
+
+// syncToken is where a client is in the change log: the changes of transactions from
+// since on with a seq after afterSeq. While paging next is the since of the following sync.
+type syncToken struct {
+	since    string
+	afterSeq int64
+	next     string
+}
+
+func (t *syncToken) String() string {
+	s := fmt.Sprintf("%s.%d.%s", t.since, t.afterSeq, t.next)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func parseSyncToken(token string) (*syncToken, error) {
+	if token == "" {
+		return &syncToken{since: "0"}, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidSyncToken
+	}
+	parts := strings.Split(string(b), ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidSyncToken
+	}
+	t := &syncToken{since: parts[0], next: parts[2]}
+	if _, err := strconv.ParseUint(t.since, 10, 64); err != nil {
+		return nil, ErrInvalidSyncToken
+	}
+	if t.next != "" {
+		if _, err := strconv.ParseUint(t.next, 10, 64); err != nil {
+			return nil, ErrInvalidSyncToken
+		}
+	}
+	if t.afterSeq, err = strconv.ParseInt(parts[1], 10, 64); err != nil || t.afterSeq < 0 {
+		return nil, ErrInvalidSyncToken
+	}
+	return t, nil
+}
+
+// GetChanges returns the entities of a user changed or deleted since token,
+// an empty token returns every entity without the deleted ones.
+// The token is the oldest transaction still running when the changes were read,
+// so changes committed later are never skipped, some may be sent twice.
+func (d *Delta) GetChanges(ctx context.Context, ownerId int64, token string) (*Changes, error) {
+	t, err := parseSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+	result, err := d.Store.GetSyncChangesTx(ctx, db.GetSyncChangesTxParams{
+		OwnerID:  ownerId,
+		Since:    t.since,
+		AfterSeq: t.afterSeq,
+		Limit:    PageSize + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if t.next == "" {
+		t.next = result.Xmin
+	}
+
+	changes := &Changes{HasMore: len(result.Changes) > PageSize}
+	rows := result.Changes
+	if changes.HasMore {
+		rows = rows[:PageSize]
+		changes.Token = (&syncToken{since: t.since, afterSeq: rows[len(rows)-1].Seq, next: t.next}).String()
+	} else {
+		changes.Token = (&syncToken{since: t.next}).String()
+	}
+
+	tasks := make(map[int64]*db.Task, len(result.Tasks))
+	for i := range result.Tasks {
+		tasks[result.Tasks[i].ID] = &result.Tasks[i]
+	}
+	labels := make(map[int64]*db.Label, len(result.Labels))
+	for i := range result.Labels {
+		labels[result.Labels[i].ID] = &result.Labels[i]
+	}
+	projects := make(map[int64]*db.Project, len(result.Projects))
+	for i := range result.Projects {
+		projects[result.Projects[i].ID] = &result.Projects[i]
+	}
+	fullSync := token == ""
+	for _, row := range rows {
+		if row.Deleted && fullSync {
+			continue
+		}
+		change := Change{
+			Entity:    row.Entity,
+			ID:        row.EntityID,
+			Seq:       row.Seq,
+			ChangedAt: row.ChangedAt,
+			Deleted:   row.Deleted,
+		}
+		switch row.Entity {
+		case EntityTask:
+			change.Task = tasks[row.EntityID]
+		case EntityLabel:
+			change.Label = labels[row.EntityID]
+		case EntityProject:
+			change.Project = projects[row.EntityID]
+		}
+		changes.Changes = append(changes.Changes, change)
+	}
+	return changes, nil
+}
+
+// GetState returns the sync state of an entity owned by the user,
+// sql.ErrNoRows when the user doesn't own it
+func (d *Delta) GetState(ctx context.Context, ownerId int64, entity string, id int64) (*db.GetSyncChangeRow, error) {
+	state, err := d.Store.GetSyncChange(ctx, db.GetSyncChangeParams{
+		Entity:   entity,
+		EntityID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if state.OwnerID != ownerId {
+		return nil, sql.ErrNoRows
+	}
+	return &state, nil
+}
+
+// Resolve decides whether a client change is applied. The client made it at
+// modifiedAt on top of version baseSeq, conflict is set when the entity changed since.
+func Resolve(strategy string, baseSeq int64, modifiedAt time.Time, state *db.GetSyncChangeRow) (apply bool, conflict bool) {
+	if state.Seq <= baseSeq && !state.Deleted {
+		return true, false
+	}
+	if state.Deleted {
+		//a deleted entity can't be changed
+		return false, true
+	}
+	if strategy == StrategyLastWriterWins && modifiedAt.After(state.ChangedAt) {
+		return true, true
+	}
+	return false, true
+}
+
+// ValidStrategy reports whether strategy is a known conflict resolution strategy
+func ValidStrategy(strategy string) bool {
+	return strategy == StrategyServerWins || strategy == StrategyLastWriterWins
+}
@@ -0,0 +1,128 @@
+package delta
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncToken(t *testing.T) {
+	token := &syncToken{since: "1204", afterSeq: 88, next: "1290"}
+	parsed, err := parseSyncToken(token.String())
+	require.NoError(t, err)
+	require.Equal(t, token, parsed)
+
+	parsed, err = parseSyncToken("")
+	require.NoError(t, err)
+	require.Equal(t, &syncToken{since: "0"}, parsed)
+
+	for _, invalid := range []string{"!!", "MTIwNA", "YS4wLg", "MTIwNC4tMS4"} {
+		_, err = parseSyncToken(invalid)
+		require.ErrorIs(t, err, ErrInvalidSyncToken, invalid)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	changedAt := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name       string
+		strategy   string
+		baseSeq    int64
+		modifiedAt time.Time
+		state      db.GetSyncChangeRow
+		apply      bool
+		conflict   bool
+	}{
+		{
+			name:     "Unchanged",
+			strategy: StrategyServerWins,
+			baseSeq:  10,
+			state:    db.GetSyncChangeRow{Seq: 10, ChangedAt: changedAt},
+			apply:    true,
+		},
+		{
+			name:       "ServerWins",
+			strategy:   StrategyServerWins,
+			baseSeq:    10,
+			modifiedAt: changedAt.Add(time.Hour),
+			state:      db.GetSyncChangeRow{Seq: 12, ChangedAt: changedAt},
+			conflict:   true,
+		},
+		{
+			name:       "LastWriterWinsClient",
+			strategy:   StrategyLastWriterWins,
+			baseSeq:    10,
+			modifiedAt: changedAt.Add(time.Hour),
+			state:      db.GetSyncChangeRow{Seq: 12, ChangedAt: changedAt},
+			apply:      true,
+			conflict:   true,
+		},
+		{
+			name:       "LastWriterWinsServer",
+			strategy:   StrategyLastWriterWins,
+			baseSeq:    10,
+			modifiedAt: changedAt.Add(-time.Hour),
+			state:      db.GetSyncChangeRow{Seq: 12, ChangedAt: changedAt},
+			conflict:   true,
+		},
+		{
+			name:       "Deleted",
+			strategy:   StrategyLastWriterWins,
+			baseSeq:    10,
+			modifiedAt: changedAt.Add(time.Hour),
+			state:      db.GetSyncChangeRow{Seq: 10, ChangedAt: changedAt, Deleted: true},
+			conflict:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apply, conflict := Resolve(tc.strategy, tc.baseSeq, tc.modifiedAt, &tc.state)
+			require.Equal(t, tc.apply, apply)
+			require.Equal(t, tc.conflict, conflict)
+		})
+	}
+}
+
+func TestGetChangesPaging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	d := Delta{Store: store}
+
+	rows := make([]db.GetSyncChangesRow, PageSize+1)
+	for i := range rows {
+		rows[i] = db.GetSyncChangesRow{Entity: EntityTask, EntityID: int64(i + 1), Seq: int64(i + 1)}
+	}
+	rows[0].Deleted = true
+	store.EXPECT().
+		GetSyncChangesTx(gomock.Any(), db.GetSyncChangesTxParams{OwnerID: 1, Since: "0", Limit: PageSize + 1}).
+		Return(db.GetSyncChangesTxResult{Xmin: "700", Changes: rows}, nil)
+
+	changes, err := d.GetChanges(context.Background(), 1, "")
+	require.NoError(t, err)
+	require.True(t, changes.HasMore)
+	//the first sync skips deletions
+	require.Len(t, changes.Changes, PageSize-1)
+
+	token, err := parseSyncToken(changes.Token)
+	require.NoError(t, err)
+	require.Equal(t, &syncToken{since: "0", afterSeq: PageSize, next: "700"}, token)
+
+	store.EXPECT().
+		GetSyncChangesTx(gomock.Any(), db.GetSyncChangesTxParams{OwnerID: 1, Since: "0", AfterSeq: PageSize, Limit: PageSize + 1}).
+		Return(db.GetSyncChangesTxResult{Xmin: "720", Changes: rows[PageSize:]}, nil)
+
+	changes, err = d.GetChanges(context.Background(), 1, changes.Token)
+	require.NoError(t, err)
+	require.False(t, changes.HasMore)
+	require.Len(t, changes.Changes, 1)
+
+	//the next sync starts from the snapshot of the first page
+	token, err = parseSyncToken(changes.Token)
+	require.NoError(t, err)
+	require.Equal(t, &syncToken{since: "700"}, token)
+}
@@ -0,0 +1,137 @@
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	FrequencyOff    = "off"
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+	// digests are sent at 07:00 unless set otherwise
+	DefaultSendMinute = 7 * 60
+	DefaultWeekday    = int16(time.Monday)
+)
+
+var (
+	ErrInvalidFrequency = fmt.Errorf("frequency must be off, daily or weekly")
+	ErrInvalidSendTime  = fmt.Errorf("time must be formatted as HH:MM")
+	ErrInvalidTimezone  = fmt.Errorf("unknown timezone")
+	ErrInvalidWeekday   = fmt.Errorf("weekday must be between 0 (Sunday) and 6 (Saturday)")
+)
+
+type Digest struct {
+	Store db.Store
+	// UnsubscribeKey signs the unsubscribe links in digest emails
+	UnsubscribeKey []byte
+}
+
+// PreferenceParams are the digest settings of a user
+type PreferenceParams struct {
+	Frequency string
+	// minutes after midnight in Timezone
+	SendMinute int32
+	// only used by weekly digests
+	Weekday  int16
+	Timezone string
+}
+
+// Get digest preference of a user, users who never set one get the defaults
+func (d *Digest) GetPreference(ctx context.Context, userId int64) (*db.DigestPreference, error) {
+	pref, err := d.Store.GetDigestPreference(ctx, userId)
+	if err == sql.ErrNoRows {
+		return &db.DigestPreference{
+			UserID:     userId,
+			Frequency:  FrequencyOff,
+			SendMinute: DefaultSendMinute,
+			Weekday:    DefaultWeekday,
+			Timezone:   "UTC",
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// Update digest preference of a user and schedule the next digest
+func (d *Digest) UpdatePreference(ctx context.Context, userId int64, arg PreferenceParams) (*db.DigestPreference, error) {
+	if arg.Frequency != FrequencyOff && arg.Frequency != FrequencyDaily && arg.Frequency != FrequencyWeekly {
+		return nil, ErrInvalidFrequency
+	}
+	if arg.SendMinute < 0 || arg.SendMinute >= 24*60 {
+		return nil, ErrInvalidSendTime
+	}
+	if arg.Weekday < 0 || arg.Weekday > 6 {
+		return nil, ErrInvalidWeekday
+	}
+	if _, err := time.LoadLocation(arg.Timezone); err != nil || arg.Timezone == "" {
+		return nil, ErrInvalidTimezone
+	}
+
+	params := db.UpsertDigestPreferenceParams{
+		UserID:     userId,
+		Frequency:  arg.Frequency,
+		SendMinute: arg.SendMinute,
+		Weekday:    arg.Weekday,
+		Timezone:   arg.Timezone,
+	}
+	if arg.Frequency != FrequencyOff {
+		next := NextSendAt(arg.Frequency, arg.SendMinute, arg.Weekday, arg.Timezone, time.Now())
+		params.NextSendAt = sql.NullTime{Time: next, Valid: true}
+	}
+	pref, err := d.Store.UpsertDigestPreference(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// Unsubscribe turns digests off for the user the token was made for
+func (d *Digest) Unsubscribe(ctx context.Context, token string) error {
+	userId, err := ParseUnsubscribeToken(d.UnsubscribeKey, token)
+	if err != nil {
+		return err
+	}
+	return d.Store.UnsubscribeDigest(ctx, userId)
+}
+
+// ParseSendTime converts "HH:MM" to minutes after midnight
+func ParseSendTime(s string) (int32, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, ErrInvalidSendTime
+	}
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+// FormatSendTime converts minutes after midnight to "HH:MM"
+func FormatSendTime(minute int32) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// NextSendAt is the first send time of a digest after the given time.
+// The time of day is kept in the local time of timezone across DST changes.
+func NextSendAt(frequency string, sendMinute int32, weekday int16, timezone string, after time.Time) time.Time {
+	loc := location(timezone)
+	local := after.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), 0, int(sendMinute), 0, 0, loc)
+	for !next.After(after) || (frequency == FrequencyWeekly && next.Weekday() != time.Weekday(weekday)) {
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, int(sendMinute), 0, 0, loc)
+	}
+	return next
+}
+
+// location falls back to UTC for timezones that are no longer known
+func location(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
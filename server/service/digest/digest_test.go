@@ -0,0 +1,84 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextSendAt(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		frequency string
+		weekday   int16
+		after     time.Time
+		expected  time.Time
+	}{
+		{
+			name:      "LaterToday",
+			frequency: FrequencyDaily,
+			after:     time.Date(2024, 3, 5, 6, 0, 0, 0, ny),
+			expected:  time.Date(2024, 3, 5, 7, 30, 0, 0, ny),
+		},
+		{
+			name:      "Tomorrow",
+			frequency: FrequencyDaily,
+			after:     time.Date(2024, 3, 5, 7, 30, 0, 0, ny),
+			expected:  time.Date(2024, 3, 6, 7, 30, 0, 0, ny),
+		},
+		{
+			// clocks move forward on 10 March, the digest is still sent at 07:30 local time
+			name:      "AcrossDST",
+			frequency: FrequencyDaily,
+			after:     time.Date(2024, 3, 9, 8, 0, 0, 0, ny),
+			expected:  time.Date(2024, 3, 10, 7, 30, 0, 0, ny),
+		},
+		{
+			name:      "Weekly",
+			frequency: FrequencyWeekly,
+			weekday:   int16(time.Monday),
+			after:     time.Date(2024, 3, 5, 6, 0, 0, 0, ny),
+			expected:  time.Date(2024, 3, 11, 7, 30, 0, 0, ny),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := NextSendAt(tc.frequency, 7*60+30, tc.weekday, "America/New_York", tc.after.UTC())
+			require.True(t, tc.expected.Equal(next), "expected %v, got %v", tc.expected, next)
+		})
+	}
+}
+
+func TestSendTime(t *testing.T) {
+	minute, err := ParseSendTime("07:30")
+	require.NoError(t, err)
+	require.Equal(t, int32(450), minute)
+	require.Equal(t, "07:30", FormatSendTime(minute))
+
+	_, err = ParseSendTime("25:00")
+	require.ErrorIs(t, err, ErrInvalidSendTime)
+}
+
+func TestUnsubscribeToken(t *testing.T) {
+	key := UnsubscribeKey("12345678901234567890123456789012")
+	token := UnsubscribeToken(key, 42)
+
+	userId, err := ParseUnsubscribeToken(key, token)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), userId)
+
+	//the user id can't be changed without the key
+	sig := token[len("42"):]
+	_, err = ParseUnsubscribeToken(key, "43"+sig)
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+
+	_, err = ParseUnsubscribeToken(UnsubscribeKey("another secret"), token)
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+
+	_, err = ParseUnsubscribeToken(key, "42")
+	require.ErrorIs(t, err, ErrInvalidUnsubscribeToken)
+}
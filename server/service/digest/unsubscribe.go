@@ -0,0 +1,50 @@
+package digest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidUnsubscribeToken = fmt.Errorf("invalid unsubscribe token")
+
+// UnsubscribeKey derives the key that signs unsubscribe links from the token secret,
+// so an unsubscribe signature can't be used as anything else
+func UnsubscribeKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("digest-unsubscribe"))
+	return mac.Sum(nil)
+}
+
+// UnsubscribeToken is "<user id>.<signature>", it doesn't expire
+// so links in old emails keep working
+func UnsubscribeToken(key []byte, userId int64) string {
+	id := strconv.FormatInt(userId, 10)
+	return id + "." + base64.RawURLEncoding.EncodeToString(unsubscribeSignature(key, id))
+}
+
+// ParseUnsubscribeToken checks the signature of token and returns the user id in it
+func ParseUnsubscribeToken(key []byte, token string) (int64, error) {
+	id, sig, found := strings.Cut(token, ".")
+	if !found {
+		return 0, ErrInvalidUnsubscribeToken
+	}
+	userId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidUnsubscribeToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, unsubscribeSignature(key, id)) {
+		return 0, ErrInvalidUnsubscribeToken
+	}
+	return userId, nil
+}
+
+func unsubscribeSignature(key []byte, id string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	return mac.Sum(nil)
+}
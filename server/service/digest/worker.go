@@ -0,0 +1,179 @@
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/mail"
+	"github.com/punkzberryz/todo/service/task"
+)
+
+const (
+	DefaultInterval = time.Minute
+	// a digest that couldn't be sent is tried again after RetryDelay
+	RetryDelay = 15 * time.Minute
+)
+
+// Worker sends the digests that are due by email,
+// like reminder.Worker every API server runs one
+type Worker struct {
+	Store db.Store
+	Mail  mail.EmailSender
+	// UnsubscribeKey signs the unsubscribe links
+	UnsubscribeKey []byte
+	// PublicURL is the address of the API that unsubscribe links point to
+	PublicURL string
+	// how often due digests are looked for
+	Interval time.Duration
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewWorker(store db.Store, mailSender mail.EmailSender, unsubscribeKey []byte, publicURL string, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Worker{
+		Store:          store,
+		Mail:           mailSender,
+		UnsubscribeKey: unsubscribeKey,
+		PublicURL:      strings.TrimSuffix(publicURL, "/"),
+		Interval:       interval,
+		now:            time.Now,
+	}
+}
+
+// Run delivers due digests every Interval until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Println("cannot deliver digests:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends every digest that is due and returns how many were handled,
+// one digest is claimed per transaction
+func (w *Worker) DeliverDue(ctx context.Context) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		now := w.now()
+		result, err := w.Store.DeliverDigestTx(ctx, db.DeliverDigestTxParams{
+			Now: now,
+			Deliver: func(digest db.ClaimDueDigestRow) error {
+				return w.send(ctx, digest, now)
+			},
+			NextSendAt: func(digest db.ClaimDueDigestRow) time.Time {
+				return NextSendAt(digest.Frequency, digest.SendMinute, digest.Weekday, digest.Timezone, now)
+			},
+			RetryAt: now.Add(RetryDelay),
+		})
+		if err != nil {
+			return sent, err
+		}
+		if !result.Found {
+			return sent, nil
+		}
+		if result.DeliveryErr != nil {
+			log.Printf("cannot send digest to user %d: %v", result.Digest.UserID, result.DeliveryErr)
+			continue
+		}
+		sent++
+	}
+	return sent, ctx.Err()
+}
+
+// send renders and sends the digest, nothing is sent when there is nothing to report
+func (w *Worker) send(ctx context.Context, row db.ClaimDueDigestRow, now time.Time) error {
+	digest, err := w.build(ctx, row, now)
+	if err != nil {
+		return err
+	}
+	if len(digest.Due) == 0 && len(digest.Overdue) == 0 && len(digest.Completed) == 0 {
+		return nil
+	}
+	subject, htmlContent, textContent, err := mail.MakeEmailForDigest(digest)
+	if err != nil {
+		return err
+	}
+	return w.Mail.SendEmailWithText(subject, htmlContent, textContent, []string{row.Email}, nil, nil, nil)
+}
+
+// build collects the tasks of a digest. A daily digest lists the tasks due today,
+// the overdue tasks and the tasks completed yesterday, a weekly one covers 7 days.
+func (w *Worker) build(ctx context.Context, row db.ClaimDueDigestRow, now time.Time) (*mail.Digest, error) {
+	loc := location(row.Timezone)
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	days := 1
+	digest := &mail.Digest{
+		Title:          "Your daily digest",
+		Username:       row.Username,
+		Frequency:      row.Frequency,
+		Date:           local.Format("Monday, 2 January 2006"),
+		DueLabel:       "Due today",
+		CompletedLabel: "Completed yesterday",
+		UnsubscribeURL: fmt.Sprintf("%s/digest/unsubscribe?token=%s", w.PublicURL, url.QueryEscape(UnsubscribeToken(w.UnsubscribeKey, row.UserID))),
+	}
+	if row.Frequency == FrequencyWeekly {
+		days = 7
+		digest.Title = "Your weekly digest"
+		digest.DueLabel = "Due this week"
+		digest.CompletedLabel = "Completed last week"
+	}
+
+	due, err := w.Store.GetTasksDueBetween(ctx, db.GetTasksDueBetweenParams{
+		OwnerID:  row.UserID,
+		FromTime: sql.NullTime{Time: today, Valid: true},
+		ToTime:   sql.NullTime{Time: today.AddDate(0, 0, days), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	overdue, err := w.Store.GetOverdueTasks(ctx, db.GetOverdueTasksParams{
+		OwnerID: row.UserID,
+		Before:  sql.NullTime{Time: today, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	completed, err := w.Store.GetTasksCompletedBetween(ctx, db.GetTasksCompletedBetweenParams{
+		OwnerID:  row.UserID,
+		FromTime: sql.NullTime{Time: today.AddDate(0, 0, -days), Valid: true},
+		ToTime:   sql.NullTime{Time: today, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	digest.Due = digestTasks(due, loc)
+	digest.Overdue = digestTasks(overdue, loc)
+	digest.Completed = digestTasks(completed, loc)
+	return digest, nil
+}
+
+func digestTasks(tasks []db.Task, loc *time.Location) []mail.DigestTask {
+	items := make([]mail.DigestTask, len(tasks))
+	for i, t := range tasks {
+		items[i] = mail.DigestTask{Body: t.Body}
+		if t.DueAt.Valid {
+			items[i].Due = t.DueAt.Time.In(loc).Format("Mon 2 Jan 15:04")
+		}
+		if t.Priority != task.PriorityNone {
+			items[i].Priority = task.PriorityName(t.Priority)
+		}
+	}
+	return items
+}
@@ -0,0 +1,94 @@
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type sentEmail struct {
+	subject string
+	html    string
+	text    string
+	to      []string
+}
+
+type fakeSender struct {
+	sent []sentEmail
+}
+
+func (s *fakeSender) SendEmail(subject string, content string, to []string, cc []string, bcc []string, attachFiles []string) error {
+	return s.SendEmailWithText(subject, content, "", to, cc, bcc, attachFiles)
+}
+
+func (s *fakeSender) SendEmailWithText(subject string, htmlContent string, textContent string, to []string, cc []string, bcc []string, attachFiles []string) error {
+	s.sent = append(s.sent, sentEmail{subject: subject, html: htmlContent, text: textContent, to: to})
+	return nil
+}
+
+// deliverOnce returns a DeliverDigestTx implementation that hands out the digests in order
+func deliverOnce(t *testing.T, digests ...db.ClaimDueDigestRow) func(context.Context, db.DeliverDigestTxParams) (db.DeliverDigestTxResult, error) {
+	return func(ctx context.Context, arg db.DeliverDigestTxParams) (db.DeliverDigestTxResult, error) {
+		if len(digests) == 0 {
+			return db.DeliverDigestTxResult{}, nil
+		}
+		digest := digests[0]
+		digests = digests[1:]
+		require.True(t, arg.NextSendAt(digest).After(arg.Now))
+		return db.DeliverDigestTxResult{
+			Found:       true,
+			Digest:      digest,
+			DeliveryErr: arg.Deliver(digest),
+		}, nil
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	sender := &fakeSender{}
+	key := UnsubscribeKey("secret")
+	worker := NewWorker(store, sender, key, "https://todo.example.com/", time.Minute)
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	digests := []db.ClaimDueDigestRow{
+		{UserID: 1, Frequency: FrequencyDaily, SendMinute: 420, Timezone: "UTC", Username: "alice", Email: "a@email.com"},
+		{UserID: 2, Frequency: FrequencyWeekly, SendMinute: 420, Weekday: 2, Timezone: "UTC", Username: "bob", Email: "b@email.com"},
+	}
+	store.EXPECT().
+		DeliverDigestTx(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(deliverOnce(t, digests...))
+
+	//alice has a task due today, bob has nothing to report
+	store.EXPECT().
+		GetTasksDueBetween(gomock.Any(), db.GetTasksDueBetweenParams{
+			OwnerID:  1,
+			FromTime: sql.NullTime{Time: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Valid: true},
+			ToTime:   sql.NullTime{Time: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), Valid: true},
+		}).
+		Return([]db.Task{{ID: 1, Body: "<b>pay rent</b>", DueAt: sql.NullTime{Time: now, Valid: true}, Priority: 3}}, nil)
+	store.EXPECT().GetTasksDueBetween(gomock.Any(), gomock.Any()).Return([]db.Task{}, nil)
+	store.EXPECT().GetOverdueTasks(gomock.Any(), gomock.Any()).Times(2).Return([]db.Task{}, nil)
+	store.EXPECT().GetTasksCompletedBetween(gomock.Any(), gomock.Any()).Times(2).Return([]db.Task{}, nil)
+
+	sent, err := worker.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	require.Len(t, sender.sent, 1)
+
+	email := sender.sent[0]
+	require.Equal(t, "Your daily digest", email.subject)
+	require.Equal(t, []string{"a@email.com"}, email.to)
+	require.Contains(t, email.html, "&lt;b&gt;pay rent&lt;/b&gt;")
+	require.Contains(t, email.text, "- <b>pay rent</b> (due Tue 5 Mar 12:00) [high]")
+	require.Contains(t, email.text, "https://todo.example.com/digest/unsubscribe?token=")
+	require.Contains(t, email.html, "https://todo.example.com/digest/unsubscribe?token=")
+}
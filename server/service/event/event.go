@@ -0,0 +1,100 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event types
+const (
+	TypeTaskCreated = "task.created"
+	TypeTaskUpdated = "task.updated"
+	TypeTaskDeleted = "task.deleted"
+	// TypeReset tells a client that events it asked for are no longer buffered,
+	// it should fetch its tasks again
+	TypeReset = "reset"
+)
+
+const (
+	// DefaultReplaySize is how many events of a user are kept for resuming streams
+	DefaultReplaySize = 100
+	// DefaultReplayTTL is how long the events of an idle user are kept
+	DefaultReplayTTL = 24 * time.Hour
+	// a subscriber that falls this many events behind is disconnected
+	subscriberBuffer = 64
+)
+
+// Event is a change seen by one user, ids increase by one per user
+type Event struct {
+	ID   int64           `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+	// Origin is the connection that made the change, see WithOrigin
+	Origin    string    `json:"origin,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type originKey struct{}
+
+// WithOrigin marks the events published with ctx as made by a connection,
+// so that the connection can skip its own changes
+func WithOrigin(ctx context.Context, origin string) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// Broker fans events out to the streams of a user,
+// RedisBroker does it across API servers
+type Broker interface {
+	// Publish sends an event to the subscribers of a user and buffers it for replay
+	Publish(ctx context.Context, userId int64, eventType string, data any) (*Event, error)
+	// Subscribe streams the events of a user, the buffered events after lastEventId are replayed first
+	Subscribe(ctx context.Context, userId int64, lastEventId int64) (*Subscription, error)
+}
+
+// Subscription is a stream of events
+type Subscription struct {
+	// Replay are the buffered events after the requested id
+	Replay []Event
+	// Missed is true when some events after the requested id are no longer buffered,
+	// Replay is empty then
+	Missed bool
+	// LastID is the id of the latest event when subscribing
+	LastID int64
+	// Events is closed when the subscription ends,
+	// also when the subscriber couldn't keep up
+	Events <-chan Event
+	// Close ends the subscription
+	Close func()
+}
+
+func newEvent(ctx context.Context, id int64, eventType string, data any) (*Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	origin, _ := ctx.Value(originKey{}).(string)
+	return &Event{
+		ID:        id,
+		Type:      eventType,
+		Data:      payload,
+		Origin:    origin,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// missed is whether events after lastEventId were dropped from the buffer,
+// oldest is the first buffered id (0 when empty) and lastId the latest published id
+func missed(lastEventId int64, oldest int64, lastId int64) bool {
+	if lastEventId == 0 {
+		return false
+	}
+	if lastEventId > lastId {
+		//ids started over, the buffer expired
+		return true
+	}
+	if oldest == 0 {
+		return lastEventId < lastId
+	}
+	return oldest > lastEventId+1
+}
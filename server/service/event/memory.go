@@ -0,0 +1,96 @@
+package event
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker keeps events in memory, it only reaches the streams of one server
+type MemoryBroker struct {
+	ReplaySize int
+	mu         sync.Mutex
+	users      map[int64]*memoryUser
+}
+
+type memoryUser struct {
+	lastId      int64
+	buffer      []Event
+	subscribers map[chan Event]struct{}
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		ReplaySize: DefaultReplaySize,
+		users:      map[int64]*memoryUser{},
+	}
+}
+
+func (b *MemoryBroker) user(userId int64) *memoryUser {
+	u, ok := b.users[userId]
+	if !ok {
+		u = &memoryUser{subscribers: map[chan Event]struct{}{}}
+		b.users[userId] = u
+	}
+	return u
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, userId int64, eventType string, data any) (*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	u := b.user(userId)
+	event, err := newEvent(ctx, u.lastId+1, eventType, data)
+	if err != nil {
+		return nil, err
+	}
+	u.lastId = event.ID
+	u.buffer = append(u.buffer, *event)
+	if len(u.buffer) > b.ReplaySize {
+		u.buffer = u.buffer[len(u.buffer)-b.ReplaySize:]
+	}
+	for ch := range u.subscribers {
+		select {
+		case ch <- *event:
+		default:
+			//slow subscriber, it resumes from its last event id
+			delete(u.subscribers, ch)
+			close(ch)
+		}
+	}
+	return event, nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, userId int64, lastEventId int64) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	u := b.user(userId)
+	ch := make(chan Event, subscriberBuffer)
+	u.subscribers[ch] = struct{}{}
+
+	sub := &Subscription{
+		LastID: u.lastId,
+		Events: ch,
+		Close: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := u.subscribers[ch]; ok {
+				delete(u.subscribers, ch)
+				close(ch)
+			}
+		},
+	}
+	var oldest int64
+	if len(u.buffer) > 0 {
+		oldest = u.buffer[0].ID
+	}
+	sub.Missed = missed(lastEventId, oldest, u.lastId)
+	if lastEventId > 0 && !sub.Missed {
+		for _, event := range u.buffer {
+			if event.ID > lastEventId {
+				sub.Replay = append(sub.Replay, event)
+			}
+		}
+	}
+	return sub, nil
+}
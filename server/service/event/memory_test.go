@@ -0,0 +1,84 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func publishN(t *testing.T, broker Broker, userId int64, n int) {
+	for i := 0; i < n; i++ {
+		_, err := broker.Publish(context.Background(), userId, TypeTaskUpdated, map[string]int{"n": i})
+		require.NoError(t, err)
+	}
+}
+
+func TestMemoryBrokerStream(t *testing.T) {
+	broker := NewMemoryBroker()
+	sub, err := broker.Subscribe(context.Background(), 1, 0)
+	require.NoError(t, err)
+	defer sub.Close()
+	require.Empty(t, sub.Replay)
+
+	publishN(t, broker, 1, 2)
+	//events of other users are not received
+	publishN(t, broker, 2, 1)
+
+	e := <-sub.Events
+	require.Equal(t, int64(1), e.ID)
+	require.JSONEq(t, `{"n":0}`, string(e.Data))
+	e = <-sub.Events
+	require.Equal(t, int64(2), e.ID)
+	require.Empty(t, sub.Events)
+}
+
+func TestMemoryBrokerReplay(t *testing.T) {
+	broker := NewMemoryBroker()
+	broker.ReplaySize = 3
+	publishN(t, broker, 1, 5)
+
+	//events 3, 4 and 5 are buffered
+	sub, err := broker.Subscribe(context.Background(), 1, 3)
+	require.NoError(t, err)
+	require.False(t, sub.Missed)
+	require.Len(t, sub.Replay, 2)
+	require.Equal(t, int64(4), sub.Replay[0].ID)
+	require.Equal(t, int64(5), sub.LastID)
+	sub.Close()
+
+	//event 2 was dropped
+	sub, err = broker.Subscribe(context.Background(), 1, 1)
+	require.NoError(t, err)
+	require.True(t, sub.Missed)
+	require.Empty(t, sub.Replay)
+	sub.Close()
+
+	//nothing to replay when up to date
+	sub, err = broker.Subscribe(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.False(t, sub.Missed)
+	require.Empty(t, sub.Replay)
+	sub.Close()
+
+	//an id from before the buffer started over
+	sub, err = broker.Subscribe(context.Background(), 1, 42)
+	require.NoError(t, err)
+	require.True(t, sub.Missed)
+	sub.Close()
+}
+
+func TestMemoryBrokerSlowSubscriber(t *testing.T) {
+	broker := NewMemoryBroker()
+	sub, err := broker.Subscribe(context.Background(), 1, 0)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	publishN(t, broker, 1, subscriberBuffer+1)
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	require.Equal(t, subscriberBuffer, received)
+}
@@ -0,0 +1,160 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBroker publishes events on a Redis channel per user so that the
+// streams on every API server receive them. The replay buffer of a user
+// is a sorted set scored by event id, event ids come from a counter.
+type RedisBroker struct {
+	client     *redis.Client
+	ReplaySize int64
+	ReplayTTL  time.Duration
+}
+
+func NewRedisBroker(address string) (*RedisBroker, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: "",
+		DB:       0,
+	})
+	err := client.Ping(context.Background()).Err()
+	return &RedisBroker{
+		client:     client,
+		ReplaySize: DefaultReplaySize,
+		ReplayTTL:  DefaultReplayTTL,
+	}, err
+}
+
+func channelKey(userId int64) string {
+	return fmt.Sprintf("events:%d", userId)
+}
+
+func bufferKey(userId int64) string {
+	return fmt.Sprintf("events:%d:buffer", userId)
+}
+
+func lastIdKey(userId int64) string {
+	return fmt.Sprintf("events:%d:id", userId)
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, userId int64, eventType string, data any) (*Event, error) {
+	id, err := b.client.Incr(ctx, lastIdKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	event, err := newEvent(ctx, id, eventType, data)
+	if err != nil {
+		return nil, err
+	}
+	message, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	_, err = b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, bufferKey(userId), redis.Z{Score: float64(id), Member: message})
+		pipe.ZRemRangeByRank(ctx, bufferKey(userId), 0, -b.ReplaySize-1)
+		pipe.Expire(ctx, bufferKey(userId), b.ReplayTTL)
+		pipe.Expire(ctx, lastIdKey(userId), b.ReplayTTL)
+		pipe.Publish(ctx, channelKey(userId), message)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Subscribe listens on the channel before reading the buffer,
+// events published in between are both replayed and received and are skipped once
+func (b *RedisBroker) Subscribe(ctx context.Context, userId int64, lastEventId int64) (*Subscription, error) {
+	pubsub := b.client.Subscribe(ctx, channelKey(userId))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+	sub, err := b.replay(ctx, userId, lastEventId)
+	if err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	var skipUpTo int64
+	if len(sub.Replay) > 0 {
+		skipUpTo = sub.Replay[len(sub.Replay)-1].ID
+	}
+	events := make(chan Event, subscriberBuffer)
+	var once sync.Once
+	sub.Events = events
+	sub.Close = func() {
+		once.Do(func() { pubsub.Close() })
+	}
+	go func() {
+		defer close(events)
+		for message := range pubsub.Channel() {
+			var event Event
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				continue
+			}
+			if event.ID <= skipUpTo {
+				continue
+			}
+			select {
+			case events <- event:
+			default:
+				//slow subscriber, it resumes from its last event id
+				sub.Close()
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func (b *RedisBroker) replay(ctx context.Context, userId int64, lastEventId int64) (*Subscription, error) {
+	sub := &Subscription{}
+	lastId, err := b.client.Get(ctx, lastIdKey(userId)).Int64()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	sub.LastID = lastId
+	if lastEventId == 0 {
+		return sub, nil
+	}
+
+	var oldest int64
+	first, err := b.client.ZRangeWithScores(ctx, bufferKey(userId), 0, 0).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(first) > 0 {
+		oldest = int64(first[0].Score)
+	}
+	if sub.Missed = missed(lastEventId, oldest, lastId); sub.Missed {
+		return sub, nil
+	}
+
+	messages, err := b.client.ZRangeByScore(ctx, bufferKey(userId), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(lastEventId, 10),
+		Max: strconv.FormatInt(lastId, 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		var event Event
+		if err := json.Unmarshal([]byte(message), &event); err != nil {
+			return nil, err
+		}
+		sub.Replay = append(sub.Replay, event)
+	}
+	return sub, nil
+}
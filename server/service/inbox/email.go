@@ -0,0 +1,136 @@
+package inbox
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+
+	"github.com/punkzberryz/todo/service/task"
+)
+
+// bodies of tasks made from emails are cut to this many characters
+const maxEmailBodyLength = 500
+
+var wordDecoder = &mime.WordDecoder{}
+
+// ParseEmail turns an email into a message: the subject becomes the body and
+// attached files become attachments. Emails without a subject use the first
+// line of their text.
+func ParseEmail(r io.Reader) (*Message, error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := wordDecoder.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+
+	msg := &Message{}
+	var text string
+	err = walkPart(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), "", m.Body, msg, &text)
+	if err != nil {
+		return nil, err
+	}
+	msg.Body = strings.TrimSpace(subject)
+	if msg.Body == "" {
+		msg.Body = firstLine(text)
+	}
+	if len(msg.Body) > maxEmailBodyLength {
+		msg.Body = msg.Body[:maxEmailBodyLength]
+	}
+	if msg.Body == "" {
+		return nil, ErrEmptyBody
+	}
+	return msg, nil
+}
+
+// walkPart collects the attachments of a MIME part and its children,
+// text keeps the first text/plain part that isn't an attachment
+func walkPart(contentType string, encoding string, disposition string, body io.Reader, msg *Message, text *string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			err = walkPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part.Header.Get("Content-Disposition"), part, msg, text)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(io.LimitReader(decode(body, encoding), task.MaxAttachmentSize+1))
+	if err != nil {
+		return err
+	}
+	if filename := attachmentName(disposition, params); filename != "" {
+		msg.Attachments = append(msg.Attachments, task.Attachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Content:     content,
+		})
+		return nil
+	}
+	if mediaType == "text/plain" && *text == "" {
+		*text = string(content)
+	}
+	return nil
+}
+
+func decode(body io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// attachmentName is the filename of an attached part, empty for inline text
+func attachmentName(disposition string, contentTypeParams map[string]string) string {
+	kind, params, err := mime.ParseMediaType(disposition)
+	if err == nil && params["filename"] != "" {
+		return decodeWord(params["filename"])
+	}
+	if contentTypeParams["name"] != "" {
+		return decodeWord(contentTypeParams["name"])
+	}
+	if err == nil && kind == "attachment" {
+		return "attachment"
+	}
+	return ""
+}
+
+func decodeWord(s string) string {
+	if decoded, err := wordDecoder.DecodeHeader(s); err == nil {
+		return decoded
+	}
+	return s
+}
+
+func firstLine(text string) string {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package inbox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const multipartEmail = "From: Kang <kang@example.com>\r\n" +
+	"To: 0123abcd@inbox.localhost\r\n" +
+	"Subject: =?UTF-8?Q?Pay_the_=E2=82=AC50_invoice?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"see attached\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>see attached</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"invoice.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"invoice.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0xLjQK\r\n" +
+	"aW52b2ljZQ==\r\n" +
+	"--outer--\r\n"
+
+func TestParseEmail(t *testing.T) {
+	msg, err := ParseEmail(strings.NewReader(multipartEmail))
+	require.NoError(t, err)
+	require.Equal(t, "Pay the €50 invoice", msg.Body)
+	require.Len(t, msg.Attachments, 1)
+	require.Equal(t, "invoice.pdf", msg.Attachments[0].Filename)
+	require.Equal(t, "application/pdf", msg.Attachments[0].ContentType)
+	require.Equal(t, "%PDF-1.4\ninvoice", string(msg.Attachments[0].Content))
+}
+
+func TestParseEmailWithoutSubject(t *testing.T) {
+	email := "From: kang@example.com\r\n" +
+		"To: 0123abcd@inbox.localhost\r\n" +
+		"\r\n" +
+		"\r\n" +
+		"  water the plants  \r\n" +
+		"thanks\r\n"
+	msg, err := ParseEmail(strings.NewReader(email))
+	require.NoError(t, err)
+	require.Equal(t, "water the plants", msg.Body)
+	require.Empty(t, msg.Attachments)
+
+	_, err = ParseEmail(strings.NewReader("From: kang@example.com\r\n\r\n"))
+	require.ErrorIs(t, err, ErrEmptyBody)
+}
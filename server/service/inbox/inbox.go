@@ -0,0 +1,112 @@
+package inbox
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+)
+
+var (
+	ErrInvalidToken = fmt.Errorf("unknown inbox")
+	ErrEmptyBody    = fmt.Errorf("body is a required field")
+)
+
+// Inbox lets other tools create tasks for a user without logging in.
+// Every user has a secret token, tasks are posted to /inbox/<token>
+// or emailed to <token>@<inbox domain>.
+type Inbox struct {
+	Store db.Store
+	Task  task.Task
+}
+
+// Message is a task dropped into an inbox
+type Message struct {
+	Body        string
+	DueAt       *time.Time
+	Priority    int16
+	Attachments []task.Attachment
+}
+
+// tokens are lowercase so they also work as the local part of an email address
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Get the inbox token of a user, it is made on first use
+func (i *Inbox) GetToken(ctx context.Context, userId int64) (string, error) {
+	inbox, err := i.Store.GetInbox(ctx, userId)
+	if err == sql.ErrNoRows {
+		return i.RotateToken(ctx, userId)
+	}
+	if err != nil {
+		return "", err
+	}
+	return inbox.Token, nil
+}
+
+// RotateToken replaces the inbox token of a user, the old url and address stop working
+func (i *Inbox) RotateToken(ctx context.Context, userId int64) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	inbox, err := i.Store.UpsertInbox(ctx, db.UpsertInboxParams{
+		UserID: userId,
+		Token:  token,
+	})
+	if err != nil {
+		return "", err
+	}
+	return inbox.Token, nil
+}
+
+// CreateTask creates the task of a message sent to the inbox with token
+// and attaches its files
+func (i *Inbox) CreateTask(ctx context.Context, token string, msg *Message) (*db.Task, error) {
+	if msg.Body == "" {
+		return nil, ErrEmptyBody
+	}
+	if err := task.CheckAttachments(msg.Attachments); err != nil {
+		return nil, err
+	}
+	inbox, err := i.Store.GetInboxByToken(ctx, token)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := i.Task.CreateTask(ctx, db.CreateTaskParams{
+		OwnerID:  inbox.UserID,
+		Body:     msg.Body,
+		DueAt:    nullTime(msg.DueAt),
+		Priority: msg.Priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(msg.Attachments) > 0 {
+		if _, err := i.Task.AddAttachments(ctx, created.ID, msg.Attachments); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -0,0 +1,58 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	i := Inbox{Store: store, Task: task.Task{Store: store}}
+
+	store.EXPECT().GetInboxByToken(gomock.Any(), "0123abcd").Return(db.Inbox{UserID: 4, Token: "0123abcd"}, nil)
+	store.EXPECT().
+		CreateTaskTx(gomock.Any(), db.CreateTaskParams{OwnerID: 4, Body: "water the plants"}).
+		Return(db.Task{ID: 9, OwnerID: 4, Body: "water the plants"}, nil)
+	store.EXPECT().
+		CreateTaskAttachment(gomock.Any(), db.CreateTaskAttachmentParams{
+			TaskID:      9,
+			Filename:    "plants.txt",
+			ContentType: "application/octet-stream",
+			Size:        5,
+			Content:     []byte("ficus"),
+		}).
+		Return(db.CreateTaskAttachmentRow{ID: 1, TaskID: 9}, nil)
+
+	created, err := i.CreateTask(context.Background(), "0123abcd", &Message{
+		Body:        "water the plants",
+		Attachments: []task.Attachment{{Filename: `C:\home\plants.txt`, Content: []byte("ficus")}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(9), created.ID)
+}
+
+func TestCreateTaskUnknownInbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	i := Inbox{Store: store, Task: task.Task{Store: store}}
+
+	store.EXPECT().GetInboxByToken(gomock.Any(), "ffff").Return(db.Inbox{}, sql.ErrNoRows)
+	store.EXPECT().CreateTaskTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := i.CreateTask(context.Background(), "ffff", &Message{Body: "spam"})
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = i.CreateTask(context.Background(), "ffff", &Message{
+		Body:        "too many files",
+		Attachments: make([]task.Attachment, task.MaxAttachments+1),
+	})
+	require.ErrorIs(t, err, task.ErrTooManyAttachments)
+}
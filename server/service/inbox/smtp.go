@@ -0,0 +1,115 @@
+package inbox
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-smtp"
+	"github.com/punkzberryz/todo/service/task"
+)
+
+// largest email accepted, room for the base64 encoded attachments
+const maxEmailSize = 2 * task.MaxAttachmentSize
+
+// Deliver creates the task of an email sent to the inbox with token
+type Deliver func(ctx context.Context, token string, msg *Message) error
+
+var (
+	errUnknownDomain = &smtp.SMTPError{
+		Code:         550,
+		EnhancedCode: smtp.EnhancedCode{5, 1, 2},
+		Message:      "Relay not permitted",
+	}
+	errUnknownInbox = &smtp.SMTPError{
+		Code:         550,
+		EnhancedCode: smtp.EnhancedCode{5, 1, 1},
+		Message:      "No such inbox",
+	}
+	errTryAgain = &smtp.SMTPError{
+		Code:         451,
+		EnhancedCode: smtp.EnhancedCode{4, 3, 0},
+		Message:      "Cannot create task, try again later",
+	}
+)
+
+// NewSMTPServer returns a server that accepts emails to <token>@domain
+// and hands them to deliver. It doesn't relay mail or ask senders to log in,
+// the token is the secret.
+func NewSMTPServer(addr string, domain string, deliver Deliver) *smtp.Server {
+	server := smtp.NewServer(smtp.BackendFunc(func(c *smtp.Conn) (smtp.Session, error) {
+		return &session{domain: strings.ToLower(domain), deliver: deliver}, nil
+	}))
+	server.Addr = addr
+	server.Domain = domain
+	server.ReadTimeout = time.Minute
+	server.WriteTimeout = time.Minute
+	server.MaxMessageBytes = maxEmailSize
+	//an email creates one task in one inbox
+	server.MaxRecipients = 1
+	return server
+}
+
+type session struct {
+	domain  string
+	deliver Deliver
+	token   string
+}
+
+func (s *session) Reset() {
+	s.token = ""
+}
+
+func (s *session) Logout() error {
+	return nil
+}
+
+func (s *session) Mail(from string, opts *smtp.MailOptions) error {
+	return nil
+}
+
+func (s *session) Rcpt(to string, opts *smtp.RcptOptions) error {
+	address, err := mail.ParseAddress(to)
+	if err != nil {
+		return errUnknownInbox
+	}
+	local, domain, ok := strings.Cut(strings.ToLower(address.Address), "@")
+	if !ok || domain != s.domain {
+		return errUnknownDomain
+	}
+	s.token = local
+	return nil
+}
+
+func (s *session) Data(r io.Reader) error {
+	msg, err := ParseEmail(r)
+	if err != nil {
+		//the rest of the email must be read before answering
+		io.Copy(io.Discard, r)
+		return &smtp.SMTPError{
+			Code:         554,
+			EnhancedCode: smtp.EnhancedCode{5, 6, 0},
+			Message:      err.Error(),
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	switch err := s.deliver(ctx, s.token, msg); err {
+	case nil:
+		return nil
+	case ErrInvalidToken:
+		return errUnknownInbox
+	case ErrEmptyBody, task.ErrAttachmentTooLarge, task.ErrTooManyAttachments:
+		return &smtp.SMTPError{
+			Code:         554,
+			EnhancedCode: smtp.EnhancedCode{5, 6, 0},
+			Message:      err.Error(),
+		}
+	default:
+		log.Printf("cannot create task from email: %v", err)
+		return errTryAgain
+	}
+}
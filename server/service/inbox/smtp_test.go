@@ -0,0 +1,50 @@
+package inbox
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type delivered struct {
+	token string
+	msg   *Message
+}
+
+func startSMTPServer(t *testing.T, deliver Deliver) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := NewSMTPServer(listener.Addr().String(), "inbox.localhost", deliver)
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+	return listener.Addr().String()
+}
+
+func TestSMTPServer(t *testing.T) {
+	var received []delivered
+	addr := startSMTPServer(t, func(ctx context.Context, token string, msg *Message) error {
+		if token != "0123abcd" {
+			return ErrInvalidToken
+		}
+		received = append(received, delivered{token: token, msg: msg})
+		return nil
+	})
+
+	err := smtp.SendMail(addr, nil, "kang@example.com", []string{"0123ABCD@inbox.localhost"}, []byte(multipartEmail))
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	require.Equal(t, "Pay the €50 invoice", received[0].msg.Body)
+	require.Len(t, received[0].msg.Attachments, 1)
+
+	//unknown inboxes and other domains are rejected
+	err = smtp.SendMail(addr, nil, "kang@example.com", []string{"ffff@inbox.localhost"}, []byte(multipartEmail))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "550"), err.Error())
+	err = smtp.SendMail(addr, nil, "kang@example.com", []string{"0123abcd@example.com"}, []byte(multipartEmail))
+	require.Error(t, err)
+	require.Len(t, received, 1)
+}
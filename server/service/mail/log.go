@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"log"
+	"strings"
+)
+
+// LogSender writes emails to the log instead of sending them, for running without an SMTP account
+type LogSender struct{}
+
+func NewLogSender() EmailSender {
+	return &LogSender{}
+}
+
+func (m *LogSender) SendEmail(
+	subject string,
+	content string,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
+) error {
+	return m.SendEmailWithText(subject, content, "", to, cc, bcc, attachFiles)
+}
+
+func (m *LogSender) SendEmailWithText(
+	subject string,
+	htmlContent string,
+	textContent string,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
+) error {
+	content := textContent
+	if content == "" {
+		content = htmlContent
+	}
+	log.Printf("email to %s: %s\n%s", strings.Join(append(append(append([]string{}, to...), cc...), bcc...), ", "), subject, content)
+	return nil
+}
@@ -21,6 +21,16 @@ type EmailSender interface {
 		bcc []string,
 		attachFiles []string,
 	) error
+	// SendEmailWithText sends htmlContent together with a plain text alternative
+	SendEmailWithText(
+		subject string,
+		htmlContent string,
+		textContent string,
+		to []string,
+		cc []string,
+		bcc []string,
+		attachFiles []string,
+	) error
 }
 
 type GmailSender struct {
@@ -44,11 +54,26 @@ func (m *GmailSender) SendEmail(
 	cc []string,
 	bcc []string,
 	attachFiles []string,
+) error {
+	return m.SendEmailWithText(subject, content, "", to, cc, bcc, attachFiles)
+}
+
+func (m *GmailSender) SendEmailWithText(
+	subject string,
+	htmlContent string,
+	textContent string,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
 ) error {
 	e := email.NewEmail()
 	e.From = fmt.Sprintf("%s <%s>", m.name, m.fromEmailAddress)
 	e.Subject = subject
-	e.HTML = []byte(content)
+	e.HTML = []byte(htmlContent)
+	if textContent != "" {
+		e.Text = []byte(textContent)
+	}
 	e.To = to
 	e.Cc = cc
 	e.Bcc = bcc
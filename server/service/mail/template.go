@@ -1,6 +1,22 @@
 package mail
 
-import "fmt"
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	digestHTMLTemplate = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/digest.html"))
+	digestTextTemplate = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/digest.txt"))
+)
 
 //generate email content for Email Sender
 
@@ -15,3 +31,68 @@ func MakeEmailForPasswordReset(
 	`, otp)
 	return subject, content, []string{to}, nil, nil, nil
 }
+
+func MakeEmailForEmailVerification(
+	link string,
+	to string,
+) (string, string, []string, []string, []string, []string) {
+	subject := "Verify your email address"
+	content := fmt.Sprintf(`
+	<h1>Verify your email address</h1>
+	<p><a href="%s">Click here to verify your email address</a></p>
+	<p>The link works for 48 hours.</p>
+	`, html.EscapeString(link))
+	return subject, content, []string{to}, nil, nil, nil
+}
+
+func MakeEmailForReminder(
+	taskBody string,
+	dueAt *time.Time,
+	to string,
+) (string, string, []string, []string, []string, []string) {
+	subject := fmt.Sprintf("Reminder: %s", taskBody)
+	due := ""
+	if dueAt != nil {
+		due = fmt.Sprintf("<p>Due: %s</p>", dueAt.UTC().Format("Mon, 02 Jan 2006 15:04 MST"))
+	}
+	content := fmt.Sprintf(`
+	<h1>Reminder</h1>
+	<p>%s</p>
+	%s
+	`, html.EscapeString(taskBody), due)
+	return subject, content, []string{to}, nil, nil, nil
+}
+
+// DigestTask is a task listed in a digest email
+type DigestTask struct {
+	Body     string
+	Due      string
+	Priority string
+}
+
+// Digest is the data of templates/digest.html and templates/digest.txt
+type Digest struct {
+	Title          string
+	Username       string
+	Frequency      string
+	Date           string
+	DueLabel       string
+	Due            []DigestTask
+	Overdue        []DigestTask
+	CompletedLabel string
+	Completed      []DigestTask
+	UnsubscribeURL string
+}
+
+// MakeEmailForDigest renders the html and text content of a digest,
+// they are sent with EmailSender.SendEmailWithText
+func MakeEmailForDigest(digest *Digest) (subject string, htmlContent string, textContent string, err error) {
+	var htmlBuf, textBuf bytes.Buffer
+	if err = digestHTMLTemplate.Execute(&htmlBuf, digest); err != nil {
+		return
+	}
+	if err = digestTextTemplate.Execute(&textBuf, digest); err != nil {
+		return
+	}
+	return digest.Title, htmlBuf.String(), textBuf.String(), nil
+}
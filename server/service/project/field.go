@@ -0,0 +1,280 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// Custom field types
+const (
+	FieldText        = "text"
+	FieldNumber      = "number"
+	FieldDate        = "date"
+	FieldSelect      = "select"
+	FieldMultiSelect = "multi_select"
+	FieldCheckbox    = "checkbox"
+	FieldUser        = "user"
+)
+
+// date values are stored as "2006-01-02" strings so that they sort correctly in jsonb
+const DateLayout = "2006-01-02"
+
+var (
+	ErrInvalidFieldType  = fmt.Errorf("field type must be one of text, number, date, select, multi_select, checkbox or user")
+	ErrFieldNotInProject = fmt.Errorf("custom field does not belong to project")
+	ErrMissingOptions    = fmt.Errorf("select and multi_select fields need at least one option")
+)
+
+// FieldRules are the optional validation rules of a custom field.
+// Min and Max apply to number fields, MaxLength and Pattern to text fields.
+type FieldRules struct {
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	MaxLength int      `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+}
+
+// FieldValueError describes why a value was rejected by a field
+type FieldValueError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FieldValueError) Error() string {
+	return fmt.Sprintf("invalid value for field %q: %s", e.Field, e.Reason)
+}
+
+func IsValidFieldType(fieldType string) bool {
+	switch fieldType {
+	case FieldText, FieldNumber, FieldDate, FieldSelect, FieldMultiSelect, FieldCheckbox, FieldUser:
+		return true
+	}
+	return false
+}
+
+// CustomFieldParams is the editable part of a custom field definition
+type CustomFieldParams struct {
+	Name      string
+	FieldType string
+	Options   []string
+	Rules     FieldRules
+	Position  int32
+}
+
+func (arg *CustomFieldParams) validate() (options json.RawMessage, rules json.RawMessage, err error) {
+	if !IsValidFieldType(arg.FieldType) {
+		return nil, nil, ErrInvalidFieldType
+	}
+	if (arg.FieldType == FieldSelect || arg.FieldType == FieldMultiSelect) && len(arg.Options) == 0 {
+		return nil, nil, ErrMissingOptions
+	}
+	if arg.Rules.Pattern != "" {
+		if _, err := regexp.Compile(arg.Rules.Pattern); err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+	if arg.Options == nil {
+		arg.Options = []string{}
+	}
+	if options, err = json.Marshal(arg.Options); err != nil {
+		return nil, nil, err
+	}
+	if rules, err = json.Marshal(arg.Rules); err != nil {
+		return nil, nil, err
+	}
+	return options, rules, nil
+}
+
+// Get custom field definitions of a project
+func (p *Project) GetFieldList(ctx context.Context, projectId int64, ownerId int64) ([]db.CustomField, error) {
+	if _, err := p.GetProjectById(ctx, projectId, ownerId); err != nil {
+		return nil, err
+	}
+	return p.Store.GetCustomFieldList(ctx, projectId)
+}
+
+// Define a new custom field on a project
+func (p *Project) CreateField(ctx context.Context, ownerId int64, projectId int64, arg CustomFieldParams) (*db.CustomField, error) {
+	options, rules, err := arg.validate()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.GetProjectById(ctx, projectId, ownerId); err != nil {
+		return nil, err
+	}
+	field, err := p.Store.CreateCustomField(ctx, db.CreateCustomFieldParams{
+		ProjectID: projectId,
+		Name:      arg.Name,
+		FieldType: arg.FieldType,
+		Options:   options,
+		Rules:     rules,
+		Position:  arg.Position,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// Update a custom field, the type of a field cannot change once values exist
+func (p *Project) UpdateField(ctx context.Context, ownerId int64, projectId int64, fieldId int64, arg CustomFieldParams) (*db.CustomField, error) {
+	current, err := p.getField(ctx, ownerId, projectId, fieldId)
+	if err != nil {
+		return nil, err
+	}
+	arg.FieldType = current.FieldType
+	options, rules, err := arg.validate()
+	if err != nil {
+		return nil, err
+	}
+	field, err := p.Store.UpdateCustomField(ctx, db.UpdateCustomFieldParams{
+		ID:       fieldId,
+		Name:     arg.Name,
+		Options:  options,
+		Rules:    rules,
+		Position: arg.Position,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// Delete a custom field together with all its values
+func (p *Project) DeleteField(ctx context.Context, ownerId int64, projectId int64, fieldId int64) error {
+	if _, err := p.getField(ctx, ownerId, projectId, fieldId); err != nil {
+		return err
+	}
+	return p.Store.DeleteCustomField(ctx, fieldId)
+}
+
+func (p *Project) getField(ctx context.Context, ownerId int64, projectId int64, fieldId int64) (*db.CustomField, error) {
+	if _, err := p.GetProjectById(ctx, projectId, ownerId); err != nil {
+		return nil, err
+	}
+	field, err := p.Store.GetCustomField(ctx, fieldId)
+	if err != nil {
+		return nil, err
+	}
+	if field.ProjectID != projectId {
+		return nil, ErrFieldNotInProject
+	}
+	return &field, nil
+}
+
+// ValidateFieldValue checks value against the field's type, options and rules
+// and returns it in the canonical form stored in the database.
+// userExists is used to check values of user fields.
+func ValidateFieldValue(field *db.CustomField, value json.RawMessage, userExists func(id int64) bool) (json.RawMessage, error) {
+	invalid := func(reason string, args ...interface{}) error {
+		return &FieldValueError{Field: field.Name, Reason: fmt.Sprintf(reason, args...)}
+	}
+	var rules FieldRules
+	if err := json.Unmarshal(field.Rules, &rules); err != nil {
+		return nil, err
+	}
+	var options []string
+	if err := json.Unmarshal(field.Options, &options); err != nil {
+		return nil, err
+	}
+	hasOption := func(v string) bool {
+		for _, option := range options {
+			if option == v {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch field.FieldType {
+	case FieldText:
+		var v string
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be a string")
+		}
+		if rules.MaxLength > 0 && len([]rune(v)) > rules.MaxLength {
+			return nil, invalid("must be at most %d characters", rules.MaxLength)
+		}
+		if rules.Pattern != "" {
+			matched, err := regexp.MatchString(rules.Pattern, v)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				return nil, invalid("must match %s", rules.Pattern)
+			}
+		}
+		return json.Marshal(v)
+	case FieldNumber:
+		var v float64
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be a number")
+		}
+		if rules.Min != nil && v < *rules.Min {
+			return nil, invalid("must be at least %v", *rules.Min)
+		}
+		if rules.Max != nil && v > *rules.Max {
+			return nil, invalid("must be at most %v", *rules.Max)
+		}
+		return json.Marshal(v)
+	case FieldDate:
+		var v string
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be a date string")
+		}
+		date, err := time.Parse(DateLayout, v)
+		if err != nil {
+			return nil, invalid("must be a date in the form %s", DateLayout)
+		}
+		return json.Marshal(date.Format(DateLayout))
+	case FieldSelect:
+		var v string
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be a string")
+		}
+		if !hasOption(v) {
+			return nil, invalid("%q is not one of the options", v)
+		}
+		return json.Marshal(v)
+	case FieldMultiSelect:
+		var v []string
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be a list of strings")
+		}
+		seen := make(map[string]bool, len(v))
+		for _, item := range v {
+			if !hasOption(item) {
+				return nil, invalid("%q is not one of the options", item)
+			}
+			if seen[item] {
+				return nil, invalid("%q is selected twice", item)
+			}
+			seen[item] = true
+		}
+		if v == nil {
+			v = []string{}
+		}
+		return json.Marshal(v)
+	case FieldCheckbox:
+		var v bool
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be true or false")
+		}
+		return json.Marshal(v)
+	case FieldUser:
+		var v int64
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, invalid("must be a user id")
+		}
+		if userExists != nil && !userExists(v) {
+			return nil, invalid("user %d does not exist", v)
+		}
+		return json.Marshal(v)
+	}
+	return nil, ErrInvalidFieldType
+}
@@ -0,0 +1,73 @@
+package project
+
+import (
+	"encoding/json"
+	"testing"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestField(t *testing.T, fieldType string, options []string, rules FieldRules) *db.CustomField {
+	arg := CustomFieldParams{Name: "test", FieldType: fieldType, Options: options, Rules: rules}
+	optionsJSON, rulesJSON, err := arg.validate()
+	require.NoError(t, err)
+	return &db.CustomField{Name: arg.Name, FieldType: fieldType, Options: optionsJSON, Rules: rulesJSON}
+}
+
+func TestValidateFieldValue(t *testing.T) {
+	min, max := 1.0, 13.0
+	testCases := []struct {
+		name   string
+		field  *db.CustomField
+		value  string
+		stored string
+		ok     bool
+	}{
+		{"text", newTestField(t, FieldText, nil, FieldRules{MaxLength: 5}), `"acme"`, `"acme"`, true},
+		{"text too long", newTestField(t, FieldText, nil, FieldRules{MaxLength: 5}), `"acme corp"`, "", false},
+		{"text pattern", newTestField(t, FieldText, nil, FieldRules{Pattern: `^[A-Z]+-\d+$`}), `"ABC-12"`, `"ABC-12"`, true},
+		{"text pattern mismatch", newTestField(t, FieldText, nil, FieldRules{Pattern: `^[A-Z]+-\d+$`}), `"abc"`, "", false},
+		{"number", newTestField(t, FieldNumber, nil, FieldRules{Min: &min, Max: &max}), `8`, `8`, true},
+		{"number below min", newTestField(t, FieldNumber, nil, FieldRules{Min: &min, Max: &max}), `0`, "", false},
+		{"number as string", newTestField(t, FieldNumber, nil, FieldRules{}), `"8"`, "", false},
+		{"date", newTestField(t, FieldDate, nil, FieldRules{}), `"2024-02-29"`, `"2024-02-29"`, true},
+		{"date invalid", newTestField(t, FieldDate, nil, FieldRules{}), `"2023-02-29"`, "", false},
+		{"select", newTestField(t, FieldSelect, []string{"low", "high"}, FieldRules{}), `"high"`, `"high"`, true},
+		{"select unknown option", newTestField(t, FieldSelect, []string{"low", "high"}, FieldRules{}), `"urgent"`, "", false},
+		{"multi select", newTestField(t, FieldMultiSelect, []string{"ios", "web"}, FieldRules{}), `["web","ios"]`, `["web","ios"]`, true},
+		{"multi select duplicate", newTestField(t, FieldMultiSelect, []string{"ios", "web"}, FieldRules{}), `["web","web"]`, "", false},
+		{"checkbox", newTestField(t, FieldCheckbox, nil, FieldRules{}), `true`, `true`, true},
+		{"checkbox not bool", newTestField(t, FieldCheckbox, nil, FieldRules{}), `"yes"`, "", false},
+		{"user", newTestField(t, FieldUser, nil, FieldRules{}), `7`, `7`, true},
+		{"user unknown", newTestField(t, FieldUser, nil, FieldRules{}), `8`, "", false},
+	}
+
+	userExists := func(id int64) bool { return id == 7 }
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stored, err := ValidateFieldValue(tc.field, json.RawMessage(tc.value), userExists)
+			if !tc.ok {
+				require.Error(t, err)
+				require.IsType(t, &FieldValueError{}, err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tc.stored, string(stored))
+		})
+	}
+}
+
+func TestCustomFieldParamsValidate(t *testing.T) {
+	arg := CustomFieldParams{Name: "severity", FieldType: "priority"}
+	_, _, err := arg.validate()
+	require.ErrorIs(t, err, ErrInvalidFieldType)
+
+	arg = CustomFieldParams{Name: "severity", FieldType: FieldSelect}
+	_, _, err = arg.validate()
+	require.ErrorIs(t, err, ErrMissingOptions)
+
+	arg = CustomFieldParams{Name: "ticket", FieldType: FieldText, Rules: FieldRules{Pattern: "("}}
+	_, _, err = arg.validate()
+	require.Error(t, err)
+}
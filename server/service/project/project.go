@@ -0,0 +1,247 @@
+package project
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// Status categories, a task is done when its status is in CategoryDone
+const (
+	CategoryTodo       = "todo"
+	CategoryInProgress = "in_progress"
+	CategoryDone       = "done"
+)
+
+var (
+	ErrOwnerNotMatched    = fmt.Errorf("owner id does not match user id")
+	ErrInvalidCategory    = fmt.Errorf("category must be one of %s, %s or %s", CategoryTodo, CategoryInProgress, CategoryDone)
+	ErrInvalidWipLimit    = fmt.Errorf("wip limit must not be negative")
+	ErrStatusNotInProject = fmt.Errorf("status does not belong to project")
+	ErrStatusNotEmpty     = fmt.Errorf("status still has tasks, move them first")
+	ErrLastStatus         = fmt.Errorf("project must have at least one status")
+)
+
+// Columns every new project starts with
+var DefaultStatuses = []db.CreateProjectStatusParams{
+	{Name: "Todo", Category: CategoryTodo, Position: 0},
+	{Name: "In Progress", Category: CategoryInProgress, Position: 1},
+	{Name: "Review", Category: CategoryInProgress, Position: 2},
+	{Name: "Done", Category: CategoryDone, Position: 3},
+}
+
+func IsValidCategory(category string) bool {
+	switch category {
+	case CategoryTodo, CategoryInProgress, CategoryDone:
+		return true
+	}
+	return false
+}
+
+type Project struct {
+	Store db.Store
+}
+
+// Create project with the default workflow
+func (p *Project) CreateProject(ctx context.Context, arg db.CreateProjectParams) (*db.CreateProjectTxResult, error) {
+	result, err := p.Store.CreateProjectTx(ctx, db.CreateProjectTxParams{
+		CreateProjectParams: arg,
+		Statuses:            DefaultStatuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Get project by Id
+func (p *Project) GetProjectById(ctx context.Context, id int64, ownerId int64) (*db.Project, error) {
+	project, err := p.Store.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	//check if project belongs to user
+	if project.OwnerID != ownerId {
+		return nil, ErrOwnerNotMatched
+	}
+	return &project, nil
+}
+
+// Get project list
+func (p *Project) GetProjectList(ctx context.Context, ownerId int64) ([]db.Project, error) {
+	return p.Store.GetProjectList(ctx, ownerId)
+}
+
+// Update project by Id and OwnerId
+func (p *Project) UpdateProject(ctx context.Context, arg db.UpdateProjectParams) (*db.Project, error) {
+	project, err := p.Store.UpdateProject(ctx, arg)
+	return &project, err
+}
+
+// Delete project by Id and OwnerId, tasks are kept but detached from the project
+func (p *Project) DeleteProject(ctx context.Context, arg db.DeleteProjectParams) error {
+	return p.Store.DeleteProject(ctx, arg)
+}
+
+// Get status columns of a project ordered by position
+func (p *Project) GetStatusList(ctx context.Context, projectId int64, ownerId int64) ([]db.ProjectStatus, error) {
+	if _, err := p.GetProjectById(ctx, projectId, ownerId); err != nil {
+		return nil, err
+	}
+	return p.Store.GetProjectStatusList(ctx, projectId)
+}
+
+// Add a status column to a project
+func (p *Project) CreateStatus(ctx context.Context, ownerId int64, arg db.CreateProjectStatusParams) (*db.ProjectStatus, error) {
+	if err := validateStatus(arg.Category, arg.WipLimit); err != nil {
+		return nil, err
+	}
+	if _, err := p.GetProjectById(ctx, arg.ProjectID, ownerId); err != nil {
+		return nil, err
+	}
+	status, err := p.Store.CreateProjectStatus(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Update a status column of a project,
+// when its category changes the tasks in the column become done or not done with it
+func (p *Project) UpdateStatus(ctx context.Context, ownerId int64, projectId int64, arg db.UpdateProjectStatusParams) (*db.ProjectStatus, error) {
+	if err := validateStatus(arg.Category, arg.WipLimit); err != nil {
+		return nil, err
+	}
+	if _, err := p.getStatus(ctx, ownerId, projectId, arg.ID); err != nil {
+		return nil, err
+	}
+	status, err := p.Store.UpdateProjectStatusTx(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Delete an empty status column, transitions from and to it are removed as well
+func (p *Project) DeleteStatus(ctx context.Context, ownerId int64, projectId int64, statusId int64) error {
+	if _, err := p.getStatus(ctx, ownerId, projectId, statusId); err != nil {
+		return err
+	}
+	statuses, err := p.Store.GetProjectStatusList(ctx, projectId)
+	if err != nil {
+		return err
+	}
+	if len(statuses) <= 1 {
+		return ErrLastStatus
+	}
+	count, err := p.Store.CountTasksByStatus(ctx, sql.NullInt64{Int64: statusId, Valid: true})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrStatusNotEmpty
+	}
+	return p.Store.DeleteProjectStatus(ctx, statusId)
+}
+
+// Get allowed transitions of a project
+func (p *Project) GetTransitionList(ctx context.Context, projectId int64, ownerId int64) ([]db.StatusTransition, error) {
+	if _, err := p.GetProjectById(ctx, projectId, ownerId); err != nil {
+		return nil, err
+	}
+	return p.Store.GetStatusTransitionList(ctx, projectId)
+}
+
+// Replace allowed transitions of a project.
+// A status without any outgoing transition can move to every other status.
+func (p *Project) ReplaceTransitions(ctx context.Context, ownerId int64, projectId int64, transitions []db.CreateStatusTransitionParams) ([]db.StatusTransition, error) {
+	statuses, err := p.GetStatusList(ctx, projectId, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	inProject := make(map[int64]bool, len(statuses))
+	for _, status := range statuses {
+		inProject[status.ID] = true
+	}
+	for _, transition := range transitions {
+		if !inProject[transition.FromStatusID] || !inProject[transition.ToStatusID] {
+			return nil, ErrStatusNotInProject
+		}
+	}
+	return p.Store.ReplaceStatusTransitionsTx(ctx, db.ReplaceStatusTransitionsTxParams{
+		ProjectID:   projectId,
+		Transitions: transitions,
+	})
+}
+
+// Board is a project's tasks grouped by status column
+type Board struct {
+	Project db.Project
+	Columns []BoardColumn
+}
+
+type BoardColumn struct {
+	Status db.ProjectStatus
+	Tasks  []db.Task
+}
+
+// Get tasks of a project grouped by status, archived tasks are left out
+func (p *Project) GetBoard(ctx context.Context, projectId int64, ownerId int64) (*Board, error) {
+	project, err := p.GetProjectById(ctx, projectId, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := p.Store.GetProjectStatusList(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := p.Store.GetTaskListByProject(ctx, sql.NullInt64{Int64: projectId, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	board := &Board{
+		Project: *project,
+		Columns: make([]BoardColumn, len(statuses)),
+	}
+	columnOf := make(map[int64]int, len(statuses))
+	for i, status := range statuses {
+		board.Columns[i] = BoardColumn{Status: status, Tasks: []db.Task{}}
+		columnOf[status.ID] = i
+	}
+	for _, task := range tasks {
+		i, ok := columnOf[task.StatusID.Int64]
+		if !ok {
+			continue
+		}
+		board.Columns[i].Tasks = append(board.Columns[i].Tasks, task)
+	}
+	return board, nil
+}
+
+// get status and check that it belongs to the user's project
+func (p *Project) getStatus(ctx context.Context, ownerId int64, projectId int64, statusId int64) (*db.ProjectStatus, error) {
+	if _, err := p.GetProjectById(ctx, projectId, ownerId); err != nil {
+		return nil, err
+	}
+	status, err := p.Store.GetProjectStatus(ctx, statusId)
+	if err != nil {
+		return nil, err
+	}
+	if status.ProjectID != projectId {
+		return nil, ErrStatusNotInProject
+	}
+	return &status, nil
+}
+
+func validateStatus(category string, wipLimit int32) error {
+	if !IsValidCategory(category) {
+		return ErrInvalidCategory
+	}
+	if wipLimit < 0 {
+		return ErrInvalidWipLimit
+	}
+	return nil
+}
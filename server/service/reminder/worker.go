@@ -0,0 +1,99 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/mail"
+)
+
+const (
+	DefaultInterval    = 30 * time.Second
+	DefaultMaxAttempts = 5
+)
+
+// Worker sends due reminders by email.
+// Every API server runs one, reminders are claimed with row locks
+// so each of them is sent by a single server.
+type Worker struct {
+	Store db.Store
+	Mail  mail.EmailSender
+	// how often due reminders are looked for
+	Interval time.Duration
+	// a reminder is given up after this many failed deliveries
+	MaxAttempts int32
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewWorker(store db.Store, mailSender mail.EmailSender, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Worker{
+		Store:       store,
+		Mail:        mailSender,
+		Interval:    interval,
+		MaxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Run delivers due reminders every Interval until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Println("cannot deliver reminders:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends every reminder that is due and returns how many were sent.
+// Reminders are claimed one per transaction so a slow mail server
+// doesn't keep other reminders locked.
+func (w *Worker) DeliverDue(ctx context.Context) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		result, err := w.Store.DeliverReminderTx(ctx, db.DeliverReminderTxParams{
+			Now:         w.now(),
+			MaxAttempts: w.MaxAttempts,
+			Deliver:     w.send,
+			RetryAt:     w.retryAt,
+		})
+		if err != nil {
+			return sent, err
+		}
+		if !result.Found {
+			return sent, nil
+		}
+		if result.DeliveryErr != nil {
+			log.Printf("cannot send reminder %d: %v", result.Reminder.ID, result.DeliveryErr)
+			continue
+		}
+		sent++
+	}
+	return sent, ctx.Err()
+}
+
+func (w *Worker) send(reminder db.ClaimDueReminderRow) error {
+	var dueAt *time.Time
+	if reminder.DueAt.Valid {
+		dueAt = &reminder.DueAt.Time
+	}
+	return w.Mail.SendEmail(mail.MakeEmailForReminder(reminder.Body, dueAt, reminder.Email))
+}
+
+// failed deliveries are retried after 1, 4, 9, 16... minutes
+func (w *Worker) retryAt(attempts int32) time.Time {
+	backoff := time.Duration(attempts*attempts) * time.Minute
+	return w.now().Add(backoff)
+}
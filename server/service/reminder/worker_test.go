@@ -0,0 +1,133 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	memdb "github.com/punkzberryz/todo/db/memory"
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type sentEmail struct {
+	subject string
+	to      []string
+}
+
+type fakeSender struct {
+	sent []sentEmail
+	err  error
+}
+
+func (s *fakeSender) SendEmail(subject string, content string, to []string, cc []string, bcc []string, attachFiles []string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, sentEmail{subject: subject, to: to})
+	return nil
+}
+
+func (s *fakeSender) SendEmailWithText(subject string, htmlContent string, textContent string, to []string, cc []string, bcc []string, attachFiles []string) error {
+	return s.SendEmail(subject, htmlContent, to, cc, bcc, attachFiles)
+}
+
+// deliverOnce returns a DeliverReminderTx implementation that hands out the reminders in order
+func deliverOnce(reminders ...db.ClaimDueReminderRow) func(context.Context, db.DeliverReminderTxParams) (db.DeliverReminderTxResult, error) {
+	return func(ctx context.Context, arg db.DeliverReminderTxParams) (db.DeliverReminderTxResult, error) {
+		if len(reminders) == 0 {
+			return db.DeliverReminderTxResult{}, nil
+		}
+		reminder := reminders[0]
+		reminders = reminders[1:]
+		return db.DeliverReminderTxResult{
+			Found:       true,
+			Reminder:    reminder,
+			DeliveryErr: arg.Deliver(reminder),
+		}, nil
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	sender := &fakeSender{}
+	worker := NewWorker(store, sender, time.Minute)
+
+	reminders := []db.ClaimDueReminderRow{
+		{ID: 1, Body: "pay rent", Email: "a@email.com", DueAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		{ID: 2, Body: "call mom", Email: "b@email.com"},
+	}
+	store.EXPECT().
+		DeliverReminderTx(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(deliverOnce(reminders...))
+
+	sent, err := worker.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	require.Len(t, sender.sent, 2)
+	require.Equal(t, "Reminder: pay rent", sender.sent[0].subject)
+	require.Equal(t, []string{"b@email.com"}, sender.sent[1].to)
+}
+
+func TestDeliverDueFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	sender := &fakeSender{err: fmt.Errorf("mail server down")}
+	worker := NewWorker(store, sender, time.Minute)
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	deliver := deliverOnce(db.ClaimDueReminderRow{ID: 1, Body: "pay rent"})
+	store.EXPECT().
+		DeliverReminderTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, arg db.DeliverReminderTxParams) (db.DeliverReminderTxResult, error) {
+			//failed reminders are retried later instead of being claimed again right away
+			require.Equal(t, now.Add(9*time.Minute), arg.RetryAt(3))
+			require.Equal(t, int32(DefaultMaxAttempts), arg.MaxAttempts)
+			return deliver(ctx, arg)
+		})
+
+	sent, err := worker.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+}
+
+func TestDeliverDueSkipsDoneAndArchivedTasks(t *testing.T) {
+	store := memdb.NewStore()
+	sender := &fakeSender{}
+	worker := NewWorker(store, sender, time.Minute)
+	ctx := context.Background()
+
+	user, err := store.CreateUser(ctx, db.CreateUserParams{Username: "user", HashedPassword: "secret", Email: "user@email.com"})
+	require.NoError(t, err)
+	remind := func(body string) db.Task {
+		task, err := store.CreateTask(ctx, db.CreateTaskParams{Body: body, OwnerID: user.ID})
+		require.NoError(t, err)
+		fireAt := sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+		_, err = store.CreateReminder(ctx, db.CreateReminderParams{TaskID: task.ID, OwnerID: user.ID, RemindAt: fireAt, FireAt: fireAt})
+		require.NoError(t, err)
+		return task
+	}
+	remind("pay rent")
+	done := remind("call mom")
+	_, err = store.UpdateTask(ctx, db.UpdateTaskParams{ID: done.ID, OwnerID: user.ID, Body: done.Body, IsDone: true})
+	require.NoError(t, err)
+	archived := remind("water plants")
+	_, err = store.UpdateTask(ctx, db.UpdateTaskParams{ID: archived.ID, OwnerID: user.ID, Body: archived.Body, IsDone: true})
+	require.NoError(t, err)
+	_, err = store.ArchiveTask(ctx, db.ArchiveTaskParams{ID: archived.ID, OwnerID: user.ID})
+	require.NoError(t, err)
+
+	sent, err := worker.DeliverDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	require.Len(t, sender.sent, 1)
+	require.Equal(t, "Reminder: pay rent", sender.sent[0].subject)
+}
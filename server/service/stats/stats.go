@@ -0,0 +1,216 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+	// longest range of statistics
+	MaxRange = 366 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPeriod   = fmt.Errorf("period must be day or week")
+	ErrInvalidRange    = fmt.Errorf("from must be before to and at most 366 days apart")
+	ErrInvalidTimezone = fmt.Errorf("unknown timezone")
+)
+
+type Stats struct {
+	Store db.Store
+}
+
+// Params select the tasks counted, From and To are the range and
+// Location decides where days and weeks start
+type Params struct {
+	From     time.Time
+	To       time.Time
+	Period   string
+	Location *time.Location
+}
+
+// Counts are about the tasks created, completed and due in the range.
+// Due only counts tasks that were due before now, Overdue the ones of them
+// that are still open or were completed late
+type Counts struct {
+	Created                  int64   `json:"created"`
+	Completed                int64   `json:"completed"`
+	AverageCompletionSeconds int64   `json:"averageCompletionSeconds"`
+	Due                      int64   `json:"due"`
+	Overdue                  int64   `json:"overdue"`
+	OverdueRate              float64 `json:"overdueRate"`
+}
+
+func newCounts(created, completed int64, avgCompletionSeconds float64, due, overdue int64) Counts {
+	counts := Counts{
+		Created:                  created,
+		Completed:                completed,
+		AverageCompletionSeconds: int64(avgCompletionSeconds),
+		Due:                      due,
+		Overdue:                  overdue,
+	}
+	if due > 0 {
+		counts.OverdueRate = float64(overdue) / float64(due)
+	}
+	return counts
+}
+
+// PeriodCount is the number of tasks created and completed in a day or a week,
+// Start is the first day as YYYY-MM-DD, weeks start on Monday
+type PeriodCount struct {
+	Start     string `json:"start"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+// ProjectStats are the counts of a project, ProjectID is nil for tasks without project
+type ProjectStats struct {
+	ProjectID *int64 `json:"projectId"`
+	Name      string `json:"name"`
+	Counts
+}
+
+// LabelStats are the counts of a label, tasks without labels are not included
+type LabelStats struct {
+	LabelID int64  `json:"labelId"`
+	Name    string `json:"name"`
+	Counts
+}
+
+// Result are the statistics of a user, streaks are days in a row with
+// a completed task and don't depend on the range
+type Result struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Timezone      string    `json:"timezone"`
+	Period        string    `json:"period"`
+	CurrentStreak int64     `json:"currentStreak"`
+	LongestStreak int64     `json:"longestStreak"`
+	Counts
+	Periods  []*PeriodCount  `json:"periods"`
+	Projects []*ProjectStats `json:"projects"`
+	Labels   []*LabelStats   `json:"labels"`
+}
+
+// Get statistics of a user
+func (s *Stats) Get(ctx context.Context, ownerId int64, arg Params) (*Result, error) {
+	if arg.Period != PeriodDay && arg.Period != PeriodWeek {
+		return nil, ErrInvalidPeriod
+	}
+	if !arg.From.Before(arg.To) || arg.To.Sub(arg.From) > MaxRange {
+		return nil, ErrInvalidRange
+	}
+	loc := arg.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	//the name is passed to postgres, which has no local time zone of the server
+	if loc.String() == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+
+	summary, err := s.Store.GetTaskStatsSummary(ctx, db.GetTaskStatsSummaryParams{
+		FromTime: arg.From,
+		ToTime:   arg.To,
+		OwnerID:  ownerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	y, m, d := time.Now().In(loc).Date()
+	streaks, err := s.Store.GetCompletionStreaks(ctx, db.GetCompletionStreaksParams{
+		Timezone: loc.String(),
+		OwnerID:  ownerId,
+		Today:    time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.Store.GetTaskCountsByPeriod(ctx, db.GetTaskCountsByPeriodParams{
+		Period:   arg.Period,
+		Timezone: loc.String(),
+		OwnerID:  ownerId,
+		FromTime: arg.From,
+		ToTime:   arg.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.Store.GetTaskStatsByProject(ctx, db.GetTaskStatsByProjectParams{
+		FromTime: arg.From,
+		ToTime:   arg.To,
+		OwnerID:  ownerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.Store.GetTaskStatsByLabel(ctx, db.GetTaskStatsByLabelParams{
+		FromTime: arg.From,
+		ToTime:   arg.To,
+		OwnerID:  ownerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		From:          arg.From,
+		To:            arg.To,
+		Timezone:      loc.String(),
+		Period:        arg.Period,
+		CurrentStreak: streaks.CurrentStreak,
+		LongestStreak: streaks.LongestStreak,
+		Counts:        newCounts(summary.Created, summary.Completed, summary.AvgCompletionSeconds, summary.Due, summary.Overdue),
+		Periods:       FillPeriods(periods, arg.From, arg.To, arg.Period, loc),
+		Projects:      make([]*ProjectStats, len(projects)),
+		Labels:        make([]*LabelStats, len(labels)),
+	}
+	for i, p := range projects {
+		result.Projects[i] = &ProjectStats{
+			Name:   p.Name,
+			Counts: newCounts(p.Created, p.Completed, p.AvgCompletionSeconds, p.Due, p.Overdue),
+		}
+		if p.ProjectID.Valid {
+			id := p.ProjectID.Int64
+			result.Projects[i].ProjectID = &id
+		}
+	}
+	for i, l := range labels {
+		result.Labels[i] = &LabelStats{
+			LabelID: l.LabelID,
+			Name:    l.Name,
+			Counts:  newCounts(l.Created, l.Completed, l.AvgCompletionSeconds, l.Due, l.Overdue),
+		}
+	}
+	return result, nil
+}
+
+// FillPeriods lists every day or week between from and to,
+// with zero counts for the ones without tasks
+func FillPeriods(rows []db.GetTaskCountsByPeriodRow, from time.Time, to time.Time, period string, loc *time.Location) []*PeriodCount {
+	counts := make(map[string]db.GetTaskCountsByPeriodRow, len(rows))
+	for _, row := range rows {
+		counts[row.PeriodStart.Format("2006-01-02")] = row
+	}
+
+	y, m, d := from.In(loc).Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	step := 1
+	if period == PeriodWeek {
+		//weeks start on Monday like date_trunc
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+		step = 7
+	}
+	periods := []*PeriodCount{}
+	for ; start.Before(to); start = start.AddDate(0, 0, step) {
+		key := start.Format("2006-01-02")
+		row := counts[key]
+		periods = append(periods, &PeriodCount{Start: key, Created: row.Created, Completed: row.Completed})
+	}
+	return periods
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFillPeriods(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	//Wednesday 6 March to Tuesday 12 March in Berlin
+	from := time.Date(2024, 3, 6, 0, 0, 0, 0, berlin)
+	to := time.Date(2024, 3, 13, 0, 0, 0, 0, berlin)
+	rows := []db.GetTaskCountsByPeriodRow{
+		{PeriodStart: time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), Created: 3, Completed: 1},
+		{PeriodStart: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), Completed: 2},
+	}
+
+	days := FillPeriods(rows, from, to, PeriodDay, berlin)
+	require.Len(t, days, 7)
+	require.Equal(t, &PeriodCount{Start: "2024-03-06"}, days[0])
+	require.Equal(t, &PeriodCount{Start: "2024-03-07", Created: 3, Completed: 1}, days[1])
+	require.Equal(t, &PeriodCount{Start: "2024-03-11", Completed: 2}, days[5])
+	require.Equal(t, "2024-03-12", days[6].Start)
+
+	weeks := FillPeriods([]db.GetTaskCountsByPeriodRow{
+		{PeriodStart: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), Created: 3},
+	}, from, to, PeriodWeek, berlin)
+	require.Len(t, weeks, 2)
+	require.Equal(t, &PeriodCount{Start: "2024-03-04", Created: 3}, weeks[0])
+	require.Equal(t, "2024-03-11", weeks[1].Start)
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	s := Stats{Store: store}
+	to := time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -7)
+
+	_, err := s.Get(context.Background(), 1, Params{From: from, To: to, Period: "month"})
+	require.ErrorIs(t, err, ErrInvalidPeriod)
+	_, err = s.Get(context.Background(), 1, Params{From: to, To: from, Period: PeriodDay})
+	require.ErrorIs(t, err, ErrInvalidRange)
+	_, err = s.Get(context.Background(), 1, Params{From: from, To: to, Period: PeriodDay, Location: time.Local})
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+
+	store.EXPECT().
+		GetTaskStatsSummary(gomock.Any(), db.GetTaskStatsSummaryParams{FromTime: from, ToTime: to, OwnerID: 1}).
+		Return(db.GetTaskStatsSummaryRow{Created: 8, Completed: 6, AvgCompletionSeconds: 5400.7, Due: 4, Overdue: 1}, nil)
+	store.EXPECT().
+		GetCompletionStreaks(gomock.Any(), gomock.Any()).
+		Return(db.GetCompletionStreaksRow{CurrentStreak: 2, LongestStreak: 9}, nil)
+	store.EXPECT().
+		GetTaskCountsByPeriod(gomock.Any(), gomock.Any()).
+		Return([]db.GetTaskCountsByPeriodRow{}, nil)
+	store.EXPECT().
+		GetTaskStatsByProject(gomock.Any(), gomock.Any()).
+		Return([]db.GetTaskStatsByProjectRow{{Name: "", Created: 2}}, nil)
+	store.EXPECT().
+		GetTaskStatsByLabel(gomock.Any(), gomock.Any()).
+		Return([]db.GetTaskStatsByLabelRow{{LabelID: 4, Name: "work", Due: 2, Overdue: 2}}, nil)
+
+	result, err := s.Get(context.Background(), 1, Params{From: from, To: to, Period: PeriodDay})
+	require.NoError(t, err)
+	require.Equal(t, "UTC", result.Timezone)
+	require.Equal(t, int64(5400), result.AverageCompletionSeconds)
+	require.Equal(t, 0.25, result.OverdueRate)
+	require.Equal(t, int64(2), result.CurrentStreak)
+	require.Len(t, result.Periods, 7)
+	require.Nil(t, result.Projects[0].ProjectID)
+	require.Equal(t, 1.0, result.Labels[0].OverdueRate)
+}
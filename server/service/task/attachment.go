@@ -0,0 +1,97 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	// MaxAttachmentSize is the largest file that can be attached to a task
+	MaxAttachmentSize = 10 << 20
+	// MaxAttachments is how many files can be attached at once
+	MaxAttachments = 10
+)
+
+var (
+	ErrAttachmentTooLarge  = fmt.Errorf("attachments must not be larger than %d MB", MaxAttachmentSize>>20)
+	ErrTooManyAttachments  = fmt.Errorf("at most %d files can be attached at once", MaxAttachments)
+	ErrAttachmentNotInTask = fmt.Errorf("attachment does not belong to task")
+)
+
+// Attachment is a file to attach to a task
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// CheckAttachments validates files before they are attached
+func CheckAttachments(attachments []Attachment) error {
+	if len(attachments) > MaxAttachments {
+		return ErrTooManyAttachments
+	}
+	for _, a := range attachments {
+		if len(a.Content) > MaxAttachmentSize {
+			return ErrAttachmentTooLarge
+		}
+	}
+	return nil
+}
+
+// Attach files to a task, the owner was checked by the caller
+func (t *Task) AddAttachments(ctx context.Context, taskId int64, attachments []Attachment) ([]db.CreateTaskAttachmentRow, error) {
+	if err := CheckAttachments(attachments); err != nil {
+		return nil, err
+	}
+	result := make([]db.CreateTaskAttachmentRow, 0, len(attachments))
+	for _, a := range attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		//only the base name of a path sent by a client is kept
+		filename := filepath.Base(strings.ReplaceAll(a.Filename, `\`, "/"))
+		if filename == "." || filename == "/" {
+			filename = "attachment"
+		}
+		attachment, err := t.Store.CreateTaskAttachment(ctx, db.CreateTaskAttachmentParams{
+			TaskID:      taskId,
+			Filename:    filename,
+			ContentType: contentType,
+			Size:        int64(len(a.Content)),
+			Content:     a.Content,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, attachment)
+	}
+	return result, nil
+}
+
+// Get the attachments of a task without their content
+func (t *Task) GetAttachmentList(ctx context.Context, taskId int64, ownerId int64) ([]db.GetTaskAttachmentListRow, error) {
+	if _, err := t.GetTaskById(ctx, taskId, ownerId); err != nil {
+		return nil, err
+	}
+	return t.Store.GetTaskAttachmentList(ctx, taskId)
+}
+
+// Get an attachment of a task with its content
+func (t *Task) GetAttachment(ctx context.Context, taskId int64, attachmentId int64, ownerId int64) (*db.TaskAttachment, error) {
+	if _, err := t.GetTaskById(ctx, taskId, ownerId); err != nil {
+		return nil, err
+	}
+	attachment, err := t.Store.GetTaskAttachment(ctx, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != taskId {
+		return nil, ErrAttachmentNotInTask
+	}
+	return &attachment, nil
+}
@@ -0,0 +1,111 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+var (
+	ErrDeferInPast     = fmt.Errorf("a task can only be deferred until a time in the future")
+	ErrInvalidDeferDay = fmt.Errorf("unknown date, expected e.g. tomorrow, next monday, next week, in 3 days, +2w or 2024-01-31")
+)
+
+var (
+	weekdayOf = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+	inAmount = regexp.MustCompile(`^in (\d+) (hour|day|week|month)s?$`)
+)
+
+// nextWeekday is the first day after today that falls on weekday
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday)-int(today.Weekday())+6)%7 + 1
+	return today.AddDate(0, 0, days)
+}
+
+// ParseDeferDate turns a natural date into the time a task is deferred until.
+// Days start at midnight in loc. Besides the dates of task filters
+// (tomorrow, +3d, +2w, 2024-01-31, RFC 3339) it understands weekdays like
+// monday or next monday (both the first Monday after today), next week
+// (next Monday), weekend (next Saturday), next month and in 3 days, in 2 hours...
+func ParseDeferDate(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	value = strings.Join(strings.Fields(strings.ToLower(value)), " ")
+
+	switch value {
+	case "next week":
+		return nextWeekday(today, time.Monday), nil
+	case "weekend", "this weekend":
+		return nextWeekday(today, time.Saturday), nil
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, loc), nil
+	}
+	if weekday, ok := weekdayOf[strings.TrimPrefix(value, "next ")]; ok {
+		return nextWeekday(today, weekday), nil
+	}
+	if m := inAmount.FindStringSubmatch(value); m != nil {
+		amount, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, ErrInvalidDeferDay
+		}
+		switch m[2] {
+		case "hour":
+			return now.Add(time.Duration(amount) * time.Hour), nil
+		case "day":
+			return today.AddDate(0, 0, amount), nil
+		case "week":
+			return today.AddDate(0, 0, 7*amount), nil
+		case "month":
+			return today.AddDate(0, amount, 0), nil
+		}
+	}
+	if ft, ok := parseFilterTime(value, now, loc); ok {
+		return ft.start, nil
+	}
+	return time.Time{}, ErrInvalidDeferDay
+}
+
+// Defer hides a task from the task list until the given time, it shows up again by itself
+func (t *Task) DeferTask(ctx context.Context, id int64, ownerId int64, until time.Time) (*db.Task, error) {
+	if !until.After(time.Now()) {
+		return nil, ErrDeferInPast
+	}
+	return t.setDeferredUntil(ctx, id, ownerId, sql.NullTime{Time: until, Valid: true})
+}
+
+// Undefer shows a deferred task in the task list right away
+func (t *Task) UndeferTask(ctx context.Context, id int64, ownerId int64) (*db.Task, error) {
+	return t.setDeferredUntil(ctx, id, ownerId, sql.NullTime{})
+}
+
+func (t *Task) setDeferredUntil(ctx context.Context, id int64, ownerId int64, until sql.NullTime) (*db.Task, error) {
+	if _, err := t.GetTaskById(ctx, id, ownerId); err != nil {
+		return nil, err
+	}
+	task, err := t.Store.SetTaskDeferredUntil(ctx, db.SetTaskDeferredUntilParams{
+		ID:            id,
+		OwnerID:       ownerId,
+		DeferredUntil: until,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
@@ -0,0 +1,74 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseDeferDate(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	//Thursday 14 March 2024, 23:30 in Berlin
+	now := time.Date(2024, 3, 14, 22, 30, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, berlin) }
+
+	testCases := []struct {
+		value string
+		want  time.Time
+	}{
+		{"tomorrow", day(3, 15)},
+		{"next monday", day(3, 18)},
+		{"Monday", day(3, 18)},
+		{"next  Thu", day(3, 21)},
+		{"next week", day(3, 18)},
+		{"weekend", day(3, 16)},
+		{"next month", day(4, 1)},
+		{"in 3 days", day(3, 17)},
+		{"in 1 week", day(3, 21)},
+		{"in 2 hours", now.Add(2 * time.Hour)},
+		{"+2w", day(3, 28)},
+		{"2024-04-02", day(4, 2)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := ParseDeferDate(tc.value, now, berlin)
+			require.NoError(t, err)
+			require.True(t, tc.want.Equal(got), "got %v", got)
+		})
+	}
+
+	_, err = ParseDeferDate("someday", now, berlin)
+	require.ErrorIs(t, err, ErrInvalidDeferDay)
+}
+
+func TestDeferTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	task := Task{Store: store}
+
+	_, err := task.DeferTask(context.Background(), 1, 1, time.Now().Add(-time.Minute))
+	require.ErrorIs(t, err, ErrDeferInPast)
+
+	until := time.Now().Add(time.Hour)
+	store.EXPECT().GetTask(gomock.Any(), int64(1)).Return(db.Task{ID: 1, OwnerID: 1}, nil)
+	store.EXPECT().
+		SetTaskDeferredUntil(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.SetTaskDeferredUntilParams) (db.Task, error) {
+			require.True(t, arg.DeferredUntil.Valid)
+			require.True(t, until.Equal(arg.DeferredUntil.Time))
+			return db.Task{ID: 1, OwnerID: 1, DeferredUntil: arg.DeferredUntil}, nil
+		})
+	deferred, err := task.DeferTask(context.Background(), 1, 1, until)
+	require.NoError(t, err)
+	require.True(t, deferred.DeferredUntil.Valid)
+
+	store.EXPECT().GetTask(gomock.Any(), int64(1)).Return(db.Task{ID: 1, OwnerID: 2}, nil)
+	_, err = task.UndeferTask(context.Background(), 1, 1)
+	require.ErrorIs(t, err, ErrOwnerNotMatched)
+}
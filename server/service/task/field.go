@@ -0,0 +1,74 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+)
+
+var (
+	ErrTaskNotInProject  = fmt.Errorf("custom fields need the task to be in a project")
+	ErrFieldNotInProject = fmt.Errorf("custom field does not belong to the task's project")
+)
+
+// Set custom field values of a task, a JSON null removes the value
+func (t *Task) SetFieldValues(ctx context.Context, taskId int64, ownerId int64, values map[int64]json.RawMessage) ([]db.TaskCustomFieldValue, error) {
+	task, err := t.GetTaskById(ctx, taskId, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	if !task.ProjectID.Valid {
+		return nil, ErrTaskNotInProject
+	}
+	fields, err := t.Store.GetCustomFieldList(ctx, task.ProjectID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	fieldOf := make(map[int64]*db.CustomField, len(fields))
+	for i := range fields {
+		fieldOf[fields[i].ID] = &fields[i]
+	}
+
+	arg := db.SetCustomFieldValuesTxParams{
+		TaskID: taskId,
+		Values: make(map[int64]json.RawMessage, len(values)),
+	}
+	for fieldId, value := range values {
+		field, ok := fieldOf[fieldId]
+		if !ok {
+			return nil, ErrFieldNotInProject
+		}
+		if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+			arg.Values[fieldId] = nil
+			continue
+		}
+		arg.Values[fieldId], err = project.ValidateFieldValue(field, value, func(id int64) bool {
+			_, err := t.Store.GetUser(ctx, db.GetUserParams{ID: id})
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t.Store.SetCustomFieldValuesTx(ctx, arg)
+}
+
+// Get custom field values of tasks, grouped by task id
+func (t *Task) GetFieldValues(ctx context.Context, taskIds []int64) (map[int64][]db.TaskCustomFieldValue, error) {
+	grouped := make(map[int64][]db.TaskCustomFieldValue, len(taskIds))
+	if len(taskIds) == 0 {
+		return grouped, nil
+	}
+	values, err := t.Store.GetCustomFieldValuesByTasks(ctx, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		grouped[value.TaskID] = append(grouped[value.TaskID], value)
+	}
+	return grouped, nil
+}
@@ -0,0 +1,310 @@
+package task
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+)
+
+// filterEnv is what a filter is evaluated against
+type filterEnv struct {
+	ownerId int64
+	now     time.Time
+	// dates like today or 2024-01-31 are days in this location
+	loc *time.Location
+	// custom fields of the owner, only needed for cf.<id> terms
+	fieldOf map[int64]*db.CustomField
+}
+
+// ValidateFilter reports the first syntax error of a filter
+func ValidateFilter(filter string) error {
+	_, err := parseFilter(filter)
+	return err
+}
+
+// compileFilter turns a filter into a condition on tasks
+func compileFilter(filter string, env *filterEnv) (db.TaskFilter, error) {
+	node, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return compileNode(node, env)
+}
+
+func compileNode(node filterNode, env *filterEnv) (db.TaskFilter, error) {
+	switch n := node.(type) {
+	case *andNode:
+		return compileBoth(n.left, n.right, env, func(l, r db.TaskFilter) db.TaskFilter { return db.FilterAnd{l, r} })
+	case *orNode:
+		return compileBoth(n.left, n.right, env, func(l, r db.TaskFilter) db.TaskFilter { return db.FilterOr{l, r} })
+	case *notNode:
+		expr, err := compileNode(n.expr, env)
+		if err != nil {
+			return nil, err
+		}
+		return db.FilterNot{Filter: expr}, nil
+	case *flagNode:
+		switch strings.ToLower(n.name) {
+		case "done":
+			return db.FilterCompare{Column: db.TaskColumnIsDone, Op: "=", Value: true}, nil
+		case "overdue":
+			return db.FilterAnd{
+				db.FilterCompare{Column: db.TaskColumnDueAt, Op: "<", Value: env.now},
+				db.FilterCompare{Column: db.TaskColumnIsDone, Op: "=", Value: false},
+			}, nil
+		}
+		return nil, filterErrorf(n.pos, "unknown filter %q, expected a comparison like field = value or a flag (done, overdue)", n.name)
+	case *textNode:
+		return db.FilterBodyContains{Text: n.text}, nil
+	case *compareNode:
+		return compileCompare(n, env)
+	}
+	return nil, filterErrorf(node.position(), "unsupported filter term")
+}
+
+func compileBoth(left, right filterNode, env *filterEnv, join func(l, r db.TaskFilter) db.TaskFilter) (db.TaskFilter, error) {
+	l, err := compileNode(left, env)
+	if err != nil {
+		return nil, err
+	}
+	r, err := compileNode(right, env)
+	if err != nil {
+		return nil, err
+	}
+	return join(l, r), nil
+}
+
+// filterOps are the operators of the query language as they are compared in a db.TaskFilter
+var filterOps = map[string]string{"=": "=", "!=": "<>", "<": "<", "<=": "<=", ">": ">", ">=": ">="}
+
+func compileCompare(n *compareNode, env *filterEnv) (db.TaskFilter, error) {
+	unsupported := func() error {
+		return filterErrorf(n.opPos, "operator %s is not supported for %s", n.op, n.field)
+	}
+	switch n.field {
+	case "due":
+		return compileTime(db.TaskColumnDueAt, n, env)
+	case "created":
+		return compileTime(db.TaskColumnCreatedAt, n, env)
+	case "priority":
+		priority, err := ParsePriority(n.value)
+		if err != nil {
+			return nil, filterErrorf(n.valuePos, "%v", err)
+		}
+		if n.op == "~" {
+			return nil, unsupported()
+		}
+		return db.FilterCompare{Column: db.TaskColumnPriority, Op: filterOps[n.op], Value: priority}, nil
+	case "done":
+		done, err := strconv.ParseBool(n.value)
+		if err != nil {
+			return nil, filterErrorf(n.valuePos, "done must be true or false")
+		}
+		switch n.op {
+		case "=", "!=":
+			return db.FilterCompare{Column: db.TaskColumnIsDone, Op: filterOps[n.op], Value: done}, nil
+		}
+		return nil, unsupported()
+	case "body", "text":
+		switch n.op {
+		case "~":
+			return db.FilterBodyContains{Text: n.value}, nil
+		case "=":
+			return db.FilterBodyEquals{Text: n.value}, nil
+		case "!=":
+			return db.FilterNot{Filter: db.FilterBodyEquals{Text: n.value}}, nil
+		}
+		return nil, unsupported()
+	case "status":
+		cond := db.FilterStatusName{OwnerID: env.ownerId, Name: n.value}
+		return equalityOnly(n, cond, db.FilterIsNull{Column: db.TaskColumnStatusID}, db.TaskColumnStatusID, unsupported)
+	case "project":
+		var cond db.TaskFilter = db.FilterProjectName{OwnerID: env.ownerId, Name: n.value}
+		if id, err := strconv.ParseInt(n.value, 10, 64); err == nil {
+			cond = db.FilterCompare{Column: db.TaskColumnProjectID, Op: "=", Value: id}
+		}
+		return equalityOnly(n, cond, db.FilterIsNull{Column: db.TaskColumnProjectID}, db.TaskColumnProjectID, unsupported)
+	case "label":
+		cond := db.FilterLabel{Name: n.value}
+		return equalityOnly(n, cond, db.FilterNot{Filter: db.FilterLabel{}}, "", unsupported)
+	}
+	if strings.HasPrefix(n.field, "cf.") {
+		return compileField(n, env)
+	}
+	return nil, filterErrorf(n.fieldPos, "unknown field %q, expected due, created, priority, status, project, label, body, done or cf.<id>", n.field)
+}
+
+// equalityOnly handles = and != of a term that is true when cond holds,
+// isNone is the condition for "= none" and column, when set, is a nullable column
+// that makes != also match tasks without a value
+func equalityOnly(n *compareNode, cond db.TaskFilter, isNone db.TaskFilter, column db.TaskColumn, unsupported func() error) (db.TaskFilter, error) {
+	none := strings.EqualFold(n.value, "none")
+	switch {
+	case n.op == "=" && none:
+		return isNone, nil
+	case n.op == "=":
+		return cond, nil
+	case n.op == "!=" && none:
+		return db.FilterNot{Filter: isNone}, nil
+	case n.op == "!=" && column != "":
+		return db.FilterOr{db.FilterIsNull{Column: column}, db.FilterNot{Filter: cond}}, nil
+	case n.op == "!=":
+		return db.FilterNot{Filter: cond}, nil
+	}
+	return nil, unsupported()
+}
+
+// filterTime is a point in time or, for values like today, a whole day
+type filterTime struct {
+	start time.Time
+	day   bool
+}
+
+var relativeTime = regexp.MustCompile(`^([+-]?)(\d+)([hdwm])$`)
+
+// parseFilterTime understands now, today, tomorrow, yesterday,
+// offsets like +3h, -2d, +1w, +1m and dates like 2024-01-31 or RFC 3339 timestamps
+func parseFilterTime(value string, now time.Time, loc *time.Location) (filterTime, bool) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	switch strings.ToLower(value) {
+	case "now":
+		return filterTime{start: now}, true
+	case "today":
+		return filterTime{start: today, day: true}, true
+	case "tomorrow":
+		return filterTime{start: today.AddDate(0, 0, 1), day: true}, true
+	case "yesterday":
+		return filterTime{start: today.AddDate(0, 0, -1), day: true}, true
+	}
+	if m := relativeTime.FindStringSubmatch(value); m != nil {
+		amount, err := strconv.Atoi(m[2])
+		if err != nil {
+			return filterTime{}, false
+		}
+		if m[1] == "-" {
+			amount = -amount
+		}
+		switch m[3] {
+		case "h":
+			return filterTime{start: now.Add(time.Duration(amount) * time.Hour)}, true
+		case "d":
+			return filterTime{start: today.AddDate(0, 0, amount), day: true}, true
+		case "w":
+			return filterTime{start: today.AddDate(0, 0, 7*amount), day: true}, true
+		case "m":
+			return filterTime{start: today.AddDate(0, amount, 0), day: true}, true
+		}
+	}
+	if date, err := time.ParseInLocation(project.DateLayout, value, loc); err == nil {
+		return filterTime{start: date, day: true}, true
+	}
+	if instant, err := time.Parse(time.RFC3339, value); err == nil {
+		return filterTime{start: instant}, true
+	}
+	return filterTime{}, false
+}
+
+// compileTime compares a timestamp column, a day matches any time within it
+// so "due = today" and "due <= +7d" include the whole day
+func compileTime(column db.TaskColumn, n *compareNode, env *filterEnv) (db.TaskFilter, error) {
+	if strings.EqualFold(n.value, "none") {
+		switch n.op {
+		case "=":
+			return db.FilterIsNull{Column: column}, nil
+		case "!=":
+			return db.FilterNot{Filter: db.FilterIsNull{Column: column}}, nil
+		}
+		return nil, filterErrorf(n.opPos, "only = and != can be used with none")
+	}
+	ft, ok := parseFilterTime(n.value, env.now, env.loc)
+	if !ok {
+		return nil, filterErrorf(n.valuePos, "invalid date %q, expected e.g. today, tomorrow, +7d, -2w, +3h or 2024-01-31", n.value)
+	}
+	compare := func(op string, value time.Time) db.TaskFilter {
+		return db.FilterCompare{Column: column, Op: op, Value: value}
+	}
+	if !ft.day {
+		if n.op == "~" {
+			return nil, filterErrorf(n.opPos, "operator ~ is not supported for %s", n.field)
+		}
+		return compare(filterOps[n.op], ft.start), nil
+	}
+	end := ft.start.AddDate(0, 0, 1)
+	switch n.op {
+	case "=":
+		return db.FilterAnd{compare(">=", ft.start), compare("<", end)}, nil
+	case "!=":
+		return db.FilterOr{compare("<", ft.start), compare(">=", end)}, nil
+	case "<":
+		return compare("<", ft.start), nil
+	case "<=":
+		return compare("<", end), nil
+	case ">":
+		return compare(">=", end), nil
+	case ">=":
+		return compare(">=", ft.start), nil
+	}
+	return nil, filterErrorf(n.opPos, "operator %s is not supported for %s", n.op, n.field)
+}
+
+// compileField compares a custom field value,
+// ordering works on number and date fields and ~ on text fields
+func compileField(n *compareNode, env *filterEnv) (db.TaskFilter, error) {
+	fieldId, err := strconv.ParseInt(strings.TrimPrefix(n.field, "cf."), 10, 64)
+	if err != nil {
+		return nil, filterErrorf(n.fieldPos, "custom fields are written cf.<id>, e.g. cf.12")
+	}
+	field, ok := env.fieldOf[fieldId]
+	if !ok {
+		return nil, filterErrorf(n.fieldPos, "custom field %d not found", fieldId)
+	}
+	if strings.EqualFold(n.value, "none") {
+		switch n.op {
+		case "=":
+			return db.FilterNot{Filter: db.FilterFieldSet{FieldID: field.ID}}, nil
+		case "!=":
+			return db.FilterFieldSet{FieldID: field.ID}, nil
+		}
+		return nil, filterErrorf(n.opPos, "only = and != can be used with none")
+	}
+
+	switch n.op {
+	case "=", "!=":
+		cond, err := fieldCondition(field, n.value)
+		if err != nil {
+			return nil, filterErrorf(n.valuePos, "%v", err)
+		}
+		if n.op == "!=" {
+			return db.FilterNot{Filter: cond}, nil
+		}
+		return cond, nil
+	case "~":
+		if field.FieldType != project.FieldText {
+			return nil, filterErrorf(n.opPos, "operator ~ only works on text fields")
+		}
+		return db.FilterFieldContains{FieldID: field.ID, Text: n.value}, nil
+	}
+
+	op := filterOps[n.op]
+	switch field.FieldType {
+	case project.FieldNumber:
+		v, err := strconv.ParseFloat(n.value, 64)
+		if err != nil {
+			return nil, filterErrorf(n.valuePos, "%s is a number field", field.Name)
+		}
+		return db.FilterFieldCompare{FieldID: field.ID, Op: op, Value: v}, nil
+	case project.FieldDate:
+		ft, ok := parseFilterTime(n.value, env.now, env.loc)
+		if !ok {
+			return nil, filterErrorf(n.valuePos, "invalid date %q", n.value)
+		}
+		//date values are stored as 2006-01-02 strings which compare like dates
+		return db.FilterFieldCompare{FieldID: field.ID, Op: op, Value: ft.start.Format(project.DateLayout)}, nil
+	}
+	return nil, filterErrorf(n.opPos, "operator %s only works on number and date fields", n.op)
+}
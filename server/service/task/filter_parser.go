@@ -0,0 +1,336 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A task filter is a small boolean query language, e.g.
+//
+//	due < +7d and priority >= high and label:backend and not done
+//	(status = Review or status = "In Progress") and body ~ login
+//
+// Terms are joined with and/or (and binds tighter, adjacent terms are and-ed),
+// negated with not and grouped with parentheses.
+// A term is a comparison "field op value", a shorthand "field:value" for "field = value",
+// a bare flag (done, overdue) or a quoted string searched in the task body.
+
+// FilterError is a syntax or semantic error in a filter, Pos is the 1-based column it refers to
+type FilterError struct {
+	Pos int
+	Msg string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("filter error at position %d: %s", e.Pos, e.Msg)
+}
+
+func filterErrorf(offset int, format string, args ...interface{}) error {
+	return &FilterError{Pos: offset + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokColon
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()<>=!~:"`, r)
+}
+
+// lexFilter splits a filter into tokens
+func lexFilter(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == ':':
+			tokens = append(tokens, token{kind: tokColon, text: ":", pos: i})
+			i++
+		case r == '=' || r == '~':
+			tokens = append(tokens, token{kind: tokOp, text: string(r), pos: i})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			op := string(r)
+			if i+1 < len(input) && input[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, filterErrorf(i, "unexpected \"!\", did you mean \"!=\" or \"not\"?")
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		case r == '"':
+			var sb strings.Builder
+			j := i + 1
+			closed := false
+			for j < len(input) {
+				c := input[j]
+				if c == '\\' && j+1 < len(input) {
+					sb.WriteByte(input[j+1])
+					j += 2
+					continue
+				}
+				if c == '"' {
+					closed = true
+					j++
+					break
+				}
+				sb.WriteByte(c)
+				j++
+			}
+			if !closed {
+				return nil, filterErrorf(i, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: i})
+			i = j
+		default:
+			j := i
+			for j < len(input) {
+				r, size := utf8.DecodeRuneInString(input[j:])
+				if !isWordRune(r) {
+					break
+				}
+				j += size
+			}
+			tokens = append(tokens, token{kind: tokWord, text: input[i:j], pos: i})
+			i = j
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// filter syntax tree
+
+type filterNode interface {
+	position() int
+}
+
+type andNode struct {
+	left, right filterNode
+}
+
+type orNode struct {
+	left, right filterNode
+}
+
+type notNode struct {
+	expr filterNode
+	pos  int
+}
+
+// compareNode is "field op value", fieldPos/opPos/valuePos are byte offsets
+type compareNode struct {
+	field    string
+	fieldPos int
+	op       string
+	opPos    int
+	value    string
+	valuePos int
+}
+
+// flagNode is a bare word such as done
+type flagNode struct {
+	name string
+	pos  int
+}
+
+// textNode is a bare quoted string matched against the body
+type textNode struct {
+	text string
+	pos  int
+}
+
+func (n *andNode) position() int     { return n.left.position() }
+func (n *orNode) position() int      { return n.left.position() }
+func (n *notNode) position() int     { return n.pos }
+func (n *compareNode) position() int { return n.fieldPos }
+func (n *flagNode) position() int    { return n.pos }
+func (n *textNode) position() int    { return n.pos }
+
+type filterParser struct {
+	tokens []token
+	i      int
+}
+
+// parseFilter parses a filter into its syntax tree
+func parseFilter(input string) (filterNode, error) {
+	tokens, err := lexFilter(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, filterErrorf(0, "filter is empty")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		if t.kind == tokRParen {
+			return nil, filterErrorf(t.pos, "unexpected \")\" without matching \"(\"")
+		}
+		return nil, filterErrorf(t.pos, "unexpected %s", t)
+	}
+	return node, nil
+}
+
+func (p *filterParser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *filterParser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *filterParser) isKeyword(t token, keyword string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if p.isKeyword(t, "and") {
+			p.next()
+		} else if !p.startsTerm(t) {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+}
+
+// startsTerm reports whether t can begin a term that is implicitly and-ed
+func (p *filterParser) startsTerm(t token) bool {
+	switch t.kind {
+	case tokWord:
+		return !p.isKeyword(t, "or") && !p.isKeyword(t, "and")
+	case tokString, tokLParen:
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	t := p.peek()
+	if p.isKeyword(t, "not") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{expr: expr, pos: t.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLParen:
+		if p.peek().kind == tokRParen {
+			return nil, filterErrorf(p.peek().pos, "empty parentheses")
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokRParen {
+			return nil, filterErrorf(closing.pos, "expected \")\" to close \"(\" at position %d, found %s", t.pos+1, closing)
+		}
+		p.next()
+		return node, nil
+	case tokString:
+		return &textNode{text: t.text, pos: t.pos}, nil
+	case tokWord:
+		if p.isKeyword(t, "and") || p.isKeyword(t, "or") {
+			return nil, filterErrorf(t.pos, "expected a term before %q", t.text)
+		}
+		op := p.peek()
+		if op.kind != tokOp && op.kind != tokColon {
+			return &flagNode{name: t.text, pos: t.pos}, nil
+		}
+		p.next()
+		value := p.next()
+		if value.kind != tokWord && value.kind != tokString {
+			return nil, filterErrorf(value.pos, "expected a value after %q, found %s", op.text, value)
+		}
+		opText := op.text
+		if op.kind == tokColon {
+			opText = "="
+		}
+		return &compareNode{
+			field:    strings.ToLower(t.text),
+			fieldPos: t.pos,
+			op:       opText,
+			opPos:    op.pos,
+			value:    value.text,
+			valuePos: value.pos,
+		}, nil
+	case tokEOF:
+		return nil, filterErrorf(t.pos, "unexpected end of filter, expected a term")
+	}
+	return nil, filterErrorf(t.pos, "unexpected %s, expected a term", t)
+}
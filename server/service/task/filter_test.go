@@ -0,0 +1,188 @@
+package task
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEnv() *filterEnv {
+	return &filterEnv{
+		ownerId: 7,
+		now:     time.Date(2024, 3, 14, 15, 30, 0, 0, time.UTC),
+		loc:     time.UTC,
+		fieldOf: map[int64]*db.CustomField{
+			4: {ID: 4, Name: "points", FieldType: "number"},
+			5: {ID: 5, Name: "platform", FieldType: "multi_select"},
+		},
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	compare := func(column db.TaskColumn, op string, value interface{}) db.TaskFilter {
+		return db.FilterCompare{Column: column, Op: op, Value: value}
+	}
+	done := compare(db.TaskColumnIsDone, "=", true)
+	testCases := []struct {
+		filter string
+		want   db.TaskFilter
+	}{
+		{
+			"due < +7d and priority >= high and label:backend and not done",
+			db.FilterAnd{
+				db.FilterAnd{
+					db.FilterAnd{compare(db.TaskColumnDueAt, "<", day(21)), compare(db.TaskColumnPriority, ">=", PriorityHigh)},
+					db.FilterLabel{Name: "backend"},
+				},
+				db.FilterNot{Filter: done},
+			},
+		},
+		{
+			"due = today or due = none",
+			db.FilterOr{
+				db.FilterAnd{compare(db.TaskColumnDueAt, ">=", day(14)), compare(db.TaskColumnDueAt, "<", day(15))},
+				db.FilterIsNull{Column: db.TaskColumnDueAt},
+			},
+		},
+		{
+			"due <= tomorrow",
+			compare(db.TaskColumnDueAt, "<", day(16)),
+		},
+		{
+			"created > -1w",
+			compare(db.TaskColumnCreatedAt, ">=", day(8)),
+		},
+		{
+			"due < +2h",
+			compare(db.TaskColumnDueAt, "<", time.Date(2024, 3, 14, 17, 30, 0, 0, time.UTC)),
+		},
+		{
+			"due != +2h",
+			compare(db.TaskColumnDueAt, "<>", time.Date(2024, 3, 14, 17, 30, 0, 0, time.UTC)),
+		},
+		{
+			`"fix login" overdue`,
+			db.FilterAnd{
+				db.FilterBodyContains{Text: "fix login"},
+				db.FilterAnd{
+					compare(db.TaskColumnDueAt, "<", time.Date(2024, 3, 14, 15, 30, 0, 0, time.UTC)),
+					compare(db.TaskColumnIsDone, "=", false),
+				},
+			},
+		},
+		{
+			"body != todo",
+			db.FilterNot{Filter: db.FilterBodyEquals{Text: "todo"}},
+		},
+		{
+			`status != "In Progress"`,
+			db.FilterOr{
+				db.FilterIsNull{Column: db.TaskColumnStatusID},
+				db.FilterNot{Filter: db.FilterStatusName{OwnerID: 7, Name: "In Progress"}},
+			},
+		},
+		{
+			"status = none or label != none",
+			db.FilterOr{
+				db.FilterIsNull{Column: db.TaskColumnStatusID},
+				db.FilterNot{Filter: db.FilterNot{Filter: db.FilterLabel{}}},
+			},
+		},
+		{
+			"project = 12 and (priority = urgent or priority = 3)",
+			db.FilterAnd{
+				compare(db.TaskColumnProjectID, "=", int64(12)),
+				db.FilterOr{compare(db.TaskColumnPriority, "=", PriorityUrgent), compare(db.TaskColumnPriority, "=", PriorityHigh)},
+			},
+		},
+		{
+			"project != work",
+			db.FilterOr{
+				db.FilterIsNull{Column: db.TaskColumnProjectID},
+				db.FilterNot{Filter: db.FilterProjectName{OwnerID: 7, Name: "work"}},
+			},
+		},
+		{
+			"cf.4 >= 3 and cf.5 = ios",
+			db.FilterAnd{
+				db.FilterFieldCompare{FieldID: 4, Op: ">=", Value: 3.0},
+				db.FilterFieldEquals{FieldID: 5, Value: json.RawMessage(`["ios"]`), Contains: true},
+			},
+		},
+		{
+			"cf.4 = none",
+			db.FilterNot{Filter: db.FilterFieldSet{FieldID: 4}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			filter, err := compileFilter(tc.filter, newTestEnv())
+			require.NoError(t, err)
+			require.Equal(t, tc.want, filter)
+		})
+	}
+}
+
+func TestCompileFilterInLocation(t *testing.T) {
+	env := newTestEnv()
+	loc, err := time.LoadLocation("Pacific/Auckland")
+	require.NoError(t, err)
+	env.loc = loc
+	//15:30 UTC is already the 15th in Auckland
+	filter, err := compileFilter("due = today", env)
+	require.NoError(t, err)
+	start := filter.(db.FilterAnd)[0].(db.FilterCompare).Value.(time.Time)
+	require.True(t, start.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, loc)))
+}
+
+func TestFilterErrors(t *testing.T) {
+	testCases := []struct {
+		filter string
+		pos    int
+		msg    string
+	}{
+		{"", 1, "filter is empty"},
+		{"due <", 6, `expected a value after "<", found end of filter`},
+		{"due < +7d and", 14, "unexpected end of filter, expected a term"},
+		{"(done or overdue", 17, `expected ")" to close "(" at position 1, found end of filter`},
+		{"done)", 5, `unexpected ")" without matching "("`},
+		{`body ~ "login`, 8, "unterminated string"},
+		{"due ! today", 5, `unexpected "!", did you mean "!=" or "not"?`},
+		{"priority >= highest", 13, "priority must be one of none, low, medium, high or urgent"},
+		{"due < someday", 7, `invalid date "someday", expected e.g. today, tomorrow, +7d, -2w, +3h or 2024-01-31`},
+		{"label < backend", 7, "operator < is not supported for label"},
+		{"not colour = red", 5, `unknown field "colour", expected due, created, priority, status, project, label, body, done or cf.<id>`},
+		{"done and urgent", 10, `unknown filter "urgent", expected a comparison like field = value or a flag (done, overdue)`},
+		{"cf.9 = 1", 1, "custom field 9 not found"},
+		{"cf.5 > ios", 6, "operator > only works on number and date fields"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			_, err := compileFilter(tc.filter, newTestEnv())
+			require.Error(t, err)
+			filterErr, ok := err.(*FilterError)
+			require.True(t, ok, err.Error())
+			require.Equal(t, tc.pos, filterErr.Pos)
+			require.Equal(t, tc.msg, filterErr.Msg)
+		})
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	priority, err := ParsePriority("High")
+	require.NoError(t, err)
+	require.Equal(t, PriorityHigh, priority)
+
+	priority, err = ParsePriority("4")
+	require.NoError(t, err)
+	require.Equal(t, "urgent", PriorityName(priority))
+
+	_, err = ParsePriority("5")
+	require.ErrorIs(t, err, ErrInvalidPriority)
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+var ErrInvalidLabel = fmt.Errorf("labels must not be empty or contain spaces, quotes or parentheses")
+
+// NormalizeLabels trims names and drops duplicates,
+// labels are compared case-insensitively so "Bug" and "bug" are one label
+func NormalizeLabels(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsAny(name, " \t\"():") {
+			return nil, ErrInvalidLabel
+		}
+		if seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// Replace the labels of a task
+func (t *Task) SetLabels(ctx context.Context, taskId int64, ownerId int64, names []string) ([]db.Label, error) {
+	names, err := NormalizeLabels(names)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.GetTaskById(ctx, taskId, ownerId); err != nil {
+		return nil, err
+	}
+	return t.Store.SetTaskLabelsTx(ctx, db.SetTaskLabelsTxParams{
+		TaskID:  taskId,
+		OwnerID: ownerId,
+		Names:   names,
+	})
+}
+
+// Get label names of tasks, grouped by task id
+func (t *Task) GetLabels(ctx context.Context, taskIds []int64) (map[int64][]string, error) {
+	grouped := make(map[int64][]string, len(taskIds))
+	if len(taskIds) == 0 {
+		return grouped, nil
+	}
+	rows, err := t.Store.GetLabelsByTasks(ctx, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		grouped[row.TaskID] = append(grouped[row.TaskID], row.Name)
+	}
+	return grouped, nil
+}
+
+// Get all labels of an owner
+func (t *Task) GetLabelList(ctx context.Context, ownerId int64) ([]db.Label, error) {
+	return t.Store.GetLabelList(ctx, ownerId)
+}
+
+// Delete a label, it is removed from all tasks
+func (t *Task) DeleteLabel(ctx context.Context, labelId int64, ownerId int64) error {
+	return t.Store.DeleteLabel(ctx, db.DeleteLabelParams{
+		ID:      labelId,
+		OwnerID: ownerId,
+	})
+}
@@ -0,0 +1,272 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+)
+
+var (
+	ErrInvalidSort   = fmt.Errorf("sort must be id, createdAt, due, priority or cf.<fieldId>, optionally prefixed with -")
+	ErrFieldNotFound = fmt.Errorf("custom field not found")
+	ErrInvalidView   = fmt.Errorf("view must be deferred or all")
+)
+
+// the default view leaves out deferred tasks, ViewDeferred lists only them and ViewAll both
+const (
+	ViewDeferred = "deferred"
+	ViewAll      = "all"
+)
+
+// number of tasks fetched per query while exporting
+const exportPageSize = 500
+
+// FieldFilter matches tasks whose custom field equals Value,
+// for multi_select fields the task has to have Value selected
+type FieldFilter struct {
+	FieldID int64
+	Value   string
+}
+
+// ListParams filters and sorts the task list of one owner
+type ListParams struct {
+	OwnerID   int64
+	ProjectID sql.NullInt64
+	Fields    []FieldFilter
+	// Filter is a task query, see filter_parser.go
+	Filter string
+	// Location is the time zone of dates in Filter, UTC when nil
+	Location *time.Location
+	Sort     string
+	// View is empty, ViewDeferred or ViewAll
+	View string
+	// archived tasks are left out unless IncludeArchived is set
+	IncludeArchived bool
+	Limit           int32
+	PageID          int32
+}
+
+// Get task list filtered by project, custom fields and a query, sorted by Sort
+func (t *Task) FilterTaskList(ctx context.Context, arg ListParams) ([]db.Task, error) {
+	now := time.Now()
+	conds := db.FilterAnd{db.FilterCompare{Column: db.TaskColumnOwnerID, Op: "=", Value: arg.OwnerID}}
+	if arg.ProjectID.Valid {
+		conds = append(conds, db.FilterCompare{Column: db.TaskColumnProjectID, Op: "=", Value: arg.ProjectID.Int64})
+	}
+	if !arg.IncludeArchived {
+		conds = append(conds, db.FilterIsNull{Column: db.TaskColumnArchivedAt})
+	}
+	switch arg.View {
+	case "":
+		conds = append(conds, db.FilterOr{
+			db.FilterIsNull{Column: db.TaskColumnDeferredUntil},
+			db.FilterCompare{Column: db.TaskColumnDeferredUntil, Op: "<=", Value: now},
+		})
+	case ViewDeferred:
+		conds = append(conds, db.FilterCompare{Column: db.TaskColumnDeferredUntil, Op: ">", Value: now})
+	case ViewAll:
+	default:
+		return nil, ErrInvalidView
+	}
+
+	var fieldOf map[int64]*db.CustomField
+	if len(arg.Fields) > 0 || strings.Contains(arg.Sort, "cf.") || strings.Contains(arg.Filter, "cf.") {
+		var err error
+		fieldOf, err = t.ownerFields(ctx, arg.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, filter := range arg.Fields {
+		field, ok := fieldOf[filter.FieldID]
+		if !ok {
+			return nil, ErrFieldNotFound
+		}
+		cond, err := fieldCondition(field, filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	if arg.Filter != "" {
+		loc := arg.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		cond, err := compileFilter(arg.Filter, &filterEnv{
+			ownerId: arg.OwnerID,
+			now:     now,
+			loc:     loc,
+			fieldOf: fieldOf,
+		})
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	orderBy, err := taskOrder(arg.Sort, fieldOf)
+	if err != nil {
+		return nil, err
+	}
+	//deferred tasks are listed in the order they come back
+	if len(orderBy) == 0 && arg.View == ViewDeferred {
+		orderBy = []db.TaskOrder{{Column: db.TaskColumnDeferredUntil}}
+	}
+
+	return t.Store.SearchTasks(ctx, db.SearchTasksParams{
+		Filter:  conds,
+		OrderBy: orderBy,
+		Limit:   arg.Limit,
+		Offset:  (arg.PageID - 1) * arg.Limit,
+	})
+}
+
+// custom fields of all projects of the owner, by id
+func (t *Task) ownerFields(ctx context.Context, ownerId int64) (map[int64]*db.CustomField, error) {
+	fields, err := t.Store.GetCustomFieldListByOwner(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	fieldOf := make(map[int64]*db.CustomField, len(fields))
+	for i := range fields {
+		fieldOf[fields[i].ID] = &fields[i]
+	}
+	return fieldOf, nil
+}
+
+// fieldValueJSON converts a filter value from the query string into the jsonb form it is stored in
+func fieldValueJSON(field *db.CustomField, raw string) (json.RawMessage, error) {
+	invalid := &project.FieldValueError{Field: field.Name, Reason: fmt.Sprintf("cannot filter by %q", raw)}
+	switch field.FieldType {
+	case project.FieldNumber:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return json.Marshal(v)
+	case project.FieldCheckbox:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalid
+		}
+		return json.Marshal(v)
+	case project.FieldUser:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return json.Marshal(v)
+	case project.FieldDate:
+		v, err := time.Parse(project.DateLayout, raw)
+		if err != nil {
+			return nil, invalid
+		}
+		return json.Marshal(v.Format(project.DateLayout))
+	case project.FieldMultiSelect:
+		return json.Marshal([]string{raw})
+	}
+	return json.Marshal(raw)
+}
+
+func fieldCondition(field *db.CustomField, raw string) (db.TaskFilter, error) {
+	value, err := fieldValueJSON(field, raw)
+	if err != nil {
+		return nil, err
+	}
+	return db.FilterFieldEquals{
+		FieldID:  field.ID,
+		Value:    value,
+		Contains: field.FieldType == project.FieldMultiSelect,
+	}, nil
+}
+
+// taskOrder turns id, createdAt, due, priority or cf.<fieldId> (optionally prefixed with - for descending)
+// into the order of the tasks, tasks without a due date or a value for the field come last
+func taskOrder(sort string, fieldOf map[int64]*db.CustomField) ([]db.TaskOrder, error) {
+	if sort == "" {
+		return nil, nil
+	}
+	desc := strings.HasPrefix(sort, "-")
+	sort = strings.TrimPrefix(sort, "-")
+	switch {
+	case sort == "id":
+		return []db.TaskOrder{{Column: db.TaskColumnID, Desc: desc}}, nil
+	case sort == "createdAt":
+		return []db.TaskOrder{{Column: db.TaskColumnCreatedAt, Desc: desc}}, nil
+	case sort == "due":
+		return []db.TaskOrder{{Column: db.TaskColumnDueAt, Desc: desc, NullsLast: true}}, nil
+	case sort == "priority":
+		return []db.TaskOrder{{Column: db.TaskColumnPriority, Desc: desc}}, nil
+	case strings.HasPrefix(sort, "cf."):
+		fieldId, err := strconv.ParseInt(strings.TrimPrefix(sort, "cf."), 10, 64)
+		if err != nil {
+			return nil, ErrInvalidSort
+		}
+		if _, ok := fieldOf[fieldId]; !ok {
+			return nil, ErrFieldNotFound
+		}
+		return []db.TaskOrder{{FieldID: fieldId, Desc: desc, NullsLast: true}}, nil
+	}
+	return nil, ErrInvalidSort
+}
+
+// Export is every task of an owner together with the custom fields they can have
+type Export struct {
+	Fields []db.CustomField
+	Tasks  []db.Task
+	Values map[int64][]db.TaskCustomFieldValue
+	Labels map[int64][]string
+}
+
+// Export all tasks of an owner including deferred and archived ones, optionally of a single project
+func (t *Task) ExportTasks(ctx context.Context, ownerId int64, projectId sql.NullInt64) (*Export, error) {
+	export := &Export{Tasks: []db.Task{}}
+	var err error
+	if projectId.Valid {
+		export.Fields, err = t.Store.GetCustomFieldList(ctx, projectId.Int64)
+	} else {
+		export.Fields, err = t.Store.GetCustomFieldListByOwner(ctx, ownerId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for page := int32(1); ; page++ {
+		tasks, err := t.FilterTaskList(ctx, ListParams{
+			OwnerID:         ownerId,
+			ProjectID:       projectId,
+			View:            ViewAll,
+			IncludeArchived: true,
+			Limit:           exportPageSize,
+			PageID:          page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		export.Tasks = append(export.Tasks, tasks...)
+		if len(tasks) < exportPageSize {
+			break
+		}
+	}
+
+	taskIds := make([]int64, len(export.Tasks))
+	for i, task := range export.Tasks {
+		taskIds[i] = task.ID
+	}
+	export.Values, err = t.GetFieldValues(ctx, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	export.Labels, err = t.GetLabels(ctx, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
@@ -0,0 +1,42 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Task priorities, stored as their number so that they sort
+const (
+	PriorityNone int16 = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+var ErrInvalidPriority = fmt.Errorf("priority must be one of none, low, medium, high or urgent")
+
+// ParsePriority accepts a priority name or its number 0-4
+func ParsePriority(s string) (int16, error) {
+	for i, name := range priorityNames {
+		if strings.EqualFold(s, name) {
+			return int16(i), nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < int(PriorityNone) || n > int(PriorityUrgent) {
+		return 0, ErrInvalidPriority
+	}
+	return int16(n), nil
+}
+
+// PriorityName is the name of a priority number
+func PriorityName(priority int16) string {
+	if priority < PriorityNone || priority > PriorityUrgent {
+		return strconv.Itoa(int(priority))
+	}
+	return priorityNames[priority]
+}
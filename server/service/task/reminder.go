@@ -0,0 +1,122 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+var (
+	ErrInvalidReminder   = fmt.Errorf("a reminder needs either remindAt or minutesBeforeDue")
+	ErrReminderNotInTask = fmt.Errorf("reminder does not belong to task")
+	ErrSnoozeInPast      = fmt.Errorf("a reminder can only be snoozed until a time in the future")
+)
+
+// ReminderParams sets a reminder either at RemindAt
+// or MinutesBeforeDue minutes before the task is due
+type ReminderParams struct {
+	RemindAt         *time.Time
+	MinutesBeforeDue *int32
+}
+
+// relativeFireAt is when a reminder MinutesBeforeDue before dueAt fires,
+// it doesn't fire while the task has no due date
+func relativeFireAt(dueAt sql.NullTime, minutesBeforeDue int32) sql.NullTime {
+	if !dueAt.Valid {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: dueAt.Time.Add(-time.Duration(minutesBeforeDue) * time.Minute), Valid: true}
+}
+
+// Add a reminder to a task
+func (t *Task) CreateReminder(ctx context.Context, taskId int64, ownerId int64, arg ReminderParams) (*db.Reminder, error) {
+	if (arg.RemindAt == nil) == (arg.MinutesBeforeDue == nil) {
+		return nil, ErrInvalidReminder
+	}
+	task, err := t.GetTaskById(ctx, taskId, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.CreateReminderParams{
+		TaskID:  taskId,
+		OwnerID: ownerId,
+	}
+	if arg.RemindAt != nil {
+		params.RemindAt = sql.NullTime{Time: *arg.RemindAt, Valid: true}
+		params.FireAt = params.RemindAt
+	} else {
+		params.OffsetMinutes = sql.NullInt32{Int32: *arg.MinutesBeforeDue, Valid: true}
+		params.FireAt = relativeFireAt(task.DueAt, *arg.MinutesBeforeDue)
+	}
+	reminder, err := t.Store.CreateReminder(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// Get reminders of a task
+func (t *Task) GetReminderList(ctx context.Context, taskId int64, ownerId int64) ([]db.Reminder, error) {
+	if _, err := t.GetTaskById(ctx, taskId, ownerId); err != nil {
+		return nil, err
+	}
+	return t.Store.GetReminderListByTask(ctx, taskId)
+}
+
+// Snooze a reminder until the given time, a reminder that was already sent is sent again
+func (t *Task) SnoozeReminder(ctx context.Context, taskId int64, reminderId int64, ownerId int64, until time.Time) (*db.Reminder, error) {
+	if !until.After(time.Now()) {
+		return nil, ErrSnoozeInPast
+	}
+	if _, err := t.getReminder(ctx, taskId, reminderId, ownerId); err != nil {
+		return nil, err
+	}
+	reminder, err := t.Store.SnoozeReminder(ctx, db.SnoozeReminderParams{
+		ID:     reminderId,
+		FireAt: sql.NullTime{Time: until, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// Delete a reminder of a task
+func (t *Task) DeleteReminder(ctx context.Context, taskId int64, reminderId int64, ownerId int64) error {
+	if _, err := t.getReminder(ctx, taskId, reminderId, ownerId); err != nil {
+		return err
+	}
+	return t.Store.DeleteReminder(ctx, db.DeleteReminderParams{
+		ID:      reminderId,
+		OwnerID: ownerId,
+	})
+}
+
+func (t *Task) getReminder(ctx context.Context, taskId int64, reminderId int64, ownerId int64) (*db.Reminder, error) {
+	reminder, err := t.Store.GetReminder(ctx, reminderId)
+	if err != nil {
+		return nil, err
+	}
+	if reminder.OwnerID != ownerId {
+		return nil, ErrOwnerNotMatched
+	}
+	if reminder.TaskID != taskId {
+		return nil, ErrReminderNotInTask
+	}
+	return &reminder, nil
+}
+
+// dueAtChanged moves the pending reminders that are relative to the due date of a task
+func (t *Task) dueAtChanged(ctx context.Context, before *db.Task, after *db.Task) error {
+	if before.DueAt.Valid == after.DueAt.Valid && before.DueAt.Time.Equal(after.DueAt.Time) {
+		return nil
+	}
+	return t.Store.ResetRelativeReminders(ctx, db.ResetRelativeRemindersParams{
+		DueAt:  after.DueAt,
+		TaskID: after.ID,
+	})
+}
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+var (
+	ErrFilterNameRequired = fmt.Errorf("name is a required field")
+	ErrFilterNameInUse    = fmt.Errorf("a saved filter with this name already exists")
+)
+
+func savedFilterError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return ErrFilterNameInUse
+		}
+	}
+	return err
+}
+
+// Save a named filter, the query is checked before it is stored
+func (t *Task) CreateSavedFilter(ctx context.Context, arg db.CreateSavedFilterParams) (*db.SavedFilter, error) {
+	if arg.Name == "" {
+		return nil, ErrFilterNameRequired
+	}
+	if err := ValidateFilter(arg.Query); err != nil {
+		return nil, err
+	}
+	filter, err := t.Store.CreateSavedFilter(ctx, arg)
+	if err != nil {
+		return nil, savedFilterError(err)
+	}
+	return &filter, nil
+}
+
+// Get saved filter by id
+func (t *Task) GetSavedFilterById(ctx context.Context, id int64, ownerId int64) (*db.SavedFilter, error) {
+	filter, err := t.Store.GetSavedFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if filter.OwnerID != ownerId {
+		return nil, ErrOwnerNotMatched
+	}
+	return &filter, nil
+}
+
+// Get all saved filters of an owner
+func (t *Task) GetSavedFilterList(ctx context.Context, ownerId int64) ([]db.SavedFilter, error) {
+	return t.Store.GetSavedFilterList(ctx, ownerId)
+}
+
+// Rename a saved filter or change its query
+func (t *Task) UpdateSavedFilter(ctx context.Context, arg db.UpdateSavedFilterParams) (*db.SavedFilter, error) {
+	if arg.Name == "" {
+		return nil, ErrFilterNameRequired
+	}
+	if err := ValidateFilter(arg.Query); err != nil {
+		return nil, err
+	}
+	if _, err := t.GetSavedFilterById(ctx, arg.ID, arg.OwnerID); err != nil {
+		return nil, err
+	}
+	filter, err := t.Store.UpdateSavedFilter(ctx, arg)
+	if err != nil {
+		return nil, savedFilterError(err)
+	}
+	return &filter, nil
+}
+
+// Delete saved filter by Id and OwnerId
+func (t *Task) DeleteSavedFilter(ctx context.Context, arg db.DeleteSavedFilterParams) error {
+	return t.Store.DeleteSavedFilter(ctx, arg)
+}
+
+// Run a saved filter, arg.Filter is replaced by the saved query
+func (t *Task) GetSavedFilterTasks(ctx context.Context, filterId int64, arg ListParams) ([]db.Task, error) {
+	filter, err := t.GetSavedFilterById(ctx, filterId, arg.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	arg.Filter = filter.Query
+	return t.FilterTaskList(ctx, arg)
+}
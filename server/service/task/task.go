@@ -2,9 +2,11 @@ package task
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
 )
 
 var ErrOwnerNotMatched = fmt.Errorf("ownwer id does not match user id")
@@ -13,13 +15,49 @@ type Task struct {
 	Store db.Store
 }
 
+// PrepareTask checks the project of a new task,
+// a task created in a project is placed in a status and its IsDone follows that status.
+// The wip limit of the status is checked by the transaction that creates the task
+func (t *Task) PrepareTask(ctx context.Context, arg *db.CreateTaskParams) error {
+	if arg.ProjectID.Valid {
+		p, err := t.Store.GetProject(ctx, arg.ProjectID.Int64)
+		if err != nil {
+			return err
+		}
+		if p.OwnerID != arg.OwnerID {
+			return ErrOwnerNotMatched
+		}
+		status, err := t.initialStatus(ctx, p.ID, arg.StatusID)
+		if err != nil {
+			return err
+		}
+		arg.StatusID = sql.NullInt64{Int64: status.ID, Valid: true}
+		arg.IsDone = status.Category == project.CategoryDone
+	} else if arg.StatusID.Valid {
+		return ErrStatusNotInProject
+	}
+	return nil
+}
+
 // Create task from db
 func (t *Task) CreateTask(ctx context.Context, arg db.CreateTaskParams) (*db.Task, error) {
-	task, err := t.Store.CreateTask(ctx, arg)
+	if err := t.PrepareTask(ctx, &arg); err != nil {
+		return nil, err
+	}
+
+	task, err := t.Store.CreateTaskTx(ctx, arg)
 
 	return &task, err
 }
 
+// Get subtasks of a task
+func (t *Task) GetSubtasks(ctx context.Context, id int64, ownerId int64) ([]db.Task, error) {
+	if _, err := t.GetTaskById(ctx, id, ownerId); err != nil {
+		return nil, err
+	}
+	return t.Store.GetSubtasks(ctx, sql.NullInt64{Int64: id, Valid: true})
+}
+
 // Get task by Id
 func (t *Task) GetTaskById(ctx context.Context, id int64, ownerId int64) (*db.Task, error) {
 	task, err := t.Store.GetTask(ctx, id)
@@ -43,10 +81,42 @@ func (t *Task) GetTaskList(ctx context.Context, ownerId int64, limit int32, page
 	return taskList, err
 }
 
-// Update task by Id and OwnerId
+// Update task by Id and OwnerId.
+// For a task in a project the status decides IsDone,
+// moving between statuses has to follow the project's workflow.
 func (t *Task) UpdateTask(ctx context.Context, arg db.UpdateTaskParams) (*db.Task, error) {
-	task, err := t.Store.UpdateTask(ctx, arg)
-	return &task, err
+	current, err := t.GetTaskById(ctx, arg.ID, arg.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	txArg := db.UpdateTaskTxParams{UpdateTaskParams: arg}
+	if current.ProjectID.Valid {
+		status, err := t.nextStatus(ctx, current, arg.StatusID, arg.IsDone)
+		if err != nil {
+			return nil, err
+		}
+		txArg.StatusID = sql.NullInt64{Int64: status.ID, Valid: true}
+		txArg.IsDone = status.Category == project.CategoryDone
+		txArg.CheckWipLimit = current.StatusID != txArg.StatusID
+		if current.StatusID.Valid && txArg.CheckWipLimit {
+			txArg.Transition = &db.CreateStatusTransitionParams{
+				ProjectID:    current.ProjectID.Int64,
+				FromStatusID: current.StatusID.Int64,
+				ToStatusID:   status.ID,
+			}
+		}
+	} else if arg.StatusID.Valid {
+		return nil, ErrStatusNotInProject
+	}
+
+	task, err := t.Store.UpdateTaskTx(ctx, txArg)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.dueAtChanged(ctx, current, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
 }
 
 // Delete task by Id and OwnerId
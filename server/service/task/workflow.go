@@ -0,0 +1,94 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+)
+
+var (
+	ErrStatusNotInProject   = fmt.Errorf("status does not belong to the task's project")
+	ErrTransitionNotAllowed = db.ErrTransitionNotAllowed
+	ErrWipLimitReached      = db.ErrWipLimitReached
+	ErrNoStatusInCategory   = fmt.Errorf("project has no status in the requested category")
+)
+
+// resolve the status a new project task starts in,
+// the first column of the project unless statusId is given
+func (t *Task) initialStatus(ctx context.Context, projectId int64, statusId sql.NullInt64) (*db.ProjectStatus, error) {
+	statuses, err := t.Store.GetProjectStatusList(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, ErrNoStatusInCategory
+	}
+	status := &statuses[0]
+	if statusId.Valid {
+		status, err = t.projectStatus(ctx, projectId, statusId.Int64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// resolve the status a project task moves to.
+// An explicit statusId wins, otherwise a change of isDone moves the task
+// to the first status of the done (or todo) category.
+// Whether the workflow allows the move is checked by the transaction that updates the task
+func (t *Task) nextStatus(ctx context.Context, task *db.Task, statusId sql.NullInt64, isDone bool) (*db.ProjectStatus, error) {
+	projectId := task.ProjectID.Int64
+	var (
+		status *db.ProjectStatus
+		err    error
+	)
+	switch {
+	case statusId.Valid:
+		status, err = t.projectStatus(ctx, projectId, statusId.Int64)
+	case isDone != task.IsDone:
+		category := project.CategoryTodo
+		if isDone {
+			category = project.CategoryDone
+		}
+		status, err = t.firstStatusInCategory(ctx, projectId, category)
+	case task.StatusID.Valid:
+		status, err = t.projectStatus(ctx, projectId, task.StatusID.Int64)
+	default:
+		return t.initialStatus(ctx, projectId, statusId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (t *Task) projectStatus(ctx context.Context, projectId int64, statusId int64) (*db.ProjectStatus, error) {
+	status, err := t.Store.GetProjectStatus(ctx, statusId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrStatusNotInProject
+		}
+		return nil, err
+	}
+	if status.ProjectID != projectId {
+		return nil, ErrStatusNotInProject
+	}
+	return &status, nil
+}
+
+func (t *Task) firstStatusInCategory(ctx context.Context, projectId int64, category string) (*db.ProjectStatus, error) {
+	statuses, err := t.Store.GetProjectStatusList(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range statuses {
+		if statuses[i].Category == category {
+			return &statuses[i], nil
+		}
+	}
+	return nil, ErrNoStatusInCategory
+}
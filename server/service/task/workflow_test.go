@@ -0,0 +1,208 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/project"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateTaskWorkflow(t *testing.T) {
+	statuses := []db.ProjectStatus{
+		{ID: 1, ProjectID: 1, Name: "Todo", Category: project.CategoryTodo, Position: 0},
+		{ID: 2, ProjectID: 1, Name: "Doing", Category: project.CategoryInProgress, Position: 1},
+		{ID: 3, ProjectID: 1, Name: "Done", Category: project.CategoryDone, Position: 2},
+		{ID: 4, ProjectID: 1, Name: "Shipped", Category: project.CategoryDone, Position: 3},
+	}
+	inStatus := func(statusId int64, isDone bool) db.Task {
+		return db.Task{
+			ID:        1,
+			OwnerID:   1,
+			ProjectID: sql.NullInt64{Int64: 1, Valid: true},
+			StatusID:  sql.NullInt64{Int64: statusId, Valid: true},
+			IsDone:    isDone,
+		}
+	}
+	status := func(id int64) sql.NullInt64 { return sql.NullInt64{Int64: id, Valid: true} }
+	// the update is expected to reach the store with these values
+	expectUpdate := func(store *mockdb.MockStore, statusId sql.NullInt64, isDone bool, transition *db.CreateStatusTransitionParams, err error) {
+		store.EXPECT().
+			UpdateTaskTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.UpdateTaskTxParams) (db.Task, error) {
+				require.Equal(t, statusId, arg.StatusID)
+				require.Equal(t, isDone, arg.IsDone)
+				require.Equal(t, transition, arg.Transition)
+				require.Equal(t, transition != nil, arg.CheckWipLimit)
+				if err != nil {
+					return db.Task{}, err
+				}
+				return db.Task{ID: arg.ID, OwnerID: arg.OwnerID, StatusID: arg.StatusID, IsDone: arg.IsDone}, nil
+			})
+	}
+
+	testCases := []struct {
+		name       string
+		current    db.Task
+		arg        db.UpdateTaskParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, task *db.Task, err error)
+	}{
+		{
+			name:    "MoveToStatus",
+			current: inStatus(1, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, StatusID: status(2)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatus(gomock.Any(), int64(2)).Times(1).Return(statuses[1], nil)
+				expectUpdate(store, status(2), false, &db.CreateStatusTransitionParams{ProjectID: 1, FromStatusID: 1, ToStatusID: 2}, nil)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.NoError(t, err)
+				require.Equal(t, status(2), task.StatusID)
+				require.False(t, task.IsDone)
+			},
+		},
+		{
+			name:    "TransitionNotAllowed",
+			current: inStatus(1, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, StatusID: status(3)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatus(gomock.Any(), int64(3)).Times(1).Return(statuses[2], nil)
+				expectUpdate(store, status(3), true, &db.CreateStatusTransitionParams{ProjectID: 1, FromStatusID: 1, ToStatusID: 3}, db.ErrTransitionNotAllowed)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.ErrorIs(t, err, ErrTransitionNotAllowed)
+			},
+		},
+		{
+			name:    "StayInStatus",
+			current: inStatus(2, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, Body: "renamed"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatus(gomock.Any(), int64(2)).Times(1).Return(statuses[1], nil)
+				expectUpdate(store, status(2), false, nil, nil)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "DoneMovesToFirstDoneStatus",
+			current: inStatus(2, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, IsDone: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatusList(gomock.Any(), int64(1)).Times(1).Return(statuses, nil)
+				expectUpdate(store, status(3), true, &db.CreateStatusTransitionParams{ProjectID: 1, FromStatusID: 2, ToStatusID: 3}, nil)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.NoError(t, err)
+				require.True(t, task.IsDone)
+			},
+		},
+		{
+			name:    "NotDoneMovesToFirstTodoStatus",
+			current: inStatus(4, true),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, IsDone: false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatusList(gomock.Any(), int64(1)).Times(1).Return(statuses, nil)
+				expectUpdate(store, status(1), false, &db.CreateStatusTransitionParams{ProjectID: 1, FromStatusID: 4, ToStatusID: 1}, nil)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.NoError(t, err)
+				require.False(t, task.IsDone)
+			},
+		},
+		{
+			name:    "StatusWinsOverIsDone",
+			current: inStatus(1, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, StatusID: status(2), IsDone: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatus(gomock.Any(), int64(2)).Times(1).Return(statuses[1], nil)
+				expectUpdate(store, status(2), false, &db.CreateStatusTransitionParams{ProjectID: 1, FromStatusID: 1, ToStatusID: 2}, nil)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.NoError(t, err)
+				require.False(t, task.IsDone)
+			},
+		},
+		{
+			name:    "NoStatusInCategory",
+			current: inStatus(1, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, IsDone: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatusList(gomock.Any(), int64(1)).Times(1).Return(statuses[:2], nil)
+				store.EXPECT().UpdateTaskTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.ErrorIs(t, err, ErrNoStatusInCategory)
+			},
+		},
+		{
+			name:    "StatusOfOtherProject",
+			current: inStatus(1, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, StatusID: status(9)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatus(gomock.Any(), int64(9)).Times(1).
+					Return(db.ProjectStatus{ID: 9, ProjectID: 2, Category: project.CategoryTodo}, nil)
+				store.EXPECT().UpdateTaskTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.ErrorIs(t, err, ErrStatusNotInProject)
+			},
+		},
+		{
+			name:    "StatusNotFound",
+			current: inStatus(1, false),
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, StatusID: status(9)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProjectStatus(gomock.Any(), int64(9)).Times(1).Return(db.ProjectStatus{}, sql.ErrNoRows)
+				store.EXPECT().UpdateTaskTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.ErrorIs(t, err, ErrStatusNotInProject)
+			},
+		},
+		{
+			name:    "TaskWithoutProject",
+			current: db.Task{ID: 1, OwnerID: 1},
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, IsDone: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectUpdate(store, sql.NullInt64{}, true, nil, nil)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.NoError(t, err)
+				require.True(t, task.IsDone)
+			},
+		},
+		{
+			name:    "StatusWithoutProject",
+			current: db.Task{ID: 1, OwnerID: 1},
+			arg:     db.UpdateTaskParams{ID: 1, OwnerID: 1, StatusID: status(1)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateTaskTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, task *db.Task, err error) {
+				require.ErrorIs(t, err, ErrStatusNotInProject)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			task := Task{Store: store}
+
+			store.EXPECT().GetTask(gomock.Any(), tc.current.ID).Times(1).Return(tc.current, nil)
+			tc.buildStubs(store)
+
+			updated, err := task.UpdateTask(context.Background(), tc.arg)
+			tc.check(t, updated, err)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package template
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+)
+
+const (
+	// most tasks one template creates, the task itself included
+	MaxTasks = 100
+	// most levels of subtasks under the task
+	MaxDepth = 3
+)
+
+var (
+	ErrOwnerNotMatched = fmt.Errorf("owner id does not match user id")
+	ErrEmptyName       = fmt.Errorf("name is a required field")
+	ErrEmptyBody       = fmt.Errorf("the template and each subtask need a body")
+	ErrTooManyTasks    = fmt.Errorf("a template creates at most %d tasks", MaxTasks)
+	ErrTooDeep         = fmt.Errorf("subtasks can be nested at most %d levels", MaxDepth)
+)
+
+// Template manages task templates. Templates are personal, only their owner can see and
+// use them: the app has no workspaces, so templates cannot be shared within one
+type Template struct {
+	Store db.Store
+	Task  task.Task
+}
+
+// Subtask is a task created under the template task or another subtask.
+// Body and labels may hold {{variables}}, priority is a name like in tasks
+type Subtask struct {
+	Body             string    `json:"body"`
+	Priority         string    `json:"priority,omitempty"`
+	Labels           []string  `json:"labels,omitempty"`
+	DueOffsetMinutes *int32    `json:"dueOffsetMinutes,omitempty"`
+	Subtasks         []Subtask `json:"subtasks,omitempty"`
+}
+
+// TemplateParams are the settings of a template, DueOffsetMinutes is
+// the due date of the created task relative to when the template is used
+type TemplateParams struct {
+	Name             string
+	Body             string
+	Priority         int16
+	Labels           []string
+	DueOffsetMinutes *int32
+	Subtasks         []Subtask
+}
+
+// validate checks the tree and normalizes labels and priorities of subtasks
+func (arg *TemplateParams) validate() error {
+	if arg.Name == "" {
+		return ErrEmptyName
+	}
+	if arg.Body == "" {
+		return ErrEmptyBody
+	}
+	labels, err := normalizeTemplateLabels(arg.Labels)
+	if err != nil {
+		return err
+	}
+	arg.Labels = labels
+	if arg.Subtasks == nil {
+		arg.Subtasks = []Subtask{}
+	}
+	count := 1
+	return validateSubtasks(arg.Subtasks, 1, &count)
+}
+
+func validateSubtasks(subtasks []Subtask, depth int, count *int) error {
+	if len(subtasks) > 0 && depth > MaxDepth {
+		return ErrTooDeep
+	}
+	for i := range subtasks {
+		subtask := &subtasks[i]
+		if *count++; *count > MaxTasks {
+			return ErrTooManyTasks
+		}
+		if subtask.Body == "" {
+			return ErrEmptyBody
+		}
+		if subtask.Priority != "" {
+			priority, err := task.ParsePriority(subtask.Priority)
+			if err != nil {
+				return err
+			}
+			subtask.Priority = task.PriorityName(priority)
+		}
+		labels, err := normalizeTemplateLabels(subtask.Labels)
+		if err != nil {
+			return err
+		}
+		subtask.Labels = labels
+		if err := validateSubtasks(subtask.Subtasks, depth+1, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func nullOffset(offset *int32) sql.NullInt32 {
+	if offset == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *offset, Valid: true}
+}
+
+// Create template
+func (t *Template) CreateTemplate(ctx context.Context, ownerId int64, arg TemplateParams) (*db.TaskTemplate, error) {
+	if err := arg.validate(); err != nil {
+		return nil, err
+	}
+	subtasks, err := json.Marshal(arg.Subtasks)
+	if err != nil {
+		return nil, err
+	}
+	template, err := t.Store.CreateTaskTemplate(ctx, db.CreateTaskTemplateParams{
+		OwnerID:          ownerId,
+		Name:             arg.Name,
+		Body:             arg.Body,
+		Priority:         arg.Priority,
+		Labels:           arg.Labels,
+		DueOffsetMinutes: nullOffset(arg.DueOffsetMinutes),
+		Subtasks:         subtasks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Get template by Id, only its owner can read it
+func (t *Template) GetTemplateById(ctx context.Context, id int64, userId int64) (*db.TaskTemplate, error) {
+	template, err := t.Store.GetTaskTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID != userId {
+		return nil, ErrOwnerNotMatched
+	}
+	return &template, nil
+}
+
+// Get templates of a user
+func (t *Template) GetTemplateList(ctx context.Context, userId int64) ([]db.TaskTemplate, error) {
+	return t.Store.GetTaskTemplateList(ctx, userId)
+}
+
+// Update template, only its owner can change it
+func (t *Template) UpdateTemplate(ctx context.Context, id int64, ownerId int64, arg TemplateParams) (*db.TaskTemplate, error) {
+	if err := arg.validate(); err != nil {
+		return nil, err
+	}
+	if err := t.checkOwner(ctx, id, ownerId); err != nil {
+		return nil, err
+	}
+	subtasks, err := json.Marshal(arg.Subtasks)
+	if err != nil {
+		return nil, err
+	}
+	template, err := t.Store.UpdateTaskTemplate(ctx, db.UpdateTaskTemplateParams{
+		ID:               id,
+		OwnerID:          ownerId,
+		Name:             arg.Name,
+		Body:             arg.Body,
+		Priority:         arg.Priority,
+		Labels:           arg.Labels,
+		DueOffsetMinutes: nullOffset(arg.DueOffsetMinutes),
+		Subtasks:         subtasks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Delete template, only its owner can delete it
+func (t *Template) DeleteTemplate(ctx context.Context, id int64, ownerId int64) error {
+	if err := t.checkOwner(ctx, id, ownerId); err != nil {
+		return err
+	}
+	return t.Store.DeleteTaskTemplate(ctx, db.DeleteTaskTemplateParams{
+		ID:      id,
+		OwnerID: ownerId,
+	})
+}
+
+func (t *Template) checkOwner(ctx context.Context, id int64, ownerId int64) error {
+	template, err := t.Store.GetTaskTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if template.OwnerID != ownerId {
+		return ErrOwnerNotMatched
+	}
+	return nil
+}
+
+// InstantiateParams are how a template is used. Due offsets are counted
+// from StartAt, the tasks are created in ProjectID when it is set
+type InstantiateParams struct {
+	ProjectID sql.NullInt64
+	StartAt   time.Time
+	Variables map[string]string
+}
+
+// Instantiate creates the task of a template and all its subtasks in one transaction
+func (t *Template) Instantiate(ctx context.Context, id int64, userId int64, arg InstantiateParams) (*db.InstantiateTemplateTxResult, error) {
+	template, err := t.GetTemplateById(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	var subtasks []Subtask
+	if err := json.Unmarshal(template.Subtasks, &subtasks); err != nil {
+		return nil, err
+	}
+	if missing := missingVariables(template, subtasks, arg.Variables); len(missing) > 0 {
+		return nil, &MissingVariablesError{Names: missing}
+	}
+
+	root := db.CreateTaskParams{
+		OwnerID:   userId,
+		ProjectID: arg.ProjectID,
+	}
+	//subtasks share the status the task gets in the project
+	if err := t.Task.PrepareTask(ctx, &root); err != nil {
+		return nil, err
+	}
+	var offset *int32
+	if template.DueOffsetMinutes.Valid {
+		offset = &template.DueOffsetMinutes.Int32
+	}
+	tree, err := newTemplateTask(root, Subtask{
+		Body:             template.Body,
+		Priority:         task.PriorityName(template.Priority),
+		Labels:           template.Labels,
+		DueOffsetMinutes: offset,
+		Subtasks:         subtasks,
+	}, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := t.Store.InstantiateTemplateTx(ctx, db.InstantiateTemplateTxParams{
+		OwnerID: userId,
+		Root:    *tree,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// newTemplateTask fills in variables and due dates of a task and its subtasks
+func newTemplateTask(base db.CreateTaskParams, subtask Subtask, arg InstantiateParams) (*db.TemplateTask, error) {
+	params := base
+	params.Body = substitute(subtask.Body, arg.Variables)
+	if subtask.Priority != "" {
+		priority, err := task.ParsePriority(subtask.Priority)
+		if err != nil {
+			return nil, err
+		}
+		params.Priority = priority
+	}
+	if subtask.DueOffsetMinutes != nil {
+		params.DueAt = sql.NullTime{
+			Time:  arg.StartAt.Add(time.Duration(*subtask.DueOffsetMinutes) * time.Minute),
+			Valid: true,
+		}
+	}
+	labels := make([]string, len(subtask.Labels))
+	for i, label := range subtask.Labels {
+		labels[i] = substitute(label, arg.Variables)
+	}
+	labels, err := task.NormalizeLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &db.TemplateTask{Task: params, Labels: labels}
+	for _, child := range subtask.Subtasks {
+		childTask, err := newTemplateTask(base, child, arg)
+		if err != nil {
+			return nil, err
+		}
+		tree.Subtasks = append(tree.Subtasks, *childTask)
+	}
+	return tree, nil
+}
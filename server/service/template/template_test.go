@@ -0,0 +1,112 @@
+package template
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidate(t *testing.T) {
+	arg := TemplateParams{
+		Name:   "release",
+		Body:   "Release {{version}}",
+		Labels: []string{" release-{{ version }} "},
+		Subtasks: []Subtask{
+			{Body: "Tag", Priority: "HIGH"},
+		},
+	}
+	require.NoError(t, arg.validate())
+	require.Equal(t, []string{"release-{{ version }}"}, arg.Labels)
+	require.Equal(t, "high", arg.Subtasks[0].Priority)
+
+	arg.Subtasks[0].Labels = []string{"two words"}
+	require.ErrorIs(t, arg.validate(), task.ErrInvalidLabel)
+
+	deep := []Subtask{{Body: "1", Subtasks: []Subtask{{Body: "2", Subtasks: []Subtask{{Body: "3", Subtasks: []Subtask{{Body: "4"}}}}}}}}
+	arg = TemplateParams{Name: "deep", Body: "root", Subtasks: deep}
+	require.ErrorIs(t, arg.validate(), ErrTooDeep)
+
+	arg = TemplateParams{Name: "many", Body: "root", Subtasks: make([]Subtask, MaxTasks)}
+	for i := range arg.Subtasks {
+		arg.Subtasks[i].Body = "step"
+	}
+	require.ErrorIs(t, arg.validate(), ErrTooManyTasks)
+
+	arg = TemplateParams{Name: "empty", Body: "root", Subtasks: []Subtask{{}}}
+	require.ErrorIs(t, arg.validate(), ErrEmptyBody)
+}
+
+func TestSubstitute(t *testing.T) {
+	values := map[string]string{"version": "1.4.0"}
+	require.Equal(t, "Release 1.4.0 on {{date}}", substitute("Release {{ version }} on {{date}}", values))
+
+	template := &db.TaskTemplate{Body: "Release {{version}}", Labels: []string{"{{team}}"}}
+	subtasks := []Subtask{{Body: "Announce {{version}} in {{channel}}"}}
+	require.Equal(t, []string{"channel", "team", "version"}, Variables(template, subtasks))
+	require.Equal(t, []string{"channel", "team"}, missingVariables(template, subtasks, values))
+}
+
+func TestInstantiate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tmpl := Template{Store: store, Task: task.Task{Store: store}}
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	subtasks, err := json.Marshal([]Subtask{
+		{Body: "Freeze {{version}}", DueOffsetMinutes: ptr(-60), Subtasks: []Subtask{{Body: "Notify", Priority: "urgent"}}},
+		{Body: "Tag {{version}}", Labels: []string{"v{{version}}"}},
+	})
+	require.NoError(t, err)
+	template := db.TaskTemplate{
+		ID:               3,
+		OwnerID:          1,
+		Body:             "Release {{version}}",
+		Labels:           []string{"release"},
+		DueOffsetMinutes: sql.NullInt32{Int32: 24 * 60, Valid: true},
+		Subtasks:         subtasks,
+	}
+	store.EXPECT().GetTaskTemplate(gomock.Any(), int64(3)).Return(template, nil).Times(2)
+
+	_, err = tmpl.Instantiate(context.Background(), 3, 1, InstantiateParams{StartAt: start})
+	require.Equal(t, &MissingVariablesError{Names: []string{"version"}}, err)
+
+	store.EXPECT().
+		InstantiateTemplateTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.InstantiateTemplateTxParams) (db.InstantiateTemplateTxResult, error) {
+			require.Equal(t, int64(1), arg.OwnerID)
+			root := arg.Root
+			require.Equal(t, "Release 1.4", root.Task.Body)
+			require.Equal(t, []string{"release"}, root.Labels)
+			require.Equal(t, start.Add(24*time.Hour), root.Task.DueAt.Time)
+			require.Len(t, root.Subtasks, 2)
+			require.Equal(t, "Freeze 1.4", root.Subtasks[0].Task.Body)
+			require.Equal(t, start.Add(-time.Hour), root.Subtasks[0].Task.DueAt.Time)
+			require.Equal(t, task.PriorityUrgent, root.Subtasks[0].Subtasks[0].Task.Priority)
+			require.False(t, root.Subtasks[1].Task.DueAt.Valid)
+			require.Equal(t, []string{"v1.4"}, root.Subtasks[1].Labels)
+			return db.InstantiateTemplateTxResult{}, nil
+		})
+	_, err = tmpl.Instantiate(context.Background(), 3, 1, InstantiateParams{
+		StartAt:   start,
+		Variables: map[string]string{"version": "1.4"},
+	})
+	require.NoError(t, err)
+
+	//templates of others can't be used
+	template.OwnerID = 2
+	store.EXPECT().GetTaskTemplate(gomock.Any(), int64(3)).Return(template, nil)
+	_, err = tmpl.Instantiate(context.Background(), 3, 1, InstantiateParams{StartAt: start})
+	require.ErrorIs(t, err, ErrOwnerNotMatched)
+}
+
+func ptr(n int32) *int32 {
+	return &n
+}
@@ -0,0 +1,94 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/task"
+)
+
+// variables are written as {{name}}, spaces inside the braces are allowed
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// MissingVariablesError lists the variables of a template that were not given a value
+type MissingVariablesError struct {
+	Names []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("missing template variables: %s", strings.Join(e.Names, ", "))
+}
+
+// substitute replaces the variables in s, unknown ones are kept
+func substitute(s string, values map[string]string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+func collectVariables(s string, names map[string]bool) {
+	for _, match := range variablePattern.FindAllStringSubmatch(s, -1) {
+		names[match[1]] = true
+	}
+}
+
+func collectSubtaskVariables(subtasks []Subtask, names map[string]bool) {
+	for _, subtask := range subtasks {
+		collectVariables(subtask.Body, names)
+		for _, label := range subtask.Labels {
+			collectVariables(label, names)
+		}
+		collectSubtaskVariables(subtask.Subtasks, names)
+	}
+}
+
+// Variables are the names of the variables used in a template, sorted
+func Variables(template *db.TaskTemplate, subtasks []Subtask) []string {
+	names := map[string]bool{}
+	collectVariables(template.Body, names)
+	for _, label := range template.Labels {
+		collectVariables(label, names)
+	}
+	collectSubtaskVariables(subtasks, names)
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func missingVariables(template *db.TaskTemplate, subtasks []Subtask, values map[string]string) []string {
+	var missing []string
+	for _, name := range Variables(template, subtasks) {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// normalizeTemplateLabels checks labels with their variables filled in,
+// the variables themselves are kept
+func normalizeTemplateLabels(labels []string) ([]string, error) {
+	filled := make([]string, len(labels))
+	for i, label := range labels {
+		filled[i] = variablePattern.ReplaceAllString(label, "x")
+	}
+	if _, err := task.NormalizeLabels(filled); err != nil {
+		return nil, err
+	}
+	result := make([]string, len(labels))
+	for i, label := range labels {
+		result[i] = strings.TrimSpace(label)
+	}
+	return result, nil
+}
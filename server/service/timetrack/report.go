@@ -0,0 +1,229 @@
+package timetrack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	GroupByProject = "project"
+	GroupByLabel   = "label"
+	GroupByDay     = "day"
+)
+
+var ErrInvalidGroupBy = fmt.Errorf("group_by must be project, label or day")
+
+// ReportParams select the entries of a report, entries are cut to [From, To)
+type ReportParams struct {
+	From    time.Time
+	To      time.Time
+	GroupBy string
+	// Location decides where days start when grouping by day
+	Location *time.Location
+}
+
+// TaskTotal is the time tracked on a task, to compare against its estimate
+type TaskTotal struct {
+	TaskID          int64  `json:"taskId"`
+	Body            string `json:"body"`
+	TrackedSeconds  int64  `json:"trackedSeconds"`
+	EstimateMinutes *int32 `json:"estimateMinutes"`
+}
+
+// Group is the time tracked in a project, on a label or on a day.
+// Key is the project id, the label name or the day as YYYY-MM-DD,
+// it is empty for tasks without a project or without labels.
+type Group struct {
+	Key            string `json:"key"`
+	TrackedSeconds int64  `json:"trackedSeconds"`
+	// EstimateMinutes is the sum of the estimates of the tasks in the group,
+	// EstimatedTrackedSeconds the time tracked on those tasks
+	EstimateMinutes         int64        `json:"estimateMinutes"`
+	EstimatedTrackedSeconds int64        `json:"estimatedTrackedSeconds"`
+	Tasks                   []*TaskTotal `json:"tasks"`
+}
+
+// Report is the time tracked between From and To.
+// A task with several labels is counted in each of their groups, the totals count it once
+type Report struct {
+	From                    time.Time `json:"from"`
+	To                      time.Time `json:"to"`
+	GroupBy                 string    `json:"groupBy"`
+	TrackedSeconds          int64     `json:"trackedSeconds"`
+	EstimateMinutes         int64     `json:"estimateMinutes"`
+	EstimatedTrackedSeconds int64     `json:"estimatedTrackedSeconds"`
+	Groups                  []*Group  `json:"groups"`
+}
+
+// Report of the time tracked by a user, running timers count until now
+func (t *TimeTrack) Report(ctx context.Context, ownerId int64, arg ReportParams) (*Report, error) {
+	if arg.GroupBy != GroupByProject && arg.GroupBy != GroupByLabel && arg.GroupBy != GroupByDay {
+		return nil, ErrInvalidGroupBy
+	}
+	entries, err := t.GetEntries(ctx, ownerId, arg.From, arg.To)
+	if err != nil {
+		return nil, err
+	}
+	var labels []db.GetLabelsByTasksRow
+	if arg.GroupBy == GroupByLabel && len(entries) > 0 {
+		taskIds := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			taskIds = append(taskIds, entry.TaskID)
+		}
+		labels, err = t.Store.GetLabelsByTasks(ctx, taskIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return BuildReport(arg, entries, labels, time.Now()), nil
+}
+
+// a part of an entry counted in a group
+type span struct {
+	key     string
+	entry   *db.GetTimeEntriesBetweenRow
+	seconds int64
+}
+
+// BuildReport sums up entries, labels are only needed when grouping by label
+func BuildReport(arg ReportParams, entries []db.GetTimeEntriesBetweenRow, labels []db.GetLabelsByTasksRow, now time.Time) *Report {
+	loc := arg.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	labelsByTask := map[int64][]string{}
+	for _, label := range labels {
+		labelsByTask[label.TaskID] = append(labelsByTask[label.TaskID], label.Name)
+	}
+
+	report := &Report{From: arg.From, To: arg.To, GroupBy: arg.GroupBy, Groups: []*Group{}}
+	total := map[int64]*TaskTotal{}
+	var spans []span
+	for i := range entries {
+		entry := &entries[i]
+		start, end := clip(entry, arg.From, arg.To, now)
+		if !end.After(start) {
+			continue
+		}
+		seconds := int64(end.Sub(start) / time.Second)
+		addTask(total, entry, seconds)
+
+		switch arg.GroupBy {
+		case GroupByProject:
+			key := ""
+			if entry.ProjectID.Valid {
+				key = strconv.FormatInt(entry.ProjectID.Int64, 10)
+			}
+			spans = append(spans, span{key: key, entry: entry, seconds: seconds})
+		case GroupByLabel:
+			names := labelsByTask[entry.TaskID]
+			if len(names) == 0 {
+				names = []string{""}
+			}
+			for _, name := range names {
+				spans = append(spans, span{key: name, entry: entry, seconds: seconds})
+			}
+		case GroupByDay:
+			for day := start; day.Before(end); {
+				y, m, d := day.In(loc).Date()
+				next := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+				if next.After(end) {
+					next = end
+				}
+				spans = append(spans, span{
+					key:     day.In(loc).Format("2006-01-02"),
+					entry:   entry,
+					seconds: int64(next.Sub(day) / time.Second),
+				})
+				day = next
+			}
+		}
+	}
+
+	groups := map[string]*Group{}
+	tasks := map[string]map[int64]*TaskTotal{}
+	for _, s := range spans {
+		group, ok := groups[s.key]
+		if !ok {
+			group = &Group{Key: s.key, Tasks: []*TaskTotal{}}
+			groups[s.key] = group
+			tasks[s.key] = map[int64]*TaskTotal{}
+			report.Groups = append(report.Groups, group)
+		}
+		group.TrackedSeconds += s.seconds
+		addTask(tasks[s.key], s.entry, s.seconds)
+	}
+	for _, group := range report.Groups {
+		group.Tasks = sortedTasks(tasks[group.Key])
+		group.EstimateMinutes, group.EstimatedTrackedSeconds = estimates(group.Tasks)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Key < report.Groups[j].Key
+	})
+
+	for _, task := range total {
+		report.TrackedSeconds += task.TrackedSeconds
+	}
+	report.EstimateMinutes, report.EstimatedTrackedSeconds = estimates(sortedTasks(total))
+	return report
+}
+
+// clip cuts an entry to [from, to), a running entry ends now
+func clip(entry *db.GetTimeEntriesBetweenRow, from time.Time, to time.Time, now time.Time) (time.Time, time.Time) {
+	start, end := entry.StartedAt, now
+	if entry.EndedAt.Valid {
+		end = entry.EndedAt.Time
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return start, end
+}
+
+func addTask(tasks map[int64]*TaskTotal, entry *db.GetTimeEntriesBetweenRow, seconds int64) {
+	task, ok := tasks[entry.TaskID]
+	if !ok {
+		task = &TaskTotal{TaskID: entry.TaskID, Body: entry.Body}
+		if entry.EstimateMinutes.Valid {
+			estimate := entry.EstimateMinutes.Int32
+			task.EstimateMinutes = &estimate
+		}
+		tasks[entry.TaskID] = task
+	}
+	task.TrackedSeconds += seconds
+}
+
+// sortedTasks puts the tasks with the most time first
+func sortedTasks(tasks map[int64]*TaskTotal) []*TaskTotal {
+	sorted := make([]*TaskTotal, 0, len(tasks))
+	for _, task := range tasks {
+		sorted = append(sorted, task)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].TrackedSeconds != sorted[j].TrackedSeconds {
+			return sorted[i].TrackedSeconds > sorted[j].TrackedSeconds
+		}
+		return sorted[i].TaskID < sorted[j].TaskID
+	})
+	return sorted
+}
+
+// estimates sums the estimates of tasks and the time tracked on the tasks that have one
+func estimates(tasks []*TaskTotal) (int64, int64) {
+	var minutes, seconds int64
+	for _, task := range tasks {
+		if task.EstimateMinutes != nil {
+			minutes += int64(*task.EstimateMinutes)
+			seconds += task.TrackedSeconds
+		}
+	}
+	return minutes, seconds
+}
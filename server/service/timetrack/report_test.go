@@ -0,0 +1,98 @@
+package timetrack
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func reportEntries(at func(day int, hour int) time.Time) []db.GetTimeEntriesBetweenRow {
+	return []db.GetTimeEntriesBetweenRow{
+		{
+			//started before the report, only the last hour counts
+			ID: 1, TaskID: 10, Body: "design",
+			StartedAt:       at(0, 23),
+			EndedAt:         sql.NullTime{Time: at(1, 1), Valid: true},
+			ProjectID:       sql.NullInt64{Int64: 3, Valid: true},
+			EstimateMinutes: sql.NullInt32{Int32: 240, Valid: true},
+		},
+		{
+			//runs over midnight
+			ID: 2, TaskID: 10, Body: "design",
+			StartedAt:       at(1, 22),
+			EndedAt:         sql.NullTime{Time: at(2, 2), Valid: true},
+			ProjectID:       sql.NullInt64{Int64: 3, Valid: true},
+			EstimateMinutes: sql.NullInt32{Int32: 240, Valid: true},
+		},
+		{
+			//still running
+			ID: 3, TaskID: 11, Body: "errands",
+			StartedAt: at(2, 9),
+		},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, 3, 4+day, hour, 0, 0, 0, time.UTC)
+	}
+	arg := ReportParams{From: at(1, 0), To: at(3, 0), GroupBy: GroupByProject}
+	now := at(2, 10)
+
+	report := BuildReport(arg, reportEntries(at), nil, now)
+	require.Equal(t, int64(6*3600), report.TrackedSeconds)
+	require.Equal(t, int64(240), report.EstimateMinutes)
+	require.Equal(t, int64(5*3600), report.EstimatedTrackedSeconds)
+	require.Len(t, report.Groups, 2)
+	require.Equal(t, "", report.Groups[0].Key)
+	require.Equal(t, int64(3600), report.Groups[0].TrackedSeconds)
+	require.Equal(t, int64(0), report.Groups[0].EstimateMinutes)
+	require.Equal(t, "3", report.Groups[1].Key)
+	require.Equal(t, int64(5*3600), report.Groups[1].TrackedSeconds)
+	require.Len(t, report.Groups[1].Tasks, 1)
+	require.Equal(t, int64(10), report.Groups[1].Tasks[0].TaskID)
+	require.Equal(t, int32(240), *report.Groups[1].Tasks[0].EstimateMinutes)
+
+	//a task counts in each of its labels
+	arg.GroupBy = GroupByLabel
+	labels := []db.GetLabelsByTasksRow{
+		{TaskID: 10, ID: 1, Name: "backend"},
+		{TaskID: 10, ID: 2, Name: "urgent"},
+	}
+	report = BuildReport(arg, reportEntries(at), labels, now)
+	require.Equal(t, int64(6*3600), report.TrackedSeconds)
+	require.Len(t, report.Groups, 3)
+	require.Equal(t, []string{"", "backend", "urgent"}, []string{report.Groups[0].Key, report.Groups[1].Key, report.Groups[2].Key})
+	require.Equal(t, int64(5*3600), report.Groups[2].TrackedSeconds)
+}
+
+func TestBuildReportByDay(t *testing.T) {
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, 3, 4+day, hour, 0, 0, 0, time.UTC)
+	}
+	arg := ReportParams{From: at(1, 0), To: at(3, 0), GroupBy: GroupByDay}
+	now := at(2, 10)
+
+	report := BuildReport(arg, reportEntries(at), nil, now)
+	require.Len(t, report.Groups, 2)
+	require.Equal(t, "2024-03-05", report.Groups[0].Key)
+	require.Equal(t, int64(3*3600), report.Groups[0].TrackedSeconds)
+	require.Equal(t, "2024-03-06", report.Groups[1].Key)
+	require.Equal(t, int64(3*3600), report.Groups[1].TrackedSeconds)
+	require.Len(t, report.Groups[1].Tasks, 2)
+
+	//days start at midnight in the given location
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	arg.Location = tokyo
+	report = BuildReport(arg, reportEntries(at), nil, now)
+	require.Equal(t, int64(6*3600), report.TrackedSeconds)
+	require.Len(t, report.Groups, 2)
+	require.Equal(t, "2024-03-05", report.Groups[0].Key)
+	require.Equal(t, int64(1*3600), report.Groups[0].TrackedSeconds)
+	require.Equal(t, "2024-03-06", report.Groups[1].Key)
+	require.Equal(t, int64(5*3600), report.Groups[1].TrackedSeconds)
+}
@@ -0,0 +1,190 @@
+package timetrack
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// longest range of a report or export
+const MaxRange = 366 * 24 * time.Hour
+
+var (
+	ErrOwnerNotMatched  = fmt.Errorf("owner id does not match user id")
+	ErrTimerRunning     = fmt.Errorf("another timer was started at the same time")
+	ErrNoTimerRunning   = fmt.Errorf("no timer is running")
+	ErrInvalidTimeRange = fmt.Errorf("start must not be after end")
+	ErrInvalidRange     = fmt.Errorf("from must be before to and at most 366 days apart")
+)
+
+type TimeTrack struct {
+	Store db.Store
+}
+
+// EntryParams are the fields of a manual time entry
+type EntryParams struct {
+	StartedAt time.Time
+	// EndedAt is only left empty when editing a running timer
+	EndedAt *time.Time
+	Note    string
+}
+
+func (t *TimeTrack) checkTask(ctx context.Context, ownerId int64, taskId int64) error {
+	task, err := t.Store.GetTask(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	if task.OwnerID != ownerId {
+		return ErrOwnerNotMatched
+	}
+	return nil
+}
+
+func timerError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return ErrTimerRunning
+		}
+	}
+	return err
+}
+
+// Start a timer on a task, the running timer of the user is stopped first
+func (t *TimeTrack) Start(ctx context.Context, ownerId int64, taskId int64, note string) (*db.StartTimerTxResult, error) {
+	if err := t.checkTask(ctx, ownerId, taskId); err != nil {
+		return nil, err
+	}
+	result, err := t.Store.StartTimerTx(ctx, db.StartTimerTxParams{
+		OwnerID: ownerId,
+		TaskID:  taskId,
+		Note:    note,
+		Now:     time.Now(),
+	})
+	if err != nil {
+		return nil, timerError(err)
+	}
+	return &result, nil
+}
+
+// Stop the running timer of a user
+func (t *TimeTrack) Stop(ctx context.Context, ownerId int64) (*db.TimeEntry, error) {
+	entry, err := t.Store.StopTimeEntry(ctx, db.StopTimeEntryParams{
+		EndedAt: time.Now(),
+		OwnerID: ownerId,
+	})
+	if err == sql.ErrNoRows {
+		return nil, ErrNoTimerRunning
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Get the running timer of a user
+func (t *TimeTrack) GetRunning(ctx context.Context, ownerId int64) (*db.TimeEntry, error) {
+	entry, err := t.Store.GetRunningTimeEntry(ctx, ownerId)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoTimerRunning
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Create a time entry for work that wasn't timed
+func (t *TimeTrack) CreateEntry(ctx context.Context, ownerId int64, taskId int64, arg EntryParams) (*db.TimeEntry, error) {
+	if arg.EndedAt == nil || arg.EndedAt.Before(arg.StartedAt) {
+		return nil, ErrInvalidTimeRange
+	}
+	if err := t.checkTask(ctx, ownerId, taskId); err != nil {
+		return nil, err
+	}
+	entry, err := t.Store.CreateTimeEntry(ctx, db.CreateTimeEntryParams{
+		OwnerID:   ownerId,
+		TaskID:    taskId,
+		StartedAt: arg.StartedAt,
+		EndedAt:   sql.NullTime{Time: *arg.EndedAt, Valid: true},
+		Note:      arg.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (t *TimeTrack) getEntry(ctx context.Context, ownerId int64, id int64) (*db.TimeEntry, error) {
+	entry, err := t.Store.GetTimeEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.OwnerID != ownerId {
+		return nil, ErrOwnerNotMatched
+	}
+	return &entry, nil
+}
+
+// Update a time entry, a running timer keeps running unless an end is set
+func (t *TimeTrack) UpdateEntry(ctx context.Context, ownerId int64, id int64, arg EntryParams) (*db.TimeEntry, error) {
+	entry, err := t.getEntry(ctx, ownerId, id)
+	if err != nil {
+		return nil, err
+	}
+	endedAt := sql.NullTime{}
+	if arg.EndedAt != nil {
+		if arg.EndedAt.Before(arg.StartedAt) {
+			return nil, ErrInvalidTimeRange
+		}
+		endedAt = sql.NullTime{Time: *arg.EndedAt, Valid: true}
+	} else if entry.EndedAt.Valid || arg.StartedAt.After(time.Now()) {
+		return nil, ErrInvalidTimeRange
+	}
+	updated, err := t.Store.UpdateTimeEntry(ctx, db.UpdateTimeEntryParams{
+		ID:        id,
+		OwnerID:   ownerId,
+		StartedAt: arg.StartedAt,
+		EndedAt:   endedAt,
+		Note:      arg.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete a time entry
+func (t *TimeTrack) DeleteEntry(ctx context.Context, ownerId int64, id int64) error {
+	if _, err := t.getEntry(ctx, ownerId, id); err != nil {
+		return err
+	}
+	return t.Store.DeleteTimeEntry(ctx, db.DeleteTimeEntryParams{
+		ID:      id,
+		OwnerID: ownerId,
+	})
+}
+
+// Get time entries of a task
+func (t *TimeTrack) GetTaskEntries(ctx context.Context, ownerId int64, taskId int64) ([]db.TimeEntry, error) {
+	if err := t.checkTask(ctx, ownerId, taskId); err != nil {
+		return nil, err
+	}
+	return t.Store.GetTimeEntryListByTask(ctx, taskId)
+}
+
+// Get time entries of a user that overlap [from, to)
+func (t *TimeTrack) GetEntries(ctx context.Context, ownerId int64, from time.Time, to time.Time) ([]db.GetTimeEntriesBetweenRow, error) {
+	if !from.Before(to) || to.Sub(from) > MaxRange {
+		return nil, ErrInvalidRange
+	}
+	return t.Store.GetTimeEntriesBetween(ctx, db.GetTimeEntriesBetweenParams{
+		OwnerID:  ownerId,
+		FromTime: from,
+		ToTime:   to,
+	})
+}
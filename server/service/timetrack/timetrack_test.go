@@ -0,0 +1,59 @@
+package timetrack
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tt := TimeTrack{Store: store}
+
+	store.EXPECT().GetTask(gomock.Any(), int64(5)).Return(db.Task{ID: 5, OwnerID: 2}, nil).AnyTimes()
+
+	_, err := tt.Start(context.Background(), 1, 5, "")
+	require.ErrorIs(t, err, ErrOwnerNotMatched)
+
+	//a timer started at the same time hits the unique index
+	store.EXPECT().
+		StartTimerTx(gomock.Any(), gomock.Any()).
+		Return(db.StartTimerTxResult{}, &pq.Error{Code: "23505"})
+	_, err = tt.Start(context.Background(), 2, 5, "")
+	require.ErrorIs(t, err, ErrTimerRunning)
+
+	store.EXPECT().
+		StopTimeEntry(gomock.Any(), gomock.Any()).
+		Return(db.TimeEntry{}, sql.ErrNoRows)
+	_, err = tt.Stop(context.Background(), 2)
+	require.ErrorIs(t, err, ErrNoTimerRunning)
+}
+
+func TestEntryRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tt := TimeTrack{Store: store}
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	_, err := tt.CreateEntry(context.Background(), 1, 5, EntryParams{StartedAt: now, EndedAt: &before})
+	require.ErrorIs(t, err, ErrInvalidTimeRange)
+	_, err = tt.CreateEntry(context.Background(), 1, 5, EntryParams{StartedAt: now})
+	require.ErrorIs(t, err, ErrInvalidTimeRange)
+
+	_, err = tt.GetEntries(context.Background(), 1, now, before)
+	require.ErrorIs(t, err, ErrInvalidRange)
+	_, err = tt.GetEntries(context.Background(), 1, now.Add(-MaxRange-time.Hour), now)
+	require.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = tt.Report(context.Background(), 1, ReportParams{From: before, To: now, GroupBy: "week"})
+	require.ErrorIs(t, err, ErrInvalidGroupBy)
+}
@@ -0,0 +1,63 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTEdDSAMaker makes JWT tokens signed by the keys of a Keyring, the kid header names the key
+type JWTEdDSAMaker struct {
+	keyring *Keyring
+}
+
+// NewJWTEdDSAMaker creates a new JWTEdDSAMaker
+func NewJWTEdDSAMaker(keyring *Keyring) Maker {
+	return &JWTEdDSAMaker{keyring}
+}
+
+func (maker *JWTEdDSAMaker) CreateToken(user User, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(user, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	//registered claims so services verifying with other JWT libraries check the expiry too
+	payload.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        payload.ID.String(),
+		IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+		ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+	}
+	key := maker.keyring.SigningKey()
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, payload)
+	jwtToken.Header["kid"] = key.ID
+	token, err := jwtToken.SignedString(key.PrivateKey)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *JWTEdDSAMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		return maker.keyring.VerificationKey(kid)
+	}
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrExpiredToken
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
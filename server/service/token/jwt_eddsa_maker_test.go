@@ -0,0 +1,82 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTEdDSAMaker(t *testing.T) {
+	keyring := newTestKeyring(t)
+	maker := NewJWTEdDSAMaker(keyring)
+
+	username := util.RandomString(6)
+	email := util.RandomEmail()
+	id := util.RandomInt(1, 100)
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(User{
+		ID:       id,
+		Email:    email,
+		Username: username,
+	}, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.User.Username)
+	require.Equal(t, id, payload.User.ID)
+	require.Equal(t, email, payload.User.Email)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+	//the kid header names the signing key
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Payload{})
+	require.NoError(t, err)
+	require.Equal(t, keyring.SigningKey().ID, parsed.Header["kid"])
+
+	_, err = NewJWTEdDSAMaker(newTestKeyring(t)).VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestExpiredJWTEdDSAToken(t *testing.T) {
+	maker := NewJWTEdDSAMaker(newTestKeyring(t))
+
+	token, payload, err := maker.CreateToken(User{
+		ID:       util.RandomInt(1, 100),
+		Email:    util.RandomEmail(),
+		Username: util.RandomString(32),
+	}, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestInvalidJWTEdDSATokenAlgNone(t *testing.T) {
+	maker := NewJWTEdDSAMaker(newTestKeyring(t))
+	payload, err := NewPayload(User{ID: util.RandomInt(1, 100)}, time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken.Header["kid"] = "none"
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
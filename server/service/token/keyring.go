@@ -0,0 +1,259 @@
+package token
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aead/chacha20poly1305"
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// SigningKey is the Ed25519 key pair of one rotation period
+type SigningKey struct {
+	ID         string //RFC 7638 thumbprint of the public key, the kid of tokens and the JWKS
+	PrivateKey ed25519.PrivateKey
+	PublicKey  ed25519.PublicKey
+	NotBefore  time.Time //the key signs tokens from NotBefore
+	NotAfter   time.Time //until NotAfter, its tokens verify until NotAfter plus the grace period
+}
+
+// Keyring holds the Ed25519 keys tokens are signed with. Keys are random and kept in the
+// signing_keys table with the period they sign in, so every server signs with the same key.
+// Rotate creates the key of the next period ahead of time so services caching the JWKS know
+// it before it signs, old keys verify during the grace period.
+// The private keys are stored encrypted with XChaCha20-Poly1305, the key id is the additional data
+type Keyring struct {
+	store            db.Store
+	aead             cipher.AEAD
+	rotationInterval time.Duration
+	gracePeriod      time.Duration
+	now              func() time.Time
+
+	mu   sync.Mutex
+	keys []*SigningKey //the oldest first
+}
+
+// NewKeyring creates a keyring that rotates keys every rotationInterval and loads its keys,
+// gracePeriod must be at least the lifetime of the longest living token. The private keys
+// in the store are encrypted with encryptionKey, every server needs the same one
+func NewKeyring(ctx context.Context, store db.Store, encryptionKey string, rotationInterval time.Duration, gracePeriod time.Duration) (*Keyring, error) {
+	return newKeyring(ctx, store, encryptionKey, rotationInterval, gracePeriod, time.Now)
+}
+
+func newKeyring(ctx context.Context, store db.Store, encryptionKey string, rotationInterval time.Duration, gracePeriod time.Duration, now func() time.Time) (*Keyring, error) {
+	if len(encryptionKey) != chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("invalid encryption key size: must be exactly %d characters", chacha20poly1305.KeySize)
+	}
+	aead, err := chacha20poly1305.NewXCipher([]byte(encryptionKey))
+	if err != nil {
+		return nil, err
+	}
+	if rotationInterval <= 0 {
+		return nil, fmt.Errorf("invalid rotation interval: %v", rotationInterval)
+	}
+	if gracePeriod < 0 {
+		return nil, fmt.Errorf("invalid grace period: %v", gracePeriod)
+	}
+	k := &Keyring{
+		store:            store,
+		aead:             aead,
+		rotationInterval: rotationInterval,
+		gracePeriod:      gracePeriod,
+		now:              now,
+	}
+	if err := k.Rotate(ctx); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Rotate creates the keys of the current and the next period unless another server did,
+// deletes the keys whose grace period is over and loads the keys other servers created
+func (k *Keyring) Rotate(ctx context.Context) error {
+	now := k.now()
+	oldest := now.Add(-k.gracePeriod)
+	if _, err := k.store.DeleteExpiredSigningKeys(ctx, oldest); err != nil {
+		return err
+	}
+	rows, err := k.store.ListSigningKeys(ctx, oldest)
+	if err != nil {
+		return err
+	}
+	current := now.Truncate(k.rotationInterval)
+	created := false
+	for _, notBefore := range []time.Time{current, current.Add(k.rotationInterval)} {
+		if hasKey(rows, notBefore) {
+			continue
+		}
+		if err := k.createKey(ctx, notBefore); err != nil {
+			return err
+		}
+		created = true
+	}
+	if created {
+		if rows, err = k.store.ListSigningKeys(ctx, oldest); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]*SigningKey, len(rows))
+	for i, row := range rows {
+		seed, err := k.open(row)
+		if err != nil {
+			return err
+		}
+		privateKey := ed25519.NewKeyFromSeed(seed)
+		keys[i] = &SigningKey{
+			ID:         row.ID,
+			PrivateKey: privateKey,
+			PublicKey:  privateKey.Public().(ed25519.PublicKey),
+			NotBefore:  row.NotBefore,
+			NotAfter:   row.NotAfter,
+		}
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+	return nil
+}
+
+func hasKey(rows []db.SigningKey, notBefore time.Time) bool {
+	for _, row := range rows {
+		if row.NotBefore.Equal(notBefore) {
+			return true
+		}
+	}
+	return false
+}
+
+// createKey generates the key of the period starting at notBefore, when another server
+// stored one first its key is kept
+func (k *Keyring) createKey(ctx context.Context, notBefore time.Time) error {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	id := thumbprint(publicKey)
+	sealed, err := k.seal(id, privateKey.Seed())
+	if err != nil {
+		return err
+	}
+	_, err = k.store.CreateSigningKey(ctx, db.CreateSigningKeyParams{
+		ID:         id,
+		PrivateKey: sealed,
+		NotBefore:  notBefore,
+		NotAfter:   notBefore.Add(k.rotationInterval),
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// seal encrypts the seed of a private key, the random nonce goes first
+func (k *Keyring) seal(id string, seed []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize(), k.aead.NonceSize()+len(seed)+k.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return k.aead.Seal(nonce, nonce, seed, []byte(id)), nil
+}
+
+// open decrypts the seed of a stored key, it fails when the key was encrypted with another
+// encryption key or moved to another id
+func (k *Keyring) open(row db.SigningKey) ([]byte, error) {
+	if len(row.PrivateKey) < k.aead.NonceSize() {
+		return nil, fmt.Errorf("invalid signing key %s", row.ID)
+	}
+	nonce, sealed := row.PrivateKey[:k.aead.NonceSize()], row.PrivateKey[k.aead.NonceSize():]
+	seed, err := k.aead.Open(nil, nonce, sealed, []byte(row.ID))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key %s", row.ID)
+	}
+	return seed, nil
+}
+
+// SigningKey returns the key of the current period, or the newest key before it when the
+// keys have not been rotated in time
+func (k *Keyring) SigningKey() *SigningKey {
+	now := k.now()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var current *SigningKey
+	for _, key := range k.keys {
+		if !key.NotBefore.After(now) {
+			current = key
+		}
+	}
+	return current
+}
+
+// VerificationKeys returns the keys in the grace period, the current and the next key, the oldest first
+func (k *Keyring) VerificationKeys() []*SigningKey {
+	oldest := k.now().Add(-k.gracePeriod)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keys := make([]*SigningKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if key.NotAfter.After(oldest) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// VerificationKey returns the public key of kid, the next key is accepted too in case the
+// clock of the server that signed the token is ahead
+func (k *Keyring) VerificationKey(kid string) (ed25519.PublicKey, error) {
+	for _, key := range k.VerificationKeys() {
+		if key.ID == kid {
+			return key.PublicKey, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// thumbprint is the RFC 7638 JWK thumbprint of an Ed25519 public key
+func thumbprint(publicKey ed25519.PublicKey) string {
+	jwk := `{"crv":"Ed25519","kty":"OKP","x":"` + base64.RawURLEncoding.EncodeToString(publicKey) + `"}`
+	sum := sha256.Sum256([]byte(jwk))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// JWK is a public key of the JWKS, RFC 8037
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS returns the public keys other services verify tokens with
+func (k *Keyring) JWKS() []JWK {
+	keys := k.VerificationKeys()
+	jwks := make([]JWK, len(keys))
+	for i, key := range keys {
+		jwks[i] = JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key.PublicKey),
+			Kid: key.ID,
+			Use: "sig",
+			Alg: "EdDSA",
+		}
+	}
+	return jwks
+}
@@ -0,0 +1,78 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	memdb "github.com/punkzberryz/todo/db/memory"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	secret := util.RandomString(32)
+	keyring, err := newKeyring(ctx, store, secret, 24*time.Hour, 48*time.Hour, func() time.Time { return now })
+	require.NoError(t, err)
+
+	first := keyring.SigningKey()
+	require.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), first.NotBefore.UTC())
+	require.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), first.NotAfter.UTC())
+	//the next key is published a period ahead
+	keys := keyring.JWKS()
+	require.Len(t, keys, 2)
+	require.Equal(t, first.ID, keys[0].Kid)
+	next := keys[1].Kid
+
+	//another server loads the keys instead of creating its own
+	other, err := newKeyring(ctx, store, secret, 24*time.Hour, 48*time.Hour, func() time.Time { return now })
+	require.NoError(t, err)
+	require.Equal(t, first.ID, other.SigningKey().ID)
+	require.Equal(t, first.PrivateKey, other.SigningKey().PrivateKey)
+	//the stored keys are encrypted, another secret can't load them
+	rows, err := store.ListSigningKeys(ctx, time.Time{})
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotContains(t, string(row.PrivateKey), string(first.PrivateKey.Seed()))
+	}
+	_, err = newKeyring(ctx, store, util.RandomString(32), 24*time.Hour, 48*time.Hour, func() time.Time { return now })
+	require.Error(t, err)
+
+	//a new key is created for the period after the next one, the other server picks it up
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, keyring.Rotate(ctx))
+	second := keyring.SigningKey()
+	require.Equal(t, next, second.ID)
+	_, err = keyring.VerificationKey(first.ID)
+	require.NoError(t, err)
+	third := keyring.JWKS()[2].Kid
+	require.NoError(t, other.Rotate(ctx))
+	require.Equal(t, third, other.JWKS()[2].Kid)
+
+	//the first key stopped signing 2 days ago, its grace period is over
+	now = now.Add(48 * time.Hour)
+	_, err = keyring.VerificationKey(first.ID)
+	require.ErrorIs(t, err, ErrUnknownKey)
+	_, err = keyring.VerificationKey(second.ID)
+	require.NoError(t, err)
+	require.NoError(t, keyring.Rotate(ctx))
+	rows, err = store.ListSigningKeys(ctx, time.Time{})
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotEqual(t, first.ID, row.ID)
+	}
+}
+
+func TestNewKeyring(t *testing.T) {
+	store := memdb.NewStore()
+	secret := util.RandomString(32)
+	_, err := NewKeyring(context.Background(), store, secret, 0, time.Hour)
+	require.Error(t, err)
+	_, err = NewKeyring(context.Background(), store, secret, time.Hour, -time.Hour)
+	require.Error(t, err)
+	_, err = NewKeyring(context.Background(), store, util.RandomString(16), time.Hour, time.Hour)
+	require.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package token
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pasetoV4PublicHeader is the header of v4.public tokens, the paseto library only does v1 and v2
+const pasetoV4PublicHeader = "v4.public."
+
+// pasetoFooter names the key that signed a token
+type pasetoFooter struct {
+	Kid string `json:"kid"`
+}
+
+// pasetoClaims is the message of v4.public tokens, the times and the id use the registered
+// claims of PASETO so other libraries verifying the tokens check them too
+type pasetoClaims struct {
+	ID        string    `json:"jti"`
+	User      User      `json:"user"`
+	IssuedAt  time.Time `json:"iat"`
+	NotBefore time.Time `json:"nbf"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// pasetoClockSkew is how far ahead the clock of the server that signed a token may be
+const pasetoClockSkew = time.Minute
+
+// PasetoPublicMaker makes PASETO v4.public tokens signed by the keys of a Keyring,
+// the footer names the key
+type PasetoPublicMaker struct {
+	keyring *Keyring
+}
+
+// NewPasetoPublicMaker creates a new PasetoPublicMaker
+func NewPasetoPublicMaker(keyring *Keyring) Maker {
+	return &PasetoPublicMaker{keyring}
+}
+
+func (maker *PasetoPublicMaker) CreateToken(user User, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(user, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	message, err := json.Marshal(pasetoClaims{
+		ID:        payload.ID.String(),
+		User:      payload.User,
+		IssuedAt:  payload.IssuedAt,
+		NotBefore: payload.IssuedAt,
+		ExpiresAt: payload.ExpiredAt,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	key := maker.keyring.SigningKey()
+	footer, err := json.Marshal(pasetoFooter{Kid: key.ID})
+	if err != nil {
+		return "", nil, err
+	}
+	signature := ed25519.Sign(key.PrivateKey, pae([]byte(pasetoV4PublicHeader), message, footer, nil))
+	token := pasetoV4PublicHeader +
+		base64.RawURLEncoding.EncodeToString(append(message, signature...)) + "." +
+		base64.RawURLEncoding.EncodeToString(footer)
+	return token, payload, nil
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	if !strings.HasPrefix(token, pasetoV4PublicHeader) {
+		return nil, ErrInvalidToken
+	}
+	//the footer is required, it names the key
+	body, encodedFooter, ok := strings.Cut(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	signed, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil || len(signed) < ed25519.SignatureSize {
+		return nil, ErrInvalidToken
+	}
+	footer, err := base64.RawURLEncoding.DecodeString(encodedFooter)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var f pasetoFooter
+	if err := json.Unmarshal(footer, &f); err != nil {
+		return nil, ErrInvalidToken
+	}
+	publicKey, err := maker.keyring.VerificationKey(f.Kid)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	message := signed[:len(signed)-ed25519.SignatureSize]
+	signature := signed[len(signed)-ed25519.SignatureSize:]
+	if !ed25519.Verify(publicKey, pae([]byte(pasetoV4PublicHeader), message, footer, nil), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims pasetoClaims
+	if err := json.Unmarshal(message, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	id, err := uuid.Parse(claims.ID)
+	if err != nil || claims.NotBefore.After(time.Now().Add(pasetoClockSkew)) {
+		return nil, ErrInvalidToken
+	}
+	payload := &Payload{
+		ID:        id,
+		User:      claims.User,
+		IssuedAt:  claims.IssuedAt,
+		ExpiredAt: claims.ExpiresAt,
+	}
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// pae is the pre-authentication encoding of PASETO, the signature covers it
+func pae(pieces ...[]byte) []byte {
+	var buf bytes.Buffer
+	le64 := func(n int) {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, uint64(n))
+		buf.Write(b)
+	}
+	le64(len(pieces))
+	for _, piece := range pieces {
+		le64(len(piece))
+		buf.Write(piece)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,102 @@
+package token
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	memdb "github.com/punkzberryz/todo/db/memory"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyring(t *testing.T) *Keyring {
+	keyring, err := NewKeyring(context.Background(), memdb.NewStore(), util.RandomString(32), time.Hour, 24*time.Hour)
+	require.NoError(t, err)
+	return keyring
+}
+
+func TestPasetoPublicMaker(t *testing.T) {
+	maker := NewPasetoPublicMaker(newTestKeyring(t))
+
+	username := util.RandomString(6)
+	email := util.RandomEmail()
+	id := util.RandomInt(1, 100)
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(User{
+		ID:       id,
+		Email:    email,
+		Username: username,
+	}, duration)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.User.Username)
+	require.Equal(t, id, payload.User.ID)
+	require.Equal(t, email, payload.User.Email)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+	//the registered claims of PASETO
+	body, _, _ := strings.Cut(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	signed, err := base64.RawURLEncoding.DecodeString(body)
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(signed[:len(signed)-ed25519.SignatureSize], &claims))
+	require.Equal(t, payload.ID.String(), claims["jti"])
+	for _, claim := range []string{"iat", "nbf", "exp"} {
+		_, err := time.Parse(time.RFC3339, claims[claim].(string))
+		require.NoError(t, err)
+	}
+	require.NotContains(t, claims, "issued_at")
+	require.NotContains(t, claims, "expired_at")
+
+	//signed by a key of another keyring
+	_, err = NewPasetoPublicMaker(newTestKeyring(t)).VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	//tampered footer
+	_, err = maker.VerifyToken(token[:strings.LastIndex(token, ".")+1] + base64.RawURLEncoding.EncodeToString([]byte(`{"kid":"x"}`)))
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	maker := NewPasetoPublicMaker(newTestKeyring(t))
+
+	token, payload, err := maker.CreateToken(User{
+		ID:       util.RandomInt(1, 100),
+		Email:    util.RandomEmail(),
+		Username: util.RandomString(32),
+	}, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+// TestPasetoPae checks the signature of the 4-S-1 test vector of the PASETO spec
+func TestPasetoPae(t *testing.T) {
+	publicKey, err := hex.DecodeString("1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+	token := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+	signed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, pasetoV4PublicHeader))
+	require.NoError(t, err)
+	message := signed[:len(signed)-ed25519.SignatureSize]
+	require.True(t, ed25519.Verify(publicKey, pae([]byte(pasetoV4PublicHeader), message, nil, nil), signed[len(message):]))
+}
@@ -0,0 +1,49 @@
+package token
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const DefaultRotationCheckInterval = time.Hour
+
+// RotationWorker rotates the keys of a Keyring, every server runs one so it picks up the
+// keys the others created
+type RotationWorker struct {
+	Keyring *Keyring
+	// how often keys are rotated and reloaded
+	Interval time.Duration
+}
+
+// NewRotationWorker checks at least twice per rotation interval, so the next key is
+// loaded well before it signs
+func NewRotationWorker(keyring *Keyring, interval time.Duration) *RotationWorker {
+	if interval <= 0 {
+		interval = DefaultRotationCheckInterval
+	}
+	if half := keyring.rotationInterval / 2; half > 0 && interval > half {
+		interval = half
+	}
+	return &RotationWorker{
+		Keyring:  keyring,
+		Interval: interval,
+	}
+}
+
+// Run rotates the keys every Interval until ctx is cancelled, the keyring rotated them
+// when it was created
+func (w *RotationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := w.Keyring.Rotate(ctx); err != nil && ctx.Err() == nil {
+			log.Println("cannot rotate signing keys:", err)
+		}
+	}
+}
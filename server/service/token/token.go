@@ -3,6 +3,7 @@ package token
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +15,8 @@ var (
 	ErrIncorrectSessionUser = fmt.Errorf("incorrect session user")
 	ErrMismatchSessionToken = fmt.Errorf("mismatch session token")
 	ErrSessionExpired       = fmt.Errorf("expired session")
+	ErrRefreshTokenReused   = fmt.Errorf("refresh token was used already, all sessions of this login are revoked")
+	ErrRevokedToken         = fmt.Errorf("token has been revoked")
 )
 
 type Token struct {
@@ -56,6 +59,9 @@ func (t *Token) CreateNewAccessToken(ctx context.Context, arg CreateTokenParams)
 		ClientIp:     arg.ClientIp,
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+
+		AccessTokenID:        accessPayload.ID,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
 	})
 
 	if err != nil {
@@ -71,48 +77,114 @@ func (t *Token) CreateNewAccessToken(ctx context.Context, arg CreateTokenParams)
 	return &rsp, nil
 }
 
-// For renew the AccessToken with refreshToken
+// For renew the AccessToken with refreshToken,
+// the refresh token is rotated too and the old one stops working
 type RenewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
-func (t *Token) RenewAccessToken(ctx context.Context, refreshToken string) (*RenewAccessTokenResponse, error) {
+// clientIp is where the token is renewed from, it is shown in the sessions of the user
+func (t *Token) RenewAccessToken(ctx context.Context, refreshToken string, clientIp string) (*RenewAccessTokenResponse, error) {
 	refreshPayload, err := t.Maker.VerifyToken(refreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	session, err := t.Session.GetTokenSession(ctx, refreshPayload.ID)
+	if err := t.CheckRevoked(ctx, refreshPayload); err != nil {
+		return nil, err
+	}
+	tokenSession, err := t.Session.GetTokenSession(ctx, refreshPayload.ID)
 
 	if err != nil {
 		return nil, err
 	}
-	if session.IsBlocked {
+	if tokenSession.IsBlocked {
 		return nil, ErrBlockedSession
 	}
-	if session.UserID != refreshPayload.User.ID {
+	if tokenSession.UserID != refreshPayload.User.ID {
 		return nil, ErrIncorrectSessionUser
 	}
 
-	if session.RefreshToken != refreshToken {
+	if tokenSession.RefreshToken != refreshToken {
 		return nil, ErrMismatchSessionToken
 	}
-	if time.Now().After(session.ExpiresAt) {
+	if time.Now().After(tokenSession.ExpiresAt) {
 		return nil, ErrSessionExpired
 	}
+	//a rotated token coming back means it leaked, whoever holds the newer one may be an attacker
+	if tokenSession.ReplacedBy != uuid.Nil {
+		return nil, t.revokeFamily(ctx, tokenSession)
+	}
 
 	accessToken, accessPayload, err := t.Maker.CreateToken(refreshPayload.User, t.AccessTokenDuration)
 	if err != nil {
 		return nil, err
 	}
-	rsp := RenewAccessTokenResponse{
-		AccessToken:          accessToken,
+	if clientIp == "" {
+		clientIp = tokenSession.ClientIp
+	}
+	//rotating doesn't make a login last longer
+	newRefreshToken, newRefreshPayload, err := t.Maker.CreateToken(refreshPayload.User, time.Until(tokenSession.ExpiresAt))
+	if err != nil {
+		return nil, err
+	}
+	_, err = t.Session.RotateTokenSession(ctx, tokenSession.ID, session.CreateTokenSessionParams{
+		ID:           newRefreshPayload.ID,
+		FamilyID:     tokenSession.FamilyID,
+		UserID:       tokenSession.UserID,
+		RefreshToken: newRefreshToken,
+		UserAgent:    tokenSession.UserAgent,
+		ClientIp:     clientIp,
+		ExpiresAt:    tokenSession.ExpiresAt,
+
+		AccessTokenID:        accessPayload.ID,
 		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+	})
+	if err == session.ErrTokenSessionRotated {
+		return nil, t.revokeFamily(ctx, tokenSession)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rsp := RenewAccessTokenResponse{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          newRefreshToken,
+		RefreshTokenExpiresAt: tokenSession.ExpiresAt,
 	}
 	return &rsp, nil
 }
 
+// revokeFamily blocks the sessions that rotated from a reused one and revokes the access
+// tokens of the login like RevokeSession, then returns ErrRefreshTokenReused
+func (t *Token) revokeFamily(ctx context.Context, reused *session.TokenSession) error {
+	log.Printf("refresh token reuse detected for user %d, revoking session family %s", reused.UserID, reused.FamilyID)
+	//blocking the sessions unlists the login, its access tokens are read first
+	userSession, err := t.Session.GetUserSession(ctx, reused.UserID, reused.FamilyID)
+	if err != nil && err != session.ErrTokenSessionNotFound {
+		return err
+	}
+	next, err := t.Session.GetTokenSession(ctx, reused.ID)
+	for err == nil && next.ReplacedBy != uuid.Nil {
+		if err = t.Session.BlockTokenSession(ctx, next.ReplacedBy); err != nil {
+			break
+		}
+		next, err = t.Session.GetTokenSession(ctx, next.ReplacedBy)
+	}
+	if err != nil && err != session.ErrTokenSessionNotFound {
+		return err
+	}
+	if userSession != nil {
+		if err := t.revokeAccessTokens(ctx, userSession); err != nil {
+			return err
+		}
+	}
+	return ErrRefreshTokenReused
+}
+
 // Delete token session
 func (t *Token) DeleteTokenSession(ctx context.Context, refreshToken string) error {
 	refreshPayload, err := t.Maker.VerifyToken(refreshToken)
@@ -122,3 +194,76 @@ func (t *Token) DeleteTokenSession(ctx context.Context, refreshToken string) err
 	err = t.Session.DeleteTokenSession(ctx, refreshPayload.ID)
 	return err
 }
+
+// ListSessions returns where the user is logged in
+func (t *Token) ListSessions(ctx context.Context, userId int64) ([]session.UserSession, error) {
+	return t.Session.ListUserSessions(ctx, userId)
+}
+
+// RevokeSession logs out one login of the user, its refresh token and every access token it got stop working
+func (t *Token) RevokeSession(ctx context.Context, userId int64, sessionId uuid.UUID) error {
+	userSession, err := t.Session.DeleteUserSession(ctx, userId, sessionId)
+	if err != nil {
+		return err
+	}
+	return t.revokeAccessTokens(ctx, userSession)
+}
+
+// revokeAccessTokens revokes every access token issued to a login
+func (t *Token) revokeAccessTokens(ctx context.Context, userSession *session.UserSession) error {
+	accessTokens := userSession.AccessTokens
+	if len(accessTokens) == 0 {
+		//logins listed before their access tokens were recorded only know the latest one
+		accessTokens = []session.AccessToken{{ID: userSession.AccessTokenID, ExpiresAt: userSession.AccessTokenExpiresAt}}
+	}
+	for _, accessToken := range accessTokens {
+		if err := t.Session.RevokeToken(ctx, accessToken.ID, time.Until(accessToken.ExpiresAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyAccessToken is Maker.VerifyToken that also rejects revoked tokens
+func (t *Token) VerifyAccessToken(ctx context.Context, accessToken string) (*Payload, error) {
+	payload, err := t.Maker.VerifyToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.CheckRevoked(ctx, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// CheckRevoked fails with ErrRevokedToken once the token, its session or all tokens
+// of its user were revoked
+func (t *Token) CheckRevoked(ctx context.Context, payload *Payload) error {
+	revoked, err := t.Session.IsTokenRevoked(ctx, payload.ID, payload.User.ID, payload.IssuedAt)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrRevokedToken
+	}
+	return nil
+}
+
+// RevokeAccessToken makes an access token stop working before it expires
+func (t *Token) RevokeAccessToken(ctx context.Context, accessToken string) error {
+	payload, err := t.Maker.VerifyToken(accessToken)
+	if err != nil {
+		return err
+	}
+	return t.Session.RevokeToken(ctx, payload.ID, time.Until(payload.ExpiredAt))
+}
+
+// RevokeUserTokens logs a user out everywhere, every access and refresh token issued until now stops working
+func (t *Token) RevokeUserTokens(ctx context.Context, userId int64) error {
+	//no token issued before now outlives the longer of both durations
+	ttl := t.RefreshTokenDuration
+	if t.AccessTokenDuration > ttl {
+		ttl = t.AccessTokenDuration
+	}
+	return t.Session.RevokeUserTokens(ctx, userId, time.Now(), ttl)
+}
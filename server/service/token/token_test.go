@@ -0,0 +1,117 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/punkzberryz/todo/session"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewAccessTokenRotates(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+	sessions := session.NewMemoryStore()
+	tokens := Token{Maker: maker, Session: sessions, AccessTokenDuration: time.Minute, RefreshTokenDuration: time.Hour}
+
+	login, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+
+	renewed, err := tokens.RenewAccessToken(context.Background(), login.RefreshToken, "")
+	require.NoError(t, err)
+	require.NotEqual(t, login.RefreshToken, renewed.RefreshToken)
+	require.WithinDuration(t, login.RefreshTokenExpiresAt, renewed.RefreshTokenExpiresAt, time.Second)
+	payload, err := maker.VerifyToken(renewed.RefreshToken)
+	require.NoError(t, err)
+	rotated, err := sessions.GetTokenSession(context.Background(), payload.ID)
+	require.NoError(t, err)
+	require.Equal(t, login.SessionID, rotated.FamilyID)
+
+	renewedAgain, err := tokens.RenewAccessToken(context.Background(), renewed.RefreshToken, "")
+	require.NoError(t, err)
+	other, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+
+	//the first token comes back, everything after it is revoked
+	_, err = tokens.RenewAccessToken(context.Background(), login.RefreshToken, "")
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+	_, err = tokens.RenewAccessToken(context.Background(), renewedAgain.RefreshToken, "")
+	require.ErrorIs(t, err, ErrBlockedSession)
+	_, err = tokens.RenewAccessToken(context.Background(), renewed.RefreshToken, "")
+	require.ErrorIs(t, err, ErrBlockedSession)
+	for _, accessToken := range []string{login.AccessToken, renewed.AccessToken, renewedAgain.AccessToken} {
+		_, err = tokens.VerifyAccessToken(context.Background(), accessToken)
+		require.ErrorIs(t, err, ErrRevokedToken)
+	}
+
+	//other logins are not affected
+	_, err = tokens.VerifyAccessToken(context.Background(), other.AccessToken)
+	require.NoError(t, err)
+	_, err = tokens.RenewAccessToken(context.Background(), other.RefreshToken, "")
+	require.NoError(t, err)
+}
+
+func TestRevokeTokens(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+	tokens := Token{Maker: maker, Session: session.NewMemoryStore(), AccessTokenDuration: time.Minute, RefreshTokenDuration: time.Hour}
+
+	login, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+	other, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+
+	require.NoError(t, tokens.RevokeAccessToken(context.Background(), login.AccessToken))
+	_, err = tokens.VerifyAccessToken(context.Background(), login.AccessToken)
+	require.ErrorIs(t, err, ErrRevokedToken)
+	_, err = tokens.VerifyAccessToken(context.Background(), other.AccessToken)
+	require.NoError(t, err)
+
+	require.NoError(t, tokens.RevokeUserTokens(context.Background(), 7))
+	_, err = tokens.VerifyAccessToken(context.Background(), other.AccessToken)
+	require.ErrorIs(t, err, ErrRevokedToken)
+	_, err = tokens.RenewAccessToken(context.Background(), other.RefreshToken, "")
+	require.ErrorIs(t, err, ErrRevokedToken)
+
+	//logging in again works
+	again, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+	_, err = tokens.VerifyAccessToken(context.Background(), again.AccessToken)
+	require.NoError(t, err)
+}
+
+func TestRevokeSession(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+	tokens := Token{Maker: maker, Session: session.NewMemoryStore(), AccessTokenDuration: time.Minute, RefreshTokenDuration: time.Hour}
+
+	login, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+	other, err := tokens.CreateNewAccessToken(context.Background(), CreateTokenParams{User: User{ID: 7}})
+	require.NoError(t, err)
+	renewed, err := tokens.RenewAccessToken(context.Background(), login.RefreshToken, "")
+	require.NoError(t, err)
+
+	sessions, err := tokens.ListSessions(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	//another user can't revoke it
+	require.ErrorIs(t, tokens.RevokeSession(context.Background(), 8, login.SessionID), session.ErrTokenSessionNotFound)
+
+	require.NoError(t, tokens.RevokeSession(context.Background(), 7, login.SessionID))
+	_, err = tokens.VerifyAccessToken(context.Background(), renewed.AccessToken)
+	require.ErrorIs(t, err, ErrRevokedToken)
+	_, err = tokens.RenewAccessToken(context.Background(), renewed.RefreshToken, "")
+	require.ErrorIs(t, err, session.ErrTokenSessionNotFound)
+
+	//the other login still works
+	_, err = tokens.VerifyAccessToken(context.Background(), other.AccessToken)
+	require.NoError(t, err)
+	sessions, err = tokens.ListSessions(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, other.SessionID, sessions[0].ID)
+}
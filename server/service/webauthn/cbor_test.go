@@ -0,0 +1,134 @@
+package webauthn
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cborMap keeps the order of its entries, authenticators send canonical CBOR
+type cborMap []cborPair
+
+type cborPair struct {
+	key   interface{}
+	value interface{}
+}
+
+// encodeCBOR is the encoder side of decodeCBOR for the software authenticator in tests
+func encodeCBOR(value interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n <= 0xff:
+			return []byte{major<<5 | 24, byte(n)}
+		case n <= 0xffff:
+			return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+		}
+		return []byte{major<<5 | 26, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+	}
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case []interface{}:
+		out := head(4, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(item)...)
+		}
+		return out
+	case cborMap:
+		out := head(5, uint64(len(v)))
+		for _, pair := range v {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	case bool:
+		if v {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	case nil:
+		return []byte{0xf6}
+	}
+	panic("cannot encode")
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	//examples from RFC 8949 appendix A
+	testCases := []struct {
+		hex   string
+		value interface{}
+	}{
+		{"00", int64(0)},
+		{"17", int64(23)},
+		{"1818", int64(24)},
+		{"1903e8", int64(1000)},
+		{"1b000000e8d4a51000", int64(1000000000000)},
+		{"20", int64(-1)},
+		{"3903e7", int64(-1000)},
+		{"f4", false},
+		{"f5", true},
+		{"f6", nil},
+		{"4401020304", []byte{1, 2, 3, 4}},
+		{"6449455446", "IETF"},
+		{"83010203", []interface{}{int64(1), int64(2), int64(3)}},
+		{"8301820203820405", []interface{}{int64(1), []interface{}{int64(2), int64(3)}, []interface{}{int64(4), int64(5)}}},
+		{"a201020304", map[interface{}]interface{}{int64(1): int64(2), int64(3): int64(4)}},
+		{"a26161016162820203", map[interface{}]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}},
+	}
+	for _, tc := range testCases {
+		data, err := hex.DecodeString(tc.hex)
+		require.NoError(t, err)
+		value, rest, err := decodeCBOR(data)
+		require.NoError(t, err, tc.hex)
+		require.Equal(t, tc.value, value, tc.hex)
+		require.Empty(t, rest)
+	}
+
+	value, rest, err := decodeCBOR(encodeCBOR(cborMap{{"fmt", "none"}, {-2, []byte{9}}}))
+	require.NoError(t, err)
+	require.Empty(t, rest)
+	require.Equal(t, map[interface{}]interface{}{"fmt": "none", int64(-2): []byte{9}}, value)
+
+	value, rest, err = decodeCBOR([]byte{0x01, 0xff})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), value)
+	require.Equal(t, []byte{0xff}, rest)
+}
+
+func TestDecodeInvalidCBOR(t *testing.T) {
+	for _, h := range []string{
+		"",
+		"18",         //missing argument
+		"5a00000010", //byte string longer than the input
+		"9f01ff",     //indefinite array
+		"9bffffffffffffffff",
+		"a20102",             //map missing an entry
+		"a2010201",           //duplicate key
+		"a1f401",             //bool key
+		"c11a514b67b0",       //tag
+		"fb3ff199999999999a", //float
+		"1bffffffffffffffff", //uint64 beyond int64
+	} {
+		data, err := hex.DecodeString(h)
+		require.NoError(t, err)
+		_, _, err = decodeCBOR(data)
+		require.ErrorIs(t, err, ErrInvalidCBOR, h)
+	}
+
+	nested := []byte{}
+	for i := 0; i < 100; i++ {
+		nested = append(nested, 0x81)
+	}
+	_, _, err := decodeCBOR(append(nested, 0x01))
+	require.ErrorIs(t, err, ErrInvalidCBOR)
+}
@@ -0,0 +1,123 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidResponse   = fmt.Errorf("invalid authenticator response")
+	ErrChallengeMismatch = fmt.Errorf("response does not answer the challenge")
+	ErrOriginNotAllowed  = fmt.Errorf("response comes from an origin that is not allowed")
+	ErrRPIDMismatch      = fmt.Errorf("credential is scoped to another relying party")
+	ErrUserNotPresent    = fmt.Errorf("authenticator did not check user presence")
+	ErrUserNotVerified   = fmt.Errorf("authenticator did not verify the user")
+)
+
+// authenticator data flags
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+// authenticatorData is the part of every authenticator response the signature covers
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	// only set in registration responses
+	credentialID []byte
+	publicKey    *publicKey
+	rawPublicKey []byte
+}
+
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < 37 {
+		return nil, ErrInvalidResponse
+	}
+	a := &authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	if a.flags&flagAttested == 0 {
+		return a, nil
+	}
+	//aaguid, credential id length, credential id, COSE public key
+	rest := data[37:]
+	if len(rest) < 18 {
+		return nil, ErrInvalidResponse
+	}
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLength == 0 || idLength > 1023 || len(rest) < idLength {
+		return nil, ErrInvalidResponse
+	}
+	a.credentialID = rest[:idLength]
+	rest = rest[idLength:]
+	key, after, err := parsePublicKey(rest)
+	if err != nil {
+		return nil, err
+	}
+	a.publicKey = key
+	a.rawPublicKey = rest[:len(rest)-len(after)]
+	return a, nil
+}
+
+// check verifies the parts of authenticator data that are the same for both ceremonies
+func (a *authenticatorData) check(rpID string, requireVerification bool) error {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(a.rpIDHash, rpIDHash[:]) {
+		return ErrRPIDMismatch
+	}
+	if a.flags&flagUserPresent == 0 {
+		return ErrUserNotPresent
+	}
+	if requireVerification && a.flags&flagUserVerified == 0 {
+		return ErrUserNotVerified
+	}
+	return nil
+}
+
+// clientData is what the browser signs over together with the authenticator data
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// checkClientData verifies the ceremony type, the challenge and the origin of clientDataJSON
+func checkClientData(raw []byte, ceremonyType string, challenge []byte, origins []string) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return ErrInvalidResponse
+	}
+	if data.Type != ceremonyType {
+		return ErrInvalidResponse
+	}
+	got, err := decodeBase64(data.Challenge)
+	if err != nil || !bytes.Equal(got, challenge) {
+		return ErrChallengeMismatch
+	}
+	for _, origin := range origins {
+		if data.Origin == origin {
+			return nil
+		}
+	}
+	return ErrOriginNotAllowed
+}
+
+// decodeBase64 accepts base64url with or without padding, as browsers and libraries differ
+func decodeBase64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+func encodeBase64(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
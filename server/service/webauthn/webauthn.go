@@ -0,0 +1,413 @@
+package webauthn
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/session"
+)
+
+const (
+	// how long a browser has to answer a challenge
+	CeremonyTimeout         = 5 * time.Minute
+	MaxCredentialNameLength = 64
+
+	typeCreate = "webauthn.create"
+	typeGet    = "webauthn.get"
+)
+
+var (
+	ErrInvalidCeremony       = fmt.Errorf("ceremony is unknown or has expired")
+	ErrCredentialExists      = fmt.Errorf("credential is already registered")
+	ErrUnknownCredential     = fmt.Errorf("credential is not registered")
+	ErrSignCountMismatch     = fmt.Errorf("signature counter did not increase, the authenticator may be cloned")
+	ErrCredentialNotFound    = fmt.Errorf("credential not found")
+	ErrInvalidCredentialName = fmt.Errorf("credential name must be 1 to %d characters", MaxCredentialNameLength)
+)
+
+// WebAuthn runs registration and login ceremonies for one relying party
+type WebAuthn struct {
+	Store      db.Store
+	Challenges session.ChallengeStore
+	// RPID is the domain credentials are scoped to, e.g. todo.example.com
+	RPID   string
+	RPName string
+	// Origins the browser may report in client data, e.g. https://todo.example.com
+	Origins []string
+}
+
+// ceremony is what is kept in the challenge store between begin and finish
+type ceremony struct {
+	Challenge []byte `json:"challenge"`
+	Type      string `json:"type"`
+	// zero for passkey logins, where the credential tells who the user is
+	UserID int64 `json:"userId"`
+}
+
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are the publicKey options of navigator.credentials.create, binary values are base64url
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingParty           `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the publicKey options of navigator.credentials.get, binary values are base64url
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+type CreationCeremony struct {
+	Ceremony  string          `json:"ceremony"`
+	PublicKey CreationOptions `json:"publicKey"`
+}
+
+type RequestCeremony struct {
+	Ceremony  string         `json:"ceremony"`
+	PublicKey RequestOptions `json:"publicKey"`
+}
+
+// RegistrationResponse is the PublicKeyCredential from navigator.credentials.create, binary values are base64url
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AttestationObject string `json:"attestationObject"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the PublicKeyCredential from navigator.credentials.get, binary values are base64url
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// UserHandle is the user id authenticators store with a passkey, it is the big-endian user id
+func UserHandle(userId int64) []byte {
+	handle := make([]byte, 8)
+	binary.BigEndian.PutUint64(handle, uint64(userId))
+	return handle
+}
+
+// startCeremony fills in a new challenge and keeps the ceremony until CeremonyTimeout
+func (w *WebAuthn) startCeremony(ctx context.Context, c *ceremony) (string, error) {
+	random := make([]byte, 48)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	c.Challenge = random[:32]
+	id := encodeBase64(random[32:])
+	value, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	if err := w.Challenges.SetChallenge(ctx, "webauthn:"+id, value, CeremonyTimeout); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// takeCeremony returns a started ceremony once, so each challenge can be answered only once
+func (w *WebAuthn) takeCeremony(ctx context.Context, id string, ceremonyType string, userId int64) (*ceremony, error) {
+	value, err := w.Challenges.TakeChallenge(ctx, "webauthn:"+id)
+	if err == session.ErrChallengeNotFound {
+		return nil, ErrInvalidCeremony
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := &ceremony{}
+	if err := json.Unmarshal(value, c); err != nil {
+		return nil, err
+	}
+	if c.Type != ceremonyType || c.UserID != userId {
+		return nil, ErrInvalidCeremony
+	}
+	return c, nil
+}
+
+func descriptors(credentials []db.WebauthnCredential) []CredentialDescriptor {
+	list := make([]CredentialDescriptor, len(credentials))
+	for i, credential := range credentials {
+		list[i] = CredentialDescriptor{Type: "public-key", ID: encodeBase64(credential.CredentialID)}
+	}
+	return list
+}
+
+// BeginRegistration starts adding a credential to a user, name and displayName are shown by the authenticator
+func (w *WebAuthn) BeginRegistration(ctx context.Context, userId int64, name string, displayName string) (*CreationCeremony, error) {
+	existing, err := w.Store.ListWebauthnCredentials(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	c := &ceremony{Type: typeCreate, UserID: userId}
+	id, err := w.startCeremony(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	params := make([]CredentialParameter, len(SupportedAlgorithms))
+	for i, alg := range SupportedAlgorithms {
+		params[i] = CredentialParameter{Type: "public-key", Alg: alg}
+	}
+	return &CreationCeremony{
+		Ceremony: id,
+		PublicKey: CreationOptions{
+			Challenge:          encodeBase64(c.Challenge),
+			RP:                 RelyingParty{ID: w.RPID, Name: w.RPName},
+			User:               UserEntity{ID: encodeBase64(UserHandle(userId)), Name: name, DisplayName: displayName},
+			PubKeyCredParams:   params,
+			Timeout:            CeremonyTimeout.Milliseconds(),
+			ExcludeCredentials: descriptors(existing),
+			//a discoverable credential can also log in without a password
+			AuthenticatorSelection: AuthenticatorSelection{ResidentKey: "preferred", UserVerification: "preferred"},
+			Attestation:            "none",
+		},
+	}, nil
+}
+
+// FinishRegistration checks the authenticator response and stores the new credential.
+// No attestation is requested, so the attestation statement is not checked
+func (w *WebAuthn) FinishRegistration(ctx context.Context, userId int64, ceremonyId string, name string, rsp *RegistrationResponse) (*db.WebauthnCredential, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxCredentialNameLength {
+		return nil, ErrInvalidCredentialName
+	}
+	c, err := w.takeCeremony(ctx, ceremonyId, typeCreate, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, err := decodeBase64(rsp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	if err := checkClientData(clientDataJSON, typeCreate, c.Challenge, w.Origins); err != nil {
+		return nil, err
+	}
+	rawAttestation, err := decodeBase64(rsp.Response.AttestationObject)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	value, _, err := decodeCBOR(rawAttestation)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	attestation, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := authData.check(w.RPID, false); err != nil {
+		return nil, err
+	}
+	credentialId, err := decodeBase64(rsp.ID)
+	if err != nil || authData.publicKey == nil || !bytes.Equal(credentialId, authData.credentialID) {
+		return nil, ErrInvalidResponse
+	}
+
+	credential, err := w.Store.CreateWebauthnCredential(ctx, db.CreateWebauthnCredentialParams{
+		UserID:       userId,
+		CredentialID: authData.credentialID,
+		PublicKey:    authData.rawPublicKey,
+		SignCount:    int64(authData.signCount),
+		Name:         name,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, ErrCredentialExists
+			}
+		}
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// BeginLogin starts a login with a credential. For a passkey login userId is zero and
+// the authenticator picks a discoverable credential, as a second factor userId is the
+// user who already gave their password and only their credentials are allowed
+func (w *WebAuthn) BeginLogin(ctx context.Context, userId int64) (*RequestCeremony, error) {
+	options := RequestOptions{
+		Timeout:          CeremonyTimeout.Milliseconds(),
+		RPID:             w.RPID,
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: "required",
+	}
+	if userId != 0 {
+		credentials, err := w.Store.ListWebauthnCredentials(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		if len(credentials) == 0 {
+			return nil, ErrCredentialNotFound
+		}
+		options.AllowCredentials = descriptors(credentials)
+		options.UserVerification = "preferred"
+	}
+	c := &ceremony{Type: typeGet, UserID: userId}
+	id, err := w.startCeremony(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	options.Challenge = encodeBase64(c.Challenge)
+	return &RequestCeremony{Ceremony: id, PublicKey: options}, nil
+}
+
+// FinishLogin checks the assertion for a ceremony from BeginLogin with the same userId
+// and returns the user the credential belongs to
+func (w *WebAuthn) FinishLogin(ctx context.Context, userId int64, ceremonyId string, rsp *AssertionResponse) (*db.User, error) {
+	c, err := w.takeCeremony(ctx, ceremonyId, typeGet, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialId, err := decodeBase64(rsp.ID)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	credential, err := w.Store.GetWebauthnCredentialByCredentialID(ctx, credentialId)
+	if err == sql.ErrNoRows {
+		return nil, ErrUnknownCredential
+	}
+	if err != nil {
+		return nil, err
+	}
+	passkey := userId == 0
+	if passkey {
+		//the authenticator must agree on whose passkey it is
+		userHandle, err := decodeBase64(rsp.Response.UserHandle)
+		if err != nil || !bytes.Equal(userHandle, UserHandle(credential.UserID)) {
+			return nil, ErrUnknownCredential
+		}
+	} else if credential.UserID != userId {
+		return nil, ErrUnknownCredential
+	}
+
+	clientDataJSON, err := decodeBase64(rsp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	if err := checkClientData(clientDataJSON, typeGet, c.Challenge, w.Origins); err != nil {
+		return nil, err
+	}
+	rawAuthData, err := decodeBase64(rsp.Response.AuthenticatorData)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	//without a password the credential is the only factor, so the user must be verified
+	if err := authData.check(w.RPID, passkey); err != nil {
+		return nil, err
+	}
+	key, _, err := parsePublicKey(credential.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := decodeBase64(rsp.Response.Signature)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	message := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+	if err := key.verify(message, signature); err != nil {
+		return nil, err
+	}
+
+	//the update only happens if the counter grew, or both are zero for authenticators without one
+	updated, err := w.Store.UpdateWebauthnSignCount(ctx, db.UpdateWebauthnSignCountParams{
+		SignCount: int64(authData.signCount),
+		ID:        credential.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if updated == 0 {
+		return nil, ErrSignCountMismatch
+	}
+
+	user, err := w.Store.GetUser(ctx, db.GetUserParams{ID: credential.UserID})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (w *WebAuthn) ListCredentials(ctx context.Context, userId int64) ([]db.WebauthnCredential, error) {
+	return w.Store.ListWebauthnCredentials(ctx, userId)
+}
+
+func (w *WebAuthn) DeleteCredential(ctx context.Context, userId int64, id int64) error {
+	deleted, err := w.Store.DeleteWebauthnCredential(ctx, db.DeleteWebauthnCredentialParams{
+		ID:     id,
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrCredentialNotFound
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package webauthn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/session"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testRPID   = "todo.example.com"
+	testOrigin = "https://todo.example.com"
+)
+
+type memoryChallenges map[string][]byte
+
+func (m memoryChallenges) SetChallenge(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m[key] = value
+	return nil
+}
+
+func (m memoryChallenges) TakeChallenge(ctx context.Context, key string) ([]byte, error) {
+	value, ok := m[key]
+	if !ok {
+		return nil, session.ErrChallengeNotFound
+	}
+	delete(m, key)
+	return value, nil
+}
+
+// softAuthenticator plays a security key or platform authenticator with one credential
+type softAuthenticator struct {
+	rpID         string
+	origin       string
+	credentialID []byte
+	key          crypto.Signer
+	userHandle   []byte
+	signCount    uint32
+	// flags other than user present
+	verified bool
+}
+
+func newSoftAuthenticator(t *testing.T, ed bool) *softAuthenticator {
+	a := &softAuthenticator{rpID: testRPID, origin: testOrigin, credentialID: make([]byte, 16), verified: true}
+	_, err := rand.Read(a.credentialID)
+	require.NoError(t, err)
+	if ed {
+		_, a.key, err = ed25519.GenerateKey(rand.Reader)
+	} else {
+		a.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	require.NoError(t, err)
+	return a
+}
+
+func (a *softAuthenticator) coseKey() []byte {
+	switch key := a.key.Public().(type) {
+	case *ecdsa.PublicKey:
+		x, y := make([]byte, 32), make([]byte, 32)
+		key.X.FillBytes(x)
+		key.Y.FillBytes(y)
+		return encodeCBOR(cborMap{{coseKty, coseKtyEC2}, {coseAlg, AlgES256}, {coseCrv, coseP256}, {coseX, x}, {coseY, y}})
+	case ed25519.PublicKey:
+		return encodeCBOR(cborMap{{coseKty, coseKtyOKP}, {coseAlg, AlgEdDSA}, {coseCrv, coseEd}, {coseX, []byte(key)}})
+	}
+	panic("unknown key")
+}
+
+func (a *softAuthenticator) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	flags := byte(flagUserPresent)
+	if a.verified {
+		flags |= flagUserVerified
+	}
+	if attested {
+		flags |= flagAttested
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func (a *softAuthenticator) clientData(ceremonyType string, challenge string) []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":        ceremonyType,
+		"challenge":   challenge,
+		"origin":      a.origin,
+		"crossOrigin": false,
+	})
+	return data
+}
+
+func (a *softAuthenticator) create(options CreationOptions) *RegistrationResponse {
+	a.userHandle, _ = decodeBase64(options.User.ID)
+	rsp := &RegistrationResponse{ID: encodeBase64(a.credentialID), Type: "public-key"}
+	rsp.Response.ClientDataJSON = encodeBase64(a.clientData(typeCreate, options.Challenge))
+	rsp.Response.AttestationObject = encodeBase64(encodeCBOR(cborMap{
+		{"fmt", "none"},
+		{"attStmt", cborMap{}},
+		{"authData", a.authData(true)},
+	}))
+	return rsp
+}
+
+func (a *softAuthenticator) get(t *testing.T, options RequestOptions) *AssertionResponse {
+	a.signCount++
+	authData := a.authData(false)
+	clientData := a.clientData(typeGet, options.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	message := append(append([]byte{}, authData...), clientDataHash[:]...)
+	var sig []byte
+	var err error
+	if _, ok := a.key.(ed25519.PrivateKey); ok {
+		sig, err = a.key.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(message)
+		sig, err = a.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	require.NoError(t, err)
+
+	rsp := &AssertionResponse{ID: encodeBase64(a.credentialID), Type: "public-key"}
+	rsp.Response.ClientDataJSON = encodeBase64(clientData)
+	rsp.Response.AuthenticatorData = encodeBase64(authData)
+	rsp.Response.Signature = encodeBase64(sig)
+	rsp.Response.UserHandle = encodeBase64(a.userHandle)
+	return rsp
+}
+
+// fakeCredentials backs the credential queries of the mock store with a slice
+func fakeCredentials(store *mockdb.MockStore) *[]db.WebauthnCredential {
+	credentials := &[]db.WebauthnCredential{}
+	find := func(credentialId []byte) int {
+		for i, c := range *credentials {
+			if string(c.CredentialID) == string(credentialId) {
+				return i
+			}
+		}
+		return -1
+	}
+	store.EXPECT().ListWebauthnCredentials(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userId int64) ([]db.WebauthnCredential, error) {
+			list := []db.WebauthnCredential{}
+			for _, c := range *credentials {
+				if c.UserID == userId {
+					list = append(list, c)
+				}
+			}
+			return list, nil
+		})
+	store.EXPECT().CreateWebauthnCredential(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.CreateWebauthnCredentialParams) (db.WebauthnCredential, error) {
+			c := db.WebauthnCredential{
+				ID:           int64(len(*credentials) + 1),
+				UserID:       arg.UserID,
+				CredentialID: arg.CredentialID,
+				PublicKey:    arg.PublicKey,
+				SignCount:    arg.SignCount,
+				Name:         arg.Name,
+				CreatedAt:    time.Now(),
+			}
+			*credentials = append(*credentials, c)
+			return c, nil
+		})
+	store.EXPECT().GetWebauthnCredentialByCredentialID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, credentialId []byte) (db.WebauthnCredential, error) {
+			if i := find(credentialId); i >= 0 {
+				return (*credentials)[i], nil
+			}
+			return db.WebauthnCredential{}, sql.ErrNoRows
+		})
+	store.EXPECT().UpdateWebauthnSignCount(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.UpdateWebauthnSignCountParams) (int64, error) {
+			c := &(*credentials)[arg.ID-1]
+			if c.SignCount < arg.SignCount || (c.SignCount == 0 && arg.SignCount == 0) {
+				c.SignCount = arg.SignCount
+				return 1, nil
+			}
+			return 0, nil
+		})
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.GetUserParams) (db.User, error) {
+			return db.User{ID: arg.ID}, nil
+		})
+	return credentials
+}
+
+func newTestWebAuthn(t *testing.T) (*WebAuthn, *[]db.WebauthnCredential) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	w := &WebAuthn{
+		Store:      store,
+		Challenges: memoryChallenges{},
+		RPID:       testRPID,
+		RPName:     "Todo",
+		Origins:    []string{testOrigin},
+	}
+	return w, fakeCredentials(store)
+}
+
+func register(t *testing.T, w *WebAuthn, userId int64, authenticator *softAuthenticator) *db.WebauthnCredential {
+	ceremony, err := w.BeginRegistration(context.Background(), userId, "a@email.com", "a")
+	require.NoError(t, err)
+	credential, err := w.FinishRegistration(context.Background(), userId, ceremony.Ceremony, "key", authenticator.create(ceremony.PublicKey))
+	require.NoError(t, err)
+	return credential
+}
+
+func TestPasskeyLogin(t *testing.T) {
+	for _, ed := range []bool{false, true} {
+		w, _ := newTestWebAuthn(t)
+		authenticator := newSoftAuthenticator(t, ed)
+		credential := register(t, w, 7, authenticator)
+		require.Equal(t, int64(7), credential.UserID)
+
+		ceremony, err := w.BeginLogin(context.Background(), 0)
+		require.NoError(t, err)
+		require.Equal(t, "required", ceremony.PublicKey.UserVerification)
+		user, err := w.FinishLogin(context.Background(), 0, ceremony.Ceremony, authenticator.get(t, ceremony.PublicKey))
+		require.NoError(t, err)
+		require.Equal(t, int64(7), user.ID)
+
+		//a ceremony is answered once
+		_, err = w.FinishLogin(context.Background(), 0, ceremony.Ceremony, authenticator.get(t, ceremony.PublicKey))
+		require.ErrorIs(t, err, ErrInvalidCeremony)
+	}
+}
+
+func TestSecondFactorLogin(t *testing.T) {
+	w, _ := newTestWebAuthn(t)
+	first := newSoftAuthenticator(t, false)
+	second := newSoftAuthenticator(t, true)
+	register(t, w, 7, first)
+	register(t, w, 7, second)
+	other := newSoftAuthenticator(t, false)
+	register(t, w, 8, other)
+
+	ceremony, err := w.BeginLogin(context.Background(), 7)
+	require.NoError(t, err)
+	require.Len(t, ceremony.PublicKey.AllowCredentials, 2)
+
+	//a second factor doesn't need user verification
+	second.verified = false
+	user, err := w.FinishLogin(context.Background(), 7, ceremony.Ceremony, second.get(t, ceremony.PublicKey))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), user.ID)
+
+	ceremony, err = w.BeginLogin(context.Background(), 7)
+	require.NoError(t, err)
+	_, err = w.FinishLogin(context.Background(), 7, ceremony.Ceremony, other.get(t, ceremony.PublicKey))
+	require.ErrorIs(t, err, ErrUnknownCredential)
+
+	//a second factor ceremony can't finish as a passkey login
+	ceremony, err = w.BeginLogin(context.Background(), 7)
+	require.NoError(t, err)
+	_, err = w.FinishLogin(context.Background(), 0, ceremony.Ceremony, first.get(t, ceremony.PublicKey))
+	require.ErrorIs(t, err, ErrInvalidCeremony)
+
+	_, err = w.BeginLogin(context.Background(), 9)
+	require.ErrorIs(t, err, ErrCredentialNotFound)
+}
+
+func TestLoginChecks(t *testing.T) {
+	w, credentials := newTestWebAuthn(t)
+	authenticator := newSoftAuthenticator(t, false)
+	register(t, w, 7, authenticator)
+
+	login := func(rsp func(options RequestOptions) *AssertionResponse) error {
+		ceremony, err := w.BeginLogin(context.Background(), 0)
+		require.NoError(t, err)
+		_, err = w.FinishLogin(context.Background(), 0, ceremony.Ceremony, rsp(ceremony.PublicKey))
+		return err
+	}
+	get := func(options RequestOptions) *AssertionResponse {
+		return authenticator.get(t, options)
+	}
+	require.NoError(t, login(get))
+
+	//a clone reuses an old counter
+	authenticator.signCount = 0
+	require.ErrorIs(t, login(get), ErrSignCountMismatch)
+	authenticator.signCount = uint32((*credentials)[0].SignCount)
+	require.NoError(t, login(get))
+
+	authenticator.verified = false
+	require.ErrorIs(t, login(get), ErrUserNotVerified)
+	authenticator.verified = true
+
+	authenticator.origin = "https://evil.example.com"
+	require.ErrorIs(t, login(get), ErrOriginNotAllowed)
+	authenticator.origin = testOrigin
+
+	authenticator.rpID = "evil.example.com"
+	require.ErrorIs(t, login(get), ErrRPIDMismatch)
+	authenticator.rpID = testRPID
+
+	require.ErrorIs(t, login(func(options RequestOptions) *AssertionResponse {
+		options.Challenge = encodeBase64([]byte("another challenge"))
+		return get(options)
+	}), ErrChallengeMismatch)
+
+	require.ErrorIs(t, login(func(options RequestOptions) *AssertionResponse {
+		rsp := get(options)
+		rsp.Response.UserHandle = encodeBase64(UserHandle(8))
+		return rsp
+	}), ErrUnknownCredential)
+
+	require.ErrorIs(t, login(func(options RequestOptions) *AssertionResponse {
+		rsp := get(options)
+		sig, _ := decodeBase64(rsp.Response.Signature)
+		sig[len(sig)-1] ^= 1
+		rsp.Response.Signature = encodeBase64(sig)
+		return rsp
+	}), ErrBadSignature)
+
+	require.NoError(t, login(get))
+}
+
+func TestRegistrationChecks(t *testing.T) {
+	w, _ := newTestWebAuthn(t)
+	authenticator := newSoftAuthenticator(t, false)
+
+	ceremony, err := w.BeginRegistration(context.Background(), 7, "a@email.com", "a")
+	require.NoError(t, err)
+	require.Equal(t, encodeBase64(UserHandle(7)), ceremony.PublicKey.User.ID)
+	_, err = w.FinishRegistration(context.Background(), 7, ceremony.Ceremony, " ", authenticator.create(ceremony.PublicKey))
+	require.ErrorIs(t, err, ErrInvalidCredentialName)
+
+	//another user can't finish the ceremony
+	_, err = w.FinishRegistration(context.Background(), 8, ceremony.Ceremony, "key", authenticator.create(ceremony.PublicKey))
+	require.ErrorIs(t, err, ErrInvalidCeremony)
+
+	ceremony, err = w.BeginRegistration(context.Background(), 7, "a@email.com", "a")
+	require.NoError(t, err)
+	rsp := authenticator.create(ceremony.PublicKey)
+	rsp.ID = encodeBase64([]byte("another id"))
+	_, err = w.FinishRegistration(context.Background(), 7, ceremony.Ceremony, "key", rsp)
+	require.ErrorIs(t, err, ErrInvalidResponse)
+
+	register(t, w, 7, authenticator)
+	ceremony, err = w.BeginRegistration(context.Background(), 7, "a@email.com", "a")
+	require.NoError(t, err)
+	require.Len(t, ceremony.PublicKey.ExcludeCredentials, 1)
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// deliveries follow at most this many redirects
+const maxRedirects = 5
+
+// NewClient creates the http client deliveries are sent with. Unless allowPrivateNetworks is
+// set, which is meant for tests and dev mode only, it refuses to connect to loopback, private,
+// link-local and multicast addresses. The check runs on the address that is dialed, after the
+// host name is resolved, so a public name resolving to a private address is refused too, and
+// again for every redirect
+func NewClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: DefaultTimeout}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil || !isPublic(ip) {
+				return ErrPrivateAddress
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	//a proxy would be dialed instead of the webhook host
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   DefaultTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrInvalidURL
+			}
+			if !allowPrivateNetworks && isPrivateHost(req.URL.Hostname()) {
+				return ErrPrivateAddress
+			}
+			return nil
+		},
+	}
+}
+
+// isPublic reports if ip is a unicast address reachable over the internet
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		ip != netip.AddrFrom4([4]byte{255, 255, 255, 255})
+}
+
+// isPrivateHost reports if host is localhost or a non-public ip, names that resolve to
+// private addresses are only caught when they are dialed
+func isPrivateHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip, err := netip.ParseAddr(host)
+	return err == nil && !isPublic(ip)
+}
+
+// checkURL validates the url of a webhook
+func checkURL(rawURL string, allowPrivateNetworks bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if !allowPrivateNetworks && isPrivateHost(u.Hostname()) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
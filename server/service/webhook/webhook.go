@@ -0,0 +1,244 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/event"
+)
+
+// Events a webhook can subscribe to
+var Events = []string{event.TypeTaskCreated, event.TypeTaskUpdated, event.TypeTaskDeleted}
+
+// secrets chosen by users must be at least this long
+const minSecretLength = 16
+
+var (
+	ErrOwnerNotMatched = fmt.Errorf("owner id does not match user id")
+	ErrInvalidURL      = fmt.Errorf("url must be an absolute http or https url")
+	ErrPrivateAddress  = fmt.Errorf("url must not point to a private or local address")
+	ErrInvalidEvent    = fmt.Errorf("events must be %s, %s or %s", event.TypeTaskCreated, event.TypeTaskUpdated, event.TypeTaskDeleted)
+	ErrInvalidSecret   = fmt.Errorf("secret must be at least %d characters", minSecretLength)
+)
+
+type Webhook struct {
+	Store db.Store
+	// AllowPrivateNetworks accepts urls of localhost and private addresses, for tests and dev mode only
+	AllowPrivateNetworks bool
+}
+
+// WebhookParams are the settings of a webhook
+type WebhookParams struct {
+	// ProjectID limits the webhook to the tasks of a project
+	ProjectID *int64
+	URL       string
+	// Events are the event types sent, all of them when empty
+	Events []string
+	// Secret signs the deliveries, a random one is made when empty.
+	// It can't be changed later
+	Secret string
+	Active bool
+}
+
+// Payload is the body of a delivery, data is the task like in the REST API
+type Payload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      any       `json:"data"`
+}
+
+func (w *Webhook) validate(ctx context.Context, ownerId int64, arg *WebhookParams) error {
+	if err := checkURL(arg.URL, w.AllowPrivateNetworks); err != nil {
+		return err
+	}
+	for _, e := range arg.Events {
+		if !isEvent(e) {
+			return ErrInvalidEvent
+		}
+	}
+	if arg.ProjectID != nil {
+		project, err := w.Store.GetProject(ctx, *arg.ProjectID)
+		if err != nil {
+			return err
+		}
+		if project.OwnerID != ownerId {
+			return ErrOwnerNotMatched
+		}
+	}
+	return nil
+}
+
+func isEvent(eventType string) bool {
+	for _, e := range Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func nullProjectID(projectId *int64) sql.NullInt64 {
+	if projectId == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *projectId, Valid: true}
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Create webhook, the secret is only shown to the user in the result
+func (w *Webhook) CreateWebhook(ctx context.Context, ownerId int64, arg WebhookParams) (*db.Webhook, error) {
+	if err := w.validate(ctx, ownerId, &arg); err != nil {
+		return nil, err
+	}
+	secret := arg.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minSecretLength {
+		return nil, ErrInvalidSecret
+	}
+	events := arg.Events
+	if events == nil {
+		events = []string{}
+	}
+	webhook, err := w.Store.CreateWebhook(ctx, db.CreateWebhookParams{
+		OwnerID:   ownerId,
+		ProjectID: nullProjectID(arg.ProjectID),
+		Url:       arg.URL,
+		Events:    events,
+		Secret:    secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Get webhook by Id
+func (w *Webhook) GetWebhookById(ctx context.Context, id int64, ownerId int64) (*db.Webhook, error) {
+	webhook, err := w.Store.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.OwnerID != ownerId {
+		return nil, ErrOwnerNotMatched
+	}
+	return &webhook, nil
+}
+
+// Get webhook list
+func (w *Webhook) GetWebhookList(ctx context.Context, ownerId int64) ([]db.Webhook, error) {
+	return w.Store.GetWebhookList(ctx, ownerId)
+}
+
+// Update webhook, the secret stays the same
+func (w *Webhook) UpdateWebhook(ctx context.Context, id int64, ownerId int64, arg WebhookParams) (*db.Webhook, error) {
+	if err := w.validate(ctx, ownerId, &arg); err != nil {
+		return nil, err
+	}
+	events := arg.Events
+	if events == nil {
+		events = []string{}
+	}
+	webhook, err := w.Store.UpdateWebhook(ctx, db.UpdateWebhookParams{
+		ID:        id,
+		OwnerID:   ownerId,
+		ProjectID: nullProjectID(arg.ProjectID),
+		Url:       arg.URL,
+		Events:    events,
+		Active:    arg.Active,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Delete webhook with its deliveries
+func (w *Webhook) DeleteWebhook(ctx context.Context, id int64, ownerId int64) error {
+	return w.Store.DeleteWebhook(ctx, db.DeleteWebhookParams{
+		ID:      id,
+		OwnerID: ownerId,
+	})
+}
+
+// Get the delivery log of a webhook, latest first
+func (w *Webhook) GetDeliveries(ctx context.Context, webhookId int64, ownerId int64, limit int32, pageId int32) ([]db.WebhookDelivery, error) {
+	if _, err := w.GetWebhookById(ctx, webhookId, ownerId); err != nil {
+		return nil, err
+	}
+	return w.Store.GetWebhookDeliveryList(ctx, db.GetWebhookDeliveryListParams{
+		WebhookID: webhookId,
+		Limit:     limit,
+		Offset:    (pageId - 1) * limit,
+	})
+}
+
+// Redeliver queues the payload of a past delivery again as a new delivery
+func (w *Webhook) Redeliver(ctx context.Context, webhookId int64, deliveryId int64, ownerId int64) (*db.WebhookDelivery, error) {
+	if _, err := w.GetWebhookById(ctx, webhookId, ownerId); err != nil {
+		return nil, err
+	}
+	delivery, err := w.Store.GetWebhookDelivery(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhookId {
+		return nil, sql.ErrNoRows
+	}
+	redelivery, err := w.Store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		WebhookID:     webhookId,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		NextAttemptAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &redelivery, nil
+}
+
+// Enqueue queues a delivery of an event for every webhook of the owner that listens to it,
+// projectId is the project of the task. The worker sends them.
+func (w *Webhook) Enqueue(ctx context.Context, ownerId int64, projectId *int64, eventType string, data any) error {
+	webhooks, err := w.Store.GetWebhooksForEvent(ctx, db.GetWebhooksForEventParams{
+		OwnerID:   ownerId,
+		ProjectID: nullProjectID(projectId),
+		Event:     eventType,
+	})
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+	now := time.Now()
+	payload, err := json.Marshal(&Payload{Event: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		_, err := w.Store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			WebhookID:     webhook.ID,
+			Event:         eventType,
+			Payload:       payload,
+			NextAttemptAt: sql.NullTime{Time: now, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
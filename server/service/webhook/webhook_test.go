@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/service/event"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	w := Webhook{Store: store}
+	ctx := context.Background()
+
+	_, err := w.CreateWebhook(ctx, 1, WebhookParams{URL: "ftp://example.com/hook"})
+	require.ErrorIs(t, err, ErrInvalidURL)
+	_, err = w.CreateWebhook(ctx, 1, WebhookParams{URL: "/hook"})
+	require.ErrorIs(t, err, ErrInvalidURL)
+	for _, url := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://10.0.0.5/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://[::ffff:192.168.0.1]/hook"} {
+		_, err = w.CreateWebhook(ctx, 1, WebhookParams{URL: url})
+		require.ErrorIs(t, err, ErrPrivateAddress, url)
+	}
+	_, err = w.CreateWebhook(ctx, 1, WebhookParams{URL: "https://example.com/hook", Events: []string{"task.moved"}})
+	require.ErrorIs(t, err, ErrInvalidEvent)
+	_, err = w.CreateWebhook(ctx, 1, WebhookParams{URL: "https://example.com/hook", Secret: "short"})
+	require.ErrorIs(t, err, ErrInvalidSecret)
+
+	projectId := int64(3)
+	store.EXPECT().GetProject(gomock.Any(), projectId).Return(db.Project{ID: projectId, OwnerID: 2}, nil)
+	_, err = w.CreateWebhook(ctx, 1, WebhookParams{URL: "https://example.com/hook", ProjectID: &projectId})
+	require.ErrorIs(t, err, ErrOwnerNotMatched)
+
+	store.EXPECT().
+		CreateWebhook(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, arg db.CreateWebhookParams) (db.Webhook, error) {
+			//a random secret is made and no events means all of them
+			require.Len(t, arg.Secret, 64)
+			require.Equal(t, []string{}, arg.Events)
+			return db.Webhook{ID: 1, OwnerID: arg.OwnerID, Url: arg.Url, Secret: arg.Secret}, nil
+		})
+	hook, err := w.CreateWebhook(ctx, 1, WebhookParams{URL: "https://example.com/hook"})
+	require.NoError(t, err)
+	require.NotEmpty(t, hook.Secret)
+}
+
+func TestEnqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	w := Webhook{Store: store}
+	projectId := int64(3)
+
+	store.EXPECT().
+		GetWebhooksForEvent(gomock.Any(), db.GetWebhooksForEventParams{
+			OwnerID:   1,
+			ProjectID: sql.NullInt64{Int64: projectId, Valid: true},
+			Event:     event.TypeTaskCreated,
+		}).
+		Return([]db.Webhook{{ID: 5}, {ID: 6}}, nil)
+	var queued []int64
+	store.EXPECT().
+		CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, arg db.CreateWebhookDeliveryParams) (db.WebhookDelivery, error) {
+			require.True(t, arg.NextAttemptAt.Valid)
+			var payload struct {
+				Event string `json:"event"`
+				Data  struct {
+					Body string `json:"body"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			require.Equal(t, event.TypeTaskCreated, payload.Event)
+			require.Equal(t, "ship it", payload.Data.Body)
+			queued = append(queued, arg.WebhookID)
+			return db.WebhookDelivery{WebhookID: arg.WebhookID}, nil
+		})
+
+	err := w.Enqueue(context.Background(), 1, &projectId, event.TypeTaskCreated, map[string]string{"body": "ship it"})
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 6}, queued)
+}
+
+func TestRedeliverOtherWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	w := Webhook{Store: store}
+
+	store.EXPECT().GetWebhook(gomock.Any(), int64(5)).Return(db.Webhook{ID: 5, OwnerID: 1}, nil)
+	store.EXPECT().GetWebhookDelivery(gomock.Any(), int64(9)).Return(db.WebhookDelivery{ID: 9, WebhookID: 6}, nil)
+	store.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := w.Redeliver(context.Background(), 5, 9, 1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
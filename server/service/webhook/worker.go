@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const (
+	DefaultInterval    = 10 * time.Second
+	DefaultMaxAttempts = 8
+	DefaultTimeout     = 10 * time.Second
+	// failed deliveries are retried after 30s, 1m, 2m, 4m... up to maxRetryDelay
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = 6 * time.Hour
+)
+
+// Worker sends queued webhook deliveries.
+// Every API server runs one, deliveries are claimed with row locks
+// so each of them is sent by a single server.
+type Worker struct {
+	Store  db.Store
+	Client *http.Client
+	// how often due deliveries are looked for
+	Interval time.Duration
+	// a delivery is given up after this many failed attempts
+	MaxAttempts int32
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewWorker(store db.Store, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Worker{
+		Store:       store,
+		Client:      NewClient(false),
+		Interval:    interval,
+		MaxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Run sends due deliveries every Interval until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Println("cannot deliver webhooks:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends every delivery that is due and returns how many succeeded
+func (w *Worker) DeliverDue(ctx context.Context) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		result, err := w.Store.DeliverWebhookTx(ctx, db.DeliverWebhookTxParams{
+			Now: w.now(),
+			Deliver: func(delivery db.ClaimDueWebhookDeliveryRow) (int, error) {
+				return w.send(ctx, delivery)
+			},
+			RetryAt: w.retryAt,
+		})
+		if err != nil {
+			return sent, err
+		}
+		if !result.Found {
+			return sent, nil
+		}
+		if result.DeliveryErr != nil {
+			log.Printf("cannot deliver webhook delivery %d: %v", result.Delivery.ID, result.DeliveryErr)
+			continue
+		}
+		sent++
+	}
+	return sent, ctx.Err()
+}
+
+// send posts a delivery, receivers must answer with a 2xx status
+func (w *Worker) send(ctx context.Context, delivery db.ClaimDueWebhookDeliveryRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := w.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	rsp, err := w.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(rsp.Body, 64<<10))
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return rsp.StatusCode, fmt.Errorf("receiver answered %s", rsp.Status)
+	}
+	return rsp.StatusCode, nil
+}
+
+// retryAt doubles the delay after every failed attempt and gives up after MaxAttempts
+func (w *Worker) retryAt(attempts int32) (time.Time, bool) {
+	if attempts >= w.MaxAttempts {
+		return time.Time{}, false
+	}
+	delay := firstRetryDelay
+	for i := int32(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return w.now().Add(delay), true
+}
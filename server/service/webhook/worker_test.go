@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a local webhook receiver that answers with status
+func newReceiver(t *testing.T, status int) (*httptest.Server, *[]received) {
+	deliveries := &[]received{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*deliveries = append(*deliveries, received{header: r.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, deliveries
+}
+
+// deliverOnce returns a DeliverWebhookTx implementation that hands out the deliveries in order
+func deliverOnce(deliveries ...db.ClaimDueWebhookDeliveryRow) func(context.Context, db.DeliverWebhookTxParams) (db.DeliverWebhookTxResult, error) {
+	return func(ctx context.Context, arg db.DeliverWebhookTxParams) (db.DeliverWebhookTxResult, error) {
+		if len(deliveries) == 0 {
+			return db.DeliverWebhookTxResult{}, nil
+		}
+		delivery := deliveries[0]
+		deliveries = deliveries[1:]
+		result := db.DeliverWebhookTxResult{Found: true, Delivery: delivery}
+		result.Status, result.DeliveryErr = arg.Deliver(delivery)
+		return result, nil
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	receiver, deliveries := newReceiver(t, http.StatusNoContent)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	worker := NewWorker(store, time.Minute)
+	worker.Client = NewClient(true)
+
+	payload := []byte(`{"event":"task.created","data":{"id":1,"body":"ship it"}}`)
+	store.EXPECT().
+		DeliverWebhookTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(deliverOnce(db.ClaimDueWebhookDeliveryRow{
+			ID:      7,
+			Event:   "task.created",
+			Payload: payload,
+			Url:     receiver.URL,
+			Secret:  "0123456789abcdef",
+		}))
+
+	sent, err := worker.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	require.Len(t, *deliveries, 1)
+	delivery := (*deliveries)[0]
+	require.Equal(t, payload, delivery.body)
+	require.Equal(t, "task.created", delivery.header.Get(HeaderEvent))
+	require.Equal(t, "7", delivery.header.Get(HeaderDelivery))
+	require.NoError(t, Verify("0123456789abcdef", delivery.header, delivery.body, time.Minute))
+	require.ErrorIs(t, Verify("another secret!!", delivery.header, delivery.body, 0), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("0123456789abcdef", delivery.header, []byte("{}"), 0), ErrInvalidSignature)
+}
+
+func TestDeliverDueFailure(t *testing.T) {
+	receiver, deliveries := newReceiver(t, http.StatusBadGateway)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	worker := NewWorker(store, time.Minute)
+	worker.Client = NewClient(true)
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	deliver := deliverOnce(db.ClaimDueWebhookDeliveryRow{ID: 1, Payload: []byte("{}"), Url: receiver.URL})
+	var status int
+	store.EXPECT().
+		DeliverWebhookTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, arg db.DeliverWebhookTxParams) (db.DeliverWebhookTxResult, error) {
+			result, err := deliver(ctx, arg)
+			if result.Found {
+				status = result.Status
+				require.Error(t, result.DeliveryErr)
+			}
+			return result, err
+		})
+
+	sent, err := worker.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Len(t, *deliveries, 1)
+	require.Equal(t, http.StatusBadGateway, status)
+}
+
+func TestDeliverDuePrivateAddress(t *testing.T) {
+	receiver, deliveries := newReceiver(t, http.StatusNoContent)
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	worker := NewWorker(store, time.Minute)
+
+	deliver := deliverOnce(db.ClaimDueWebhookDeliveryRow{ID: 1, Payload: []byte("{}"), Url: receiver.URL})
+	var deliveryErr error
+	store.EXPECT().
+		DeliverWebhookTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, arg db.DeliverWebhookTxParams) (db.DeliverWebhookTxResult, error) {
+			result, err := deliver(ctx, arg)
+			if result.Found {
+				deliveryErr = result.DeliveryErr
+			}
+			return result, err
+		})
+
+	//the receiver listens on 127.0.0.1, it is refused when it is dialed
+	sent, err := worker.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Empty(t, *deliveries)
+	require.ErrorIs(t, deliveryErr, ErrPrivateAddress)
+
+	//and so are redirects to a local address
+	redirect, err := http.NewRequest(http.MethodPost, "http://169.254.169.254/latest/meta-data", nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, worker.Client.CheckRedirect(redirect, []*http.Request{{}}), ErrPrivateAddress)
+}
+
+func TestRetryAt(t *testing.T) {
+	worker := NewWorker(nil, time.Minute)
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	testCases := []struct {
+		attempts int32
+		delay    time.Duration
+	}{
+		{attempts: 1, delay: 30 * time.Second},
+		{attempts: 2, delay: time.Minute},
+		{attempts: 4, delay: 4 * time.Minute},
+		{attempts: 7, delay: 32 * time.Minute},
+	}
+	for _, tc := range testCases {
+		retryAt, retry := worker.retryAt(tc.attempts)
+		require.True(t, retry)
+		require.Equal(t, now.Add(tc.delay), retryAt)
+	}
+
+	worker.MaxAttempts = 30
+	retryAt, retry := worker.retryAt(20)
+	require.True(t, retry)
+	require.Equal(t, now.Add(maxRetryDelay), retryAt)
+
+	//given up after MaxAttempts
+	_, retry = worker.retryAt(30)
+	require.False(t, retry)
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrChallengeNotFound = fmt.Errorf("challenge not found")
+)
+
+// ChallengeStore keeps short-lived challenges of login ceremonies
+type ChallengeStore interface {
+	SetChallenge(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// TakeChallenge returns and deletes a challenge so it can only be answered once
+	TakeChallenge(ctx context.Context, key string) ([]byte, error)
+}
+
+type RedisChallengeStore struct {
+	db redis.Client
+}
+
+func NewChallengeStore(address string) (ChallengeStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: "",
+		DB:       0,
+	})
+	err := client.Ping(context.Background())
+	return &RedisChallengeStore{
+		db: *client,
+	}, err.Err()
+}
+
+func challengeKey(key string) string {
+	return "challenge:" + key
+}
+
+func (s *RedisChallengeStore) SetChallenge(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.db.Set(ctx, challengeKey(key), value, ttl).Err()
+}
+
+func (s *RedisChallengeStore) TakeChallenge(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.db.GetDel(ctx, challengeKey(key)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrChallengeNotFound
+	}
+	return value, err
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+const DefaultCleanupInterval = time.Hour
+
+// CleanupWorker deletes expired sessions, revocations and challenges of the Postgres stores,
+// redis expires its keys by itself
+type CleanupWorker struct {
+	Store db.Store
+	// how often expired rows are deleted
+	Interval time.Duration
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewCleanupWorker(store db.Store, interval time.Duration) *CleanupWorker {
+	if interval <= 0 {
+		interval = DefaultCleanupInterval
+	}
+	return &CleanupWorker{
+		Store:    store,
+		Interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run deletes expired rows every Interval until ctx is cancelled
+func (w *CleanupWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+			log.Println("cannot delete expired sessions:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteExpired deletes every expired row and returns how many were deleted
+func (w *CleanupWorker) DeleteExpired(ctx context.Context) (int64, error) {
+	now := w.now()
+	var deleted int64
+	for _, deleteExpired := range []func(context.Context, time.Time) (int64, error){
+		w.Store.DeleteExpiredSessions,
+		w.Store.DeleteExpiredRevokedTokens,
+		w.Store.DeleteExpiredUserTokenRevocations,
+		w.Store.DeleteExpiredLoginChallenges,
+	} {
+		n, err := deleteExpired(ctx, now)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
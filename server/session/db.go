@@ -12,6 +12,7 @@ import (
 
 var (
 	ErrTokenSessionNotFound = fmt.Errorf("token session not found")
+	ErrTokenSessionRotated  = fmt.Errorf("token session was rotated already")
 )
 
 type Queries struct {
@@ -19,17 +20,25 @@ type Queries struct {
 }
 
 type CreateTokenSessionParams struct {
-	ID           uuid.UUID `json:"id"`
+	ID uuid.UUID `json:"id"`
+	// FamilyID is the first session of a login, sessions made by rotating it share it.
+	// A new login leaves it empty and starts a family with its own id
+	FamilyID     uuid.UUID `json:"familyId"`
 	UserID       int64     `json:"userId"`
 	RefreshToken string    `json:"refreshToken"`
 	UserAgent    string    `json:"userAgent"`
 	ClientIp     string    `json:"clientIp"`
 	IsBlocked    bool      `json:"isBlocked"`
 	ExpiresAt    time.Time `json:"expiresAt"`
+	// AccessTokenID is the access token issued with the refresh token,
+	// it is kept on the UserSession so revoking the login revokes it too
+	AccessTokenID        uuid.UUID `json:"accessTokenId"`
+	AccessTokenExpiresAt time.Time `json:"accessTokenExpiresAt"`
 }
 
 type TokenSession struct {
 	ID           uuid.UUID `json:"id"`
+	FamilyID     uuid.UUID `json:"familyId"`
 	UserID       int64     `json:"userId"`
 	RefreshToken string    `json:"refreshToken"`
 	UserAgent    string    `json:"userAgent"`
@@ -37,13 +46,19 @@ type TokenSession struct {
 	IsBlocked    bool      `json:"isBlocked"`
 	ExpiresAt    time.Time `json:"expiresAt"`
 	CreatedAt    time.Time `json:"createdAt"`
+	// ReplacedBy is the session that rotated this one, a rotated session
+	// is kept until it expires so reusing its refresh token can be detected
+	ReplacedBy uuid.UUID `json:"replacedBy"`
 }
 
-func (q *Queries) CreateTokenSession(ctx context.Context, arg CreateTokenSessionParams) (*TokenSession, error) {
-	id := arg.ID.String()
-
-	token := TokenSession{
+func newTokenSession(arg CreateTokenSessionParams) TokenSession {
+	familyId := arg.FamilyID
+	if familyId == uuid.Nil {
+		familyId = arg.ID
+	}
+	return TokenSession{
 		ID:           arg.ID,
+		FamilyID:     familyId,
 		UserID:       arg.UserID,
 		RefreshToken: arg.RefreshToken,
 		UserAgent:    arg.UserAgent,
@@ -52,13 +67,40 @@ func (q *Queries) CreateTokenSession(ctx context.Context, arg CreateTokenSession
 		ExpiresAt:    arg.ExpiresAt,
 		CreatedAt:    time.Now(),
 	}
+}
 
-	json, err := json.Marshal(token)
+func (q *Queries) CreateTokenSession(ctx context.Context, arg CreateTokenSessionParams) (*TokenSession, error) {
+	id := arg.ID.String()
+
+	token := newTokenSession(arg)
+
+	tokenJson, err := json.Marshal(token)
 	if err != nil {
 		return nil, err
 	}
+	if token.FamilyID != token.ID {
+		err = q.db.Set(ctx, id, tokenJson, time.Until(arg.ExpiresAt)).Err()
+		if err != nil {
+			return nil, err
+		}
+		return &token, nil
+	}
 
-	err = q.db.Set(ctx, id, json, time.Until(arg.ExpiresAt)).Err()
+	//a new login, it is listed in the sessions of the user
+	userSession := newUserSession(token, arg)
+	userSessionJson, err := json.Marshal(userSession)
+	if err != nil {
+		return nil, err
+	}
+	_, err = q.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, id, tokenJson, time.Until(arg.ExpiresAt))
+		pipe.Set(ctx, userSessionKey(userSession.ID), userSessionJson, time.Until(arg.ExpiresAt))
+		pipe.ZAdd(ctx, userSessionsKey(userSession.UserID), redis.Z{
+			Score:  float64(userSession.ExpiresAt.Unix()),
+			Member: userSession.ID.String(),
+		})
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -81,11 +123,110 @@ func (q *Queries) GetTokenSession(ctx context.Context, sessionId uuid.UUID) (*To
 	return token, nil
 }
 
+// DeleteTokenSession logs out the login the session belongs to, its current session is deleted too
 func (q *Queries) DeleteTokenSession(ctx context.Context, sessionId uuid.UUID) error {
+	token, err := q.GetTokenSession(ctx, sessionId)
+	if err == ErrTokenSessionNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	userSession, err := q.getUserSession(ctx, token.FamilyID)
+	if err == ErrTokenSessionNotFound {
+		return q.db.Del(ctx, sessionId.String()).Err()
+	}
+	if err != nil {
+		return err
+	}
+	_, err = q.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionId.String(), userSession.SessionID.String())
+		removeUserSession(ctx, pipe, userSession)
+		return nil
+	})
+	return err
+}
+
+func (q *Queries) RotateTokenSession(ctx context.Context, sessionId uuid.UUID, arg CreateTokenSessionParams) (*TokenSession, error) {
 	id := sessionId.String()
-	err := q.db.Del(ctx, id).Err()
+	next := newTokenSession(arg)
+	nextJson, err := json.Marshal(next)
+	if err != nil {
+		return nil, err
+	}
+
+	//the old session is only changed if no one rotated it since we read it
+	familyKey := userSessionKey(next.FamilyID)
+	err = q.db.Watch(ctx, func(tx *redis.Tx) error {
+		result, err := tx.Get(ctx, id).Result()
+		if err == redis.Nil {
+			return ErrTokenSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+		token := &TokenSession{}
+		if err := json.Unmarshal([]byte(result), token); err != nil {
+			return err
+		}
+		if token.ReplacedBy != uuid.Nil {
+			return ErrTokenSessionRotated
+		}
+		token.ReplacedBy = next.ID
+		tokenJson, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		//logins from before sessions were listed have no UserSession
+		var userSessionJson []byte
+		result, err = tx.Get(ctx, familyKey).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if err == nil {
+			userSession := &UserSession{}
+			if err := json.Unmarshal([]byte(result), userSession); err != nil {
+				return err
+			}
+			userSession.use(next, arg)
+			if userSessionJson, err = json.Marshal(userSession); err != nil {
+				return err
+			}
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SetArgs(ctx, id, tokenJson, redis.SetArgs{KeepTTL: true})
+			pipe.Set(ctx, next.ID.String(), nextJson, time.Until(next.ExpiresAt))
+			if userSessionJson != nil {
+				pipe.SetArgs(ctx, familyKey, userSessionJson, redis.SetArgs{KeepTTL: true})
+			}
+			return nil
+		})
+		return err
+	}, id, familyKey)
+	if err == redis.TxFailedErr {
+		return nil, ErrTokenSessionRotated
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+func (q *Queries) BlockTokenSession(ctx context.Context, sessionId uuid.UUID) error {
+	token, err := q.GetTokenSession(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	token.IsBlocked = true
+	tokenJson, err := json.Marshal(token)
 	if err != nil {
 		return err
 	}
-	return nil
+	//a blocked login is not listed anymore
+	_, err = q.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetArgs(ctx, sessionId.String(), tokenJson, redis.SetArgs{KeepTTL: true})
+		removeUserSession(ctx, pipe, &UserSession{ID: token.FamilyID, UserID: token.UserID})
+		return nil
+	})
+	return err
 }
@@ -0,0 +1,301 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sweepInterval is how often expired entries are dropped, reads ignore them in between
+const sweepInterval = time.Minute
+
+type revokedBefore struct {
+	before    time.Time
+	expiresAt time.Time
+}
+
+// MemoryStore is a Store in the memory of the process, for development and tests.
+// Entries expire like their Redis keys would, it is safe for concurrent use
+type MemoryStore struct {
+	mu            sync.Mutex
+	now           func() time.Time
+	lastSweep     time.Time
+	tokens        map[uuid.UUID]TokenSession
+	userSessions  map[uuid.UUID]UserSession
+	revoked       map[uuid.UUID]time.Time
+	revokedBefore map[int64]revokedBefore
+}
+
+func NewMemoryStore() Store {
+	return newMemoryStore(time.Now)
+}
+
+func newMemoryStore(now func() time.Time) *MemoryStore {
+	return &MemoryStore{
+		now:           now,
+		lastSweep:     now(),
+		tokens:        map[uuid.UUID]TokenSession{},
+		userSessions:  map[uuid.UUID]UserSession{},
+		revoked:       map[uuid.UUID]time.Time{},
+		revokedBefore: map[int64]revokedBefore{},
+	}
+}
+
+// sweep drops expired entries, it must be called with mu held
+func (m *MemoryStore) sweep() time.Time {
+	now := m.now()
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return now
+	}
+	m.lastSweep = now
+	for id, token := range m.tokens {
+		if !now.Before(token.ExpiresAt) {
+			delete(m.tokens, id)
+		}
+	}
+	for id, userSession := range m.userSessions {
+		if !now.Before(userSession.ExpiresAt) {
+			delete(m.userSessions, id)
+		}
+	}
+	for id, expiresAt := range m.revoked {
+		if !now.Before(expiresAt) {
+			delete(m.revoked, id)
+		}
+	}
+	for userId, revoked := range m.revokedBefore {
+		if !now.Before(revoked.expiresAt) {
+			delete(m.revokedBefore, userId)
+		}
+	}
+	return now
+}
+
+func (m *MemoryStore) getToken(now time.Time, sessionId uuid.UUID) (TokenSession, bool) {
+	token, ok := m.tokens[sessionId]
+	if !ok || !now.Before(token.ExpiresAt) {
+		return TokenSession{}, false
+	}
+	return token, true
+}
+
+func (m *MemoryStore) getUserSession(now time.Time, id uuid.UUID) (UserSession, bool) {
+	userSession, ok := m.userSessions[id]
+	if !ok || !now.Before(userSession.ExpiresAt) {
+		return UserSession{}, false
+	}
+	return userSession, true
+}
+
+func (m *MemoryStore) CreateTokenSession(ctx context.Context, arg CreateTokenSessionParams) (*TokenSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep()
+	token := newTokenSession(arg)
+	m.tokens[token.ID] = token
+	if token.FamilyID == token.ID {
+		//a new login, it is listed in the sessions of the user
+		m.userSessions[token.FamilyID] = newUserSession(token, arg)
+	}
+	return &token, nil
+}
+
+func (m *MemoryStore) GetTokenSession(ctx context.Context, sessionId uuid.UUID) (*TokenSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.getToken(m.now(), sessionId)
+	if !ok {
+		return nil, ErrTokenSessionNotFound
+	}
+	return &token, nil
+}
+
+// DeleteTokenSession logs out the login the session belongs to, its current session is deleted too
+func (m *MemoryStore) DeleteTokenSession(ctx context.Context, sessionId uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.sweep()
+	token, ok := m.getToken(now, sessionId)
+	if !ok {
+		return nil
+	}
+	delete(m.tokens, sessionId)
+	if userSession, ok := m.getUserSession(now, token.FamilyID); ok {
+		delete(m.tokens, userSession.SessionID)
+		delete(m.userSessions, userSession.ID)
+	}
+	return nil
+}
+
+func (m *MemoryStore) RotateTokenSession(ctx context.Context, sessionId uuid.UUID, arg CreateTokenSessionParams) (*TokenSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.sweep()
+	token, ok := m.getToken(now, sessionId)
+	if !ok {
+		return nil, ErrTokenSessionNotFound
+	}
+	if token.ReplacedBy != uuid.Nil {
+		return nil, ErrTokenSessionRotated
+	}
+	next := newTokenSession(arg)
+	token.ReplacedBy = next.ID
+	m.tokens[sessionId] = token
+	m.tokens[next.ID] = next
+	if userSession, ok := m.getUserSession(now, next.FamilyID); ok {
+		userSession.use(next, arg)
+		m.userSessions[userSession.ID] = userSession
+	}
+	return &next, nil
+}
+
+func (m *MemoryStore) BlockTokenSession(ctx context.Context, sessionId uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.getToken(m.sweep(), sessionId)
+	if !ok {
+		return ErrTokenSessionNotFound
+	}
+	token.IsBlocked = true
+	m.tokens[sessionId] = token
+	//a blocked login is not listed anymore
+	delete(m.userSessions, token.FamilyID)
+	return nil
+}
+
+// ListUserSessions returns the logins of a user, the most recently used first
+func (m *MemoryStore) ListUserSessions(ctx context.Context, userId int64) ([]UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	userSessions := []UserSession{}
+	for id := range m.userSessions {
+		if userSession, ok := m.getUserSession(now, id); ok && userSession.UserID == userId {
+			userSessions = append(userSessions, userSession)
+		}
+	}
+	sort.Slice(userSessions, func(i, j int) bool {
+		return userSessions[i].LastUsedAt.After(userSessions[j].LastUsedAt)
+	})
+	return userSessions, nil
+}
+
+func (m *MemoryStore) GetUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	userSession, ok := m.getUserSession(m.now(), id)
+	if !ok || userSession.UserID != userId {
+		return nil, ErrTokenSessionNotFound
+	}
+	return &userSession, nil
+}
+
+// DeleteUserSession logs out one login of a user, it fails with ErrTokenSessionNotFound
+// when the login is not one of the user's
+func (m *MemoryStore) DeleteUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	userSession, ok := m.getUserSession(m.sweep(), id)
+	if !ok || userSession.UserID != userId {
+		return nil, ErrTokenSessionNotFound
+	}
+	delete(m.tokens, userSession.SessionID)
+	delete(m.userSessions, id)
+	return &userSession, nil
+}
+
+func (m *MemoryStore) RevokeToken(ctx context.Context, tokenId uuid.UUID, ttl time.Duration) error {
+	if ttl <= 0 {
+		//expired already
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revoked[tokenId] = m.sweep().Add(ttl)
+	return nil
+}
+
+func (m *MemoryStore) RevokeUserTokens(ctx context.Context, userId int64, before time.Time, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revokedBefore[userId] = revokedBefore{
+		before:    before,
+		expiresAt: m.sweep().Add(ttl),
+	}
+	//the refresh tokens of the sessions are revoked, so they are not listed anymore
+	for id, token := range m.tokens {
+		if token.UserID == userId {
+			delete(m.tokens, id)
+		}
+	}
+	for id, userSession := range m.userSessions {
+		if userSession.UserID == userId {
+			delete(m.userSessions, id)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) IsTokenRevoked(ctx context.Context, tokenId uuid.UUID, userId int64, issuedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if expiresAt, ok := m.revoked[tokenId]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+	if revoked, ok := m.revokedBefore[userId]; ok && now.Before(revoked.expiresAt) {
+		return !issuedAt.After(revoked.before), nil
+	}
+	return false, nil
+}
+
+type memoryChallenge struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryChallengeStore is a ChallengeStore in the memory of the process
+type MemoryChallengeStore struct {
+	mu         sync.Mutex
+	now        func() time.Time
+	challenges map[string]memoryChallenge
+}
+
+func NewMemoryChallengeStore() ChallengeStore {
+	return &MemoryChallengeStore{
+		now:        time.Now,
+		challenges: map[string]memoryChallenge{},
+	}
+}
+
+func (s *MemoryChallengeStore) SetChallenge(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	//challenges live for minutes, there are few enough to check on every write
+	for key, challenge := range s.challenges {
+		if !now.Before(challenge.expiresAt) {
+			delete(s.challenges, key)
+		}
+	}
+	s.challenges[key] = memoryChallenge{
+		value:     bytes.Clone(value),
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+func (s *MemoryChallengeStore) TakeChallenge(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	challenge, ok := s.challenges[key]
+	delete(s.challenges, key)
+	if !ok || !s.now().Before(challenge.expiresAt) {
+		return nil, ErrChallengeNotFound
+	}
+	return challenge.value, nil
+}
@@ -0,0 +1,141 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreExpires(t *testing.T) {
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	sessions := newMemoryStore(func() time.Time { return now })
+	ctx := context.Background()
+
+	login, err := sessions.CreateTokenSession(ctx, CreateTokenSessionParams{ID: uuid.New(), UserID: 7, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, login.ID, login.FamilyID)
+	require.NoError(t, sessions.RevokeToken(ctx, login.ID, time.Minute))
+	revoked, err := sessions.IsTokenRevoked(ctx, login.ID, 7, now)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	userSessions, err := sessions.ListUserSessions(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+
+	now = now.Add(2 * time.Minute)
+	revoked, err = sessions.IsTokenRevoked(ctx, login.ID, 7, now)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	now = now.Add(time.Hour)
+	_, err = sessions.GetTokenSession(ctx, login.ID)
+	require.ErrorIs(t, err, ErrTokenSessionNotFound)
+	userSessions, err = sessions.ListUserSessions(ctx, 7)
+	require.NoError(t, err)
+	require.Empty(t, userSessions)
+
+	//the next write drops them
+	_, err = sessions.CreateTokenSession(ctx, CreateTokenSessionParams{ID: uuid.New(), UserID: 8, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, sessions.tokens, 1)
+	require.Len(t, sessions.userSessions, 1)
+	require.Empty(t, sessions.revoked)
+}
+
+func TestMemoryStoreRotate(t *testing.T) {
+	sessions := NewMemoryStore()
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	login, err := sessions.CreateTokenSession(ctx, CreateTokenSessionParams{ID: uuid.New(), UserID: 7, ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	//only one of the concurrent rotations wins
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sessions.RotateTokenSession(ctx, login.ID, CreateTokenSessionParams{ID: uuid.New(), FamilyID: login.FamilyID, UserID: 7, ExpiresAt: expiresAt})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+	rotated := 0
+	for err := range results {
+		if err == nil {
+			rotated++
+			continue
+		}
+		require.ErrorIs(t, err, ErrTokenSessionRotated)
+	}
+	require.Equal(t, 1, rotated)
+
+	userSessions, err := sessions.ListUserSessions(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+	require.NotEqual(t, login.ID, userSessions[0].SessionID)
+
+	//logging out the login deletes its current session
+	current := userSessions[0].SessionID
+	require.NoError(t, sessions.DeleteTokenSession(ctx, login.ID))
+	_, err = sessions.GetTokenSession(ctx, current)
+	require.ErrorIs(t, err, ErrTokenSessionNotFound)
+	userSessions, err = sessions.ListUserSessions(ctx, 7)
+	require.NoError(t, err)
+	require.Empty(t, userSessions)
+}
+
+func TestMemoryChallengeStore(t *testing.T) {
+	challenges := NewMemoryChallengeStore()
+	ctx := context.Background()
+
+	require.NoError(t, challenges.SetChallenge(ctx, "a", []byte("value"), time.Minute))
+	value, err := challenges.TakeChallenge(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	_, err = challenges.TakeChallenge(ctx, "a")
+	require.ErrorIs(t, err, ErrChallengeNotFound)
+
+	require.NoError(t, challenges.SetChallenge(ctx, "b", []byte("value"), -time.Second))
+	_, err = challenges.TakeChallenge(ctx, "b")
+	require.ErrorIs(t, err, ErrChallengeNotFound)
+}
+
+func TestMemoryStoreRevokeUserTokens(t *testing.T) {
+	sessions := NewMemoryStore()
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	login, err := sessions.CreateTokenSession(ctx, CreateTokenSessionParams{ID: uuid.New(), UserID: 7, ExpiresAt: expiresAt, AccessTokenID: uuid.New(), AccessTokenExpiresAt: expiresAt})
+	require.NoError(t, err)
+	next := CreateTokenSessionParams{ID: uuid.New(), FamilyID: login.ID, UserID: 7, ExpiresAt: expiresAt, AccessTokenID: uuid.New(), AccessTokenExpiresAt: expiresAt}
+	_, err = sessions.RotateTokenSession(ctx, login.ID, next)
+	require.NoError(t, err)
+	_, err = sessions.CreateTokenSession(ctx, CreateTokenSessionParams{ID: uuid.New(), UserID: 8, ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	//the login remembers both of its access tokens
+	userSessions, err := sessions.ListUserSessions(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+	require.Len(t, userSessions[0].AccessTokens, 2)
+	require.Equal(t, next.AccessTokenID, userSessions[0].AccessTokens[1].ID)
+
+	//logging out everywhere drops the sessions of the user only
+	require.NoError(t, sessions.RevokeUserTokens(ctx, 7, time.Now(), time.Hour))
+	userSessions, err = sessions.ListUserSessions(ctx, 7)
+	require.NoError(t, err)
+	require.Empty(t, userSessions)
+	_, err = sessions.GetTokenSession(ctx, next.ID)
+	require.ErrorIs(t, err, ErrTokenSessionNotFound)
+	userSessions, err = sessions.ListUserSessions(ctx, 8)
+	require.NoError(t, err)
+	require.Len(t, userSessions, 1)
+}
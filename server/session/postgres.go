@@ -0,0 +1,272 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/punkzberryz/todo/db/sqlc"
+)
+
+// PostgresStore is a Store on the sessions table, so a deployment can run without Redis.
+// Expired rows are deleted by CleanupWorker
+type PostgresStore struct {
+	store db.Store
+}
+
+func NewPostgresStore(store db.Store) Store {
+	return &PostgresStore{
+		store: store,
+	}
+}
+
+func newCreateSessionParams(token TokenSession, arg CreateTokenSessionParams) db.CreateSessionParams {
+	return db.CreateSessionParams{
+		ID:                   token.ID,
+		FamilyID:             token.FamilyID,
+		UserID:               token.UserID,
+		RefreshToken:         token.RefreshToken,
+		UserAgent:            token.UserAgent,
+		ClientIp:             token.ClientIp,
+		IsBlocked:            token.IsBlocked,
+		ExpiresAt:            token.ExpiresAt,
+		AccessTokenID:        uuid.NullUUID{UUID: arg.AccessTokenID, Valid: arg.AccessTokenID != uuid.Nil},
+		AccessTokenExpiresAt: sql.NullTime{Time: arg.AccessTokenExpiresAt, Valid: !arg.AccessTokenExpiresAt.IsZero()},
+	}
+}
+
+func newTokenSessionFromRow(row db.Session) *TokenSession {
+	return &TokenSession{
+		ID:           row.ID,
+		FamilyID:     row.FamilyID,
+		UserID:       row.UserID,
+		RefreshToken: row.RefreshToken,
+		UserAgent:    row.UserAgent,
+		ClientIp:     row.ClientIp,
+		IsBlocked:    row.IsBlocked,
+		ExpiresAt:    row.ExpiresAt,
+		CreatedAt:    row.CreatedAt,
+		ReplacedBy:   row.ReplacedBy.UUID,
+	}
+}
+
+func (p *PostgresStore) CreateTokenSession(ctx context.Context, arg CreateTokenSessionParams) (*TokenSession, error) {
+	row, err := p.store.CreateSession(ctx, newCreateSessionParams(newTokenSession(arg), arg))
+	if err != nil {
+		return nil, err
+	}
+	return newTokenSessionFromRow(row), nil
+}
+
+func (p *PostgresStore) GetTokenSession(ctx context.Context, sessionId uuid.UUID) (*TokenSession, error) {
+	row, err := p.store.GetSession(ctx, sessionId)
+	if err == sql.ErrNoRows {
+		return nil, ErrTokenSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	//expired rows stay until the cleanup, redis would have dropped them
+	if time.Now().After(row.ExpiresAt) {
+		return nil, ErrTokenSessionNotFound
+	}
+	return newTokenSessionFromRow(row), nil
+}
+
+// DeleteTokenSession logs out the login the session belongs to, like Queries it keeps the
+// sessions rotated before, so an old refresh token coming back is still seen as reused
+func (p *PostgresStore) DeleteTokenSession(ctx context.Context, sessionId uuid.UUID) error {
+	token, err := p.GetTokenSession(ctx, sessionId)
+	if err == ErrTokenSessionNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := p.store.DeleteSession(ctx, sessionId); err != nil {
+		return err
+	}
+	_, err = p.store.DeleteCurrentSession(ctx, db.DeleteCurrentSessionParams{
+		FamilyID: token.FamilyID,
+		UserID:   token.UserID,
+	})
+	return err
+}
+
+func (p *PostgresStore) RotateTokenSession(ctx context.Context, sessionId uuid.UUID, arg CreateTokenSessionParams) (*TokenSession, error) {
+	next := newTokenSession(arg)
+	row, err := p.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		ID:   sessionId,
+		Next: newCreateSessionParams(next, arg),
+	})
+	if err == sql.ErrNoRows {
+		//either it's gone or someone rotated it first
+		if _, err := p.GetTokenSession(ctx, sessionId); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenSessionRotated
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newTokenSessionFromRow(row), nil
+}
+
+func (p *PostgresStore) BlockTokenSession(ctx context.Context, sessionId uuid.UUID) error {
+	blocked, err := p.store.BlockSession(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	if blocked == 0 {
+		return ErrTokenSessionNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListUserSessions(ctx context.Context, userId int64) ([]UserSession, error) {
+	rows, err := p.store.ListUserSessions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	userSessions := make([]UserSession, len(rows))
+	for i, row := range rows {
+		userSessions[i] = UserSession{
+			ID:                   row.FamilyID,
+			UserID:               userId,
+			SessionID:            row.ID,
+			AccessTokenID:        row.AccessTokenID.UUID,
+			AccessTokenExpiresAt: row.AccessTokenExpiresAt.Time,
+			UserAgent:            row.UserAgent,
+			ClientIp:             row.ClientIp,
+			CreatedAt:            row.CreatedAt,
+			LastUsedAt:           row.LastUsedAt,
+			ExpiresAt:            row.ExpiresAt,
+		}
+	}
+	return userSessions, nil
+}
+
+func (p *PostgresStore) GetUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error) {
+	rows, err := p.store.ListSessionFamily(ctx, db.ListSessionFamilyParams{
+		FamilyID: id,
+		UserID:   userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newUserSessionFromFamily(userId, id, rows)
+}
+
+// DeleteUserSession logs out one login of a user, like DeleteTokenSession only the current
+// session of the login is deleted
+func (p *PostgresStore) DeleteUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error) {
+	userSession, err := p.GetUserSession(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := p.store.DeleteCurrentSession(ctx, db.DeleteCurrentSessionParams{
+		FamilyID: id,
+		UserID:   userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		//logged out in the meantime
+		return nil, ErrTokenSessionNotFound
+	}
+	return userSession, nil
+}
+
+// newUserSessionFromFamily puts a login together from the sessions of its family,
+// the access tokens of all of them that may not have expired are included.
+// Like ListUserSessions a login whose current session is gone, blocked or expired is not found
+func newUserSessionFromFamily(userId int64, id uuid.UUID, rows []db.Session) (*UserSession, error) {
+	userSession := &UserSession{ID: id, UserID: userId, AccessTokens: []AccessToken{}}
+	found, current := false, false
+	now := time.Now()
+	for _, row := range rows {
+		if row.AccessTokenID.Valid && row.AccessTokenExpiresAt.Time.After(now) {
+			userSession.AccessTokens = append(userSession.AccessTokens, AccessToken{ID: row.AccessTokenID.UUID, ExpiresAt: row.AccessTokenExpiresAt.Time})
+		}
+		if row.ID == id {
+			found = true
+			userSession.UserAgent = row.UserAgent
+			userSession.CreatedAt = row.CreatedAt
+			userSession.ExpiresAt = row.ExpiresAt
+		}
+		//the current session is the one not rotated yet
+		if !row.ReplacedBy.Valid {
+			current = !row.IsBlocked && row.ExpiresAt.After(now)
+			userSession.SessionID = row.ID
+			userSession.AccessTokenID = row.AccessTokenID.UUID
+			userSession.AccessTokenExpiresAt = row.AccessTokenExpiresAt.Time
+			userSession.ClientIp = row.ClientIp
+			userSession.LastUsedAt = row.CreatedAt
+		}
+	}
+	if !found || !current {
+		return nil, ErrTokenSessionNotFound
+	}
+	return userSession, nil
+}
+
+func (p *PostgresStore) RevokeToken(ctx context.Context, tokenId uuid.UUID, ttl time.Duration) error {
+	if ttl <= 0 {
+		//expired already
+		return nil
+	}
+	return p.store.RevokeToken(ctx, db.RevokeTokenParams{
+		ID:        tokenId,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+}
+
+func (p *PostgresStore) RevokeUserTokens(ctx context.Context, userId int64, before time.Time, ttl time.Duration) error {
+	err := p.store.RevokeUserTokens(ctx, db.RevokeUserTokensParams{
+		UserID:        userId,
+		RevokedBefore: before,
+		ExpiresAt:     time.Now().Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+	//the refresh tokens of the sessions are revoked, so they are not listed anymore
+	return p.store.DeleteUserSessions(ctx, userId)
+}
+
+func (p *PostgresStore) IsTokenRevoked(ctx context.Context, tokenId uuid.UUID, userId int64, issuedAt time.Time) (bool, error) {
+	return p.store.IsTokenRevoked(ctx, db.IsTokenRevokedParams{
+		ID:       tokenId,
+		UserID:   userId,
+		IssuedAt: issuedAt,
+	})
+}
+
+// PostgresChallengeStore is a ChallengeStore on the login_challenges table
+type PostgresChallengeStore struct {
+	store db.Store
+}
+
+func NewPostgresChallengeStore(store db.Store) ChallengeStore {
+	return &PostgresChallengeStore{
+		store: store,
+	}
+}
+
+func (s *PostgresChallengeStore) SetChallenge(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.store.SetLoginChallenge(ctx, db.SetLoginChallengeParams{
+		Key:       key,
+		Value:     value,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+}
+
+func (s *PostgresChallengeStore) TakeChallenge(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.store.TakeLoginChallenge(ctx, key)
+	if err == sql.ErrNoRows {
+		return nil, ErrChallengeNotFound
+	}
+	return value, err
+}
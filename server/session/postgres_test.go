@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	mockdb "github.com/punkzberryz/todo/db/mock"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostgresRotateTokenSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	sessions := NewPostgresStore(store)
+	first := db.Session{ID: uuid.New(), UserID: 7, ExpiresAt: time.Now().Add(time.Hour)}
+	first.FamilyID = first.ID
+	next := CreateTokenSessionParams{ID: uuid.New(), FamilyID: first.FamilyID, UserID: 7, ExpiresAt: first.ExpiresAt}
+
+	//rotated by someone else first
+	store.EXPECT().RotateSessionTx(gomock.Any(), gomock.Any()).Return(db.Session{}, sql.ErrNoRows)
+	store.EXPECT().GetSession(gomock.Any(), first.ID).Return(first, nil)
+	_, err := sessions.RotateTokenSession(context.Background(), first.ID, next)
+	require.ErrorIs(t, err, ErrTokenSessionRotated)
+
+	//logged out
+	store.EXPECT().RotateSessionTx(gomock.Any(), gomock.Any()).Return(db.Session{}, sql.ErrNoRows)
+	store.EXPECT().GetSession(gomock.Any(), first.ID).Return(db.Session{}, sql.ErrNoRows)
+	_, err = sessions.RotateTokenSession(context.Background(), first.ID, next)
+	require.ErrorIs(t, err, ErrTokenSessionNotFound)
+
+	store.EXPECT().RotateSessionTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, arg db.RotateSessionTxParams) (db.Session, error) {
+			require.Equal(t, first.ID, arg.ID)
+			require.Equal(t, first.FamilyID, arg.Next.FamilyID)
+			return db.Session{ID: arg.Next.ID, FamilyID: arg.Next.FamilyID, UserID: 7, ExpiresAt: arg.Next.ExpiresAt}, nil
+		})
+	rotated, err := sessions.RotateTokenSession(context.Background(), first.ID, next)
+	require.NoError(t, err)
+	require.Equal(t, next.ID, rotated.ID)
+}
+
+func TestPostgresGetExpiredTokenSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	sessions := NewPostgresStore(store)
+	expired := db.Session{ID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}
+
+	store.EXPECT().GetSession(gomock.Any(), expired.ID).Return(expired, nil)
+	_, err := sessions.GetTokenSession(context.Background(), expired.ID)
+	require.ErrorIs(t, err, ErrTokenSessionNotFound)
+}
+
+func TestDeleteExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	worker := NewCleanupWorker(store, time.Minute)
+	now := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	store.EXPECT().DeleteExpiredSessions(gomock.Any(), now).Return(int64(3), nil)
+	store.EXPECT().DeleteExpiredRevokedTokens(gomock.Any(), now).Return(int64(2), nil)
+	store.EXPECT().DeleteExpiredUserTokenRevocations(gomock.Any(), now).Return(int64(0), nil)
+	store.EXPECT().DeleteExpiredLoginChallenges(gomock.Any(), now).Return(int64(1), nil)
+	deleted, err := worker.DeleteExpired(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(6), deleted)
+}
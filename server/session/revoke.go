@@ -0,0 +1,65 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+func revokedTokenKey(tokenId uuid.UUID) string {
+	return "revoked:" + tokenId.String()
+}
+
+func revokedBeforeKey(userId int64) string {
+	return fmt.Sprintf("revoked-before:%d", userId)
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, tokenId uuid.UUID, ttl time.Duration) error {
+	if ttl <= 0 {
+		//expired already
+		return nil
+	}
+	return q.db.Set(ctx, revokedTokenKey(tokenId), 1, ttl).Err()
+}
+
+func (q *Queries) RevokeUserTokens(ctx context.Context, userId int64, before time.Time, ttl time.Duration) error {
+	if err := q.db.Set(ctx, revokedBeforeKey(userId), before.UnixNano(), ttl).Err(); err != nil {
+		return err
+	}
+	//the refresh tokens of the sessions are revoked, so they are not listed anymore
+	userSessions, err := q.ListUserSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+	_, err = q.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i := range userSessions {
+			pipe.Del(ctx, userSessions[i].SessionID.String())
+			removeUserSession(ctx, pipe, &userSessions[i])
+		}
+		pipe.Del(ctx, userSessionsKey(userId))
+		return nil
+	})
+	return err
+}
+
+func (q *Queries) IsTokenRevoked(ctx context.Context, tokenId uuid.UUID, userId int64, issuedAt time.Time) (bool, error) {
+	values, err := q.db.MGet(ctx, revokedTokenKey(tokenId), revokedBeforeKey(userId)).Result()
+	if err != nil && err != redis.Nil {
+		return false, err
+	}
+	if values[0] != nil {
+		return true, nil
+	}
+	if before, ok := values[1].(string); ok {
+		nanos, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		return !issuedAt.After(time.Unix(0, nanos)), nil
+	}
+	return false, nil
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -11,6 +12,21 @@ type Store interface {
 	CreateTokenSession(ctx context.Context, arg CreateTokenSessionParams) (*TokenSession, error)
 	GetTokenSession(ctx context.Context, sessionId uuid.UUID) (*TokenSession, error)
 	DeleteTokenSession(ctx context.Context, sessionId uuid.UUID) error
+	// RotateTokenSession replaces a session with a new one of the same family. It fails with
+	// ErrTokenSessionRotated when the session was replaced already, so a refresh token rotates once
+	RotateTokenSession(ctx context.Context, sessionId uuid.UUID, arg CreateTokenSessionParams) (*TokenSession, error)
+	BlockTokenSession(ctx context.Context, sessionId uuid.UUID) error
+	ListUserSessions(ctx context.Context, userId int64) ([]UserSession, error)
+	// GetUserSession returns one login of a user, it fails with ErrTokenSessionNotFound
+	// when the login is not one of the user's or it was logged out
+	GetUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error)
+	// DeleteUserSession logs out one login of a user and returns it, so its access token can be revoked
+	DeleteUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error)
+	// RevokeToken rejects a token until ttl, the rest of its lifetime, has passed
+	RevokeToken(ctx context.Context, tokenId uuid.UUID, ttl time.Duration) error
+	// RevokeUserTokens rejects every token of a user issued until before, ttl is the longest token lifetime
+	RevokeUserTokens(ctx context.Context, userId int64, before time.Time, ttl time.Duration) error
+	IsTokenRevoked(ctx context.Context, tokenId uuid.UUID, userId int64, issuedAt time.Time) (bool, error)
 }
 
 func NewSession(address string) (Store, error) {
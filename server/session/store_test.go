@@ -0,0 +1,77 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	memdb "github.com/punkzberryz/todo/db/memory"
+	db "github.com/punkzberryz/todo/db/sqlc"
+	"github.com/punkzberryz/todo/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreLogoutThenReuse(t *testing.T) {
+	testLogoutThenReuse(t, NewMemoryStore(), 7)
+}
+
+func TestPostgresStoreLogoutThenReuse(t *testing.T) {
+	store := memdb.NewStore()
+	user, err := store.CreateUser(context.Background(), db.CreateUserParams{Username: "user", HashedPassword: "secret", Email: "user@email.com"})
+	require.NoError(t, err)
+	testLogoutThenReuse(t, NewPostgresStore(store), user.ID)
+}
+
+// testLogoutThenReuse logs out a login that was rotated, the refresh token it started with
+// must still be seen as rotated so the token service reports it as reused
+func testLogoutThenReuse(t *testing.T, sessions Store, userId int64) {
+	ctx := context.Background()
+	newSession := func(familyId uuid.UUID) CreateTokenSessionParams {
+		return CreateTokenSessionParams{
+			ID:           uuid.New(),
+			FamilyID:     familyId,
+			UserID:       userId,
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+	}
+	login := func() (*TokenSession, *TokenSession) {
+		first, err := sessions.CreateTokenSession(ctx, newSession(uuid.Nil))
+		require.NoError(t, err)
+		current, err := sessions.RotateTokenSession(ctx, first.ID, newSession(first.FamilyID))
+		require.NoError(t, err)
+		return first, current
+	}
+	requireReused := func(first *TokenSession, current *TokenSession) {
+		old, err := sessions.GetTokenSession(ctx, first.ID)
+		require.NoError(t, err)
+		require.Equal(t, current.ID, old.ReplacedBy)
+		_, err = sessions.RotateTokenSession(ctx, first.ID, newSession(first.FamilyID))
+		require.ErrorIs(t, err, ErrTokenSessionRotated)
+		_, err = sessions.GetTokenSession(ctx, current.ID)
+		require.ErrorIs(t, err, ErrTokenSessionNotFound)
+		_, err = sessions.GetUserSession(ctx, userId, first.FamilyID)
+		require.ErrorIs(t, err, ErrTokenSessionNotFound)
+	}
+
+	t.Run("DeleteTokenSession", func(t *testing.T) {
+		first, current := login()
+		require.NoError(t, sessions.DeleteTokenSession(ctx, current.ID))
+		requireReused(first, current)
+	})
+
+	t.Run("DeleteUserSession", func(t *testing.T) {
+		first, current := login()
+		userSession, err := sessions.DeleteUserSession(ctx, userId, first.FamilyID)
+		require.NoError(t, err)
+		require.Equal(t, current.ID, userSession.SessionID)
+		requireReused(first, current)
+		_, err = sessions.DeleteUserSession(ctx, userId, first.FamilyID)
+		require.ErrorIs(t, err, ErrTokenSessionNotFound)
+	})
+
+	userSessions, err := sessions.ListUserSessions(ctx, userId)
+	require.NoError(t, err)
+	require.Empty(t, userSessions)
+}
@@ -0,0 +1,176 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// AccessToken is an access token issued to a login
+type AccessToken struct {
+	ID        uuid.UUID `json:"id"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// UserSession is a login as its user sees it. Its id is the family id,
+// so it stays the same while the refresh token is rotated
+type UserSession struct {
+	ID     uuid.UUID `json:"id"`
+	UserID int64     `json:"userId"`
+	// SessionID is the current token session of the family
+	SessionID            uuid.UUID `json:"sessionId"`
+	AccessTokenID        uuid.UUID `json:"accessTokenId"`
+	AccessTokenExpiresAt time.Time `json:"accessTokenExpiresAt"`
+	UserAgent            string    `json:"userAgent"`
+	ClientIp             string    `json:"clientIp"`
+	CreatedAt            time.Time `json:"createdAt"`
+	// LastUsedAt is when the login was made or its refresh token was last used
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	// AccessTokens are the access tokens issued to the login that may not have expired,
+	// the latest one included, they are all revoked when the login is logged out
+	AccessTokens []AccessToken `json:"accessTokens"`
+}
+
+func newUserSession(token TokenSession, arg CreateTokenSessionParams) UserSession {
+	return UserSession{
+		ID:                   token.FamilyID,
+		UserID:               token.UserID,
+		SessionID:            token.ID,
+		AccessTokenID:        arg.AccessTokenID,
+		AccessTokenExpiresAt: arg.AccessTokenExpiresAt,
+		AccessTokens:         []AccessToken{{ID: arg.AccessTokenID, ExpiresAt: arg.AccessTokenExpiresAt}},
+		UserAgent:            token.UserAgent,
+		ClientIp:             token.ClientIp,
+		CreatedAt:            token.CreatedAt,
+		LastUsedAt:           token.CreatedAt,
+		ExpiresAt:            token.ExpiresAt,
+	}
+}
+
+// use records the session that rotated the current one
+func (s *UserSession) use(next TokenSession, arg CreateTokenSessionParams) {
+	s.SessionID = next.ID
+	s.AccessTokenID = arg.AccessTokenID
+	s.AccessTokenExpiresAt = arg.AccessTokenExpiresAt
+	accessTokens := []AccessToken{}
+	for _, accessToken := range s.AccessTokens {
+		if accessToken.ExpiresAt.After(next.CreatedAt) {
+			accessTokens = append(accessTokens, accessToken)
+		}
+	}
+	s.AccessTokens = append(accessTokens, AccessToken{ID: arg.AccessTokenID, ExpiresAt: arg.AccessTokenExpiresAt})
+	if next.ClientIp != "" {
+		s.ClientIp = next.ClientIp
+	}
+	s.LastUsedAt = next.CreatedAt
+}
+
+func userSessionKey(id uuid.UUID) string {
+	return "user-session:" + id.String()
+}
+
+// userSessionsKey is a sorted set of the UserSession ids of a user, scored by when they expire
+func userSessionsKey(userId int64) string {
+	return fmt.Sprintf("user-sessions:%d", userId)
+}
+
+func removeUserSession(ctx context.Context, pipe redis.Pipeliner, userSession *UserSession) {
+	pipe.Del(ctx, userSessionKey(userSession.ID))
+	pipe.ZRem(ctx, userSessionsKey(userSession.UserID), userSession.ID.String())
+}
+
+func (q *Queries) getUserSession(ctx context.Context, id uuid.UUID) (*UserSession, error) {
+	result, err := q.db.Get(ctx, userSessionKey(id)).Result()
+	if err == redis.Nil {
+		return nil, ErrTokenSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	userSession := &UserSession{}
+	if err := json.Unmarshal([]byte(result), userSession); err != nil {
+		return nil, err
+	}
+	return userSession, nil
+}
+
+// ListUserSessions returns the logins of a user, the most recently used first
+func (q *Queries) ListUserSessions(ctx context.Context, userId int64) ([]UserSession, error) {
+	key := userSessionsKey(userId)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := q.db.ZRemRangeByScore(ctx, key, "-inf", now).Err(); err != nil {
+		return nil, err
+	}
+	ids, err := q.db.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	userSessions := []UserSession{}
+	keys := []string{}
+	for _, id := range ids {
+		if id, err := uuid.Parse(id); err == nil {
+			keys = append(keys, userSessionKey(id))
+		}
+	}
+	if len(keys) == 0 {
+		return userSessions, nil
+	}
+	values, err := q.db.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		result, ok := value.(string)
+		if !ok {
+			continue
+		}
+		userSession := UserSession{}
+		if err := json.Unmarshal([]byte(result), &userSession); err != nil {
+			return nil, err
+		}
+		userSessions = append(userSessions, userSession)
+	}
+	sort.Slice(userSessions, func(i, j int) bool {
+		return userSessions[i].LastUsedAt.After(userSessions[j].LastUsedAt)
+	})
+	return userSessions, nil
+}
+
+func (q *Queries) GetUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error) {
+	userSession, err := q.getUserSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if userSession.UserID != userId {
+		return nil, ErrTokenSessionNotFound
+	}
+	return userSession, nil
+}
+
+// DeleteUserSession logs out one login of a user, it fails with ErrTokenSessionNotFound
+// when the login is not one of the user's
+func (q *Queries) DeleteUserSession(ctx context.Context, userId int64, id uuid.UUID) (*UserSession, error) {
+	userSession, err := q.getUserSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if userSession.UserID != userId {
+		return nil, ErrTokenSessionNotFound
+	}
+	_, err = q.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, userSession.SessionID.String())
+		removeUserSession(ctx, pipe, userSession)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userSession, nil
+}
@@ -2,6 +2,8 @@ package util
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -24,6 +26,20 @@ type EnvVar struct {
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	ReminderInterval     time.Duration `mapstructure:"REMINDER_INTERVAL"`
+	PublicURL            string        `mapstructure:"PUBLIC_URL"`
+	InboxSMTPAddress     string        `mapstructure:"INBOX_SMTP_ADDRESS"`
+	InboxDomain          string        `mapstructure:"INBOX_DOMAIN"`
+	RequireVerifiedEmail bool          `mapstructure:"REQUIRE_VERIFIED_EMAIL"`
+	WebauthnRPID         string        `mapstructure:"WEBAUTHN_RP_ID"`
+	WebauthnOrigins      string        `mapstructure:"WEBAUTHN_ORIGINS"`
+	WebsocketOrigins     string        `mapstructure:"WEBSOCKET_ORIGINS"`
+	SessionStore         string        `mapstructure:"SESSION_STORE"`
+	TokenFormat          string        `mapstructure:"TOKEN_FORMAT"`
+	TokenKeyRotation     time.Duration `mapstructure:"TOKEN_KEY_ROTATION"`
+	TokenKeyGracePeriod  time.Duration `mapstructure:"TOKEN_KEY_GRACE_PERIOD"`
+	TokenKeySecret       string        `mapstructure:"TOKEN_KEY_SECRET"`
+	AllowPrivateWebhooks bool          `mapstructure:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
 }
 type Config struct {
 	MigrationURL         string
@@ -34,12 +50,37 @@ type Config struct {
 	TokenSymmetricKey    string
 	AccessTokenDuration  time.Duration
 	RefreshTokenDuration time.Duration
+	TokenFormat          string        //TokenFormatPaseto, TokenFormatPasetoPublic or TokenFormatJWT
+	TokenKeyRotation     time.Duration //how long an Ed25519 key signs tokens before the next one takes over
+	TokenKeyGracePeriod  time.Duration //how long tokens of an old Ed25519 key still verify, the refresh token duration by default
+	TokenKeySecret       string        //encrypts the Ed25519 keys in the signing_keys table, 32 characters
 	RedisAddress         string
 	EmailSenderName      string
 	EmailSenderAddress   string
 	EmailSenderPassword  string
+	ReminderInterval     time.Duration
+	PublicURL            string   //address of the API used in links sent by email
+	InboxSMTPAddress     string   //listen address of the email-to-task server, empty to turn it off
+	InboxDomain          string   //domain of the inbox email addresses
+	RequireVerifiedEmail bool     //block task endpoints until the user verified their email
+	WebauthnRPID         string   //domain passkeys are registered for, the host of PublicURL by default
+	WebauthnOrigins      []string //origins of the web app that may use passkeys, PublicURL by default
+	WebsocketOrigins     []string //origins of web apps that may open a WebSocket, PublicURL by default
+	SessionStore         string   //SessionStoreRedis or SessionStorePostgres, where sessions, revoked tokens and login challenges are kept
+	AllowPrivateWebhooks bool     //let webhooks call localhost and private addresses, for development only
 }
 
+const (
+	SessionStoreRedis    = "redis"
+	SessionStorePostgres = "postgres"
+)
+
+const (
+	TokenFormatPaseto       = "paseto"           //v2.local, encrypted with TOKEN_SYMMETRIC_KEY
+	TokenFormatPasetoPublic = "paseto-v4-public" //signed with rotating Ed25519 keys published at /.well-known/jwks.json
+	TokenFormatJWT          = "jwt-eddsa"        //same keys as TokenFormatPasetoPublic
+)
+
 func getEnvVar(path string) (env EnvVar, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("app")
@@ -74,5 +115,82 @@ func LoadConfig(path string) (Config, error) {
 	config.EmailSenderName = env.EmailSenderName
 	config.EmailSenderPassword = env.EmailSenderPassword
 	config.RedisAddress = fmt.Sprintf("%s:%s", env.RedisHost, env.RedisPort)
+	config.ReminderInterval = env.ReminderInterval
+	config.PublicURL = env.PublicURL
+	config.InboxSMTPAddress = env.InboxSMTPAddress
+	config.InboxDomain = env.InboxDomain
+	config.RequireVerifiedEmail = env.RequireVerifiedEmail
+	config.AllowPrivateWebhooks = env.AllowPrivateWebhooks
+	if config.PublicURL == "" {
+		config.PublicURL = fmt.Sprintf("http://%s", config.ServerAddress)
+	}
+	publicURL, err := url.Parse(config.PublicURL)
+	if err != nil {
+		return config, fmt.Errorf("invalid PUBLIC_URL: %v", err)
+	}
+	config.WebauthnRPID = env.WebauthnRPID
+	if config.WebauthnRPID == "" {
+		config.WebauthnRPID = publicURL.Hostname()
+	}
+	config.WebauthnOrigins = []string{publicURL.Scheme + "://" + publicURL.Host}
+	if env.WebauthnOrigins != "" {
+		config.WebauthnOrigins = strings.Split(env.WebauthnOrigins, ",")
+	}
+	config.WebsocketOrigins = []string{publicURL.Scheme + "://" + publicURL.Host}
+	if env.WebsocketOrigins != "" {
+		config.WebsocketOrigins = strings.Split(env.WebsocketOrigins, ",")
+	}
+	config.SessionStore = env.SessionStore
+	if config.SessionStore == "" {
+		config.SessionStore = SessionStoreRedis
+	}
+	if config.SessionStore != SessionStoreRedis && config.SessionStore != SessionStorePostgres {
+		return config, fmt.Errorf("invalid SESSION_STORE: %s", config.SessionStore)
+	}
+	config.TokenFormat = env.TokenFormat
+	if config.TokenFormat == "" {
+		config.TokenFormat = TokenFormatPaseto
+	}
+	if config.TokenFormat != TokenFormatPaseto && config.TokenFormat != TokenFormatPasetoPublic && config.TokenFormat != TokenFormatJWT {
+		return config, fmt.Errorf("invalid TOKEN_FORMAT: %s", config.TokenFormat)
+	}
+	config.TokenKeyRotation = env.TokenKeyRotation
+	if config.TokenKeyRotation == 0 {
+		config.TokenKeyRotation = 7 * 24 * time.Hour
+	}
+	config.TokenKeyGracePeriod = env.TokenKeyGracePeriod
+	if config.TokenKeyGracePeriod == 0 {
+		config.TokenKeyGracePeriod = config.RefreshTokenDuration
+	}
+	config.TokenKeySecret = env.TokenKeySecret
+	if config.TokenFormat != TokenFormatPaseto && len(config.TokenKeySecret) != 32 {
+		return config, fmt.Errorf("TOKEN_KEY_SECRET must be 32 characters for TOKEN_FORMAT %s", config.TokenFormat)
+	}
+	//a token signed just before the rotation must verify until it expires
+	if config.TokenKeyGracePeriod < config.RefreshTokenDuration {
+		return config, fmt.Errorf("TOKEN_KEY_GRACE_PERIOD must be at least REFRESH_TOKEN_DURATION")
+	}
 	return config, nil
 }
+
+// DevConfig is the config of --dev mode, it needs no app.env. The token key is made up on
+// every start, which is fine since the in-memory data does not outlive the process either
+func DevConfig() Config {
+	return Config{
+		ServerAddress:        "localhost:8080",
+		ServerPort:           "8080",
+		TokenSymmetricKey:    RandomString(32),
+		AccessTokenDuration:  15 * time.Minute,
+		RefreshTokenDuration: 24 * time.Hour,
+		TokenFormat:          TokenFormatPasetoPublic,
+		TokenKeyRotation:     24 * time.Hour,
+		TokenKeyGracePeriod:  24 * time.Hour,
+		TokenKeySecret:       RandomString(32),
+		ReminderInterval:     30 * time.Second,
+		PublicURL:            "http://localhost:8080",
+		WebauthnRPID:         "localhost",
+		WebauthnOrigins:      []string{"http://localhost:8080", "http://localhost:3000"},
+		WebsocketOrigins:     []string{"http://localhost:8080", "http://localhost:3000"},
+		AllowPrivateWebhooks: true,
+	}
+}
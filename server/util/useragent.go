@@ -0,0 +1,78 @@
+package util
+
+import "strings"
+
+type userAgentMatch struct {
+	token string
+	name  string
+}
+
+// checked in order, most browsers also send the tokens of the ones they are based on
+var browsers = []userAgentMatch{
+	{"Edg/", "Edge"},
+	{"EdgA/", "Edge"},
+	{"EdgiOS/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+var systems = []userAgentMatch{
+	{"iPhone", "iPhone"},
+	{"iPad", "iPad"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"CrOS", "ChromeOS"},
+	{"Macintosh", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// clients that aren't browsers, named by the product at the start of the user agent
+var clients = []userAgentMatch{
+	{"curl/", "curl"},
+	{"Wget/", "Wget"},
+	{"PostmanRuntime/", "Postman"},
+	{"insomnia/", "Insomnia"},
+	{"python-requests/", "Python"},
+	{"Go-http-client/", "Go"},
+	{"okhttp/", "OkHttp"},
+}
+
+func matchUserAgent(userAgent string, matches []userAgentMatch) string {
+	for _, match := range matches {
+		if strings.Contains(userAgent, match.token) {
+			return match.name
+		}
+	}
+	return ""
+}
+
+// DeviceName turns a user agent into a name people recognize, like "Chrome on macOS"
+func DeviceName(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+	for _, client := range clients {
+		if strings.HasPrefix(userAgent, client.token) {
+			return client.name
+		}
+	}
+	browser := matchUserAgent(userAgent, browsers)
+	system := matchUserAgent(userAgent, systems)
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	//first product of the user agent, "MyApp/1.2 (...)" is MyApp
+	product, _, _ := strings.Cut(userAgent, "/")
+	product, _, _ = strings.Cut(product, " ")
+	return product
+}